@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -21,19 +22,25 @@ import (
 
 // webSocketEventValidator implementa websocket.EventValidator usando EventRepository
 type webSocketEventValidator struct {
-	eventRepo *repository.EventRepository
+	eventRepo    *repository.EventRepository
+	accessTokens *services.EventAccessTokenService
 }
 
-func (v *webSocketEventValidator) ValidateEvent(slug string) error {
+// ValidateEvent acepta también slugs anteriores del evento y devuelve el actual
+func (v *webSocketEventValidator) ValidateEvent(slug, accessToken string) (string, error) {
 	event, err := v.eventRepo.GetBySlug(slug)
 	if err != nil {
-		return err
+		return "", err
 	}
-	// Verificar que el evento esté activo
-	if !event.IsActive {
-		return &eventInactiveError{slug: slug}
+	// Verificar que el evento sea visible (ni draft ni archivado)
+	if !event.Status.IsPublic() {
+		return "", &eventInactiveError{slug: slug}
 	}
-	return nil
+	// Eventos protegidos con código: mismo token que exige EventAccessMiddleware
+	if err := v.accessTokens.Authorize(accessToken, event); err != nil {
+		return "", websocket.ErrEventAccessDenied
+	}
+	return event.Slug, nil
 }
 
 type eventInactiveError struct {
@@ -44,6 +51,33 @@ func (e *eventInactiveError) Error() string {
 	return "event is inactive"
 }
 
+// newMediaStore configura el almacenamiento de media según MEDIA_STORAGE:
+// "local" (default, disco en uploadsDir) o "s3" (bucket S3-compatible, ej. MinIO).
+// Con más de una instancia de la API hay que usar s3.
+func newMediaStore(uploadsDir string) (services.MediaStore, error) {
+	switch os.Getenv("MEDIA_STORAGE") {
+	case "", "local":
+		return services.NewLocalMediaStore(uploadsDir), nil
+	case "s3":
+		store, err := services.NewS3MediaStore(services.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+			PathStyle:       os.Getenv("S3_PATH_STYLE") != "false",
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[Media] Using S3 storage (bucket %s)", os.Getenv("S3_BUCKET"))
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown MEDIA_STORAGE %q (use local or s3)", os.Getenv("MEDIA_STORAGE"))
+	}
+}
+
 func main() {
 	// Cargar variables de entorno
 	if err := godotenv.Load(); err != nil {
@@ -78,17 +112,38 @@ func main() {
 	}
 	postcardRepo := repository.NewPostcardRepository(db, uploadPath)
 	userRepo := repository.NewUserRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
 	eventRepo := repository.NewEventRepository(db)
+	memberRepo := repository.NewEventMemberRepository(db)
+	templateRepo := repository.NewEventTemplateRepository(db)
+	guestRepo := repository.NewGuestRepository(db)
 	quizQuestionRepo := repository.NewQuizQuestionRepository(db)
 	themeRepo := repository.NewThemeRepository(db)
 	driveRepo := repository.NewDriveRepository(db)
+	mediaJobRepo := repository.NewMediaJobRepository(db)
+	uploadRepo := repository.NewUploadSessionRepository(db)
+	telemetryRepo := repository.NewTelemetryRepository(db)
 
 	// JWT secret — siempre requerido
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
 		log.Fatal("JWT_SECRET environment variable is required")
 	}
-	authService := services.NewAuthService(userRepo, jwtSecret)
+	authService := services.NewAuthService(userRepo, refreshTokenRepo, jwtSecret)
+
+	// Tokens de sesión de jugadores (invitados)
+	playerTokenSecret := os.Getenv("PLAYER_TOKEN_SECRET")
+	if playerTokenSecret == "" {
+		playerTokenSecret = jwtSecret
+	}
+	playerTokenService := services.NewPlayerTokenService(playerTokenSecret)
+	// Tokens de acceso a eventos protegidos con código (misma clave base que los de jugador)
+	eventAccessTokenService := services.NewEventAccessTokenService(playerTokenSecret)
+
+	// PLAYER_TOKEN_MODE=strict rechaza clientes que solo envían X-Player-ID.
+	// Por defecto (compat) se aceptan mientras los clientes migran a X-Player-Token;
+	// el paso a strict está descripto en docs/api/QUIZ.md.
+	allowLegacyPlayerID := os.Getenv("PLAYER_TOKEN_MODE") != "strict"
 	themeService := services.NewThemeService(themeRepo, eventRepo)
 
 	// Google Drive backup configuration
@@ -123,33 +178,99 @@ func main() {
 		driveAdminHandler = handlers.NewDriveAdminHandler(driveRepo, eventRepo, driveService, backupWorker, true)
 	}
 
-	// WebSocket event validator - valida que el evento existe y está activo
-	eventValidator := &webSocketEventValidator{eventRepo: eventRepo}
+	// WebSocket event validator - valida que el evento existe y es visible
+	eventValidator := &webSocketEventValidator{eventRepo: eventRepo, accessTokens: eventAccessTokenService}
 
 	// Crear WebSocket Hub con validador de eventos
 	hub := websocket.NewHubWithValidator(eventValidator)
+
+	// Quiz en vivo: el hub procesa los mensajes live_* de host y jugadores
+	liveQuizAuth := services.NewLiveQuizAuth(authService, playerTokenService, eventRepo, memberRepo, playerRepo)
+	hub.SetMessageHandler(websocket.NewLiveQuiz(hub, quizQuestionRepo, liveQuizAuth))
 	go hub.Run()
 
-	// Crear handlers
+	// Almacenamiento de media (postales, thumbnails, logos)
 	uploadsDir := os.Getenv("UPLOADS_DIR")
 	if uploadsDir == "" {
 		uploadsDir = uploadPath
 	}
+	mediaStore, err := newMediaStore(uploadsDir)
+	if err != nil {
+		log.Fatal("Failed to configure media storage:", err)
+	}
+	if backupWorker != nil {
+		backupWorker.SetMediaStore(mediaStore)
+	}
 
+	// Crear handlers
 	var handler *handlers.Handler
 	if enableDriveBackup && backupWorker != nil && driveRepo != nil {
-		handler = handlers.NewHandlerWithDrive(playerRepo, quizRepo, quizQuestionRepo, postcardRepo, hub, uploadsDir, driveRepo, backupWorker)
+		handler = handlers.NewHandlerWithDrive(playerRepo, quizRepo, quizQuestionRepo, postcardRepo, hub, mediaStore, driveRepo, backupWorker, playerTokenService)
 	} else {
-		handler = handlers.NewHandler(playerRepo, quizRepo, quizQuestionRepo, postcardRepo, hub, uploadsDir)
+		handler = handlers.NewHandler(playerRepo, quizRepo, quizQuestionRepo, postcardRepo, hub, mediaStore, playerTokenService)
 	}
+	handler.SetTelemetry(telemetryRepo)
+
+	// Procesamiento de videos en background (ffmpeg): MP4 H.264, thumbnail y duración
+	mediaWorker := worker.NewMediaWorker(mediaJobRepo, mediaStore, services.NewFFmpegVideoProcessor(), worker.MediaWorkerNumWorkers)
+	mediaWorker.OnReady(handler.PostcardMediaReady)
+	mediaWorker.Start()
+	handler.SetMediaJobs(mediaWorker)
+
+	// Subidas reanudables: los chunks van al MediaStore, las sesiones vencidas se limpian
+	handler.SetUploads(uploadRepo)
+	uploadCleanupWorker := worker.NewUploadCleanupWorker(uploadRepo, mediaStore, worker.UploadCleanupInterval)
+	uploadCleanupWorker.Start()
+
+	// Reveal programado de la Secret Box (sobrevive reinicios, seguro con varias instancias)
+	secretBoxScheduler := worker.NewSecretBoxScheduler(postcardRepo, hub, worker.SecretBoxSchedulerInterval)
+	secretBoxScheduler.Start()
+
+	// Ciclo de vida de los eventos según starts_at/ends_at (avisa a los rooms en cada cambio)
+	eventLifecycleWorker := worker.NewEventLifecycleWorker(eventRepo, hub, worker.EventLifecycleInterval, worker.EventArchiveAfter)
+	eventLifecycleWorker.Start()
+
+	// Recalculo de puntajes del evento (overrides del host, cambios de preguntas)
+	rescorer := services.NewRescorer(quizQuestionRepo, quizRepo, playerRepo, hub)
+	rescoreWorker := worker.NewRescoreWorker(rescorer, worker.RescoreDebounce)
+	rescoreWorker.Start()
+
+	// Export del evento completo (ZIP en background con link de descarga que vence)
+	eventExportRepo := repository.NewEventExportRepository(db)
+	eventArchiver := services.NewEventArchiver(eventRepo, themeService, postcardRepo, playerRepo, quizRepo, mediaStore)
+	exportWorker := worker.NewExportWorker(eventExportRepo, eventArchiver, mediaStore, worker.ExportRetention)
+	exportWorker.Start()
 
 	authHandler := handlers.NewAuthHandler(authService)
 	themeHandler := handlers.NewThemeHandler(themeService)
-	adminQuestionHandler := handlers.NewAdminQuestionHandler(quizQuestionRepo, eventRepo, eventRepo)
-	adminEventHandler := handlers.NewAdminEventHandler(eventRepo, uploadsDir)
+	adminQuestionHandler := handlers.NewAdminQuestionHandlerWithRescoring(quizQuestionRepo, eventRepo, eventRepo, rescoreWorker)
+	adminQuestionHandler.SetMembers(memberRepo)
+	adminEventHandler := handlers.NewAdminEventHandler(eventRepo, mediaStore)
+	adminEventHandler.SetHub(hub)
 	adminSecretBoxHandler := handlers.NewSecretBoxAdminHandler(eventRepo)
+	secretBoxScheduleHandler := handlers.NewSecretBoxScheduleHandler(eventRepo)
+	secretBoxQueueHandler := handlers.NewSecretBoxQueueHandler(postcardRepo, hub)
+	eventLifecycleHandler := handlers.NewEventLifecycleHandler(eventRepo, hub)
+	eventMemberHandler := handlers.NewEventMemberHandler(memberRepo)
+	eventTemplateHandler := handlers.NewEventTemplateHandler(templateRepo, postcardRepo, mediaStore)
+	guestHandler := handlers.NewGuestHandler(guestRepo, playerTokenService)
+	eventAccessHandler := handlers.NewEventAccessHandler(eventRepo, guestRepo, eventAccessTokenService)
+	eventAccessHandler.SetHub(hub)
+	// PUBLIC_APP_URL: origen del frontend para los QR impresos (vacío = el del pedido)
+	printHandler := handlers.NewPrintHandler(themeService, guestRepo, mediaStore, os.Getenv("PUBLIC_APP_URL"))
+	// Export y borrado de datos de jugadores (GDPR), incluidas sus copias en Drive
+	playerDataHandler := handlers.NewPlayerDataHandler(playerRepo, mediaStore)
+	playerDataHandler.SetHub(hub)
+	if backupWorker != nil {
+		playerDataHandler.SetBackups(backupWorker)
+	}
 	eventHandler := handlers.NewEventHandler(eventRepo)
-	analyticsHandler := handlers.NewAnalyticsHandler(db, eventRepo)
+	analyticsHandler := handlers.NewAnalyticsHandler(db, eventRepo, quizQuestionRepo, quizRepo)
+	quizReviewHandler := handlers.NewQuizReviewHandler(quizQuestionRepo, quizRepo, rescorer)
+	postcardModerationHandler := handlers.NewPostcardModerationHandler(postcardRepo, eventRepo, hub, mediaStore)
+	mediaJobHandler := handlers.NewMediaJobHandler(mediaJobRepo, mediaWorker)
+	uploadHandler := handlers.NewUploadHandler(uploadRepo, mediaStore)
+	eventExportHandler := handlers.NewEventExportHandler(eventExportRepo, exportWorker, services.NewExportDownloadTokenService(jwtSecret), mediaStore)
 
 	// Configurar router
 	r := gin.Default()
@@ -165,8 +286,9 @@ func main() {
 	}
 	config.AllowOrigins = strings.Split(allowedOrigins, ",")
 
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Player-ID", "X-Secret-Token"}
+	config.AllowMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Player-ID", "X-Player-Token", "X-Secret-Token", "X-Event-Access", "Upload-Offset"}
+	config.ExposeHeaders = []string{"X-Player-Token", "Deprecation", "Location", "Upload-Offset", "Upload-Length", "Upload-Expires"}
 	config.AllowCredentials = true
 	r.Use(cors.New(config))
 
@@ -174,7 +296,18 @@ func main() {
 
 	// Middlewares
 	eventMiddleware := middleware.EventMiddleware(eventRepo)
+	adminEventMiddleware := middleware.AdminEventMiddleware(eventRepo)
+	// Qué acepta cada estado: antes de empezar se puede registrar y mandar a la Secret Box,
+	// quiz y corkboard solo con el evento live; al terminar todo queda de solo lectura.
+	eventOpen := middleware.EventStatusMiddleware(models.EventStatusScheduled, models.EventStatusLive)
+	eventLive := middleware.EventStatusMiddleware(models.EventStatusLive)
+	// Las rutas legacy no tienen slug: el ciclo de vida es el del evento del jugador
+	legacyLive := middleware.PlayerEventStatusMiddleware(playerRepo, eventRepo, models.EventStatusLive)
 	authMiddleware := middleware.AuthMiddleware(authService)
+	playerSession := middleware.PlayerSessionMiddleware(playerTokenService, allowLegacyPlayerID)
+	// Los datos personales solo con token firmado: el UUID del jugador es público (ranking)
+	playerTokenOnly := middleware.PlayerSessionMiddleware(playerTokenService, false)
+	eventAccess := middleware.EventAccessMiddleware(eventAccessTokenService)
 
 	// Rutas API
 	api := r.Group("/api")
@@ -197,6 +330,13 @@ func main() {
 		users.Use(authMiddleware)
 		{
 			users.GET("/me/events", eventHandler.GetUserEvents)
+			users.GET("/me/invitations", eventMemberHandler.ListMyInvitations)
+			users.POST("/me/invitations/:id/accept", eventMemberHandler.AcceptInvitation)
+			users.DELETE("/me/invitations/:id", eventMemberHandler.DeclineInvitation)
+			users.GET("/me/templates", eventTemplateHandler.ListTemplates)
+			users.GET("/me/templates/:id", eventTemplateHandler.GetTemplate)
+			users.DELETE("/me/templates/:id", eventTemplateHandler.DeleteTemplate)
+			users.POST("/me/templates/:id/events", eventTemplateHandler.CreateEventFromTemplate)
 		}
 
 		// Events (protegido - crear evento)
@@ -209,9 +349,14 @@ func main() {
 		// Theme presets (public)
 		api.GET("/themes/presets", themeHandler.GetPresets)
 
+		// Código de acceso → token de acceso (única ruta pública sin token en eventos protegidos)
+		api.POST("/events/:slug/access", eventMiddleware, eventAccessHandler.EnterEvent)
+
 		// Event-scoped routes (nuevas - multi-event)
+		// En eventos protegidos con código todas exigen el header X-Event-Access
 		events := api.Group("/events/:slug")
 		events.Use(eventMiddleware)
+		events.Use(eventAccess)
 		{
 			// Event info
 			events.GET("", func(c *gin.Context) {
@@ -223,16 +368,26 @@ func main() {
 			events.GET("/theme", themeHandler.GetTheme)
 
 			// Players
-			events.POST("/players", handler.CreatePlayerScoped)
+			events.POST("/players", eventOpen, handler.CreatePlayerScoped)
 			events.GET("/players", handler.ListPlayersScoped)
 			events.GET("/players/:id", handler.GetPlayer)
 
+			// Datos del propio invitado (GDPR): export en ZIP y borrado
+			events.GET("/me/data", playerTokenOnly, playerDataHandler.ExportMyData)
+			events.DELETE("/me/data", playerTokenOnly, playerDataHandler.EraseMyData)
+
+			// Invitaciones personales (link con token)
+			events.GET("/invitations/:token", guestHandler.GetInvitation)
+			events.PUT("/invitations/:token/rsvp", eventOpen, guestHandler.RespondRSVP)
+			events.POST("/invitations/:token/join", eventOpen, guestHandler.JoinWithInvitation)
+
 			// Quiz
 			quiz := events.Group("/quiz")
 			quiz.Use(middleware.QuizFeatureMiddleware())
 			{
 				quiz.GET("/questions", handler.GetQuizQuestions)
-				quiz.POST("/submit", handler.SubmitQuiz)
+				quiz.POST("/start", eventLive, playerSession, handler.StartQuiz)
+				quiz.POST("/submit", eventLive, playerSession, handler.SubmitQuiz)
 				quiz.GET("/answers/:playerId", handler.GetQuizAnswers)
 			}
 
@@ -243,15 +398,27 @@ func main() {
 			corkboard := events.Group("/postcards")
 			corkboard.Use(middleware.CorkboardFeatureMiddleware())
 			{
-				corkboard.POST("", handler.CreatePostcard)
+				corkboard.POST("", eventLive, playerSession, handler.CreatePostcard)
 				corkboard.GET("", handler.ListPostcards)
+				corkboard.POST("/:id/view", playerSession, handler.ViewPostcard)
+			}
+
+			// Resumable uploads (la postal se crea con upload_id en /postcards o /secret-box)
+			uploads := events.Group("/uploads")
+			uploads.Use(eventOpen)
+			{
+				uploads.POST("", uploadHandler.CreateUpload)
+				uploads.GET("/:id", uploadHandler.GetUpload)
+				uploads.HEAD("/:id", uploadHandler.GetUpload)
+				uploads.PATCH("/:id", uploadHandler.UploadChunk)
+				uploads.DELETE("/:id", uploadHandler.DeleteUpload)
 			}
 
 			// Secret Box
 			secretBox := events.Group("/secret-box")
 			secretBox.Use(middleware.SecretBoxFeatureMiddleware())
 			{
-				secretBox.POST("", handler.CreateSecretPostcard)
+				secretBox.POST("", eventOpen, handler.CreateSecretPostcard)
 			}
 		}
 
@@ -263,7 +430,7 @@ func main() {
 
 		// Quiz
 		api.GET("/quiz/questions", handler.GetQuizQuestions)
-		api.POST("/quiz/submit", handler.SubmitQuiz)
+		api.POST("/quiz/submit", playerSession, legacyLive, handler.SubmitQuiz)
 		api.GET("/quiz/answers/:playerId", handler.GetQuizAnswers)
 		api.GET("/quiz/descriptions", handler.GetDescriptions)
 
@@ -271,8 +438,9 @@ func main() {
 		api.GET("/ranking", handler.GetRanking)
 
 		// Postcards (Cartelera de Corcho)
-		api.POST("/postcards", handler.CreatePostcard)
+		api.POST("/postcards", playerSession, legacyLive, handler.CreatePostcard)
 		api.GET("/postcards", handler.ListPostcards)
+		api.POST("/postcards/:id/view", playerSession, handler.ViewPostcard)
 
 		// Secret Box
 		api.POST("/postcards/secret", handler.CreateSecretPostcard)
@@ -280,10 +448,14 @@ func main() {
 		// Analytics (event-scoped, público para page view tracking)
 		eventsAnalytics := api.Group("/events/:slug")
 		eventsAnalytics.Use(eventMiddleware)
+		eventsAnalytics.Use(eventAccess)
 		{
-			eventsAnalytics.POST("/page-view", analyticsHandler.LogPageView)
+			eventsAnalytics.POST("/page-view", playerSession, analyticsHandler.LogPageView)
 		}
 
+		// Descarga de exports del evento: PÚBLICA, el link lleva un token firmado que vence
+		api.GET("/exports/:id/download", eventExportHandler.DownloadExport)
+
 		// Admin routes (legacy - backward compatibility)
 		api.GET("/admin/status", handler.GetSecretBoxStatus)
 		api.GET("/admin/secret-box", handler.ListSecretPostcards)
@@ -308,41 +480,119 @@ func main() {
 		}
 
 		// Admin routes (event-scoped - multi-event)
+		// Cada ruta exige un permiso según el rol del usuario en el evento
+		// (owner, co_host, moderator, viewer — ver models.EventRole).
 		adminEvents := api.Group("/admin/events/:slug")
-		adminEvents.Use(eventMiddleware)
+		adminEvents.Use(adminEventMiddleware)
 		adminEvents.Use(authMiddleware)
-		adminEvents.Use(middleware.OwnerMiddleware())
+		adminEvents.Use(middleware.EventMemberMiddleware(memberRepo))
 		{
+			canView := middleware.RequireEventPermission(models.PermissionView)
+			canModerate := middleware.RequireEventPermission(models.PermissionModerate)
+			canManage := middleware.RequireEventPermission(models.PermissionManage)
+			canManageMembers := middleware.RequireEventPermission(models.PermissionManageMembers)
+			canDelete := middleware.RequireEventPermission(models.PermissionDelete)
+
 			// Event management
-			adminEvents.DELETE("", eventHandler.DeleteEvent)
-			adminEvents.GET("/status", handler.GetSecretBoxStatus)
-			adminEvents.GET("/secret-box", handler.ListSecretPostcards)
-			adminEvents.POST("/reveal", handler.RevealSecretBox)
-			adminEvents.POST("/secret-box/reset", handler.ResetSecretBox)
-			adminEvents.PUT("/theme", themeHandler.UpdateTheme)
-			adminEvents.POST("/theme/preset", themeHandler.ApplyPreset)
-
-			// Secret Box Admin (token management)
-			adminEvents.GET("/secret-box/token", adminSecretBoxHandler.GetSecretBoxToken)
-			adminEvents.POST("/secret-box/token/regenerate", adminSecretBoxHandler.RegenerateSecretBoxToken)
+			adminEvents.PUT("", canManage, adminEventHandler.UpdateEvent)
+			adminEvents.DELETE("", canDelete, eventHandler.DeleteEvent)
+			adminEvents.GET("/lifecycle", canView, eventLifecycleHandler.GetLifecycle)
+			adminEvents.POST("/lifecycle", canManage, eventLifecycleHandler.ApplyLifecycleAction)
+			adminEvents.POST("/clone", canManage, eventTemplateHandler.CloneEvent)
+			adminEvents.POST("/template", canManage, eventTemplateHandler.SaveAsTemplate)
+			adminEvents.GET("/status", canView, handler.GetSecretBoxStatus)
+			adminEvents.GET("/secret-box", canView, handler.ListSecretPostcards)
+			adminEvents.POST("/reveal", canManage, handler.RevealSecretBox)
+			adminEvents.POST("/secret-box/reset", canManage, handler.ResetSecretBox)
+			adminEvents.PUT("/theme", canManage, themeHandler.UpdateTheme)
+			adminEvents.POST("/theme/preset", canManage, themeHandler.ApplyPreset)
+
+			// Members (co-organizadores)
+			adminEvents.GET("/members", canView, eventMemberHandler.ListMembers)
+			adminEvents.PUT("/members/:userId", canManageMembers, eventMemberHandler.UpdateMemberRole)
+			adminEvents.DELETE("/members/:userId", canView, eventMemberHandler.RemoveMember) // cualquiera puede irse
+			adminEvents.GET("/member-invitations", canManageMembers, eventMemberHandler.ListMemberInvitations)
+			adminEvents.POST("/member-invitations", canManageMembers, eventMemberHandler.InviteMember)
+			adminEvents.DELETE("/member-invitations/:id", canManageMembers, eventMemberHandler.CancelMemberInvitation)
+
+			// Código de acceso al evento
+			adminEvents.GET("/access", canView, eventAccessHandler.GetAccess)
+			adminEvents.PUT("/access", canManage, eventAccessHandler.SetAccessCode)
+			adminEvents.DELETE("/access", canManage, eventAccessHandler.RemoveAccessCode)
+			adminEvents.POST("/access/revoke", canManage, eventAccessHandler.RevokeSessions)
+			adminEvents.POST("/access/token", canView, eventAccessHandler.IssueMemberAccessToken)
+
+			// QR y tarjetas imprimibles (siempre con el slug y token actuales)
+			adminEvents.GET("/qr/:target", canView, printHandler.GetQRCode)
+			adminEvents.GET("/print/table-cards", canView, printHandler.GetTableCards)
+			adminEvents.GET("/print/invitations", canView, printHandler.GetInvitationCards)
+
+			// Guest list & RSVP
+			adminEvents.GET("/guests", canView, guestHandler.ListGuests)
+			adminEvents.POST("/guests/import", canManage, guestHandler.ImportGuests)
+			adminEvents.PUT("/guests/:id", canManage, guestHandler.UpdateGuest)
+			adminEvents.DELETE("/guests/:id", canManage, guestHandler.DeleteGuest)
+
+			// Secret Box Admin (token management, reveal programado)
+			adminEvents.GET("/secret-box/token", canManage, adminSecretBoxHandler.GetSecretBoxToken)
+			adminEvents.POST("/secret-box/token/regenerate", canManage, adminSecretBoxHandler.RegenerateSecretBoxToken)
+			adminEvents.GET("/secret-box/schedule", canView, secretBoxScheduleHandler.GetRevealSchedule)
+			adminEvents.PUT("/secret-box/schedule", canManage, secretBoxScheduleHandler.ScheduleReveal)
+			adminEvents.DELETE("/secret-box/schedule", canManage, secretBoxScheduleHandler.CancelReveal)
+
+			// Secret Box reveal uno por uno (slideshow)
+			adminEvents.GET("/secret-box/queue", canView, secretBoxQueueHandler.GetRevealQueue)
+			adminEvents.PUT("/secret-box/queue", canManage, secretBoxQueueHandler.ReorderRevealQueue)
+			adminEvents.POST("/secret-box/reveal-next", canManage, secretBoxQueueHandler.RevealNext)
 
 			// Quiz Questions Admin
-			adminEvents.GET("/questions", adminQuestionHandler.ListQuestions)
-			adminEvents.POST("/questions", adminQuestionHandler.CreateQuestion)
-			adminEvents.GET("/questions/export", adminQuestionHandler.ExportQuestions)
-			adminEvents.POST("/questions/import", adminQuestionHandler.ImportQuestions)
-			adminEvents.PATCH("/questions/reorder", adminQuestionHandler.ReorderQuestions)
+			adminEvents.GET("/questions", canView, adminQuestionHandler.ListQuestions)
+			adminEvents.POST("/questions", canManage, adminQuestionHandler.CreateQuestion)
+			adminEvents.GET("/questions/export", canView, adminQuestionHandler.ExportQuestions)
+			adminEvents.POST("/questions/import", canManage, adminQuestionHandler.ImportQuestions)
+			adminEvents.PATCH("/questions/reorder", canManage, adminQuestionHandler.ReorderQuestions)
+
+			// Quiz Review (overrides del host y recalculo). Aceptar para todos agrega una
+			// respuesta correcta a la pregunta: es editar el quiz, como /questions (manage).
+			// Los overrides por jugador y el recalculo no cambian las preguntas (moderate).
+			adminEvents.GET("/quiz/answers", canView, quizReviewHandler.ListAnswers)
+			adminEvents.POST("/quiz/players/:playerId/answers/:key/accept", canModerate, quizReviewHandler.AcceptPlayerAnswer)
+			adminEvents.DELETE("/quiz/players/:playerId/answers/:key/accept", canModerate, quizReviewHandler.RevokePlayerAnswer)
+			adminEvents.POST("/quiz/questions/:key/accept", canManage, quizReviewHandler.AcceptAnswerForAll)
+			adminEvents.POST("/quiz/rescore", canModerate, quizReviewHandler.Rescore)
+
+			// Postcard moderation
+			adminEvents.PUT("/moderation", canManage, postcardModerationHandler.UpdateModeration)
+			adminEvents.GET("/postcards", canView, postcardModerationHandler.ListPostcards)
+			adminEvents.POST("/postcards/:id/approve", canModerate, postcardModerationHandler.ApprovePostcard)
+			adminEvents.POST("/postcards/:id/reject", canModerate, postcardModerationHandler.RejectPostcard)
+			adminEvents.DELETE("/postcards/:id", canModerate, postcardModerationHandler.DeletePostcard)
+			adminEvents.GET("/postcards/:id/media", canView, postcardModerationHandler.GetPostcardMedia)
+
+			// Datos de un jugador (GDPR): export en ZIP y borrado, solo el owner
+			adminEvents.GET("/players/:id/data", canDelete, playerDataHandler.ExportPlayerData)
+			adminEvents.DELETE("/players/:id/data", canDelete, playerDataHandler.ErasePlayerData)
+
+			// Export del evento completo (solo el owner)
+			adminEvents.POST("/exports", canDelete, eventExportHandler.CreateExport)
+			adminEvents.GET("/exports", canDelete, eventExportHandler.ListExports)
+			adminEvents.GET("/exports/:id", canDelete, eventExportHandler.GetExport)
+
+			// Video processing jobs
+			adminEvents.GET("/media-jobs", canView, mediaJobHandler.ListMediaJobs)
+			adminEvents.POST("/media-jobs/:id/retry", canModerate, mediaJobHandler.RetryMediaJob)
 
 			// Event Features Admin
-			adminEvents.PUT("/features", adminEventHandler.UpdateEventFeatures)
-			adminEvents.POST("/media", adminEventHandler.UploadMedia)
-			adminEvents.DELETE("/media", adminEventHandler.DeleteMedia)
+			adminEvents.PUT("/features", canManage, adminEventHandler.UpdateEventFeatures)
+			adminEvents.POST("/media", canManage, adminEventHandler.UploadMedia)
+			adminEvents.DELETE("/media", canManage, adminEventHandler.DeleteMedia)
 
 			// Analytics
-			adminEvents.GET("/analytics", analyticsHandler.GetAnalyticsSummary)
-			adminEvents.GET("/analytics/timeline", analyticsHandler.GetAnalyticsTimeline)
-			adminEvents.GET("/analytics/funnel", analyticsHandler.GetAnalyticsFunnel)
-			adminEvents.GET("/analytics/scores", analyticsHandler.GetScoreDistribution)
+			adminEvents.GET("/analytics", canView, analyticsHandler.GetAnalyticsSummary)
+			adminEvents.GET("/analytics/timeline", canView, analyticsHandler.GetAnalyticsTimeline)
+			adminEvents.GET("/analytics/funnel", canView, analyticsHandler.GetAnalyticsFunnel)
+			adminEvents.GET("/analytics/scores", canView, analyticsHandler.GetScoreDistribution)
+			adminEvents.GET("/analytics/questions", canView, analyticsHandler.GetQuestionAnalytics)
 		}
 
 		// Admin routes (question-specific - no event slug needed)
@@ -351,6 +601,7 @@ func main() {
 		{
 			adminQuestions.PUT("/:id", adminQuestionHandler.UpdateQuestion)
 			adminQuestions.DELETE("/:id", adminQuestionHandler.DeleteQuestion)
+			adminQuestions.POST("/:id/check-answer", adminQuestionHandler.CheckAnswer)
 		}
 	}
 
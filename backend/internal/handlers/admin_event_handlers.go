@@ -5,20 +5,36 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
-	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
 )
 
-// EventUpdater define la operación para actualizar un evento.
+// EventUpdater define las operaciones para actualizar un evento.
 // Permite inyectar mocks en tests.
 type EventUpdater interface {
 	Update(event *models.Event) error
+	UpdateDetails(event *models.Event, previousSlug string) error
 }
 
+// EventRenameHub avisa a las pantallas conectadas que el evento cambió de slug
+type EventRenameHub interface {
+	BroadcastEventRenamedToRoom(previousSlug, slug string)
+}
+
+// slugPattern slugs válidos: minúsculas, números y guiones (como los de generateSlug)
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+const (
+	maxSlugLength      = 100 // events.slug VARCHAR(100)
+	maxEventNameLength = 255 // events.name VARCHAR(255)
+)
+
 // UpdateFeaturesRequest body para actualizar features del evento
 type UpdateFeaturesRequest struct {
 	Features struct {
@@ -38,17 +54,90 @@ var allowedFeatures = map[string]bool{
 // AdminEventHandler maneja las peticiones admin de eventos
 type AdminEventHandler struct {
 	eventUpdater EventUpdater
-	uploadsDir   string
+	media        services.MediaStore
+	hub          EventRenameHub
 }
 
 // NewAdminEventHandler crea un nuevo handler de admin de eventos
-func NewAdminEventHandler(eventUpdater EventUpdater, uploadsDir string) *AdminEventHandler {
+func NewAdminEventHandler(eventUpdater EventUpdater, media services.MediaStore) *AdminEventHandler {
 	return &AdminEventHandler{
 		eventUpdater: eventUpdater,
-		uploadsDir:   uploadsDir,
+		media:        media,
 	}
 }
 
+// SetHub habilita avisar por WebSocket cuando cambia el slug del evento
+func (h *AdminEventHandler) SetHub(hub EventRenameHub) {
+	h.hub = hub
+}
+
+// UpdateEvent PUT /api/admin/events/:slug
+// Edita nombre, descripción, fechas y slug del evento. El slug anterior queda como alias,
+// así los links y códigos QR ya compartidos siguen llevando al evento.
+func (h *AdminEventHandler) UpdateEvent(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxEventNameLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("name is required (max %d characters)", maxEventNameLength)})
+		return
+	}
+
+	slug := event.Slug
+	if req.Slug != nil && strings.TrimSpace(*req.Slug) != "" {
+		slug = strings.TrimSpace(*req.Slug)
+		if len(slug) > maxSlugLength || !slugPattern.MatchString(slug) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid slug: use lowercase letters, numbers and hyphens (max %d characters)", maxSlugLength)})
+			return
+		}
+	}
+
+	startsAt, endsAt := req.StartsAt.TimePtr(), req.EndsAt.TimePtr()
+	if startsAt != nil && endsAt != nil && endsAt.Before(*startsAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ends_at must not be before starts_at"})
+		return
+	}
+
+	// Con el evento en vivo las pantallas y el quiz en vivo están atados al slug
+	previousSlug := event.Slug
+	if slug != previousSlug && event.Status == models.EventStatusLive {
+		c.JSON(http.StatusConflict, gin.H{"error": "Slug can't be changed while the event is live", "status": event.Status})
+		return
+	}
+
+	updated := *event
+	updated.Slug = slug
+	updated.Name = name
+	updated.Description = req.Description
+	updated.StartsAt = startsAt
+	updated.EndsAt = endsAt
+
+	if err := h.eventUpdater.UpdateDetails(&updated, previousSlug); err != nil {
+		if err == repository.ErrDuplicateSlug {
+			c.JSON(http.StatusConflict, gin.H{"error": "Event slug already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update event"})
+		return
+	}
+
+	// Las pantallas conectadas con el slug anterior se reconectan con el nuevo
+	if slug != previousSlug && h.hub != nil {
+		h.hub.BroadcastEventRenamedToRoom(previousSlug, slug)
+	}
+
+	c.JSON(http.StatusOK, &updated)
+}
+
 // UpdateEventFeatures PUT /api/admin/events/:slug/features
 // Actualiza los feature flags del evento (merge, no overwrite)
 func (h *AdminEventHandler) UpdateEventFeatures(c *gin.Context) {
@@ -177,46 +266,22 @@ func (h *AdminEventHandler) UploadMedia(c *gin.Context) {
 		ext = ".gif"
 	}
 
-	// Crear directorio si no existe
+	// Carpeta según el tipo de media
 	subDir := "logos"
 	if mediaType == "background" {
 		subDir = "backgrounds"
 	}
 
-	baseDir := h.uploadsDir
-	if baseDir == "" {
-		baseDir = os.Getenv("UPLOADS_DIR")
-	}
-	if baseDir == "" {
-		baseDir = "/app/uploads"
-	}
-
-	uploadDir := filepath.Join(baseDir, subDir)
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload directory"})
-		return
-	}
-
 	// Generar nombre de archivo único
-	filename := fmt.Sprintf("%s_%s%s", eventModel.Slug, uuid.New().String()[:8], ext)
-	diskPath := filepath.Join(uploadDir, filename)
-
-	// Guardar archivo
-	dst, err := os.Create(diskPath)
-	if err != nil {
+	key := fmt.Sprintf("%s/%s_%s%s", subDir, eventModel.Slug, uuid.New().String()[:8], ext)
+	if err := h.media.Put(c.Request.Context(), key, file, header.Size, detectedType); err != nil {
+		fmt.Printf("[ERROR] Failed to store event media %s: %v\n", key, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 		return
 	}
-	defer dst.Close()
-
-	if _, err := io.Copy(dst, file); err != nil {
-		os.Remove(diskPath)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write file"})
-		return
-	}
 
 	// Construir URL pública
-	publicPath := fmt.Sprintf("/uploads/%s/%s", subDir, filename)
+	publicPath := h.media.URL(key)
 
 	// Actualizar el modelo del evento
 	if mediaType == "logo" {
@@ -227,7 +292,7 @@ func (h *AdminEventHandler) UploadMedia(c *gin.Context) {
 
 	// Guardar en DB
 	if err := h.eventUpdater.Update(eventModel); err != nil {
-		os.Remove(diskPath)
+		h.media.Delete(c.Request.Context(), key)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update event"})
 		return
 	}
@@ -265,13 +330,11 @@ func (h *AdminEventHandler) DeleteMedia(c *gin.Context) {
 		eventModel.Settings.BackgroundURL = ""
 	}
 
-	// Intentar eliminar el archivo物理
-	if currentURL != "" {
-		// Convertir URL pública a ruta de disco
-		// /uploads/logos/file.jpg -> /app/uploads/logos/file.jpg
-		relativePath := currentURL[1:] // quitar el "/" inicial
-		diskPath := filepath.Join(h.uploadsDir, "..", relativePath)
-		os.Remove(diskPath) // ignorar errores si no existe
+	// Eliminar el archivo del MediaStore (ignorar errores si no existe)
+	if key, ok := h.media.KeyFromURL(currentURL); ok {
+		if err := h.media.Delete(c.Request.Context(), key); err != nil {
+			fmt.Printf("[WARN] Failed to delete event media %s: %v\n", key, err)
+		}
 	}
 
 	// Guardar en DB
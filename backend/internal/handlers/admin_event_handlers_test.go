@@ -14,12 +14,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
 )
 
 // ============== MOCKS ==============
 
 type mockEventUpdater struct {
-	events map[uuid.UUID]*models.Event
+	events  map[uuid.UUID]*models.Event
+	aliases map[string]uuid.UUID // slug anterior → evento
 }
 
 func newMockEventUpdater() *mockEventUpdater {
@@ -40,6 +43,37 @@ func (m *mockEventUpdater) Update(event *models.Event) error {
 	return nil
 }
 
+func (m *mockEventUpdater) UpdateDetails(event *models.Event, previousSlug string) error {
+	if _, ok := m.events[event.ID]; !ok {
+		return sql.ErrNoRows
+	}
+	for id, other := range m.events {
+		if id != event.ID && other.Slug == event.Slug {
+			return repository.ErrDuplicateSlug
+		}
+	}
+	if owner, ok := m.aliases[event.Slug]; ok && owner != event.ID {
+		return repository.ErrDuplicateSlug
+	}
+	if event.Slug != previousSlug {
+		if m.aliases == nil {
+			m.aliases = make(map[string]uuid.UUID)
+		}
+		delete(m.aliases, event.Slug)
+		m.aliases[previousSlug] = event.ID
+	}
+	m.events[event.ID] = event
+	return nil
+}
+
+type mockEventRenameHub struct {
+	renames [][2]string
+}
+
+func (m *mockEventRenameHub) BroadcastEventRenamedToRoom(previousSlug, slug string) {
+	m.renames = append(m.renames, [2]string{previousSlug, slug})
+}
+
 // ============== HELPERS ==============
 
 func setupTestRouterForEvents(handler *AdminEventHandler) *gin.Engine {
@@ -78,6 +112,97 @@ func createTestEventForFeatures(slug, name string, ownerID uuid.UUID, features m
 
 // ============== TESTS ==============
 
+func TestUpdateEvent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ownerID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	mockUpdater := newMockEventUpdater()
+	hub := &mockEventRenameHub{}
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore(""))
+	handler.SetHub(hub)
+
+	event := createTestEventForFeatures("boda-ana", "Boda de Ana", ownerID, models.EventFeatures{})
+	event.Status = models.EventStatusScheduled
+	other := createTestEventForFeatures("cumple-mile", "Cumple de Mile", ownerID, models.EventFeatures{})
+	mockUpdater.AddEvent(event)
+	mockUpdater.AddEvent(other)
+
+	do := func(event *models.Event, body string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			eventCopy := *mockUpdater.events[event.ID]
+			c.Set("event", &eventCopy)
+			c.Next()
+		})
+		r.PUT("/api/admin/events/:slug", handler.UpdateEvent)
+
+		req, _ := http.NewRequest("PUT", "/api/admin/events/"+event.Slug, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("updates name, description and dates", func(t *testing.T) {
+		w := do(event, `{"name": " Boda de Ana y Luis ", "description": "Civil y fiesta", "starts_at": "2027-03-20", "ends_at": "2027-03-21"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var updated models.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assert.Equal(t, "boda-ana", updated.Slug)
+		assert.Equal(t, "Boda de Ana y Luis", updated.Name)
+		assert.Equal(t, "Civil y fiesta", updated.Description)
+		require.NotNil(t, updated.StartsAt)
+		assert.Equal(t, "2027-03-20", updated.StartsAt.Format("2006-01-02"))
+		assert.Empty(t, hub.renames)
+	})
+
+	t.Run("rename keeps the old slug as alias and notifies the screens", func(t *testing.T) {
+		w := do(event, `{"slug": "boda-ana-y-luis", "name": "Boda de Ana y Luis"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		assert.Equal(t, "boda-ana-y-luis", mockUpdater.events[event.ID].Slug)
+		assert.Nil(t, mockUpdater.events[event.ID].StartsAt, "PUT replaces the dates")
+		assert.Equal(t, event.ID, mockUpdater.aliases["boda-ana"])
+		assert.Equal(t, [][2]string{{"boda-ana", "boda-ana-y-luis"}}, hub.renames)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"missing name":        `{"description": "x"}`,
+			"blank name":          `{"name": "   "}`,
+			"uppercase slug":      `{"slug": "Boda-Ana", "name": "Boda"}`,
+			"slug with spaces":    `{"slug": "boda ana", "name": "Boda"}`,
+			"ends before start":   `{"name": "Boda", "starts_at": "2027-03-21", "ends_at": "2027-03-20"}`,
+			"invalid date format": `{"name": "Boda", "starts_at": "21/03/2027"}`,
+		} {
+			w := do(event, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		}
+	})
+
+	t.Run("slug in use conflicts", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, do(event, `{"slug": "cumple-mile", "name": "Boda"}`).Code)
+		// El slug anterior de otro evento sigue reservado para sus links
+		assert.Equal(t, http.StatusConflict, do(other, `{"slug": "boda-ana", "name": "Cumple"}`).Code)
+	})
+
+	t.Run("an event can go back to a previous slug", func(t *testing.T) {
+		w := do(event, `{"slug": "boda-ana", "name": "Boda de Ana"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotContains(t, mockUpdater.aliases, "boda-ana")
+		assert.Equal(t, event.ID, mockUpdater.aliases["boda-ana-y-luis"])
+	})
+
+	t.Run("slug can't change while the event is live", func(t *testing.T) {
+		mockUpdater.events[event.ID].Status = models.EventStatusLive
+		defer func() { mockUpdater.events[event.ID].Status = models.EventStatusScheduled }()
+
+		assert.Equal(t, http.StatusConflict, do(event, `{"slug": "boda-en-vivo", "name": "Boda"}`).Code)
+		assert.Equal(t, http.StatusOK, do(event, `{"name": "Boda en vivo"}`).Code)
+	})
+}
+
 func TestUpdateEventFeatures_Success(t *testing.T) {
 	mockUpdater := newMockEventUpdater()
 
@@ -89,7 +214,7 @@ func TestUpdateEventFeatures_Success(t *testing.T) {
 	})
 	mockUpdater.AddEvent(event)
 
-	handler := NewAdminEventHandler(mockUpdater, "")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore(""))
 
 	t.Run("enable quiz feature", func(t *testing.T) {
 		// Create router with event in context
@@ -173,7 +298,7 @@ func TestUpdateEventFeatures_InvalidKey(t *testing.T) {
 	event := createTestEventForFeatures("test-event", "Test Event", ownerID, models.EventFeatures{})
 	mockUpdater.AddEvent(event)
 
-	handler := NewAdminEventHandler(mockUpdater, "")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore(""))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -205,7 +330,7 @@ func TestUpdateEventFeatures_EventNotFound(t *testing.T) {
 
 	// No events in mock
 
-	handler := NewAdminEventHandler(mockUpdater, "")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore(""))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -236,7 +361,7 @@ func TestUpdateEventFeatures_InvalidJSON(t *testing.T) {
 	event := createTestEventForFeatures("test-event", "Test Event", ownerID, models.EventFeatures{})
 	mockUpdater.AddEvent(event)
 
-	handler := NewAdminEventHandler(mockUpdater, "")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore(""))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -263,7 +388,7 @@ func TestUpdateEventFeatures_InvalidJSON(t *testing.T) {
 }
 
 // TestUpdateEventFeatures_NonOwnerUser tests handler behavior when user_id doesn't match event owner.
-// Note: Authorization ( OwnerMiddleware ) is tested separately in event_handlers_test.go.
+// Note: Authorization (EventMemberMiddleware + RequireEventPermission) is tested in the middleware package.
 // This test verifies the handler doesn't crash when receiving a non-owner user_id.
 func TestUpdateEventFeatures_NonOwnerUser(t *testing.T) {
 	mockUpdater := newMockEventUpdater()
@@ -272,7 +397,7 @@ func TestUpdateEventFeatures_NonOwnerUser(t *testing.T) {
 	event := createTestEventForFeatures("test-event", "Test Event", ownerID, models.EventFeatures{})
 	mockUpdater.AddEvent(event)
 
-	handler := NewAdminEventHandler(mockUpdater, "")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore(""))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -297,7 +422,7 @@ func TestUpdateEventFeatures_NonOwnerUser(t *testing.T) {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
-	// Handler processes the request successfully (auth is handled by OwnerMiddleware)
+	// Handler processes the request successfully (auth is handled by EventMemberMiddleware)
 	// This test verifies the handler doesn't crash with a non-owner user_id
 	assert.Equal(t, http.StatusOK, w.Code)
 }
@@ -313,7 +438,7 @@ func TestUpdateEventFeatures_UpdateError(t *testing.T) {
 	event := createTestEventForFeatures("test-event", "Test Event", ownerID, models.EventFeatures{})
 	// Note: event is NOT added to failUpdater
 
-	handler := NewAdminEventHandler(failUpdater, "")
+	handler := NewAdminEventHandler(failUpdater, services.NewLocalMediaStore(""))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -348,7 +473,7 @@ func TestUploadMedia_InvalidType(t *testing.T) {
 	event := createTestEventForFeatures("test-event", "Test Event", ownerID, models.EventFeatures{})
 	mockUpdater.AddEvent(event)
 
-	handler := NewAdminEventHandler(mockUpdater, "/tmp/uploads")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore("/tmp/uploads"))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -385,7 +510,7 @@ func TestUploadMedia_NoFile(t *testing.T) {
 	event := createTestEventForFeatures("test-event", "Test Event", ownerID, models.EventFeatures{})
 	mockUpdater.AddEvent(event)
 
-	handler := NewAdminEventHandler(mockUpdater, "/tmp/uploads")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore("/tmp/uploads"))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -418,7 +543,7 @@ func TestUploadMedia_EventNotFound(t *testing.T) {
 	mockUpdater := newMockEventUpdater()
 	// No events added
 
-	handler := NewAdminEventHandler(mockUpdater, "/tmp/uploads")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore("/tmp/uploads"))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -449,7 +574,7 @@ func TestDeleteMedia_InvalidType(t *testing.T) {
 	event.Settings.LogoURL = "/uploads/logos/test.jpg"
 	mockUpdater.AddEvent(event)
 
-	handler := NewAdminEventHandler(mockUpdater, "/tmp/uploads")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore("/tmp/uploads"))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -481,7 +606,7 @@ func TestDeleteMedia_EventNotFound(t *testing.T) {
 	mockUpdater := newMockEventUpdater()
 	// No events added
 
-	handler := NewAdminEventHandler(mockUpdater, "/tmp/uploads")
+	handler := NewAdminEventHandler(mockUpdater, services.NewLocalMediaStore("/tmp/uploads"))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -8,12 +8,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/the-mile-game/backend/internal/models"
 	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
 )
 
 // QuizQuestionAdminRepo define las operaciones de repositorio para admin de preguntas.
 // Permite inyectar mocks en tests.
 type QuizQuestionAdminRepo interface {
-	Create(eventID uuid.UUID, section, key, questionText string, correctAnswers, options []string, sortOrder int, isScorable bool) (*models.QuizQuestion, error)
+	Create(eventID uuid.UUID, section, key, questionText string, correctAnswers, options []string, sortOrder int, isScorable bool, scoring models.QuestionScoring) (*models.QuizQuestion, error)
 	GetByID(id uuid.UUID) (*models.QuizQuestion, error)
 	ListByEvent(eventID uuid.UUID) ([]models.QuizQuestion, error)
 	ListByEventAndSection(eventID uuid.UUID, section string) ([]models.QuizQuestion, error)
@@ -34,11 +35,23 @@ type EventGetter interface {
 	GetByID(id uuid.UUID) (*models.Event, error)
 }
 
+// EventRoleFinder obtiene el rol de un usuario en un evento
+type EventRoleFinder interface {
+	GetRole(eventID, userID uuid.UUID) (models.EventRole, error)
+}
+
+// RescoreEnqueuer encola el recalculo de puntajes de un evento tras cambiar sus preguntas.
+type RescoreEnqueuer interface {
+	EnqueueRescore(eventID uuid.UUID, eventSlug string)
+}
+
 // AdminQuestionHandler maneja las peticiones admin de preguntas del quiz
 type AdminQuestionHandler struct {
 	quizQuestionRepo QuizQuestionAdminRepo
 	eventFinder      EventFinder
 	eventGetter      EventGetter
+	rescoreQueue     RescoreEnqueuer
+	members          EventRoleFinder // nil = solo el owner
 }
 
 // NewAdminQuestionHandler crea un nuevo handler de admin de preguntas
@@ -50,6 +63,26 @@ func NewAdminQuestionHandler(quizQuestionRepo QuizQuestionAdminRepo, eventFinder
 	}
 }
 
+// NewAdminQuestionHandlerWithRescoring crea el handler con recalculo automático de
+// puntajes cuando se editan, eliminan o importan preguntas
+func NewAdminQuestionHandlerWithRescoring(quizQuestionRepo QuizQuestionAdminRepo, eventFinder EventFinder, eventGetter EventGetter, rescoreQueue RescoreEnqueuer) *AdminQuestionHandler {
+	h := NewAdminQuestionHandler(quizQuestionRepo, eventFinder, eventGetter)
+	h.rescoreQueue = rescoreQueue
+	return h
+}
+
+// SetMembers habilita a los co-organizadores del evento según su rol
+func (h *AdminQuestionHandler) SetMembers(members EventRoleFinder) {
+	h.members = members
+}
+
+// enqueueRescore programa el recalculo del ranking del evento (si está configurado)
+func (h *AdminQuestionHandler) enqueueRescore(event *models.Event) {
+	if h.rescoreQueue != nil {
+		h.rescoreQueue.EnqueueRescore(event.ID, event.Slug)
+	}
+}
+
 // ListQuestions GET /api/admin/events/:slug/questions
 // Query params: section (optional), page, per_page
 func (h *AdminQuestionHandler) ListQuestions(c *gin.Context) {
@@ -153,6 +186,13 @@ func (h *AdminQuestionHandler) CreateQuestion(c *gin.Context) {
 		isScorable = *req.IsScorable
 	}
 
+	// Resolver y validar puntos / crédito parcial
+	scoring := resolveQuestionScoring(req.Points, req.PartialCredit, req.Fuzzy)
+	if msg := validateQuestionScoring(scoring); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
 	// Crear pregunta
 	question, err := h.quizQuestionRepo.Create(
 		event.ID,
@@ -163,6 +203,7 @@ func (h *AdminQuestionHandler) CreateQuestion(c *gin.Context) {
 		req.Options,
 		sortOrder,
 		isScorable,
+		scoring,
 	)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create question"})
@@ -172,31 +213,84 @@ func (h *AdminQuestionHandler) CreateQuestion(c *gin.Context) {
 	c.JSON(http.StatusCreated, question)
 }
 
-// checkOwnership verifica que el usuario autenticado sea owner del evento de la pregunta
-func (h *AdminQuestionHandler) checkOwnership(question *models.QuizQuestion, c *gin.Context) bool {
+// maxFuzzyEditDistance tope de errores tolerados; más allá casi cualquier respuesta coincide
+const maxFuzzyEditDistance = 3
+
+// resolveQuestionScoring aplica defaults a los puntos y descarta reglas vacías
+func resolveQuestionScoring(points *int, partialCredit *models.PartialCreditRule, fuzzy *models.FuzzyMatchRule) models.QuestionScoring {
+	scoring := models.QuestionScoring{Points: models.DefaultQuestionPoints}
+	if points != nil {
+		scoring.Points = *points
+	}
+	if partialCredit != nil && len(partialCredit.Answers) > 0 {
+		scoring.PartialCredit = partialCredit
+	}
+	if fuzzy.IsEnabled() {
+		scoring.Fuzzy = fuzzy
+	}
+	return scoring
+}
+
+// validateQuestionScoring retorna un mensaje de error si la configuración de puntos es inválida
+func validateQuestionScoring(scoring models.QuestionScoring) string {
+	if scoring.Points < 1 {
+		return "points must be at least 1 (use is_scorable=false for unscored questions)"
+	}
+	if scoring.PartialCredit != nil {
+		if scoring.PartialCredit.Points <= 0 {
+			return "partial_credit.points must be greater than zero"
+		}
+		if scoring.PartialCredit.Points >= scoring.Points {
+			return "partial_credit.points must be lower than the question points"
+		}
+	}
+	if scoring.Fuzzy != nil {
+		if scoring.Fuzzy.MaxEditDistance < 0 || scoring.Fuzzy.MaxEditDistance > maxFuzzyEditDistance {
+			return "fuzzy.max_edit_distance must be between 0 and " + strconv.Itoa(maxFuzzyEditDistance)
+		}
+		if scoring.Fuzzy.TokenSetSimilarity < 0 || scoring.Fuzzy.TokenSetSimilarity > 1 {
+			return "fuzzy.token_set_similarity must be between 0 and 1"
+		}
+	}
+	return ""
+}
+
+// checkPermission verifica que el rol del usuario autenticado en el evento de la pregunta
+// tenga el permiso. Retorna el evento para que el handler no tenga que volver a buscarlo.
+func (h *AdminQuestionHandler) checkPermission(question *models.QuizQuestion, c *gin.Context, permission models.EventPermission) (*models.Event, bool) {
 	// Obtener user_id del contexto (seteado por AuthMiddleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
-		return false
+		return nil, false
 	}
 
 	// Obtener evento
 	event, err := h.eventGetter.GetByID(question.EventID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event"})
-		return false
+		return nil, false
 	}
 
 	currentUserID := userID.(uuid.UUID)
 
-	// Verificar ownership
-	if currentUserID != event.OwnerID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized. You are not the owner of this event"})
-		return false
+	// El owner puede todo; el resto según su rol
+	if currentUserID == event.OwnerID {
+		return event, true
+	}
+	if h.members != nil {
+		role, err := h.members.GetRole(event.ID, currentUserID)
+		if err == nil && role.Can(permission) {
+			return event, true
+		}
+		if err != nil && err != repository.ErrNotEventMember {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check event membership"})
+			return nil, false
+		}
 	}
 
-	return true
+	c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized for this event"})
+	return nil, false
 }
 
 // UpdateQuestion PUT /api/admin/questions/:id
@@ -219,8 +313,9 @@ func (h *AdminQuestionHandler) UpdateQuestion(c *gin.Context) {
 		return
 	}
 
-	// Verificar ownership
-	if !h.checkOwnership(question, c) {
+	// Verificar permisos sobre el evento
+	event, ok := h.checkPermission(question, c, models.PermissionManage)
+	if !ok {
 		return
 	}
 
@@ -264,6 +359,27 @@ func (h *AdminQuestionHandler) UpdateQuestion(c *gin.Context) {
 	if req.IsScorable != nil {
 		question.IsScorable = *req.IsScorable
 	}
+	if req.Points != nil {
+		question.Points = *req.Points
+	}
+	if req.PartialCredit != nil {
+		if len(req.PartialCredit.Answers) == 0 {
+			question.PartialCredit = nil
+		} else {
+			question.PartialCredit = req.PartialCredit
+		}
+	}
+	if req.Fuzzy != nil {
+		if req.Fuzzy.IsEnabled() {
+			question.Fuzzy = req.Fuzzy
+		} else {
+			question.Fuzzy = nil
+		}
+	}
+	if msg := validateQuestionScoring(question.QuestionScoring); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
 
 	// Actualizar en DB
 	if err := h.quizQuestionRepo.Update(question); err != nil {
@@ -271,6 +387,9 @@ func (h *AdminQuestionHandler) UpdateQuestion(c *gin.Context) {
 		return
 	}
 
+	// Las respuestas válidas o los puntos pueden haber cambiado: recalcular ranking
+	h.enqueueRescore(event)
+
 	// Obtener pregunta actualizada
 	updated, err := h.quizQuestionRepo.GetByID(id)
 	if err != nil {
@@ -281,6 +400,59 @@ func (h *AdminQuestionHandler) UpdateQuestion(c *gin.Context) {
 	c.JSON(http.StatusOK, updated)
 }
 
+// CheckAnswerRequest body para probar una respuesta contra una pregunta
+type CheckAnswerRequest struct {
+	Answer string `json:"answer" binding:"required"`
+}
+
+// CheckAnswer POST /api/admin/questions/:id/check-answer
+// Puntúa una respuesta de prueba e informa por qué fue aceptada (exact, alias o fuzzy),
+// para que el host ajuste alias y tolerancia de cada pregunta.
+func (h *AdminQuestionHandler) CheckAnswer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
+		return
+	}
+
+	question, err := h.quizQuestionRepo.GetByID(id)
+	if err != nil {
+		if err == repository.ErrQuestionNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get question"})
+		return
+	}
+
+	if _, ok := h.checkPermission(question, c, models.PermissionView); !ok {
+		return
+	}
+
+	var req CheckAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scorer := services.NewScorerWithQuestions([]models.QuizQuestion{*question})
+	answers := scorer.NormalizeFavorites(map[string]string{question.Key: req.Answer})
+
+	var breakdown models.ScoreBreakdown
+	if question.Section == "preferences" {
+		breakdown = scorer.Score(nil, answers)
+	} else {
+		breakdown = scorer.Score(answers, nil)
+	}
+
+	if len(breakdown.Questions) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Question is not scorable"})
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown.Questions[0])
+}
+
 // DeleteQuestion DELETE /api/admin/questions/:id
 func (h *AdminQuestionHandler) DeleteQuestion(c *gin.Context) {
 	// Parsear ID
@@ -301,8 +473,9 @@ func (h *AdminQuestionHandler) DeleteQuestion(c *gin.Context) {
 		return
 	}
 
-	// Verificar ownership
-	if !h.checkOwnership(question, c) {
+	// Verificar permisos sobre el evento
+	event, ok := h.checkPermission(question, c, models.PermissionManage)
+	if !ok {
 		return
 	}
 
@@ -312,6 +485,8 @@ func (h *AdminQuestionHandler) DeleteQuestion(c *gin.Context) {
 		return
 	}
 
+	h.enqueueRescore(event)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Question deleted successfully"})
 }
 
@@ -403,6 +578,9 @@ func (h *AdminQuestionHandler) ExportQuestions(c *gin.Context) {
 			"options":         q.Options,
 			"sort_order":      q.SortOrder,
 			"is_scorable":     q.IsScorable,
+			"points":          q.Points,
+			"partial_credit":  q.PartialCredit,
+			"fuzzy":           q.Fuzzy,
 		}
 	}
 
@@ -471,6 +649,13 @@ func (h *AdminQuestionHandler) ImportQuestions(c *gin.Context) {
 			isScorable = *q.IsScorable
 		}
 
+		// Resolver puntos y crédito parcial
+		scoring := resolveQuestionScoring(q.Points, q.PartialCredit, q.Fuzzy)
+		if msg := validateQuestionScoring(scoring); msg != "" {
+			errors = append(errors, "Question "+strconv.Itoa(i+1)+": "+msg)
+			continue
+		}
+
 		// Crear pregunta
 		createdQuestion, err := h.quizQuestionRepo.Create(
 			event.ID,
@@ -481,6 +666,7 @@ func (h *AdminQuestionHandler) ImportQuestions(c *gin.Context) {
 			q.Options,
 			sortOrder,
 			isScorable,
+			scoring,
 		)
 		if err != nil {
 			errors = append(errors, "Question "+strconv.Itoa(i+1)+": failed to create: "+err.Error())
@@ -500,6 +686,8 @@ func (h *AdminQuestionHandler) ImportQuestions(c *gin.Context) {
 		return
 	}
 
+	h.enqueueRescore(event)
+
 	response := gin.H{
 		"imported":  len(created),
 		"questions": created,
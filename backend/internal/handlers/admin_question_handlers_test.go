@@ -32,17 +32,18 @@ func newMockQuizQuestionRepo() *mockQuizQuestionRepo {
 	}
 }
 
-func (m *mockQuizQuestionRepo) Create(eventID uuid.UUID, section, key, questionText string, correctAnswers, options []string, sortOrder int, isScorable bool) (*models.QuizQuestion, error) {
+func (m *mockQuizQuestionRepo) Create(eventID uuid.UUID, section, key, questionText string, correctAnswers, options []string, sortOrder int, isScorable bool, scoring models.QuestionScoring) (*models.QuizQuestion, error) {
 	q := &models.QuizQuestion{
-		ID:             m.nextID,
-		EventID:        eventID,
-		Section:        section,
-		Key:            key,
-		QuestionText:   questionText,
-		CorrectAnswers: correctAnswers,
-		Options:        options,
-		SortOrder:      sortOrder,
-		IsScorable:     isScorable,
+		ID:              m.nextID,
+		EventID:         eventID,
+		Section:         section,
+		Key:             key,
+		QuestionText:    questionText,
+		CorrectAnswers:  correctAnswers,
+		Options:         options,
+		SortOrder:       sortOrder,
+		IsScorable:      isScorable,
+		QuestionScoring: scoring,
 	}
 	m.nextID = uuid.New()
 	m.questions[q.ID] = q
@@ -223,6 +224,7 @@ func setupTestRouterWithAuth(handler *AdminQuestionHandler, userID uuid.UUID) *g
 	r.POST("/api/admin/events/:slug/questions", handler.CreateQuestion)
 	r.PUT("/api/admin/questions/:id", handler.UpdateQuestion)
 	r.DELETE("/api/admin/questions/:id", handler.DeleteQuestion)
+	r.POST("/api/admin/questions/:id/check-answer", handler.CheckAnswer)
 	r.PATCH("/api/admin/events/:slug/questions/reorder", handler.ReorderQuestions)
 	r.GET("/api/admin/events/:slug/questions/export", handler.ExportQuestions)
 	r.POST("/api/admin/events/:slug/questions/import", handler.ImportQuestions)
@@ -251,8 +253,8 @@ func TestAdminQuestionHandler_ListQuestions(t *testing.T) {
 	mockEventGetter.AddEvent(event)
 
 	// Add some questions
-	mockRepo.Create(event.ID, "favorites", "fav_color", "Favorite color?", []string{"Pink"}, nil, 1, true)
-	mockRepo.Create(event.ID, "preferences", "coffee_or_tea", "Coffee or tea?", []string{"Coffee"}, []string{"Coffee", "Tea"}, 1, true)
+	mockRepo.Create(event.ID, "favorites", "fav_color", "Favorite color?", []string{"Pink"}, nil, 1, true, models.QuestionScoring{Points: 1})
+	mockRepo.Create(event.ID, "preferences", "coffee_or_tea", "Coffee or tea?", []string{"Coffee"}, []string{"Coffee", "Tea"}, 1, true, models.QuestionScoring{Points: 1})
 
 	handler := NewAdminQuestionHandler(mockRepo, mockEventFinder, mockEventGetter)
 	router := setupTestRouter(handler)
@@ -332,9 +334,51 @@ func TestAdminQuestionHandler_CreateQuestion(t *testing.T) {
 		assert.Equal(t, "New question?", question.QuestionText)
 	})
 
+	t.Run("weighted with partial credit", func(t *testing.T) {
+		body := `{
+			"section": "favorites",
+			"key": "weighted_question",
+			"question_text": "Weighted?",
+			"correct_answers": ["Girasol"],
+			"points": 3,
+			"partial_credit": {"answers": ["Margarita"], "points": 1}
+		}`
+
+		req, _ := http.NewRequest("POST", "/api/admin/events/test-event/questions", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var question models.QuizQuestion
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &question))
+		assert.Equal(t, 3, question.Points)
+		require.NotNil(t, question.PartialCredit)
+		assert.Equal(t, 1, question.PartialCredit.Points)
+	})
+
+	t.Run("partial credit must be lower than points", func(t *testing.T) {
+		body := `{
+			"section": "favorites",
+			"key": "bad_partial",
+			"question_text": "Bad?",
+			"correct_answers": ["A"],
+			"points": 2,
+			"partial_credit": {"answers": ["B"], "points": 2}
+		}`
+
+		req, _ := http.NewRequest("POST", "/api/admin/events/test-event/questions", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("duplicate key", func(t *testing.T) {
 		// Create first question
-		mockRepo.Create(event.ID, "favorites", "existing_key", "Existing?", []string{"A"}, nil, 1, true)
+		mockRepo.Create(event.ID, "favorites", "existing_key", "Existing?", []string{"A"}, nil, 1, true, models.QuestionScoring{Points: 1})
 
 		body := `{
 			"section": "favorites",
@@ -384,7 +428,7 @@ func TestAdminQuestionHandler_UpdateQuestion(t *testing.T) {
 	mockEventFinder.AddEvent(event)
 	mockEventGetter.AddEvent(event)
 
-	question, _ := mockRepo.Create(event.ID, "favorites", "original_key", "Original?", []string{"Old"}, nil, 1, true)
+	question, _ := mockRepo.Create(event.ID, "favorites", "original_key", "Original?", []string{"Old"}, nil, 1, true, models.QuestionScoring{Points: 1})
 
 	handler := NewAdminQuestionHandler(mockRepo, mockEventFinder, mockEventGetter)
 	router := setupTestRouterWithAuth(handler, event.OwnerID)
@@ -412,7 +456,7 @@ func TestAdminQuestionHandler_UpdateQuestion(t *testing.T) {
 
 	t.Run("duplicate key on update", func(t *testing.T) {
 		// Create another question
-		mockRepo.Create(event.ID, "favorites", "other_key", "Other?", []string{"X"}, nil, 2, true)
+		mockRepo.Create(event.ID, "favorites", "other_key", "Other?", []string{"X"}, nil, 2, true, models.QuestionScoring{Points: 1})
 
 		body := `{"key": "other_key"}` // Try to change to existing key
 
@@ -456,6 +500,31 @@ func TestAdminQuestionHandler_UpdateQuestion(t *testing.T) {
 
 		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
+
+	t.Run("members by role", func(t *testing.T) {
+		coHost, moderator := uuid.New(), uuid.New()
+		handler.SetMembers(mockRoleFinder{coHost: models.EventRoleCoHost, moderator: models.EventRoleModerator})
+		defer handler.SetMembers(nil)
+
+		for userID, want := range map[uuid.UUID]int{coHost: http.StatusOK, moderator: http.StatusForbidden} {
+			req, _ := http.NewRequest("PUT", "/api/admin/questions/"+question.ID.String(), bytes.NewBufferString(`{"question_text": "Co-host edit?"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupTestRouterWithAuth(handler, userID).ServeHTTP(w, req)
+
+			assert.Equal(t, want, w.Code)
+		}
+	})
+}
+
+// mockRoleFinder roles de los co-organizadores por usuario
+type mockRoleFinder map[uuid.UUID]models.EventRole
+
+func (m mockRoleFinder) GetRole(eventID, userID uuid.UUID) (models.EventRole, error) {
+	if role, ok := m[userID]; ok {
+		return role, nil
+	}
+	return "", repository.ErrNotEventMember
 }
 
 func TestAdminQuestionHandler_DeleteQuestion(t *testing.T) {
@@ -468,7 +537,7 @@ func TestAdminQuestionHandler_DeleteQuestion(t *testing.T) {
 	mockEventFinder.AddEvent(event)
 	mockEventGetter.AddEvent(event)
 
-	question, _ := mockRepo.Create(event.ID, "favorites", "to_delete", "To delete?", []string{"A"}, nil, 1, true)
+	question, _ := mockRepo.Create(event.ID, "favorites", "to_delete", "To delete?", []string{"A"}, nil, 1, true, models.QuestionScoring{Points: 1})
 
 	handler := NewAdminQuestionHandler(mockRepo, mockEventFinder, mockEventGetter)
 	router := setupTestRouterWithAuth(handler, event.OwnerID)
@@ -503,7 +572,7 @@ func TestAdminQuestionHandler_DeleteQuestion(t *testing.T) {
 
 	t.Run("forbidden - wrong owner", func(t *testing.T) {
 		// Create a new question for this test (since previous test deleted the other one)
-		question2, _ := mockRepo.Create(event.ID, "favorites", "to_delete_2", "To delete 2?", []string{"B"}, nil, 2, true)
+		question2, _ := mockRepo.Create(event.ID, "favorites", "to_delete_2", "To delete 2?", []string{"B"}, nil, 2, true, models.QuestionScoring{Points: 1})
 
 		// Create router with different user
 		routerWrongOwner := setupTestRouterWithAuth(handler, uuid.MustParse("99999999-9999-9999-9999-999999999999"))
@@ -525,9 +594,9 @@ func TestAdminQuestionHandler_ReorderQuestions(t *testing.T) {
 	mockEventFinder.AddEvent(event)
 	mockEventGetter.AddEvent(event)
 
-	q1, _ := mockRepo.Create(event.ID, "favorites", "q1", "Q1?", []string{"A"}, nil, 1, true)
-	q2, _ := mockRepo.Create(event.ID, "favorites", "q2", "Q2?", []string{"B"}, nil, 2, true)
-	q3, _ := mockRepo.Create(event.ID, "favorites", "q3", "Q3?", []string{"C"}, nil, 3, true)
+	q1, _ := mockRepo.Create(event.ID, "favorites", "q1", "Q1?", []string{"A"}, nil, 1, true, models.QuestionScoring{Points: 1})
+	q2, _ := mockRepo.Create(event.ID, "favorites", "q2", "Q2?", []string{"B"}, nil, 2, true, models.QuestionScoring{Points: 1})
+	q3, _ := mockRepo.Create(event.ID, "favorites", "q3", "Q3?", []string{"C"}, nil, 3, true, models.QuestionScoring{Points: 1})
 
 	handler := NewAdminQuestionHandler(mockRepo, mockEventFinder, mockEventGetter)
 	router := setupTestRouter(handler)
@@ -555,7 +624,7 @@ func TestAdminQuestionHandler_ReorderQuestions(t *testing.T) {
 		otherEvent := createTestEvent("other-event", "Other Event")
 		mockEventFinder.AddEvent(otherEvent)
 		mockEventGetter.AddEvent(otherEvent)
-		otherQ, _ := mockRepo.Create(otherEvent.ID, "favorites", "other", "Other?", []string{"A"}, nil, 1, true)
+		otherQ, _ := mockRepo.Create(otherEvent.ID, "favorites", "other", "Other?", []string{"A"}, nil, 1, true, models.QuestionScoring{Points: 1})
 
 		body := `{"orders": [{"id": "` + otherQ.ID.String() + `", "sort_order": 1}]}`
 
@@ -588,8 +657,8 @@ func TestAdminQuestionHandler_ExportQuestions(t *testing.T) {
 	mockEventFinder.AddEvent(event)
 	mockEventGetter.AddEvent(event)
 
-	mockRepo.Create(event.ID, "favorites", "fav_color", "Favorite color?", []string{"Pink"}, nil, 1, true)
-	mockRepo.Create(event.ID, "preferences", "coffee_or_tea", "Coffee or tea?", []string{"Coffee"}, []string{"Coffee", "Tea"}, 1, true)
+	mockRepo.Create(event.ID, "favorites", "fav_color", "Favorite color?", []string{"Pink"}, nil, 1, true, models.QuestionScoring{Points: 1})
+	mockRepo.Create(event.ID, "preferences", "coffee_or_tea", "Coffee or tea?", []string{"Coffee"}, []string{"Coffee", "Tea"}, 1, true, models.QuestionScoring{Points: 1})
 
 	handler := NewAdminQuestionHandler(mockRepo, mockEventFinder, mockEventGetter)
 	router := setupTestRouter(handler)
@@ -730,3 +799,108 @@ func TestAdminQuestionHandler_ImportQuestions(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
+
+func TestAdminQuestionHandler_CheckAnswer(t *testing.T) {
+	mockRepo := newMockQuizQuestionRepo()
+	mockEventFinder := newMockEventFinder()
+	mockEventGetter := newMockEventGetter()
+
+	event := createTestEvent("test-event", "Test Event")
+	mockEventFinder.AddEvent(event)
+	mockEventGetter.AddEvent(event)
+
+	question, _ := mockRepo.Create(event.ID, "favorites", "singer", "Singer?", []string{"Ricardo Arjona", "Arjona"}, nil, 1, true,
+		models.QuestionScoring{Points: 2, Fuzzy: &models.FuzzyMatchRule{MaxEditDistance: 1}})
+
+	handler := NewAdminQuestionHandler(mockRepo, mockEventFinder, mockEventGetter)
+	router := setupTestRouterWithAuth(handler, event.OwnerID)
+
+	tests := []struct {
+		name   string
+		answer string
+		result string
+		match  string
+	}{
+		{name: "exact", answer: "ricardo arjona", result: models.ScoreResultCorrect, match: models.MatchExact},
+		{name: "alias", answer: "ARJONA", result: models.ScoreResultCorrect, match: models.MatchAlias},
+		{name: "fuzzy typo", answer: "Ricardo Arjna", result: models.ScoreResultCorrect, match: models.MatchFuzzy},
+		{name: "wrong", answer: "Shakira", result: models.ScoreResultIncorrect, match: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"answer": "` + tt.answer + `"}`
+			req, _ := http.NewRequest("POST", "/api/admin/questions/"+question.ID.String()+"/check-answer", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var score models.QuestionScore
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &score))
+			assert.Equal(t, tt.result, score.Result)
+			assert.Equal(t, tt.match, score.Match)
+		})
+	}
+
+	t.Run("not owner", func(t *testing.T) {
+		otherRouter := setupTestRouterWithAuth(handler, uuid.New())
+		req, _ := http.NewRequest("POST", "/api/admin/questions/"+question.ID.String()+"/check-answer", bytes.NewBufferString(`{"answer": "x"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		otherRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+type mockRescoreQueue struct {
+	events []string
+}
+
+func (m *mockRescoreQueue) EnqueueRescore(eventID uuid.UUID, eventSlug string) {
+	m.events = append(m.events, eventSlug)
+}
+
+func TestAdminQuestionHandler_EnqueuesRescoreOnChanges(t *testing.T) {
+	mockRepo := newMockQuizQuestionRepo()
+	mockEventFinder := newMockEventFinder()
+	mockEventGetter := newMockEventGetter()
+
+	event := createTestEvent("test-event", "Test Event")
+	mockEventFinder.AddEvent(event)
+	mockEventGetter.AddEvent(event)
+
+	question, _ := mockRepo.Create(event.ID, "favorites", "flower", "Flower?", []string{"girasol"}, nil, 1, true, models.QuestionScoring{Points: 1})
+
+	queue := &mockRescoreQueue{}
+	handler := NewAdminQuestionHandlerWithRescoring(mockRepo, mockEventFinder, mockEventGetter, queue)
+	router := setupTestRouterWithAuth(handler, event.OwnerID)
+
+	send := func(method, path, body string) int {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, send("PUT", "/api/admin/questions/"+question.ID.String(), `{"correct_answers": ["girasol", "tulipan"]}`))
+	assert.Equal(t, []string{"test-event"}, queue.events)
+
+	require.Equal(t, http.StatusCreated, send("POST", "/api/admin/events/test-event/questions/import",
+		`[{"section": "favorites", "key": "singer", "question_text": "Singer?", "correct_answers": ["arjona"]}]`))
+	assert.Len(t, queue.events, 2)
+
+	require.Equal(t, http.StatusOK, send("DELETE", "/api/admin/questions/"+question.ID.String(), ""))
+	assert.Len(t, queue.events, 3)
+
+	// Un update rechazado no dispara recalculo
+	otherRouter := setupTestRouterWithAuth(handler, uuid.New())
+	req, _ := http.NewRequest("PUT", "/api/admin/questions/"+question.ID.String(), bytes.NewBufferString(`{"points": 2}`))
+	w := httptest.NewRecorder()
+	otherRouter.ServeHTTP(w, req)
+	assert.NotEqual(t, http.StatusOK, w.Code)
+	assert.Len(t, queue.events, 3)
+}
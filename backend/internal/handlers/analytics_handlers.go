@@ -7,20 +7,37 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
 	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
 )
 
+// AnalyticsQuestionRepo obtiene las preguntas (answer key vigente) de un evento
+type AnalyticsQuestionRepo interface {
+	ListByEvent(eventID uuid.UUID) ([]models.QuizQuestion, error)
+}
+
+// AnalyticsAnswerRepo obtiene las respuestas guardadas y las aceptaciones del host
+type AnalyticsAnswerRepo interface {
+	ListAnswersByEvent(eventID uuid.UUID) ([]models.PlayerQuizAnswers, error)
+	ListAcceptedByEvent(eventID uuid.UUID) (map[uuid.UUID]map[string]string, error)
+}
+
 // AnalyticsHandler maneja las peticiones de analytics
 type AnalyticsHandler struct {
-	db        *sql.DB
-	eventRepo *repository.EventRepository
+	db           *sql.DB
+	eventRepo    *repository.EventRepository
+	questionRepo AnalyticsQuestionRepo
+	answerRepo   AnalyticsAnswerRepo
 }
 
 // NewAnalyticsHandler crea un nuevo handler de analytics
-func NewAnalyticsHandler(db *sql.DB, eventRepo *repository.EventRepository) *AnalyticsHandler {
+func NewAnalyticsHandler(db *sql.DB, eventRepo *repository.EventRepository, questionRepo AnalyticsQuestionRepo, answerRepo AnalyticsAnswerRepo) *AnalyticsHandler {
 	return &AnalyticsHandler{
-		db:        db,
-		eventRepo: eventRepo,
+		db:           db,
+		eventRepo:    eventRepo,
+		questionRepo: questionRepo,
+		answerRepo:   answerRepo,
 	}
 }
 
@@ -86,6 +103,13 @@ type ScoreDistributionResponse struct {
 	Distribution []ScoreDistribution `json:"distribution"`
 }
 
+// QuestionAnalyticsResponse estadísticas por pregunta del quiz
+type QuestionAnalyticsResponse struct {
+	EventID     uuid.UUID              `json:"event_id"`
+	Respondents int                    `json:"respondents"` // Jugadores que enviaron el quiz
+	Questions   []models.QuestionStats `json:"questions"`
+}
+
 // GetAnalyticsSummary devuelve estadísticas resumidas para un evento
 func (h *AnalyticsHandler) GetAnalyticsSummary(c *gin.Context) {
 	eventSlug := c.Param("slug")
@@ -118,7 +142,11 @@ func (h *AnalyticsHandler) GetAnalyticsSummary(c *gin.Context) {
 		postcard_stats AS (
 			SELECT 
 				COUNT(*) as total_postcards,
-				COUNT(DISTINCT player_id) as postcards_viewed
+				(
+					SELECT COUNT(DISTINCT postcard_id)
+					FROM postcard_events
+					WHERE event_id = $1 AND event_type = 'viewed'
+				) as postcards_viewed
 			FROM postcards
 			WHERE event_id = $1 AND (is_secret = FALSE OR revealed_at IS NOT NULL)
 		),
@@ -380,6 +408,40 @@ func (h *AnalyticsHandler) GetScoreDistribution(c *gin.Context) {
 	})
 }
 
+// GetQuestionAnalytics GET /api/admin/events/:slug/analytics/questions
+// Porcentaje de aciertos, dificultad y respuestas incorrectas más comunes de cada
+// pregunta, calculado con las respuestas guardadas y el answer key vigente.
+func (h *AnalyticsHandler) GetQuestionAnalytics(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	questions, err := h.questionRepo.ListByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list questions"})
+		return
+	}
+
+	answers, err := h.answerRepo.ListAnswersByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list answers"})
+		return
+	}
+
+	accepted, err := h.answerRepo.ListAcceptedByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list accepted answers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, QuestionAnalyticsResponse{
+		EventID:     event.ID,
+		Respondents: len(answers),
+		Questions:   services.AnalyzeQuestions(questions, answers, accepted),
+	})
+}
+
 // LogPageView registra una visita a una página (llamado desde frontend)
 func (h *AnalyticsHandler) LogPageView(c *gin.Context) {
 	eventSlug := c.Param("slug")
@@ -390,10 +452,9 @@ func (h *AnalyticsHandler) LogPageView(c *gin.Context) {
 		return
 	}
 
+	// Jugador opcional, resuelto por PlayerSessionMiddleware
 	var playerID *uuid.UUID
-	playerIDStr := c.GetHeader("X-Player-ID")
-	if playerIDStr != "" {
-		id, _ := uuid.Parse(playerIDStr)
+	if id, ok := sessionPlayerID(c); ok {
 		playerID = &id
 	}
 
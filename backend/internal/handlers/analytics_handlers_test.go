@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestAnalyticsHandler_GetQuestionAnalytics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	event := createTestEvent("analytics-event", "Analytics Event")
+	questionRepo := newMockQuizQuestionRepo()
+	questionRepo.Create(event.ID, "favorites", "flower", "Flor favorita?", []string{"Girasol"}, nil, 1, true, models.QuestionScoring{Points: 1})
+	questionRepo.Create(event.ID, "favorites", "city", "Ciudad favorita?", []string{}, nil, 2, true, models.QuestionScoring{Points: 1})
+
+	answerRepo := newMockQuizAnswerRepo(
+		models.PlayerQuizAnswers{Player: models.Player{ID: uuid.New(), Name: "Ana"}, Favorites: map[string]string{"flower": "girasol", "city": "rosario"}},
+		models.PlayerQuizAnswers{Player: models.Player{ID: uuid.New(), Name: "Beto"}, Favorites: map[string]string{"flower": "rosa"}},
+	)
+
+	handler := NewAnalyticsHandler(nil, nil, questionRepo, answerRepo)
+	router := gin.New()
+	router.GET("/api/admin/events/:slug/analytics/questions", func(c *gin.Context) {
+		c.Set("event", event)
+		handler.GetQuestionAnalytics(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/admin/events/analytics-event/analytics/questions", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp QuestionAnalyticsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, event.ID, resp.EventID)
+	assert.Equal(t, 2, resp.Respondents)
+	require.Len(t, resp.Questions, 2)
+
+	flower := resp.Questions[0]
+	assert.Equal(t, "flower", flower.Key)
+	assert.Equal(t, 1, flower.Correct)
+	assert.Equal(t, float64(50), flower.CorrectRate)
+	assert.Equal(t, []models.AnswerCount{{Answer: "rosa", Count: 1}}, flower.TopWrongAnswers)
+
+	city := resp.Questions[1]
+	assert.Equal(t, []string{models.QuestionWarningNoValidAnswer}, city.Warnings)
+}
+
+func TestAnalyticsHandler_GetQuestionAnalytics_NoEvent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewAnalyticsHandler(nil, nil, newMockQuizQuestionRepo(), newMockQuizAnswerRepo())
+	router := gin.New()
+	router.GET("/api/admin/events/:slug/analytics/questions", handler.GetQuestionAnalytics)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/admin/events/missing/analytics/questions", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/the-mile-game/backend/internal/models"
 	"github.com/the-mile-game/backend/internal/services"
 )
@@ -77,6 +80,10 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
 			return
 		}
+		if err == services.ErrRefreshTokenReused {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token reuse detected, please log in again"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
 		return
 	}
@@ -104,17 +111,40 @@ func (h *AuthHandler) Me(c *gin.Context) {
 
 // Logout cierra la sesión del usuario autenticado
 // POST /api/auth/logout
-// Nota: Dado que usamos JWT stateless sin blacklist, este endpoint
-// hace logout del lado del cliente. El servidor simplemente confirma.
+// Body opcional {"refresh_token": "..."}: si viene, se revoca solo esa sesión
+// (su familia de rotación); si no, se revocan todos los refresh tokens del usuario.
+// El access token sigue siendo válido hasta expirar (15 min).
 func (h *AuthHandler) Logout(c *gin.Context) {
 	// Verificar que el usuario está autenticado (middleware ya validó el token)
-	_, exists := c.Get("user_id")
+	userIDVal, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
+	userID, ok := userIDVal.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	// El body es opcional; también puede venir chunked (ContentLength -1).
+	// Sin refresh_token se cierran todas las sesiones del usuario.
+	var req models.LogoutRequest
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.authService.Logout(userID, req.RefreshToken); err != nil {
+		if err == services.ErrInvalidRefreshToken {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+		return
+	}
 
-	// Logout stateless: el cliente debe eliminar los tokens
-	// El servidor solo confirma el logout
 	c.JSON(http.StatusOK, gin.H{"message": "Successfully logged out"})
 }
@@ -2,15 +2,20 @@ package handlers
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/the-mile-game/backend/internal/models"
 	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
 )
 
 // MockAuthService simula el servicio de autenticación para tests
@@ -918,3 +923,88 @@ func TestLogoutUnauthenticated(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
 	}
 }
+
+// mockRefreshTokenStore resuelve un único refresh token y registra las revocaciones
+type mockRefreshTokenStore struct {
+	token           *models.RefreshToken
+	revokedFamilies []uuid.UUID
+	revokedUsers    []uuid.UUID
+}
+
+func (m *mockRefreshTokenStore) Create(token *models.RefreshToken) error { return nil }
+
+func (m *mockRefreshTokenStore) GetByHash(tokenHash string) (*models.RefreshToken, error) {
+	if m.token == nil || m.token.TokenHash != tokenHash {
+		return nil, repository.ErrRefreshTokenNotFound
+	}
+	return m.token, nil
+}
+
+func (m *mockRefreshTokenStore) Rotate(oldID uuid.UUID, next *models.RefreshToken) error { return nil }
+
+func (m *mockRefreshTokenStore) RevokeFamily(familyID uuid.UUID) error {
+	m.revokedFamilies = append(m.revokedFamilies, familyID)
+	return nil
+}
+
+func (m *mockRefreshTokenStore) RevokeAllForUser(userID uuid.UUID) error {
+	m.revokedUsers = append(m.revokedUsers, userID)
+	return nil
+}
+
+// TestLogoutReadsBody usa el handler real: el refresh_token del body se respeta
+// aunque el request venga chunked (sin Content-Length)
+func TestLogoutReadsBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	sum := sha256.Sum256([]byte("refresh-token"))
+	familyID := uuid.New()
+
+	do := func(body io.Reader, contentLength int64) *mockRefreshTokenStore {
+		store := &mockRefreshTokenStore{token: &models.RefreshToken{
+			ID: uuid.New(), UserID: userID, FamilyID: familyID, TokenHash: hex.EncodeToString(sum[:]),
+		}}
+		handler := NewAuthHandler(services.NewAuthService(nil, store, "test-secret"))
+
+		r := gin.New()
+		r.POST("/api/auth/logout", func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		}, handler.Logout)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/auth/logout", body)
+		req.ContentLength = contentLength
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		return store
+	}
+
+	t.Run("chunked body with refresh_token revokes only that session", func(t *testing.T) {
+		store := do(io.NopCloser(strings.NewReader(`{"refresh_token":"refresh-token"}`)), -1)
+		if len(store.revokedFamilies) != 1 || store.revokedFamilies[0] != familyID {
+			t.Errorf("Expected family %s revoked, got %v", familyID, store.revokedFamilies)
+		}
+		if len(store.revokedUsers) != 0 {
+			t.Errorf("Expected no global logout, got %v", store.revokedUsers)
+		}
+	})
+
+	t.Run("empty chunked body logs out everywhere", func(t *testing.T) {
+		store := do(io.NopCloser(strings.NewReader("")), -1)
+		if len(store.revokedUsers) != 1 || store.revokedUsers[0] != userID {
+			t.Errorf("Expected user %s revoked, got %v", userID, store.revokedUsers)
+		}
+	})
+
+	t.Run("no body logs out everywhere", func(t *testing.T) {
+		store := do(http.NoBody, 0)
+		if len(store.revokedUsers) != 1 {
+			t.Errorf("Expected global logout, got %v", store.revokedUsers)
+		}
+	})
+}
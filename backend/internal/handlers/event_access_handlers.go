@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// EventAccessRepo operaciones sobre el código de acceso del evento
+type EventAccessRepo interface {
+	SetAccessCode(eventID uuid.UUID, codeHash *string) (int, error)
+	RevokeAccess(eventID uuid.UUID) (int, error)
+}
+
+// InvitationFinder busca la invitación de un link personal
+type InvitationFinder interface {
+	GetByToken(eventID uuid.UUID, token string) (*models.GuestInvitation, error)
+}
+
+// EventAccessTokenIssuer firma los tokens de acceso a eventos protegidos
+type EventAccessTokenIssuer interface {
+	Issue(event *models.Event) (string, time.Time, error)
+}
+
+// EventAccessHub desconecta los WebSockets de un evento al revocar su acceso
+type EventAccessHub interface {
+	RevokeRoomAccess(eventSlug string) int
+}
+
+// Límite de intentos fallidos por IP y evento: los códigos son cortos y se pueden adivinar
+const (
+	maxAccessAttempts   = 10
+	accessAttemptWindow = 15 * time.Minute
+)
+
+// EventAccessHandler maneja el código de acceso de los eventos protegidos
+type EventAccessHandler struct {
+	events      EventAccessRepo
+	invitations InvitationFinder
+	tokens      EventAccessTokenIssuer
+	hub         EventAccessHub
+	attempts    *accessAttemptLimiter
+}
+
+// NewEventAccessHandler crea un nuevo handler de acceso a eventos
+func NewEventAccessHandler(events EventAccessRepo, invitations InvitationFinder, tokens EventAccessTokenIssuer) *EventAccessHandler {
+	return &EventAccessHandler{
+		events:      events,
+		invitations: invitations,
+		tokens:      tokens,
+		attempts:    newAccessAttemptLimiter(maxAccessAttempts, accessAttemptWindow),
+	}
+}
+
+// SetHub configura el hub para desconectar a los invitados al rotar el código o revocar sesiones
+func (h *EventAccessHandler) SetHub(hub EventAccessHub) {
+	h.hub = hub
+}
+
+// EnterEventRequest credencial para entrar a un evento protegido: el código del evento
+// o cualquier link que el host ya compartió (invitación personal o Secret Box)
+type EnterEventRequest struct {
+	Code            string `json:"code"`
+	InvitationToken string `json:"invitation_token"`
+	SecretBoxToken  string `json:"secret_box_token"`
+}
+
+// SetAccessCodeRequest body para proteger el evento; sin code se genera uno
+type SetAccessCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// EnterEvent POST /api/events/:slug/access
+// Cambia el código de acceso (o un link personal / de Secret Box) por un token de acceso.
+// El token va en el header X-Event-Access y, para /ws, en el query param event_access.
+func (h *EventAccessHandler) EnterEvent(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	// Eventos públicos: no hace falta credencial
+	if !event.AccessProtected {
+		h.respondAccessToken(c, event)
+		return
+	}
+
+	var req EnterEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if req.Code == "" && req.InvitationToken == "" && req.SecretBoxToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code is required"})
+		return
+	}
+
+	attemptKey := event.ID.String() + "|" + c.ClientIP()
+	if retryAfter := h.attempts.blockedFor(attemptKey); retryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many failed attempts, try again later"})
+		return
+	}
+
+	granted, err := h.checkCredential(event, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check access"})
+		return
+	}
+	if !granted {
+		h.attempts.fail(attemptKey)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid access code"})
+		return
+	}
+
+	h.attempts.reset(attemptKey)
+	h.respondAccessToken(c, event)
+}
+
+// checkCredential valida la credencial enviada contra el evento
+func (h *EventAccessHandler) checkCredential(event *models.Event, req EnterEventRequest) (bool, error) {
+	switch {
+	case req.Code != "":
+		return services.CheckAccessCode(event, req.Code), nil
+	case req.InvitationToken != "":
+		_, err := h.invitations.GetByToken(event.ID, req.InvitationToken)
+		if err == repository.ErrGuestNotFound {
+			return false, nil
+		}
+		return err == nil, err
+	default:
+		return event.SecretBoxToken != nil && *event.SecretBoxToken != "" &&
+			subtle.ConstantTimeCompare([]byte(req.SecretBoxToken), []byte(*event.SecretBoxToken)) == 1, nil
+	}
+}
+
+// respondAccessToken emite el token de acceso para la versión actual del evento
+func (h *EventAccessHandler) respondAccessToken(c *gin.Context, event *models.Event) {
+	token, expiresAt, err := h.tokens.Issue(event)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue access token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token":     token,
+		"expires_at":       expiresAt,
+		"access_protected": event.AccessProtected,
+	})
+}
+
+// GetAccess GET /api/admin/events/:slug/access
+func (h *EventAccessHandler) GetAccess(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"access_protected": event.AccessProtected})
+}
+
+// SetAccessCode PUT /api/admin/events/:slug/access
+// Protege el evento o rota su código. Se guarda solo el hash, así que el código se
+// devuelve una única vez. Las sesiones abiertas con el código anterior quedan afuera.
+func (h *EventAccessHandler) SetAccessCode(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req SetAccessCodeRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+
+	code := services.NormalizeAccessCode(req.Code)
+	if code == "" {
+		generated, err := services.GenerateAccessCode()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate access code"})
+			return
+		}
+		code = generated
+	}
+	if len(code) < services.MinAccessCodeLength || len(code) > services.MaxAccessCodeLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code must be between 4 and 64 characters"})
+		return
+	}
+
+	hash, err := services.HashAccessCode(code)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save access code"})
+		return
+	}
+	if _, err := h.events.SetAccessCode(event.ID, &hash); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save access code"})
+		return
+	}
+
+	disconnected := h.disconnect(event)
+	c.JSON(http.StatusOK, gin.H{
+		"access_protected": true,
+		"code":             code,
+		"disconnected":     disconnected,
+	})
+}
+
+// RemoveAccessCode DELETE /api/admin/events/:slug/access
+// Vuelve público el evento
+func (h *EventAccessHandler) RemoveAccessCode(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	if _, err := h.events.SetAccessCode(event.ID, nil); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove access code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"access_protected": false})
+}
+
+// RevokeSessions POST /api/admin/events/:slug/access/revoke
+// Invalida los tokens de acceso emitidos y desconecta los WebSockets, sin cambiar el código
+func (h *EventAccessHandler) RevokeSessions(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	if !event.AccessProtected {
+		c.JSON(http.StatusConflict, gin.H{"error": "Event has no access code"})
+		return
+	}
+
+	if _, err := h.events.RevokeAccess(event.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"revoked":      true,
+		"disconnected": h.disconnect(event),
+	})
+}
+
+// IssueMemberAccessToken POST /api/admin/events/:slug/access/token
+// Los miembros del evento entran a las vistas públicas y a /ws sin el código
+func (h *EventAccessHandler) IssueMemberAccessToken(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	h.respondAccessToken(c, event)
+}
+
+// disconnect cierra los WebSockets del evento si hay hub configurado
+func (h *EventAccessHandler) disconnect(event *models.Event) int {
+	if h.hub == nil {
+		return 0
+	}
+	return h.hub.RevokeRoomAccess(event.Slug)
+}
+
+// accessAttemptLimiter cuenta los intentos fallidos por clave (evento + IP) dentro de
+// una ventana fija; al llegar al máximo bloquea la clave hasta que termine la ventana.
+// Vive en memoria: con varias instancias cada una lleva su propia cuenta.
+type accessAttemptLimiter struct {
+	mu          sync.Mutex
+	maxFailures int
+	window      time.Duration
+	now         func() time.Time
+	attempts    map[string]*accessAttempts
+}
+
+type accessAttempts struct {
+	failures    int
+	windowStart time.Time
+}
+
+func newAccessAttemptLimiter(maxFailures int, window time.Duration) *accessAttemptLimiter {
+	return &accessAttemptLimiter{
+		maxFailures: maxFailures,
+		window:      window,
+		now:         time.Now,
+		attempts:    make(map[string]*accessAttempts),
+	}
+}
+
+// blockedFor devuelve cuánto falta para que la clave pueda volver a intentar (0 si puede)
+func (l *accessAttemptLimiter) blockedFor(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	a, ok := l.attempts[key]
+	if !ok {
+		return 0
+	}
+	remaining := a.windowStart.Add(l.window).Sub(l.now())
+	if remaining <= 0 {
+		delete(l.attempts, key)
+		return 0
+	}
+	if a.failures < l.maxFailures {
+		return 0
+	}
+	return remaining
+}
+
+// fail registra un intento fallido
+func (l *accessAttemptLimiter) fail(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	a, ok := l.attempts[key]
+	if !ok || now.Sub(a.windowStart) >= l.window {
+		l.pruneLocked(now)
+		a = &accessAttempts{windowStart: now}
+		l.attempts[key] = a
+	}
+	a.failures++
+}
+
+// reset olvida los intentos de la clave tras un acceso correcto
+func (l *accessAttemptLimiter) reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.attempts, key)
+}
+
+// pruneLocked descarta las ventanas vencidas para que el mapa no crezca sin límite
+func (l *accessAttemptLimiter) pruneLocked(now time.Time) {
+	for key, a := range l.attempts {
+		if now.Sub(a.windowStart) >= l.window {
+			delete(l.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/middleware"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// mockEventAccessRepo aplica los cambios sobre el evento en memoria
+type mockEventAccessRepo struct {
+	event *models.Event
+}
+
+func (m *mockEventAccessRepo) SetAccessCode(eventID uuid.UUID, codeHash *string) (int, error) {
+	m.event.AccessCodeHash = codeHash
+	m.event.AccessProtected = codeHash != nil
+	m.event.AccessVersion++
+	return m.event.AccessVersion, nil
+}
+
+func (m *mockEventAccessRepo) RevokeAccess(eventID uuid.UUID) (int, error) {
+	m.event.AccessVersion++
+	return m.event.AccessVersion, nil
+}
+
+type mockInvitationFinder struct {
+	token string
+}
+
+func (m *mockInvitationFinder) GetByToken(eventID uuid.UUID, token string) (*models.GuestInvitation, error) {
+	if token != m.token {
+		return nil, repository.ErrGuestNotFound
+	}
+	return &models.GuestInvitation{ID: uuid.New(), EventID: eventID, Token: token}, nil
+}
+
+type mockEventAccessHub struct {
+	revoked []string
+}
+
+func (m *mockEventAccessHub) RevokeRoomAccess(eventSlug string) int {
+	m.revoked = append(m.revoked, eventSlug)
+	return 3
+}
+
+func TestEventAccessHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	secretToken := uuid.New().String()
+	event := createTestEvent("party", "Party")
+	event.SecretBoxToken = &secretToken
+
+	repo := &mockEventAccessRepo{event: event}
+	tokens := services.NewEventAccessTokenService("test-secret")
+	hub := &mockEventAccessHub{}
+	handler := NewEventAccessHandler(repo, &mockInvitationFinder{token: "invite-token"}, tokens)
+	handler.SetHub(hub)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Next()
+	})
+	router.POST("/access", handler.EnterEvent)
+	router.GET("/admin/access", handler.GetAccess)
+	router.PUT("/admin/access", handler.SetAccessCode)
+	router.DELETE("/admin/access", handler.RemoveAccessCode)
+	router.POST("/admin/access/revoke", handler.RevokeSessions)
+	router.POST("/admin/access/token", handler.IssueMemberAccessToken)
+	public := router.Group("/public")
+	public.Use(middleware.EventAccessMiddleware(tokens))
+	public.GET("", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+	enter := func(body string) (*httptest.ResponseRecorder, string) {
+		w := do("POST", "/access", body)
+		var resp struct {
+			AccessToken string `json:"access_token"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return w, resp.AccessToken
+	}
+	visit := func(token string) int {
+		return do("GET", "/public", "", middleware.EventAccessHeader, token).Code
+	}
+
+	t.Run("public events need no code", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, visit(""))
+		w, token := enter("")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotEmpty(t, token)
+	})
+
+	var code string
+	t.Run("owner protects the event with a generated code", func(t *testing.T) {
+		w := do("PUT", "/admin/access", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Code         string `json:"code"`
+			Disconnected int    `json:"disconnected"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp.Code, 6)
+		assert.Equal(t, 3, resp.Disconnected)
+		assert.Equal(t, []string{"party"}, hub.revoked)
+		assert.True(t, event.AccessProtected)
+		code = resp.Code
+
+		w = do("GET", "/public", "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), `"access_required":true`)
+	})
+
+	t.Run("guests exchange the code or a shared link for a token", func(t *testing.T) {
+		w, _ := enter(`{"code": "WRONG1"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w, _ = enter(`{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		for _, body := range []string{
+			`{"code": "` + code + `"}`,
+			`{"invitation_token": "invite-token"}`,
+			`{"secret_box_token": "` + secretToken + `"}`,
+		} {
+			w, token := enter(body)
+			require.Equal(t, http.StatusOK, w.Code, body)
+			assert.Equal(t, http.StatusOK, visit(token), body)
+		}
+
+		w, _ = enter(`{"invitation_token": "someone-else"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("revoking sessions invalidates issued tokens", func(t *testing.T) {
+		_, token := enter(`{"code": "` + code + `"}`)
+		require.Equal(t, http.StatusOK, visit(token))
+
+		w := do("POST", "/admin/access/revoke", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, http.StatusUnauthorized, visit(token))
+
+		// El mismo código sigue sirviendo para volver a entrar
+		_, token = enter(`{"code": "` + code + `"}`)
+		assert.Equal(t, http.StatusOK, visit(token))
+	})
+
+	t.Run("rotating the code locks out the old one", func(t *testing.T) {
+		_, oldToken := enter(`{"code": "` + code + `"}`)
+
+		w := do("PUT", "/admin/access", `{"code": "mile 2026"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"code":"MILE2026"`)
+		assert.Equal(t, http.StatusUnauthorized, visit(oldToken))
+
+		w, _ = enter(`{"code": "` + code + `"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w, token := enter(`{"code": "Mile2026"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusOK, visit(token))
+
+		assert.Equal(t, http.StatusBadRequest, do("PUT", "/admin/access", `{"code": "abc"}`).Code)
+	})
+
+	t.Run("members get a token without the code", func(t *testing.T) {
+		w := do("POST", "/admin/access/token", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			AccessToken string `json:"access_token"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, http.StatusOK, visit(resp.AccessToken))
+	})
+
+	t.Run("removing the code makes the event public", func(t *testing.T) {
+		w := do("DELETE", "/admin/access", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, event.AccessProtected)
+		assert.Equal(t, http.StatusOK, visit(""))
+		assert.Equal(t, http.StatusConflict, do("POST", "/admin/access/revoke", "").Code)
+	})
+}
+
+func TestEventAccessHandler_LimitsFailedAttempts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	hash, err := services.HashAccessCode("FIESTA")
+	require.NoError(t, err)
+	event := createTestEvent("party", "Party")
+	event.AccessCodeHash = &hash
+	event.AccessProtected = true
+
+	handler := NewEventAccessHandler(&mockEventAccessRepo{event: event}, &mockInvitationFinder{}, services.NewEventAccessTokenService("test-secret"))
+	now := time.Now()
+	handler.attempts.now = func() time.Time { return now }
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Next()
+	})
+	router.POST("/access", handler.EnterEvent)
+	enter := func(code string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/access", bytes.NewBufferString(`{"code": "`+code+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < maxAccessAttempts; i++ {
+		require.Equal(t, http.StatusUnauthorized, enter("WRONG").Code)
+	}
+
+	// Bloqueado incluso con el código correcto hasta que pase la ventana
+	w := enter("FIESTA")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	now = now.Add(accessAttemptWindow)
+	assert.Equal(t, http.StatusOK, enter("FIESTA").Code)
+}
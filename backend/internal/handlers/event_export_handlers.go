@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// EventExportRepo define las operaciones sobre los exports del evento
+type EventExportRepo interface {
+	Create(eventID uuid.UUID, requestedBy *uuid.UUID) (*models.EventExport, error)
+	GetByID(id uuid.UUID) (*models.EventExport, error)
+	ListByEvent(eventID uuid.UUID) ([]models.EventExport, error)
+}
+
+// EventExportWaker despierta al ExportWorker para que arme el export recién pedido
+type EventExportWaker interface {
+	Wake()
+}
+
+// ExportDownloadTokens firma y valida los links de descarga de los exports
+type ExportDownloadTokens interface {
+	Issue(exportID uuid.UUID, archiveExpiresAt time.Time) (string, time.Time, error)
+	Validate(token string) (uuid.UUID, error)
+}
+
+// EventExportHandler permite al owner descargar todo el evento en un ZIP: lo pide,
+// sigue el progreso y lo baja con un link firmado que vence
+type EventExportHandler struct {
+	exports EventExportRepo
+	worker  EventExportWaker
+	tokens  ExportDownloadTokens
+	media   services.MediaStore
+}
+
+// NewEventExportHandler crea un nuevo handler de exports del evento
+func NewEventExportHandler(exports EventExportRepo, worker EventExportWaker, tokens ExportDownloadTokens, media services.MediaStore) *EventExportHandler {
+	return &EventExportHandler{exports: exports, worker: worker, tokens: tokens, media: media}
+}
+
+// CreateExport POST /api/admin/events/:slug/exports
+// Encola el armado del ZIP del evento (solo el owner). 409 si ya hay uno en curso.
+func (h *EventExportHandler) CreateExport(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var requestedBy *uuid.UUID
+	if userID, ok := c.Get("user_id"); ok {
+		id := userID.(uuid.UUID)
+		requestedBy = &id
+	}
+
+	export, err := h.exports.Create(event.ID, requestedBy)
+	if err == repository.ErrEventExportInProgress {
+		c.JSON(http.StatusConflict, gin.H{"error": "An export of this event is already in progress"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create export"})
+		return
+	}
+
+	if h.worker != nil {
+		h.worker.Wake()
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+// ListExports GET /api/admin/events/:slug/exports
+// Exports del evento, más recientes primero, con el link de descarga de los terminados
+func (h *EventExportHandler) ListExports(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	exports, err := h.exports.ListByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list exports"})
+		return
+	}
+	if exports == nil {
+		exports = []models.EventExport{}
+	}
+
+	for i := range exports {
+		if !h.withDownloadURL(c, &exports[i]) {
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"exports": exports, "total": len(exports)})
+}
+
+// GetExport GET /api/admin/events/:slug/exports/:id
+// Estado y progreso del export. Cada consulta de un export terminado trae un link nuevo.
+func (h *EventExportHandler) GetExport(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export ID"})
+		return
+	}
+
+	export, err := h.exports.GetByID(exportID)
+	if err == repository.ErrEventExportNotFound || (err == nil && export.EventID != event.ID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Export not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get export"})
+		return
+	}
+
+	if !h.withDownloadURL(c, export) {
+		return
+	}
+
+	c.JSON(http.StatusOK, export)
+}
+
+// DownloadExport GET /api/exports/:id/download?token=...
+// Descarga el ZIP con el link firmado (sin login: lo abre el navegador directamente)
+func (h *EventExportHandler) DownloadExport(c *gin.Context) {
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export ID"})
+		return
+	}
+
+	tokenExportID, err := h.tokens.Validate(c.Query("token"))
+	if err != nil || tokenExportID != exportID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid or expired download link"})
+		return
+	}
+
+	export, err := h.exports.GetByID(exportID)
+	if err == repository.ErrEventExportNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Export not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get export"})
+		return
+	}
+	if !downloadable(export) {
+		c.JSON(http.StatusGone, gin.H{"error": "Export is no longer available"})
+		return
+	}
+
+	archive, err := h.media.Get(c.Request.Context(), *export.ArchiveKey)
+	if err == services.ErrMediaNotFound {
+		c.JSON(http.StatusGone, gin.H{"error": "Export is no longer available"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open export"})
+		return
+	}
+	defer archive.Close()
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="event-export-`+export.QueuedAt.Format("2006-01-02")+`.zip"`)
+	if export.SizeBytes != nil {
+		c.Header("Content-Length", strconv.FormatInt(*export.SizeBytes, 10))
+	}
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, archive); err != nil {
+		fmt.Printf("[ERROR] Export %s download interrupted: %v\n", exportID, err)
+	}
+}
+
+// withDownloadURL agrega el link firmado a un export descargable.
+// Escribe la respuesta de error si falla.
+func (h *EventExportHandler) withDownloadURL(c *gin.Context, export *models.EventExport) bool {
+	if !downloadable(export) {
+		return true
+	}
+
+	token, expiresAt, err := h.tokens.Issue(export.ID, *export.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign download link"})
+		return false
+	}
+	export.DownloadURL = "/api/exports/" + export.ID.String() + "/download?token=" + token
+	export.DownloadExpiresAt = &expiresAt
+	return true
+}
+
+// downloadable indica si el ZIP del export está terminado y todavía no venció
+func downloadable(export *models.EventExport) bool {
+	return export.Status == models.EventExportStatusDone && export.ArchiveKey != nil &&
+		export.ExpiresAt != nil && time.Now().Before(*export.ExpiresAt)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// ============== MOCKS ==============
+
+type mockEventExportRepo struct {
+	exports map[uuid.UUID]*models.EventExport
+}
+
+func (m *mockEventExportRepo) Create(eventID uuid.UUID, requestedBy *uuid.UUID) (*models.EventExport, error) {
+	for _, e := range m.exports {
+		if e.EventID == eventID && (e.Status == models.EventExportStatusQueued || e.Status == models.EventExportStatusInProgress) {
+			return nil, repository.ErrEventExportInProgress
+		}
+	}
+	export := &models.EventExport{ID: uuid.New(), EventID: eventID, RequestedBy: requestedBy, Status: models.EventExportStatusQueued}
+	m.exports[export.ID] = export
+	return export, nil
+}
+
+func (m *mockEventExportRepo) GetByID(id uuid.UUID) (*models.EventExport, error) {
+	export, ok := m.exports[id]
+	if !ok {
+		return nil, repository.ErrEventExportNotFound
+	}
+	copied := *export
+	return &copied, nil
+}
+
+func (m *mockEventExportRepo) ListByEvent(eventID uuid.UUID) ([]models.EventExport, error) {
+	var result []models.EventExport
+	for _, e := range m.exports {
+		if e.EventID == eventID {
+			result = append(result, *e)
+		}
+	}
+	return result, nil
+}
+
+// ============== TESTS ==============
+
+func TestEventExportHandler(t *testing.T) {
+	event := createTestEvent("mile-30", "Los 30 de Mile")
+	userID := uuid.New()
+	store := services.NewLocalMediaStore(t.TempDir())
+	archiveKey := "exports/" + uuid.New().String() + ".zip"
+	require.NoError(t, store.Put(context.Background(), archiveKey, strings.NewReader("zip bytes"), -1, "application/zip"))
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	size := int64(len("zip bytes"))
+	done := &models.EventExport{ID: uuid.New(), EventID: event.ID, Status: models.EventExportStatusDone, Progress: 100,
+		ArchiveKey: &archiveKey, SizeBytes: &size, ExpiresAt: &expiresAt, QueuedAt: time.Now()}
+	expiredAt := time.Now().Add(-time.Hour)
+	expired := &models.EventExport{ID: uuid.New(), EventID: event.ID, Status: models.EventExportStatusDone,
+		ArchiveKey: &archiveKey, ExpiresAt: &expiredAt}
+	otherEvent := &models.EventExport{ID: uuid.New(), EventID: uuid.New(), Status: models.EventExportStatusDone,
+		ArchiveKey: &archiveKey, ExpiresAt: &expiresAt}
+
+	repo := &mockEventExportRepo{exports: map[uuid.UUID]*models.EventExport{
+		done.ID: done, expired.ID: expired, otherEvent.ID: otherEvent,
+	}}
+	waker := &mockMediaJobWaker{}
+	tokens := services.NewExportDownloadTokenService("secret")
+	handler := NewEventExportHandler(repo, waker, tokens, store)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	admin := router.Group("/api/admin/events/:slug", func(c *gin.Context) {
+		c.Set("event", event)
+		c.Set("user_id", userID)
+		c.Next()
+	})
+	admin.POST("/exports", handler.CreateExport)
+	admin.GET("/exports", handler.ListExports)
+	admin.GET("/exports/:id", handler.GetExport)
+	router.GET("/api/exports/:id/download", handler.DownloadExport)
+	base := "/api/admin/events/mile-30/exports"
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("request an export", func(t *testing.T) {
+		w := do("POST", base)
+		require.Equal(t, http.StatusAccepted, w.Code)
+		var export models.EventExport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+		assert.Equal(t, models.EventExportStatusQueued, export.Status)
+		assert.Equal(t, &userID, export.RequestedBy)
+		assert.Equal(t, 1, waker.woken)
+
+		// Solo uno en curso por evento
+		w = do("POST", base)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		delete(repo.exports, export.ID)
+	})
+
+	t.Run("done export has a download link", func(t *testing.T) {
+		w := do("GET", base+"/"+done.ID.String())
+		require.Equal(t, http.StatusOK, w.Code)
+		var export models.EventExport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+		assert.Equal(t, 100, export.Progress)
+		require.NotEmpty(t, export.DownloadURL)
+		require.NotNil(t, export.DownloadExpiresAt)
+		assert.True(t, export.DownloadExpiresAt.Before(expiresAt))
+
+		w = do("GET", export.DownloadURL)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+		assert.Equal(t, "zip bytes", w.Body.String())
+	})
+
+	t.Run("list only signs downloadable exports", func(t *testing.T) {
+		w := do("GET", base)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Exports []models.EventExport `json:"exports"`
+			Total   int                  `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, 2, resp.Total)
+		for _, e := range resp.Exports {
+			assert.Equal(t, e.ID == done.ID, e.DownloadURL != "", "export %s", e.ID)
+		}
+	})
+
+	t.Run("export of another event", func(t *testing.T) {
+		w := do("GET", base+"/"+otherEvent.ID.String())
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("download rejects bad or mismatched tokens", func(t *testing.T) {
+		w := do("GET", "/api/exports/"+done.ID.String()+"/download?token=nope")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		token, _, err := tokens.Issue(otherEvent.ID, expiresAt)
+		require.NoError(t, err)
+		w = do("GET", "/api/exports/"+done.ID.String()+"/download?token="+token)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("expired archive is gone", func(t *testing.T) {
+		token, _, err := tokens.Issue(expired.ID, expiresAt)
+		require.NoError(t, err)
+		w := do("GET", "/api/exports/"+expired.ID.String()+"/download?token="+token)
+		assert.Equal(t, http.StatusGone, w.Code)
+	})
+}
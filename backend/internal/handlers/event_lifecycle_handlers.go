@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// EventLifecycleRepo cambia el estado de un evento
+type EventLifecycleRepo interface {
+	SetStatus(eventID uuid.UUID, from, to models.EventStatus) error
+}
+
+// EventStatusHub avisa a las pantallas del evento que cambió su estado
+type EventStatusHub interface {
+	BroadcastEventStatusToRoom(eventSlug string, from, to models.EventStatus)
+}
+
+// EventLifecycleHandler permite al owner mover su evento en el ciclo de vida a mano.
+// Las transiciones por fecha (scheduled → live → ended → archived) las hace el EventLifecycleWorker.
+type EventLifecycleHandler struct {
+	events EventLifecycleRepo
+	hub    EventStatusHub
+}
+
+// NewEventLifecycleHandler crea un nuevo handler del ciclo de vida
+func NewEventLifecycleHandler(events EventLifecycleRepo, hub EventStatusHub) *EventLifecycleHandler {
+	return &EventLifecycleHandler{events: events, hub: hub}
+}
+
+// LifecycleActionRequest acción del owner sobre el ciclo de vida del evento
+type LifecycleActionRequest struct {
+	Action string `json:"action" binding:"required"`
+}
+
+// lifecycleTransition estados desde los que se permite una acción y el estado resultante
+type lifecycleTransition struct {
+	from []models.EventStatus
+	to   func(event *models.Event, now time.Time) models.EventStatus
+}
+
+func toStatus(status models.EventStatus) func(*models.Event, time.Time) models.EventStatus {
+	return func(*models.Event, time.Time) models.EventStatus { return status }
+}
+
+var lifecycleActions = map[string]lifecycleTransition{
+	// publish: según las fechas queda scheduled, live o ended
+	"publish": {
+		from: []models.EventStatus{models.EventStatusDraft},
+		to: func(event *models.Event, now time.Time) models.EventStatus {
+			published := *event
+			published.Status = models.EventStatusScheduled
+			return published.DueStatus(now, 0)
+		},
+	},
+	"unpublish": {from: []models.EventStatus{models.EventStatusScheduled}, to: toStatus(models.EventStatusDraft)},
+	"start":     {from: []models.EventStatus{models.EventStatusDraft, models.EventStatusScheduled}, to: toStatus(models.EventStatusLive)},
+	"end":       {from: []models.EventStatus{models.EventStatusScheduled, models.EventStatusLive}, to: toStatus(models.EventStatusEnded)},
+	"archive": {
+		from: []models.EventStatus{models.EventStatusDraft, models.EventStatusScheduled, models.EventStatusLive, models.EventStatusEnded},
+		to:   toStatus(models.EventStatusArchived),
+	},
+	// restore: vuelve como terminado (solo lectura); se archiva de nuevo pasado el plazo
+	"restore": {from: []models.EventStatus{models.EventStatusArchived}, to: toStatus(models.EventStatusEnded)},
+}
+
+// GetLifecycle GET /api/admin/events/:slug/lifecycle
+func (h *EventLifecycleHandler) GetLifecycle(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, lifecycleResponse(event))
+}
+
+// ApplyLifecycleAction POST /api/admin/events/:slug/lifecycle
+// Acciones: publish, unpublish, start, end, archive, restore.
+func (h *EventLifecycleHandler) ApplyLifecycleAction(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req LifecycleActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "action is required"})
+		return
+	}
+
+	transition, ok := lifecycleActions[req.Action]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown action (use publish, unpublish, start, end, archive or restore)"})
+		return
+	}
+
+	allowed := false
+	for _, s := range transition.from {
+		if event.Status == s {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Action " + req.Action + " is not allowed for a " + string(event.Status) + " event", "status": event.Status})
+		return
+	}
+
+	now := time.Now()
+	from, to := event.Status, transition.to(event, now)
+	if err := h.events.SetStatus(event.ID, from, to); err != nil {
+		if err == repository.ErrEventStatusChanged {
+			c.JSON(http.StatusConflict, gin.H{"error": "Event status changed, reload and try again"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update event status"})
+		return
+	}
+
+	if h.hub != nil {
+		h.hub.BroadcastEventStatusToRoom(event.Slug, from, to)
+	}
+
+	event.Status = to
+	event.StatusChangedAt = now
+	event.IsActive = to.IsActive()
+	c.JSON(http.StatusOK, lifecycleResponse(event))
+}
+
+func lifecycleResponse(event *models.Event) gin.H {
+	return gin.H{
+		"status":            event.Status,
+		"status_changed_at": event.StatusChangedAt,
+		"starts_at":         event.StartsAt,
+		"ends_at":           event.EndsAt,
+		"is_active":         event.IsActive,
+	}
+}
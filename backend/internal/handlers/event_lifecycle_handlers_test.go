@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// ============== MOCKS ==============
+
+type mockLifecycleRepo struct {
+	statuses map[uuid.UUID]models.EventStatus
+}
+
+func (m *mockLifecycleRepo) SetStatus(eventID uuid.UUID, from, to models.EventStatus) error {
+	if m.statuses[eventID] != from {
+		return repository.ErrEventStatusChanged
+	}
+	m.statuses[eventID] = to
+	return nil
+}
+
+type mockEventStatusHub struct {
+	changes [][2]models.EventStatus
+}
+
+func (h *mockEventStatusHub) BroadcastEventStatusToRoom(eventSlug string, from, to models.EventStatus) {
+	h.changes = append(h.changes, [2]models.EventStatus{from, to})
+}
+
+// ============== TESTS ==============
+
+func TestApplyLifecycleAction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		status     models.EventStatus
+		startsAt   *time.Time
+		action     string
+		wantCode   int
+		wantStatus models.EventStatus
+	}{
+		{"publish before the start date schedules", models.EventStatusDraft, &future, "publish", http.StatusOK, models.EventStatusScheduled},
+		{"publish after the start date goes live", models.EventStatusDraft, &past, "publish", http.StatusOK, models.EventStatusLive},
+		{"start early", models.EventStatusScheduled, &future, "start", http.StatusOK, models.EventStatusLive},
+		{"end", models.EventStatusLive, &past, "end", http.StatusOK, models.EventStatusEnded},
+		{"archive", models.EventStatusEnded, &past, "archive", http.StatusOK, models.EventStatusArchived},
+		{"restore comes back read-only", models.EventStatusArchived, &past, "restore", http.StatusOK, models.EventStatusEnded},
+		{"unpublish a live event", models.EventStatusLive, &past, "unpublish", http.StatusConflict, models.EventStatusLive},
+		{"restart an ended event", models.EventStatusEnded, &past, "start", http.StatusConflict, models.EventStatusEnded},
+		{"unknown action", models.EventStatusLive, &past, "pause", http.StatusBadRequest, models.EventStatusLive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := createTestEvent("lifecycle", "Lifecycle")
+			event.Status = tt.status
+			event.StartsAt = tt.startsAt
+			repo := &mockLifecycleRepo{statuses: map[uuid.UUID]models.EventStatus{event.ID: tt.status}}
+			hub := &mockEventStatusHub{}
+			handler := NewEventLifecycleHandler(repo, hub)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("event", event)
+				c.Next()
+			})
+			router.POST("/lifecycle", handler.ApplyLifecycleAction)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/lifecycle", bytes.NewBufferString(`{"action": "`+tt.action+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantCode, w.Code, w.Body.String())
+			assert.Equal(t, tt.wantStatus, repo.statuses[event.ID])
+
+			if tt.wantCode != http.StatusOK {
+				assert.Empty(t, hub.changes)
+				return
+			}
+			var resp struct {
+				Status   models.EventStatus `json:"status"`
+				IsActive bool               `json:"is_active"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, tt.wantStatus.IsActive(), resp.IsActive)
+			assert.Equal(t, [][2]models.EventStatus{{tt.status, tt.wantStatus}}, hub.changes)
+		})
+	}
+}
+
+func TestApplyLifecycleAction_StatusChangedConcurrently(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// El worker ya terminó el evento mientras el owner tenía la pantalla abierta
+	event := createTestEvent("race", "Race")
+	event.Status = models.EventStatusLive
+	repo := &mockLifecycleRepo{statuses: map[uuid.UUID]models.EventStatus{event.ID: models.EventStatusEnded}}
+	hub := &mockEventStatusHub{}
+	handler := NewEventLifecycleHandler(repo, hub)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Next()
+	})
+	router.POST("/lifecycle", handler.ApplyLifecycleAction)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/lifecycle", bytes.NewBufferString(`{"action": "end"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Empty(t, hub.changes)
+}
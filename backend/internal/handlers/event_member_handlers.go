@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// EventMemberRepo define las operaciones sobre los organizadores de un evento
+type EventMemberRepo interface {
+	ListByEvent(eventID uuid.UUID) ([]models.EventMember, error)
+	UpdateRole(eventID, userID uuid.UUID, role models.EventRole) error
+	Remove(eventID, userID uuid.UUID) error
+	Invite(eventID uuid.UUID, email string, role models.EventRole, invitedBy uuid.UUID) (*models.EventMemberInvitation, error)
+	ListInvitationsByEvent(eventID uuid.UUID) ([]models.EventMemberInvitation, error)
+	CancelInvitation(eventID, invitationID uuid.UUID) error
+	ListInvitationsByEmail(email string) ([]models.EventMemberInvitation, error)
+	AcceptInvitation(invitationID, userID uuid.UUID, email string) (*models.EventMember, error)
+	DeclineInvitation(invitationID uuid.UUID, email string) error
+}
+
+// EventMemberHandler maneja los co-organizadores de un evento: invitaciones por email,
+// roles y bajas. Los permisos de cada ruta los verifica RequireEventPermission.
+type EventMemberHandler struct {
+	members EventMemberRepo
+}
+
+// NewEventMemberHandler crea un nuevo handler de miembros
+func NewEventMemberHandler(members EventMemberRepo) *EventMemberHandler {
+	return &EventMemberHandler{members: members}
+}
+
+// ListMembers GET /api/admin/events/:slug/members
+func (h *EventMemberHandler) ListMembers(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.members.ListByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list members"})
+		return
+	}
+	if members == nil {
+		members = []models.EventMember{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// InviteMember POST /api/admin/events/:slug/member-invitations
+// Invita un email con un rol; la invitación la acepta el usuario registrado con ese email.
+func (h *EventMemberHandler) InviteMember(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A valid email and role are required"})
+		return
+	}
+	if !isAssignableRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be co_host, moderator or viewer"})
+		return
+	}
+
+	invitation, err := h.members.Invite(event.ID, req.Email, req.Role, c.MustGet("user_id").(uuid.UUID))
+	if err != nil {
+		switch err {
+		case repository.ErrAlreadyEventMember:
+			c.JSON(http.StatusConflict, gin.H{"error": "User is already a member of this event"})
+		case repository.ErrAlreadyInvited:
+			c.JSON(http.StatusConflict, gin.H{"error": "Email already has a pending invitation"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListMemberInvitations GET /api/admin/events/:slug/member-invitations
+func (h *EventMemberHandler) ListMemberInvitations(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.members.ListInvitationsByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list invitations"})
+		return
+	}
+	if invitations == nil {
+		invitations = []models.EventMemberInvitation{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+// CancelMemberInvitation DELETE /api/admin/events/:slug/member-invitations/:id
+func (h *EventMemberHandler) CancelMemberInvitation(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	if err := h.members.CancelInvitation(event.ID, invitationID); err != nil {
+		if err == repository.ErrInvitationNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel invitation"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateMemberRole PUT /api/admin/events/:slug/members/:userId
+func (h *EventMemberHandler) UpdateMemberRole(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req models.UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role is required"})
+		return
+	}
+	if !isAssignableRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be co_host, moderator or viewer"})
+		return
+	}
+
+	if err := h.members.UpdateRole(event.ID, userID, req.Role); err != nil {
+		respondMemberError(c, err, "Failed to update member role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": userID, "role": req.Role})
+}
+
+// RemoveMember DELETE /api/admin/events/:slug/members/:userId
+// Requiere manage_members, salvo que el miembro se quite a sí mismo (dejar el evento).
+func (h *EventMemberHandler) RemoveMember(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	role, _ := c.Get("event_role")
+	currentRole, _ := role.(models.EventRole)
+	if userID != c.MustGet("user_id").(uuid.UUID) && !currentRole.Can(models.PermissionManageMembers) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your role in this event does not allow this action", "role": currentRole})
+		return
+	}
+
+	if err := h.members.Remove(event.ID, userID); err != nil {
+		respondMemberError(c, err, "Failed to remove member")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListMyInvitations GET /api/users/me/invitations
+// Invitaciones pendientes al email del usuario autenticado.
+func (h *EventMemberHandler) ListMyInvitations(c *gin.Context) {
+	email, _ := c.Get("email")
+
+	invitations, err := h.members.ListInvitationsByEmail(email.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list invitations"})
+		return
+	}
+	if invitations == nil {
+		invitations = []models.EventMemberInvitation{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+// AcceptInvitation POST /api/users/me/invitations/:id/accept
+func (h *EventMemberHandler) AcceptInvitation(c *gin.Context) {
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	email, _ := c.Get("email")
+	member, err := h.members.AcceptInvitation(invitationID, c.MustGet("user_id").(uuid.UUID), email.(string))
+	if err != nil {
+		if err == repository.ErrInvitationNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept invitation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// DeclineInvitation DELETE /api/users/me/invitations/:id
+func (h *EventMemberHandler) DeclineInvitation(c *gin.Context) {
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	email, _ := c.Get("email")
+	if err := h.members.DeclineInvitation(invitationID, email.(string)); err != nil {
+		if err == repository.ErrInvitationNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decline invitation"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// isAssignableRole indica si el rol se puede dar por invitación o cambio de rol (todos menos owner)
+func isAssignableRole(role models.EventRole) bool {
+	return role.IsValid() && role != models.EventRoleOwner
+}
+
+func respondMemberError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case repository.ErrNotEventMember:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+	case repository.ErrOwnerRoleImmutable:
+		c.JSON(http.StatusConflict, gin.H{"error": "The owner cannot be removed or change role"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/middleware"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// ============== MOCKS ==============
+
+type mockEventMemberRepo struct {
+	members     map[uuid.UUID]*models.EventMember
+	invitations map[uuid.UUID]*models.EventMemberInvitation
+}
+
+func newMockEventMemberRepo() *mockEventMemberRepo {
+	return &mockEventMemberRepo{
+		members:     make(map[uuid.UUID]*models.EventMember),
+		invitations: make(map[uuid.UUID]*models.EventMemberInvitation),
+	}
+}
+
+func (m *mockEventMemberRepo) add(eventID uuid.UUID, email string, role models.EventRole) uuid.UUID {
+	userID := uuid.New()
+	m.members[userID] = &models.EventMember{EventID: eventID, UserID: userID, Email: email, Role: role}
+	return userID
+}
+
+func (m *mockEventMemberRepo) GetRole(eventID, userID uuid.UUID) (models.EventRole, error) {
+	member, ok := m.members[userID]
+	if !ok || member.EventID != eventID {
+		return "", repository.ErrNotEventMember
+	}
+	return member.Role, nil
+}
+
+func (m *mockEventMemberRepo) ListByEvent(eventID uuid.UUID) ([]models.EventMember, error) {
+	var result []models.EventMember
+	for _, member := range m.members {
+		result = append(result, *member)
+	}
+	return result, nil
+}
+
+func (m *mockEventMemberRepo) UpdateRole(eventID, userID uuid.UUID, role models.EventRole) error {
+	member, ok := m.members[userID]
+	if !ok {
+		return repository.ErrNotEventMember
+	}
+	if member.Role == models.EventRoleOwner {
+		return repository.ErrOwnerRoleImmutable
+	}
+	member.Role = role
+	return nil
+}
+
+func (m *mockEventMemberRepo) Remove(eventID, userID uuid.UUID) error {
+	member, ok := m.members[userID]
+	if !ok {
+		return repository.ErrNotEventMember
+	}
+	if member.Role == models.EventRoleOwner {
+		return repository.ErrOwnerRoleImmutable
+	}
+	delete(m.members, userID)
+	return nil
+}
+
+func (m *mockEventMemberRepo) Invite(eventID uuid.UUID, email string, role models.EventRole, invitedBy uuid.UUID) (*models.EventMemberInvitation, error) {
+	for _, member := range m.members {
+		if strings.EqualFold(member.Email, email) {
+			return nil, repository.ErrAlreadyEventMember
+		}
+	}
+	for _, invitation := range m.invitations {
+		if strings.EqualFold(invitation.Email, email) {
+			return nil, repository.ErrAlreadyInvited
+		}
+	}
+	invitation := &models.EventMemberInvitation{ID: uuid.New(), EventID: eventID, Email: email, Role: role, InvitedBy: &invitedBy, CreatedAt: time.Now()}
+	m.invitations[invitation.ID] = invitation
+	return invitation, nil
+}
+
+func (m *mockEventMemberRepo) ListInvitationsByEvent(eventID uuid.UUID) ([]models.EventMemberInvitation, error) {
+	return m.ListInvitationsByEmail("")
+}
+
+func (m *mockEventMemberRepo) CancelInvitation(eventID, invitationID uuid.UUID) error {
+	if _, ok := m.invitations[invitationID]; !ok {
+		return repository.ErrInvitationNotFound
+	}
+	delete(m.invitations, invitationID)
+	return nil
+}
+
+func (m *mockEventMemberRepo) ListInvitationsByEmail(email string) ([]models.EventMemberInvitation, error) {
+	var result []models.EventMemberInvitation
+	for _, invitation := range m.invitations {
+		if email == "" || strings.EqualFold(invitation.Email, email) {
+			result = append(result, *invitation)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockEventMemberRepo) AcceptInvitation(invitationID, userID uuid.UUID, email string) (*models.EventMember, error) {
+	invitation, ok := m.invitations[invitationID]
+	if !ok || !strings.EqualFold(invitation.Email, email) {
+		return nil, repository.ErrInvitationNotFound
+	}
+	delete(m.invitations, invitationID)
+	member := &models.EventMember{EventID: invitation.EventID, UserID: userID, Email: email, Role: invitation.Role}
+	m.members[userID] = member
+	return member, nil
+}
+
+func (m *mockEventMemberRepo) DeclineInvitation(invitationID uuid.UUID, email string) error {
+	invitation, ok := m.invitations[invitationID]
+	if !ok || !strings.EqualFold(invitation.Email, email) {
+		return repository.ErrInvitationNotFound
+	}
+	delete(m.invitations, invitationID)
+	return nil
+}
+
+// ============== TESTS ==============
+
+func TestEventMemberHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	event := createTestEvent("boda", "Boda")
+	repo := newMockEventMemberRepo()
+	ownerID := repo.add(event.ID, "novia@example.com", models.EventRoleOwner)
+	event.OwnerID = ownerID
+	moderatorID := repo.add(event.ID, "prima@example.com", models.EventRoleModerator)
+	handler := NewEventMemberHandler(repo)
+
+	// Cada request simula AuthMiddleware para un usuario; las rutas del evento pasan
+	// por EventMemberMiddleware y RequireEventPermission como en main.go
+	do := func(userID uuid.UUID, email, method, path, body string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("event", event)
+			c.Set("user_id", userID)
+			c.Set("email", email)
+			c.Next()
+		})
+		canView := middleware.RequireEventPermission(models.PermissionView)
+		canManageMembers := middleware.RequireEventPermission(models.PermissionManageMembers)
+		admin := router.Group("", middleware.EventMemberMiddleware(repo))
+		admin.GET("/members", canView, handler.ListMembers)
+		admin.PUT("/members/:userId", canManageMembers, handler.UpdateMemberRole)
+		admin.DELETE("/members/:userId", canView, handler.RemoveMember)
+		admin.POST("/member-invitations", canManageMembers, handler.InviteMember)
+		router.GET("/me/invitations", handler.ListMyInvitations)
+		router.POST("/me/invitations/:id/accept", handler.AcceptInvitation)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("only members see the event and only the owner manages members", func(t *testing.T) {
+		w := do(uuid.New(), "intruso@example.com", "GET", "/members", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(moderatorID, "prima@example.com", "GET", "/members", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = do(moderatorID, "prima@example.com", "POST", "/member-invitations", `{"email": "otro@example.com", "role": "viewer"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("invite validates the role", func(t *testing.T) {
+		w := do(ownerID, "novia@example.com", "POST", "/member-invitations", `{"email": "otro@example.com", "role": "owner"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = do(ownerID, "novia@example.com", "POST", "/member-invitations", `{"email": "prima@example.com", "role": "viewer"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	var coHostID uuid.UUID
+	t.Run("invited user accepts with the invited email only", func(t *testing.T) {
+		w := do(ownerID, "novia@example.com", "POST", "/member-invitations", `{"email": "Hermano@example.com", "role": "co_host"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var invitation models.EventMemberInvitation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invitation))
+
+		w = do(ownerID, "novia@example.com", "POST", "/member-invitations", `{"email": "hermano@example.com", "role": "viewer"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = do(uuid.New(), "intruso@example.com", "POST", "/me/invitations/"+invitation.ID.String()+"/accept", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		coHostID = uuid.New()
+		w = do(coHostID, "hermano@example.com", "GET", "/me/invitations", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), invitation.ID.String())
+
+		w = do(coHostID, "hermano@example.com", "POST", "/me/invitations/"+invitation.ID.String()+"/accept", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, models.EventRoleCoHost, repo.members[coHostID].Role)
+	})
+
+	t.Run("owner changes roles but never its own", func(t *testing.T) {
+		w := do(ownerID, "novia@example.com", "PUT", "/members/"+moderatorID.String(), `{"role": "viewer"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, models.EventRoleViewer, repo.members[moderatorID].Role)
+
+		w = do(ownerID, "novia@example.com", "PUT", "/members/"+ownerID.String(), `{"role": "viewer"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("members can leave but not remove others", func(t *testing.T) {
+		w := do(moderatorID, "prima@example.com", "DELETE", "/members/"+coHostID.String(), "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, repo.members, coHostID)
+
+		w = do(moderatorID, "prima@example.com", "DELETE", "/members/"+moderatorID.String(), "")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NotContains(t, repo.members, moderatorID)
+
+		w = do(ownerID, "novia@example.com", "DELETE", "/members/"+ownerID.String(), "")
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestEventRolePermissions(t *testing.T) {
+	tests := []struct {
+		role    models.EventRole
+		allowed []models.EventPermission
+		denied  []models.EventPermission
+	}{
+		{models.EventRoleOwner, []models.EventPermission{models.PermissionDelete, models.PermissionManageMembers, models.PermissionManage}, nil},
+		{models.EventRoleCoHost, []models.EventPermission{models.PermissionManage, models.PermissionModerate}, []models.EventPermission{models.PermissionDelete, models.PermissionManageMembers}},
+		{models.EventRoleModerator, []models.EventPermission{models.PermissionModerate, models.PermissionView}, []models.EventPermission{models.PermissionManage, models.PermissionDelete}},
+		{models.EventRoleViewer, []models.EventPermission{models.PermissionView}, []models.EventPermission{models.PermissionModerate}},
+		{models.EventRole("admin"), nil, []models.EventPermission{models.PermissionView}},
+	}
+
+	for _, tt := range tests {
+		for _, p := range tt.allowed {
+			assert.True(t, tt.role.Can(p), "%s should be able to %s", tt.role, p)
+		}
+		for _, p := range tt.denied {
+			assert.False(t, tt.role.Can(p), "%s should not be able to %s", tt.role, p)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// EventTemplateRepo define las operaciones para duplicar eventos y manejar templates
+type EventTemplateRepo interface {
+	CloneEvent(source *models.Event, ownerID uuid.UUID, slug, name string,
+		startsAt, endsAt *time.Time, opts models.CloneOptions, mediaURLs map[string]string) (*models.Event, error)
+	CreateTemplate(ownerID uuid.UUID, source *models.Event, name, description string,
+		mediaURLs map[string]string) (*models.EventTemplate, error)
+	GetTemplate(id uuid.UUID) (*models.EventTemplate, error)
+	ListTemplatesByOwner(ownerID uuid.UUID) ([]models.EventTemplate, error)
+	DeleteTemplate(id uuid.UUID) error
+	CreateEventFromTemplate(template *models.EventTemplate, ownerID uuid.UUID, slug, name, description string,
+		startsAt, endsAt *time.Time, draft bool, mediaURLs map[string]string) (*models.Event, error)
+}
+
+// PostcardMediaLister lista la media de las postales de un evento para copiarla
+type PostcardMediaLister interface {
+	ListMediaPathsByEvent(eventID uuid.UUID) ([]string, error)
+}
+
+// templateMediaDir carpeta del MediaStore con las copias de media de los templates
+const templateMediaDir = "templates"
+
+// EventTemplateHandler duplica eventos y maneja los templates personales.
+// Cada copia (evento o template) tiene sus propios archivos en el MediaStore,
+// así borrar uno no rompe el logo o las postales del otro.
+type EventTemplateHandler struct {
+	templates EventTemplateRepo
+	postcards PostcardMediaLister
+	media     services.MediaStore
+}
+
+// NewEventTemplateHandler crea un nuevo handler de duplicado y templates
+func NewEventTemplateHandler(templates EventTemplateRepo, postcards PostcardMediaLister, media services.MediaStore) *EventTemplateHandler {
+	return &EventTemplateHandler{templates: templates, postcards: postcards, media: media}
+}
+
+// mediaCopies copia archivos del MediaStore recordando la URL de cada copia,
+// para reemplazarlas en el evento nuevo o borrarlas si la operación falla
+type mediaCopies struct {
+	media services.MediaStore
+	urls  map[string]string // URL original → URL de la copia
+	keys  []string
+}
+
+func newMediaCopies(media services.MediaStore) *mediaCopies {
+	return &mediaCopies{media: media, urls: make(map[string]string)}
+}
+
+// copy copia el archivo de url a dir (vacío = la misma carpeta) con un nombre nuevo.
+// Las URLs externas y los archivos que ya no existen se dejan tal cual.
+func (m *mediaCopies) copy(ctx context.Context, url, dir string) error {
+	if url == "" {
+		return nil
+	}
+	if _, done := m.urls[url]; done {
+		return nil
+	}
+	key, ok := m.media.KeyFromURL(url)
+	if !ok {
+		return nil
+	}
+
+	if dir == "" {
+		dir = path.Dir(key)
+	}
+	newKey := dir + "/" + uuid.New().String() + path.Ext(key)
+	if err := services.CopyMedia(ctx, m.media, key, newKey); err != nil {
+		if errors.Is(err, services.ErrMediaNotFound) {
+			return nil
+		}
+		return fmt.Errorf("copy %s: %w", key, err)
+	}
+
+	m.keys = append(m.keys, newKey)
+	m.urls[url] = m.media.URL(newKey)
+	return nil
+}
+
+// copySettings copia el logo y el fondo de un evento o template a dir
+// (vacío = las carpetas de media de eventos, como UploadMedia)
+func (m *mediaCopies) copySettings(ctx context.Context, settings models.EventSettings, dir string) error {
+	logoDir, backgroundDir := dir, dir
+	if dir == "" {
+		logoDir, backgroundDir = "logos", "backgrounds"
+	}
+	for url, dir := range map[string]string{
+		settings.LogoURL:         logoDir,
+		settings.BackgroundURL:   backgroundDir,
+		settings.BackgroundImage: backgroundDir,
+	} {
+		if err := m.copy(ctx, url, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discard borra las copias hechas (la operación falló y nadie las referencia)
+func (m *mediaCopies) discard(ctx context.Context) {
+	for _, key := range m.keys {
+		if err := m.media.Delete(ctx, key); err != nil {
+			fmt.Printf("[WARN] Failed to remove copied media %s: %v\n", key, err)
+		}
+	}
+}
+
+// slugOrGenerated devuelve el slug pedido o uno generado a partir del nombre
+func slugOrGenerated(slug *string, name string) string {
+	if slug == nil || strings.TrimSpace(*slug) == "" {
+		return generateSlug(name)
+	}
+	return *slug
+}
+
+// CloneEvent POST /api/admin/events/:slug/clone
+// Duplica el evento en un draft nuevo del usuario: features, settings, theme, preguntas
+// y media. Los invitados y las postales se copian salvo exclude_guests / exclude_postcards.
+func (h *EventTemplateHandler) CloneEvent(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.CloneEventRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = event.Name + " (copia)"
+	}
+
+	ctx := c.Request.Context()
+	copies := newMediaCopies(h.media)
+	if err := copies.copySettings(ctx, event.Settings, ""); err != nil {
+		fmt.Printf("[ERROR] Failed to copy media of %s: %v\n", event.Slug, err)
+		copies.discard(ctx)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to copy event media"})
+		return
+	}
+	if !req.ExcludePostcards {
+		paths, err := h.postcards.ListMediaPathsByEvent(event.ID)
+		if err != nil {
+			copies.discard(ctx)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list postcards"})
+			return
+		}
+		for _, url := range paths {
+			if err := copies.copy(ctx, url, ""); err != nil {
+				fmt.Printf("[ERROR] Failed to copy postcard media of %s: %v\n", event.Slug, err)
+				copies.discard(ctx)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to copy postcard media"})
+				return
+			}
+		}
+	}
+
+	opts := models.CloneOptions{ExcludeGuests: req.ExcludeGuests, ExcludePostcards: req.ExcludePostcards}
+	clone, err := h.templates.CloneEvent(event, c.MustGet("user_id").(uuid.UUID), slugOrGenerated(req.Slug, name), name,
+		req.StartsAt.TimePtr(), req.EndsAt.TimePtr(), opts, copies.urls)
+	if err != nil {
+		copies.discard(ctx)
+		if err == repository.ErrDuplicateSlug {
+			c.JSON(http.StatusConflict, gin.H{"error": "Event slug already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clone event"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, clone)
+}
+
+// SaveAsTemplate POST /api/admin/events/:slug/template
+// Guarda features, settings, theme, preguntas y media del evento como template personal.
+func (h *EventTemplateHandler) SaveAsTemplate(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.SaveTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	copies := newMediaCopies(h.media)
+	if err := copies.copySettings(ctx, event.Settings, templateMediaDir); err != nil {
+		fmt.Printf("[ERROR] Failed to copy media of %s: %v\n", event.Slug, err)
+		copies.discard(ctx)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to copy event media"})
+		return
+	}
+
+	template, err := h.templates.CreateTemplate(c.MustGet("user_id").(uuid.UUID), event,
+		strings.TrimSpace(req.Name), req.Description, copies.urls)
+	if err != nil {
+		copies.discard(ctx)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save template"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// ListTemplates GET /api/users/me/templates
+func (h *EventTemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.templates.ListTemplatesByOwner(c.MustGet("user_id").(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list templates"})
+		return
+	}
+	if templates == nil {
+		templates = []models.EventTemplate{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+// templateFromRequest obtiene el template del path verificando que sea del usuario.
+// Escribe la respuesta de error si falla.
+func (h *EventTemplateHandler) templateFromRequest(c *gin.Context) (*models.EventTemplate, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return nil, false
+	}
+
+	template, err := h.templates.GetTemplate(id)
+	if err == repository.ErrTemplateNotFound || (err == nil && template.OwnerID != c.MustGet("user_id").(uuid.UUID)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get template"})
+		return nil, false
+	}
+	return template, true
+}
+
+// GetTemplate GET /api/users/me/templates/:id
+func (h *EventTemplateHandler) GetTemplate(c *gin.Context) {
+	template, ok := h.templateFromRequest(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate DELETE /api/users/me/templates/:id
+// Borra el template y sus copias de media. Los eventos creados con él no cambian.
+func (h *EventTemplateHandler) DeleteTemplate(c *gin.Context) {
+	template, ok := h.templateFromRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.templates.DeleteTemplate(template.ID); err != nil {
+		if err == repository.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete template"})
+		return
+	}
+
+	for _, url := range []string{template.Settings.LogoURL, template.Settings.BackgroundURL, template.Settings.BackgroundImage} {
+		if key, ok := h.media.KeyFromURL(url); ok && strings.HasPrefix(key, templateMediaDir+"/") {
+			if err := h.media.Delete(c.Request.Context(), key); err != nil {
+				fmt.Printf("[WARN] Failed to delete template media %s: %v\n", key, err)
+			}
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateEventFromTemplate POST /api/users/me/templates/:id/events
+// Crea un evento del usuario con la configuración del template (como POST /api/events).
+func (h *EventTemplateHandler) CreateEventFromTemplate(c *gin.Context) {
+	template, ok := h.templateFromRequest(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateEventFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	description := template.Description
+	if req.Description != nil {
+		description = *req.Description
+	}
+
+	ctx := c.Request.Context()
+	copies := newMediaCopies(h.media)
+	if err := copies.copySettings(ctx, template.Settings, ""); err != nil {
+		fmt.Printf("[ERROR] Failed to copy media of template %s: %v\n", template.ID, err)
+		copies.discard(ctx)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to copy template media"})
+		return
+	}
+
+	event, err := h.templates.CreateEventFromTemplate(template, c.MustGet("user_id").(uuid.UUID),
+		slugOrGenerated(req.Slug, req.Name), req.Name, description,
+		req.StartsAt.TimePtr(), req.EndsAt.TimePtr(), req.Draft, copies.urls)
+	if err != nil {
+		copies.discard(ctx)
+		if err == repository.ErrDuplicateSlug {
+			c.JSON(http.StatusConflict, gin.H{"error": "Event slug already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create event"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, event)
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// ============== MOCKS ==============
+
+type mockEventTemplateRepo struct {
+	templates map[uuid.UUID]*models.EventTemplate
+	slugs     map[string]bool
+
+	// Última llamada a CloneEvent / CreateEventFromTemplate
+	cloneOpts models.CloneOptions
+	mediaURLs map[string]string
+}
+
+func newMockEventTemplateRepo() *mockEventTemplateRepo {
+	return &mockEventTemplateRepo{
+		templates: make(map[uuid.UUID]*models.EventTemplate),
+		slugs:     make(map[string]bool),
+	}
+}
+
+func (m *mockEventTemplateRepo) newEvent(ownerID uuid.UUID, slug, name string, settings models.EventSettings, mediaURLs map[string]string) (*models.Event, error) {
+	if m.slugs[slug] {
+		return nil, repository.ErrDuplicateSlug
+	}
+	m.slugs[slug] = true
+	m.mediaURLs = mediaURLs
+	if url, ok := mediaURLs[settings.LogoURL]; ok {
+		settings.LogoURL = url
+	}
+	return &models.Event{ID: uuid.New(), OwnerID: ownerID, Slug: slug, Name: name, Settings: settings, Status: models.EventStatusDraft}, nil
+}
+
+func (m *mockEventTemplateRepo) CloneEvent(source *models.Event, ownerID uuid.UUID, slug, name string,
+	startsAt, endsAt *time.Time, opts models.CloneOptions, mediaURLs map[string]string) (*models.Event, error) {
+	m.cloneOpts = opts
+	return m.newEvent(ownerID, slug, name, source.Settings, mediaURLs)
+}
+
+func (m *mockEventTemplateRepo) CreateTemplate(ownerID uuid.UUID, source *models.Event, name, description string,
+	mediaURLs map[string]string) (*models.EventTemplate, error) {
+	settings := source.Settings
+	if url, ok := mediaURLs[settings.LogoURL]; ok {
+		settings.LogoURL = url
+	}
+	template := &models.EventTemplate{ID: uuid.New(), OwnerID: ownerID, Name: name, Description: description, Settings: settings}
+	m.templates[template.ID] = template
+	return template, nil
+}
+
+func (m *mockEventTemplateRepo) GetTemplate(id uuid.UUID) (*models.EventTemplate, error) {
+	template, ok := m.templates[id]
+	if !ok {
+		return nil, repository.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (m *mockEventTemplateRepo) ListTemplatesByOwner(ownerID uuid.UUID) ([]models.EventTemplate, error) {
+	var result []models.EventTemplate
+	for _, template := range m.templates {
+		if template.OwnerID == ownerID {
+			result = append(result, *template)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockEventTemplateRepo) DeleteTemplate(id uuid.UUID) error {
+	if _, ok := m.templates[id]; !ok {
+		return repository.ErrTemplateNotFound
+	}
+	delete(m.templates, id)
+	return nil
+}
+
+func (m *mockEventTemplateRepo) CreateEventFromTemplate(template *models.EventTemplate, ownerID uuid.UUID, slug, name, description string,
+	startsAt, endsAt *time.Time, draft bool, mediaURLs map[string]string) (*models.Event, error) {
+	return m.newEvent(ownerID, slug, name, template.Settings, mediaURLs)
+}
+
+type mockPostcardMediaLister struct {
+	paths []string
+}
+
+func (m *mockPostcardMediaLister) ListMediaPathsByEvent(eventID uuid.UUID) ([]string, error) {
+	return m.paths, nil
+}
+
+// readMedia devuelve el contenido de una URL del MediaStore ("" si no existe)
+func readMedia(t *testing.T, media services.MediaStore, url string) string {
+	key, ok := media.KeyFromURL(url)
+	require.True(t, ok, url)
+	r, err := media.Get(context.Background(), key)
+	if err == services.ErrMediaNotFound {
+		return ""
+	}
+	require.NoError(t, err)
+	defer r.Close()
+	data, _ := io.ReadAll(r)
+	return string(data)
+}
+
+// ============== TESTS ==============
+
+func TestEventTemplateHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	uploadsDir := t.TempDir()
+	media := services.NewLocalMediaStore(uploadsDir)
+	ctx := context.Background()
+	put := func(key, content string) string {
+		require.NoError(t, media.Put(ctx, key, strings.NewReader(content), -1, "image/png"))
+		return media.URL(key)
+	}
+
+	userID := uuid.New()
+	logoURL := put("logos/boda_1234abcd.png", "logo")
+	postcardURL := put("postcards/"+uuid.New().String()+".jpg", "postcard")
+	event := &models.Event{
+		ID:   uuid.New(),
+		Slug: "boda",
+		Name: "Boda",
+		Settings: models.EventSettings{
+			LogoURL:       logoURL,
+			BackgroundURL: "https://cdn.example.com/fondo.png", // externa: se comparte tal cual
+		},
+	}
+
+	repo := newMockEventTemplateRepo()
+	repo.slugs["boda"] = true
+	postcards := &mockPostcardMediaLister{paths: []string{postcardURL}}
+	h := NewEventTemplateHandler(repo, postcards, media)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Set("user_id", userID)
+		c.Next()
+	})
+	router.POST("/api/admin/events/:slug/clone", h.CloneEvent)
+	router.POST("/api/admin/events/:slug/template", h.SaveAsTemplate)
+	router.GET("/api/users/me/templates", h.ListTemplates)
+	router.GET("/api/users/me/templates/:id", h.GetTemplate)
+	router.DELETE("/api/users/me/templates/:id", h.DeleteTemplate)
+	router.POST("/api/users/me/templates/:id/events", h.CreateEventFromTemplate)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("clone copies the media under new keys", func(t *testing.T) {
+		w := do("POST", "/api/admin/events/boda/clone", "")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var clone models.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &clone))
+		assert.Equal(t, "Boda (copia)", clone.Name)
+		assert.Equal(t, userID, clone.OwnerID)
+		assert.NotEqual(t, "boda", clone.Slug)
+
+		require.Len(t, repo.mediaURLs, 2)
+		assert.NotContains(t, repo.mediaURLs, event.Settings.BackgroundURL)
+		assert.Equal(t, "logo", readMedia(t, media, repo.mediaURLs[logoURL]))
+		assert.Equal(t, "postcard", readMedia(t, media, repo.mediaURLs[postcardURL]))
+		assert.True(t, strings.HasPrefix(repo.mediaURLs[logoURL], "/uploads/logos/"))
+		assert.Equal(t, clone.Settings.LogoURL, repo.mediaURLs[logoURL])
+	})
+
+	t.Run("clone without guests and postcards", func(t *testing.T) {
+		w := do("POST", "/api/admin/events/boda/clone", `{"slug": "boda-2027", "name": "Boda 2027", "exclude_guests": true, "exclude_postcards": true}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		assert.Equal(t, models.CloneOptions{ExcludeGuests: true, ExcludePostcards: true}, repo.cloneOpts)
+		assert.Len(t, repo.mediaURLs, 1)
+		assert.NotContains(t, repo.mediaURLs, postcardURL)
+	})
+
+	t.Run("duplicate slug conflicts and discards the copies", func(t *testing.T) {
+		logos, _ := os.ReadDir(filepath.Join(uploadsDir, "logos"))
+		w := do("POST", "/api/admin/events/boda/clone", `{"slug": "boda"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		after, _ := os.ReadDir(filepath.Join(uploadsDir, "logos"))
+		assert.Len(t, after, len(logos))
+	})
+
+	var template models.EventTemplate
+	t.Run("save as template keeps its own media copy", func(t *testing.T) {
+		w := do("POST", "/api/admin/events/boda/template", `{"name": "Mi boda"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &template))
+
+		assert.Equal(t, "Mi boda", template.Name)
+		assert.True(t, strings.HasPrefix(template.Settings.LogoURL, "/uploads/templates/"), template.Settings.LogoURL)
+		assert.Equal(t, "logo", readMedia(t, media, template.Settings.LogoURL))
+
+		w = do("POST", "/api/admin/events/boda/template", `{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list and get the user's templates", func(t *testing.T) {
+		w := do("GET", "/api/users/me/templates", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Templates []models.EventTemplate `json:"templates"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Len(t, body.Templates, 1)
+
+		w = do("GET", "/api/users/me/templates/"+template.ID.String(), "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("templates of other users are not found", func(t *testing.T) {
+		other := &models.EventTemplate{ID: uuid.New(), OwnerID: uuid.New(), Name: "Ajeno"}
+		repo.templates[other.ID] = other
+
+		assert.Equal(t, http.StatusNotFound, do("GET", "/api/users/me/templates/"+other.ID.String(), "").Code)
+		assert.Equal(t, http.StatusNotFound, do("POST", "/api/users/me/templates/"+other.ID.String()+"/events", `{"name": "X"}`).Code)
+		assert.Equal(t, http.StatusNotFound, do("DELETE", "/api/users/me/templates/"+other.ID.String(), "").Code)
+		assert.Contains(t, repo.templates, other.ID)
+	})
+
+	t.Run("create an event from a template", func(t *testing.T) {
+		w := do("POST", "/api/users/me/templates/"+template.ID.String()+"/events", `{"name": "Cumple de Mile", "slug": "cumple-mile"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var created models.Event
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.Equal(t, "cumple-mile", created.Slug)
+		assert.True(t, strings.HasPrefix(created.Settings.LogoURL, "/uploads/logos/"), created.Settings.LogoURL)
+		assert.Equal(t, "logo", readMedia(t, media, created.Settings.LogoURL))
+	})
+
+	t.Run("delete removes the template media only", func(t *testing.T) {
+		w := do("DELETE", "/api/users/me/templates/"+template.ID.String(), "")
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		assert.NotContains(t, repo.templates, template.ID)
+		assert.Equal(t, "", readMedia(t, media, template.Settings.LogoURL))
+		assert.Equal(t, "logo", readMedia(t, media, logoURL))
+	})
+}
@@ -13,10 +13,13 @@ import (
 
 // EventRepo defines the repository interface for event operations
 type EventRepo interface {
-	ListByOwner(ownerID uuid.UUID) ([]models.Event, error)
+	ListByMember(userID uuid.UUID) ([]models.Event, error)
 	Create(ownerID uuid.UUID, slug, name, description string,
 		features models.EventFeatures, settings models.EventSettings,
 		startsAt, endsAt *time.Time) (*models.Event, error)
+	CreateDraft(ownerID uuid.UUID, slug, name, description string,
+		features models.EventFeatures, settings models.EventSettings,
+		startsAt, endsAt *time.Time) (*models.Event, error)
 	Delete(id uuid.UUID) error
 }
 
@@ -32,7 +35,8 @@ func NewEventHandler(eventRepo *repository.EventRepository) *EventHandler {
 	}
 }
 
-// GetUserEvents returns all events owned by the current authenticated user
+// GetUserEvents returns all events where the current authenticated user is a member,
+// with the user's role in each one
 func (h *EventHandler) GetUserEvents(c *gin.Context) {
 	// Get user_id from context (set by AuthMiddleware)
 	userID, exists := c.Get("user_id")
@@ -41,8 +45,8 @@ func (h *EventHandler) GetUserEvents(c *gin.Context) {
 		return
 	}
 
-	// Query events by owner
-	events, err := h.eventRepo.ListByOwner(userID.(uuid.UUID))
+	// Query events by membership (owner, co-host, moderator or viewer)
+	events, err := h.eventRepo.ListByMember(userID.(uuid.UUID))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve events"})
 		return
@@ -104,26 +108,21 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 		slug = &generated
 	}
 
-	// Create event
-	var startsAt, endsAt *time.Time
-	if req.StartsAt != nil {
-		t := req.StartsAt.Time
-		startsAt = &t
-	}
-	if req.EndsAt != nil {
-		t := req.EndsAt.Time
-		endsAt = &t
+	// Drafts stay hidden from guests until the owner publishes them
+	create := h.eventRepo.Create
+	if req.Draft {
+		create = h.eventRepo.CreateDraft
 	}
 
-	event, err := h.eventRepo.Create(
+	event, err := create(
 		userID.(uuid.UUID),
 		*slug,
 		req.Name,
 		req.Description,
 		req.Features,
 		req.Settings,
-		startsAt,
-		endsAt,
+		req.StartsAt.TimePtr(),
+		req.EndsAt.TimePtr(),
 	)
 	if err != nil {
 		if err == repository.ErrDuplicateSlug {
@@ -138,8 +137,8 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 }
 
 // DeleteEvent deletes an event owned by the authenticated user.
-// Expects the event to already be loaded in context by EventMiddleware,
-// and ownership verified by OwnerMiddleware (admin route).
+// Expects the event to already be loaded in context by AdminEventMiddleware,
+// and ownership verified by EventMemberMiddleware + RequireEventPermission(PermissionDelete).
 func (h *EventHandler) DeleteEvent(c *gin.Context) {
 	event, exists := c.Get("event")
 	if !exists {
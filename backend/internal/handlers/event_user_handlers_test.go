@@ -19,7 +19,7 @@ type MockUserEventRepo struct {
 	Err    error
 }
 
-func (m *MockUserEventRepo) ListByOwner(ownerID uuid.UUID) ([]models.Event, error) {
+func (m *MockUserEventRepo) ListByMember(userID uuid.UUID) ([]models.Event, error) {
 	if m.Err != nil {
 		return nil, m.Err
 	}
@@ -49,6 +49,19 @@ func (m *MockUserEventRepo) Create(ownerID uuid.UUID, slug, name, description st
 	return event, nil
 }
 
+func (m *MockUserEventRepo) CreateDraft(ownerID uuid.UUID, slug, name, description string,
+	features models.EventFeatures, settings models.EventSettings,
+	startsAt, endsAt *time.Time) (*models.Event, error) {
+	event, err := m.Create(ownerID, slug, name, description, features, settings, startsAt, endsAt)
+	if err != nil {
+		return nil, err
+	}
+	event.Status = models.EventStatusDraft
+	event.IsActive = false
+	m.Events[len(m.Events)-1] = *event
+	return event, nil
+}
+
 func (m *MockUserEventRepo) Delete(id uuid.UUID) error {
 	if m.Err != nil {
 		return m.Err
@@ -0,0 +1,538 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// GuestRepo define las operaciones sobre la lista de invitados
+type GuestRepo interface {
+	CreateGuests(eventID uuid.UUID, guests []models.GuestInput) ([]models.GuestInvitation, error)
+	ListByEvent(eventID uuid.UUID) ([]models.GuestInvitation, error)
+	GetByID(id uuid.UUID) (*models.GuestInvitation, error)
+	GetByToken(eventID uuid.UUID, token string) (*models.GuestInvitation, error)
+	Update(g *models.GuestInvitation) error
+	SetRSVP(id uuid.UUID, status models.RSVPStatus, plusOnes int) (*models.GuestInvitation, error)
+	JoinAsPlayer(id uuid.UUID, avatar string) (*models.Player, bool, error)
+	Delete(id uuid.UUID) error
+}
+
+// PlayerTokenIssuer firma el token de sesión de un jugador
+type PlayerTokenIssuer interface {
+	Issue(playerID, eventID uuid.UUID) (string, error)
+}
+
+const (
+	maxGuestImport      = 1000            // Invitados por importación
+	maxGuestImportBytes = 2 * 1024 * 1024 // Tamaño máximo del CSV/JSON
+	maxGuestPlusOnes    = 20
+	maxGuestNameLength  = 100 // guest_invitations.name VARCHAR(100)
+	maxGuestPhoneLength = 50  // guest_invitations.phone VARCHAR(50)
+)
+
+// GuestHandler maneja la lista de invitados del host y los links personales de cada invitado
+type GuestHandler struct {
+	guests       GuestRepo
+	playerTokens PlayerTokenIssuer
+}
+
+// NewGuestHandler crea un nuevo handler de invitados
+func NewGuestHandler(guests GuestRepo, playerTokens PlayerTokenIssuer) *GuestHandler {
+	return &GuestHandler{guests: guests, playerTokens: playerTokens}
+}
+
+// buildGuestJoinURL construye el link personal del invitado (relativo, lo completa el frontend)
+func buildGuestJoinURL(slug, token string) string {
+	return "/e/" + slug + "?invite=" + token
+}
+
+// ListGuests GET /api/admin/events/:slug/guests
+// Lista los invitados con su RSVP, si entraron, jugaron o dejaron postales, y los totales.
+func (h *GuestHandler) ListGuests(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	guests, err := h.guests.ListByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list guests"})
+		return
+	}
+	for i := range guests {
+		guests[i].JoinURL = buildGuestJoinURL(event.Slug, guests[i].Token)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"guests":  guests,
+		"summary": models.SummarizeGuests(guests),
+	})
+}
+
+// ImportGuests POST /api/admin/events/:slug/guests/import
+// Importa la lista de invitados desde JSON ({"guests": [...]}) o CSV (body text/csv o
+// archivo "file" en multipart). El CSV necesita encabezado con al menos la columna name.
+// Las filas inválidas o con un email ya invitado se saltean y se informan en warnings.
+func (h *GuestHandler) ImportGuests(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	inputs, err := readGuestImport(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(inputs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No guests to import"})
+		return
+	}
+	if len(inputs) > maxGuestImport {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Too many guests (max %d per import)", maxGuestImport)})
+		return
+	}
+
+	existing, err := h.guests.ListByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list guests"})
+		return
+	}
+	emails := make(map[string]bool, len(existing))
+	for _, g := range existing {
+		if g.Email != "" {
+			emails[g.Email] = true
+		}
+	}
+
+	valid := make([]models.GuestInput, 0, len(inputs))
+	warnings := make([]string, 0)
+	for i, input := range inputs {
+		input, msg := normalizeGuestInput(input)
+		if msg == "" && input.Email != "" && emails[input.Email] {
+			msg = "email '" + input.Email + "' already invited"
+		}
+		if msg != "" {
+			warnings = append(warnings, "Guest "+strconv.Itoa(i+1)+": "+msg)
+			continue
+		}
+		if input.Email != "" {
+			emails[input.Email] = true
+		}
+		valid = append(valid, input)
+	}
+
+	if len(valid) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "Failed to import any guests",
+			"errors":   warnings,
+			"imported": 0,
+		})
+		return
+	}
+
+	created, err := h.guests.CreateGuests(event.ID, valid)
+	if err != nil {
+		if err == repository.ErrDuplicateGuestEmail {
+			c.JSON(http.StatusConflict, gin.H{"error": "A guest email is already invited"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import guests"})
+		return
+	}
+	for i := range created {
+		created[i].JoinURL = buildGuestJoinURL(event.Slug, created[i].Token)
+	}
+
+	response := gin.H{
+		"imported": len(created),
+		"guests":   created,
+	}
+	if len(warnings) > 0 {
+		response["warnings"] = warnings
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateGuest PUT /api/admin/events/:slug/guests/:id
+// Edita los datos del invitado; rsvp_status y plus_ones cargan una respuesta recibida por otro medio.
+func (h *GuestHandler) UpdateGuest(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+	guest, ok := h.guestFromParam(c, event)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateGuestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	input, msg := normalizeGuestInput(req.GuestInput)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	guest.Name = input.Name
+	guest.Email = input.Email
+	guest.Phone = input.Phone
+	guest.MaxPlusOnes = input.MaxPlusOnes
+
+	status, plusOnes := guest.RSVPStatus, guest.PlusOnes
+	if req.RSVPStatus != nil {
+		status = *req.RSVPStatus
+	}
+	if req.PlusOnes != nil {
+		plusOnes = *req.PlusOnes
+	}
+	if status != models.RSVPPending && !status.IsAnswer() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rsvp_status. Must be pending, yes, no or maybe"})
+		return
+	}
+	if status != models.RSVPYes && status != models.RSVPMaybe {
+		plusOnes = 0
+	}
+	if plusOnes < 0 || plusOnes > guest.MaxPlusOnes {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("plus_ones must be between 0 and %d", guest.MaxPlusOnes)})
+		return
+	}
+	if status != guest.RSVPStatus || plusOnes != guest.PlusOnes {
+		now := time.Now()
+		guest.RSVPAt = &now
+		if status == models.RSVPPending {
+			guest.RSVPAt = nil
+		}
+	}
+	guest.RSVPStatus, guest.PlusOnes = status, plusOnes
+
+	if err := h.guests.Update(guest); err != nil {
+		switch err {
+		case repository.ErrDuplicateGuestEmail:
+			c.JSON(http.StatusConflict, gin.H{"error": "Email already invited"})
+		case repository.ErrGuestNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Guest not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update guest"})
+		}
+		return
+	}
+
+	guest.JoinURL = buildGuestJoinURL(event.Slug, guest.Token)
+	c.JSON(http.StatusOK, guest)
+}
+
+// DeleteGuest DELETE /api/admin/events/:slug/guests/:id
+// Quita al invitado de la lista; su link deja de funcionar pero su jugador queda en el evento.
+func (h *GuestHandler) DeleteGuest(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+	guest, ok := h.guestFromParam(c, event)
+	if !ok {
+		return
+	}
+
+	if err := h.guests.Delete(guest.ID); err != nil && err != repository.ErrGuestNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete guest"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// guestFromParam busca el invitado de la ruta dentro del evento; si falla ya respondió
+func (h *GuestHandler) guestFromParam(c *gin.Context, event *models.Event) (*models.GuestInvitation, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid guest ID"})
+		return nil, false
+	}
+
+	guest, err := h.guests.GetByID(id)
+	if err == repository.ErrGuestNotFound || (err == nil && guest.EventID != event.ID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Guest not found"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get guest"})
+		return nil, false
+	}
+	return guest, true
+}
+
+// GetInvitation GET /api/events/:slug/invitations/:token
+// Datos de la invitación para la página del link personal.
+func (h *GuestHandler) GetInvitation(c *gin.Context) {
+	event, guest, ok := h.invitationFromToken(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, invitationView(event, guest))
+}
+
+// RespondRSVP PUT /api/events/:slug/invitations/:token/rsvp
+// El invitado confirma (yes / no / maybe) y cuántos acompañantes lleva.
+func (h *GuestHandler) RespondRSVP(c *gin.Context) {
+	event, guest, ok := h.invitationFromToken(c)
+	if !ok {
+		return
+	}
+
+	var req models.RSVPRequest
+	if err := c.ShouldBindJSON(&req); err != nil || !req.Status.IsAnswer() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be yes, no or maybe"})
+		return
+	}
+	if req.Status == models.RSVPNo {
+		req.PlusOnes = 0
+	}
+	if req.PlusOnes < 0 || req.PlusOnes > guest.MaxPlusOnes {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("plus_ones must be between 0 and %d", guest.MaxPlusOnes)})
+		return
+	}
+
+	updated, err := h.guests.SetRSVP(guest.ID, req.Status, req.PlusOnes)
+	if err != nil {
+		if err == repository.ErrGuestNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save RSVP"})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitationView(event, updated))
+}
+
+// JoinWithInvitation POST /api/events/:slug/invitations/:token/join
+// Entra al evento como el jugador del invitado: la primera vez lo crea con su nombre (201),
+// después devuelve el mismo jugador con un token nuevo (200), ej. desde otro dispositivo.
+func (h *GuestHandler) JoinWithInvitation(c *gin.Context) {
+	event, guest, ok := h.invitationFromToken(c)
+	if !ok {
+		return
+	}
+
+	var req models.JoinWithInvitationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+	avatar := req.Avatar
+	if avatar == "" {
+		avatar = "👤"
+	}
+
+	player, created, err := h.guests.JoinAsPlayer(guest.ID, avatar)
+	if err != nil {
+		if err == repository.ErrGuestNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create player"})
+		return
+	}
+
+	token, err := h.playerTokens.Issue(player.ID, event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue player token"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, models.CreatePlayerResponse{Player: *player, PlayerToken: token})
+}
+
+// invitationFromToken busca la invitación del link personal; si falla ya respondió
+func (h *GuestHandler) invitationFromToken(c *gin.Context) (*models.Event, *models.GuestInvitation, bool) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	guest, err := h.guests.GetByToken(event.ID, c.Param("token"))
+	if err != nil {
+		if err == repository.ErrGuestNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+			return nil, nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get invitation"})
+		return nil, nil, false
+	}
+	return event, guest, true
+}
+
+func invitationView(event *models.Event, g *models.GuestInvitation) models.GuestInvitationView {
+	return models.GuestInvitationView{
+		Name:        g.Name,
+		EventName:   event.Name,
+		MaxPlusOnes: g.MaxPlusOnes,
+		RSVPStatus:  g.RSVPStatus,
+		PlusOnes:    g.PlusOnes,
+		RSVPAt:      g.RSVPAt,
+		PlayerID:    g.PlayerID,
+	}
+}
+
+// normalizeGuestInput limpia y valida los datos de un invitado; devuelve el error o ""
+func normalizeGuestInput(input models.GuestInput) (models.GuestInput, string) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Email = strings.ToLower(strings.TrimSpace(input.Email))
+	input.Phone = strings.TrimSpace(input.Phone)
+
+	if input.Name == "" || len(input.Name) > maxGuestNameLength {
+		return input, fmt.Sprintf("name is required (max %d characters)", maxGuestNameLength)
+	}
+	if input.Email != "" {
+		if addr, err := mail.ParseAddress(input.Email); err != nil || addr.Address != input.Email {
+			return input, "invalid email '" + input.Email + "'"
+		}
+	}
+	if len(input.Phone) > maxGuestPhoneLength {
+		return input, fmt.Sprintf("phone is too long (max %d characters)", maxGuestPhoneLength)
+	}
+	if input.MaxPlusOnes < 0 || input.MaxPlusOnes > maxGuestPlusOnes {
+		return input, fmt.Sprintf("max_plus_ones must be between 0 and %d", maxGuestPlusOnes)
+	}
+	return input, ""
+}
+
+// readGuestImport lee los invitados del body: JSON, CSV o un archivo en multipart
+func readGuestImport(c *gin.Context) ([]models.GuestInput, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxGuestImportBytes)
+
+	contentType := c.ContentType()
+	var data []byte
+	var err error
+	isCSV := contentType == "text/csv"
+
+	if contentType == "multipart/form-data" {
+		file, header, ferr := c.Request.FormFile("file")
+		if ferr != nil {
+			return nil, errors.New("File required")
+		}
+		defer file.Close()
+		data, err = io.ReadAll(file)
+		isCSV = !strings.EqualFold(filepath.Ext(header.Filename), ".json")
+	} else {
+		data, err = io.ReadAll(c.Request.Body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read guest list (max %d MB)", maxGuestImportBytes/(1024*1024))
+	}
+
+	if isCSV {
+		return parseGuestCSV(data)
+	}
+
+	var req models.ImportGuestsRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, errors.New("Invalid JSON: " + err.Error())
+	}
+	return req.Guests, nil
+}
+
+// guestCSVColumns nombres de columna aceptados en el CSV (en minúsculas)
+var guestCSVColumns = map[string]string{
+	"name":          "name",
+	"nombre":        "name",
+	"email":         "email",
+	"mail":          "email",
+	"correo":        "email",
+	"phone":         "phone",
+	"telefono":      "phone",
+	"teléfono":      "phone",
+	"celular":       "phone",
+	"max_plus_ones": "max_plus_ones",
+	"plus_ones":     "max_plus_ones",
+	"acompañantes":  "max_plus_ones",
+	"acompanantes":  "max_plus_ones",
+}
+
+// parseGuestCSV lee un CSV con encabezado (separado por comas o por punto y coma, como
+// lo exporta Excel en español). Las filas vacías se ignoran; un max_plus_ones que no es
+// número queda inválido para que la validación lo informe.
+func parseGuestCSV(data []byte) ([]models.GuestInput, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM de Excel
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	firstLine, _, _ := strings.Cut(string(data), "\n")
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.New("Invalid CSV: " + err.Error())
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		if field, ok := guestCSVColumns[strings.ToLower(strings.TrimSpace(header))]; ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("Invalid CSV: a 'name' column is required")
+	}
+
+	value := func(record []string, field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var guests []models.GuestInput
+	for _, record := range records[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		guest := models.GuestInput{
+			Name:  value(record, "name"),
+			Email: value(record, "email"),
+			Phone: value(record, "phone"),
+		}
+		if plusOnes := value(record, "max_plus_ones"); plusOnes != "" {
+			n, err := strconv.Atoi(plusOnes)
+			if err != nil {
+				n = -1
+			}
+			guest.MaxPlusOnes = n
+		}
+		guests = append(guests, guest)
+	}
+	return guests, nil
+}
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// ============== MOCKS ==============
+
+type mockGuestRepo struct {
+	guests  map[uuid.UUID]*models.GuestInvitation
+	players map[uuid.UUID]*models.Player
+}
+
+func newMockGuestRepo() *mockGuestRepo {
+	return &mockGuestRepo{
+		guests:  make(map[uuid.UUID]*models.GuestInvitation),
+		players: make(map[uuid.UUID]*models.Player),
+	}
+}
+
+func (m *mockGuestRepo) CreateGuests(eventID uuid.UUID, guests []models.GuestInput) ([]models.GuestInvitation, error) {
+	var created []models.GuestInvitation
+	for _, input := range guests {
+		g := &models.GuestInvitation{
+			ID: uuid.New(), EventID: eventID, Name: input.Name, Email: input.Email, Phone: input.Phone,
+			Token: uuid.New().String(), MaxPlusOnes: input.MaxPlusOnes, RSVPStatus: models.RSVPPending,
+		}
+		m.guests[g.ID] = g
+		created = append(created, *g)
+	}
+	return created, nil
+}
+
+func (m *mockGuestRepo) ListByEvent(eventID uuid.UUID) ([]models.GuestInvitation, error) {
+	var result []models.GuestInvitation
+	for _, g := range m.guests {
+		if g.EventID == eventID {
+			result = append(result, *g)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockGuestRepo) GetByID(id uuid.UUID) (*models.GuestInvitation, error) {
+	g, ok := m.guests[id]
+	if !ok {
+		return nil, repository.ErrGuestNotFound
+	}
+	found := *g
+	return &found, nil
+}
+
+func (m *mockGuestRepo) GetByToken(eventID uuid.UUID, token string) (*models.GuestInvitation, error) {
+	for _, g := range m.guests {
+		if g.EventID == eventID && g.Token == token {
+			found := *g
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrGuestNotFound
+}
+
+func (m *mockGuestRepo) Update(g *models.GuestInvitation) error {
+	if _, ok := m.guests[g.ID]; !ok {
+		return repository.ErrGuestNotFound
+	}
+	updated := *g
+	m.guests[g.ID] = &updated
+	return nil
+}
+
+func (m *mockGuestRepo) SetRSVP(id uuid.UUID, status models.RSVPStatus, plusOnes int) (*models.GuestInvitation, error) {
+	g, ok := m.guests[id]
+	if !ok {
+		return nil, repository.ErrGuestNotFound
+	}
+	now := time.Now()
+	g.RSVPStatus, g.PlusOnes, g.RSVPAt = status, plusOnes, &now
+	found := *g
+	return &found, nil
+}
+
+func (m *mockGuestRepo) JoinAsPlayer(id uuid.UUID, avatar string) (*models.Player, bool, error) {
+	g, ok := m.guests[id]
+	if !ok {
+		return nil, false, repository.ErrGuestNotFound
+	}
+	if g.PlayerID != nil {
+		return m.players[*g.PlayerID], false, nil
+	}
+	player := &models.Player{ID: uuid.New(), EventID: g.EventID, Name: g.Name, Avatar: avatar}
+	m.players[player.ID] = player
+	g.PlayerID = &player.ID
+	return player, true, nil
+}
+
+func (m *mockGuestRepo) Delete(id uuid.UUID) error {
+	if _, ok := m.guests[id]; !ok {
+		return repository.ErrGuestNotFound
+	}
+	delete(m.guests, id)
+	return nil
+}
+
+// ============== TESTS ==============
+
+func TestGuestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	event := &models.Event{ID: uuid.New(), Slug: "boda", Name: "Boda de Ana", Status: models.EventStatusScheduled}
+	repo := newMockGuestRepo()
+	tokens := services.NewPlayerTokenService("test-secret")
+	h := NewGuestHandler(repo, tokens)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Next()
+	})
+	router.GET("/api/admin/events/:slug/guests", h.ListGuests)
+	router.POST("/api/admin/events/:slug/guests/import", h.ImportGuests)
+	router.PUT("/api/admin/events/:slug/guests/:id", h.UpdateGuest)
+	router.DELETE("/api/admin/events/:slug/guests/:id", h.DeleteGuest)
+	router.GET("/api/events/:slug/invitations/:token", h.GetInvitation)
+	router.PUT("/api/events/:slug/invitations/:token/rsvp", h.RespondRSVP)
+	router.POST("/api/events/:slug/invitations/:token/join", h.JoinWithInvitation)
+
+	do := func(method, path, contentType string, body []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	type importResponse struct {
+		Imported int                      `json:"imported"`
+		Guests   []models.GuestInvitation `json:"guests"`
+		Warnings []string                 `json:"warnings"`
+	}
+
+	var ana models.GuestInvitation
+	t.Run("import from JSON", func(t *testing.T) {
+		body := `{"guests": [
+			{"name": " Ana ", "email": "ANA@example.com", "max_plus_ones": 2},
+			{"name": "", "email": "nadie@example.com"},
+			{"name": "Ana otra vez", "email": "ana@example.com"}
+		]}`
+		w := do("POST", "/api/admin/events/boda/guests/import", "application/json", []byte(body))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var resp importResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Imported)
+		assert.Len(t, resp.Warnings, 2)
+		ana = resp.Guests[0]
+		assert.Equal(t, "Ana", ana.Name)
+		assert.Equal(t, "ana@example.com", ana.Email)
+		assert.Equal(t, "/e/boda?invite="+ana.Token, ana.JoinURL)
+	})
+
+	t.Run("import from CSV file with semicolons", func(t *testing.T) {
+		csv := "\xef\xbb\xbfNombre;Email;Teléfono;Acompañantes\nLuis;luis@example.com;+54 11 5555;1\n;;;\nMarta;;;dos\n"
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "invitados.csv")
+		part.Write([]byte(csv))
+		writer.Close()
+
+		w := do("POST", "/api/admin/events/boda/guests/import", writer.FormDataContentType(), body.Bytes())
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var resp importResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, 1, resp.Imported)
+		assert.Equal(t, models.GuestInput{Name: "Luis", Email: "luis@example.com", Phone: "+54 11 5555", MaxPlusOnes: 1},
+			models.GuestInput{Name: resp.Guests[0].Name, Email: resp.Guests[0].Email, Phone: resp.Guests[0].Phone, MaxPlusOnes: resp.Guests[0].MaxPlusOnes})
+		require.Len(t, resp.Warnings, 1)
+		assert.Contains(t, resp.Warnings[0], "max_plus_ones")
+	})
+
+	t.Run("invalid imports", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/admin/events/boda/guests/import", "text/csv", []byte("email\nx@example.com\n")).Code)
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/admin/events/boda/guests/import", "application/json", []byte(`{"guests": []}`)).Code)
+		w := do("POST", "/api/admin/events/boda/guests/import", "text/csv", []byte("name,email\nAna,ana@example.com\n"))
+		assert.Equal(t, http.StatusBadRequest, w.Code, "every row is a duplicate")
+	})
+
+	t.Run("guest answers the RSVP from the personal link", func(t *testing.T) {
+		w := do("GET", "/api/events/boda/invitations/"+ana.Token, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var view models.GuestInvitationView
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &view))
+		assert.Equal(t, "Ana", view.Name)
+		assert.Equal(t, "Boda de Ana", view.EventName)
+		assert.Equal(t, models.RSVPPending, view.RSVPStatus)
+
+		w = do("PUT", "/api/events/boda/invitations/"+ana.Token+"/rsvp", "application/json", []byte(`{"status": "yes", "plus_ones": 3}`))
+		assert.Equal(t, http.StatusBadRequest, w.Code, "more plus-ones than allowed")
+		w = do("PUT", "/api/events/boda/invitations/"+ana.Token+"/rsvp", "application/json", []byte(`{"status": "pending"}`))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = do("PUT", "/api/events/boda/invitations/"+ana.Token+"/rsvp", "application/json", []byte(`{"status": "yes", "plus_ones": 2}`))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, models.RSVPYes, repo.guests[ana.ID].RSVPStatus)
+		assert.Equal(t, 2, repo.guests[ana.ID].PlusOnes)
+
+		assert.Equal(t, http.StatusNotFound, do("GET", "/api/events/boda/invitations/not-a-token", "", nil).Code)
+	})
+
+	t.Run("joining ties the player to the invitation", func(t *testing.T) {
+		w := do("POST", "/api/events/boda/invitations/"+ana.Token+"/join", "application/json", []byte(`{"avatar": "🎉"}`))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var resp models.CreatePlayerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "Ana", resp.Player.Name)
+		assert.Equal(t, "🎉", resp.Player.Avatar)
+		require.NotNil(t, repo.guests[ana.ID].PlayerID)
+		assert.Equal(t, resp.Player.ID, *repo.guests[ana.ID].PlayerID)
+
+		claims, err := tokens.Validate(resp.PlayerToken)
+		require.NoError(t, err)
+		assert.Equal(t, resp.Player.ID, claims.PlayerID)
+		assert.Equal(t, event.ID, claims.EventID)
+
+		// Desde otro dispositivo: el mismo jugador
+		w = do("POST", "/api/events/boda/invitations/"+ana.Token+"/join", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var again models.CreatePlayerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &again))
+		assert.Equal(t, resp.Player.ID, again.Player.ID)
+	})
+
+	t.Run("host sees who answered and joined", func(t *testing.T) {
+		repo.guests[ana.ID].Played = true
+		repo.guests[ana.ID].PostcardCount = 2
+
+		w := do("GET", "/api/admin/events/boda/guests", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Guests  []models.GuestInvitation `json:"guests"`
+			Summary models.GuestListSummary  `json:"summary"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp.Guests, 2)
+		assert.Equal(t, models.GuestListSummary{Total: 2, Pending: 1, Yes: 1, Attending: 3, Joined: 1, Played: 1, Posted: 1}, resp.Summary)
+	})
+
+	t.Run("host records an RSVP received by phone", func(t *testing.T) {
+		var luis *models.GuestInvitation
+		for _, g := range repo.guests {
+			if g.Name == "Luis" {
+				luis = g
+			}
+		}
+		require.NotNil(t, luis)
+
+		path := "/api/admin/events/boda/guests/" + luis.ID.String()
+		w := do("PUT", path, "application/json", []byte(`{"name": "Luis Pérez", "max_plus_ones": 1, "rsvp_status": "no", "plus_ones": 1}`))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, models.RSVPNo, repo.guests[luis.ID].RSVPStatus)
+		assert.Equal(t, 0, repo.guests[luis.ID].PlusOnes)
+		assert.NotNil(t, repo.guests[luis.ID].RSVPAt)
+
+		w = do("PUT", path, "application/json", []byte(`{"name": "Luis", "rsvp_status": "attending"}`))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("guests of other events are not found", func(t *testing.T) {
+		other := &models.GuestInvitation{ID: uuid.New(), EventID: uuid.New(), Name: "Ajeno", Token: "ajeno"}
+		repo.guests[other.ID] = other
+
+		assert.Equal(t, http.StatusNotFound, do("DELETE", "/api/admin/events/boda/guests/"+other.ID.String(), "", nil).Code)
+		assert.Equal(t, http.StatusNotFound, do("GET", "/api/events/boda/invitations/ajeno", "", nil).Code)
+		assert.Contains(t, repo.guests, other.ID)
+	})
+
+	t.Run("delete a guest", func(t *testing.T) {
+		w := do("DELETE", "/api/admin/events/boda/guests/"+ana.ID.String(), "", nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NotContains(t, repo.guests, ana.ID)
+		assert.Equal(t, http.StatusNotFound, do("GET", "/api/events/boda/invitations/"+ana.Token, "", nil).Code)
+	})
+}
+
+func TestParseGuestCSV(t *testing.T) {
+	guests, err := parseGuestCSV([]byte("name,email,plus_ones,notes\n\"Pérez, Juan\",juan@example.com,2,vegetariano\nSofía\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []models.GuestInput{
+		{Name: "Pérez, Juan", Email: "juan@example.com", MaxPlusOnes: 2},
+		{Name: "Sofía"},
+	}, guests)
+
+	_, err = parseGuestCSV([]byte("email\nx@example.com\n"))
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "name"))
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/hex"
@@ -8,11 +10,6 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -25,9 +22,9 @@ import (
 // PostcardRepo define las operaciones de repositorio usadas por los handlers.
 // Permite inyectar mocks en tests sin necesidad de una base de datos real.
 type PostcardRepo interface {
-	Create(playerID uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error)
-	CreateWithEvent(eventID uuid.UUID, playerID *uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error)
-	CreateSecret(eventID uuid.UUID, senderName, imagePath, message string, rotation float64, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error)
+	Create(playerID uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error)
+	CreateWithEvent(eventID uuid.UUID, playerID *uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error)
+	CreateSecret(eventID uuid.UUID, senderName, imagePath, message string, rotation float64, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error)
 	GetByID(id uuid.UUID) (*models.Postcard, error)
 	List() ([]models.Postcard, error)
 	ListByEvent(eventID uuid.UUID) ([]models.Postcard, error)
@@ -40,6 +37,7 @@ type PostcardRepo interface {
 	GetSecretBoxStatusByEvent(eventID uuid.UUID) (*models.SecretBoxStatus, error)
 	ResetSecretBoxByEvent(eventID uuid.UUID) (int64, error)
 	UpdateBackupStatus(postcardID uuid.UUID, status models.BackupStatus, backupJobID *uuid.UUID) error
+	Delete(id uuid.UUID) error
 }
 
 // BroadcastHub define las operaciones de broadcast usadas por los handlers.
@@ -61,6 +59,18 @@ type BackupWorkerEnqueuer interface {
 	EnqueueExistingJob(postcardID uuid.UUID, idempotencyKey string, jobID uuid.UUID) error
 }
 
+// MediaJobEnqueuer encola el procesamiento en background de los videos subidos
+type MediaJobEnqueuer interface {
+	EnqueueMediaJob(postcardID uuid.UUID, sourceKey string) error
+}
+
+// TelemetryRepo define el registro de eventos de analytics (quiz_events y postcard_events)
+type TelemetryRepo interface {
+	LogQuizStarted(eventID, playerID uuid.UUID) (*models.QuizStart, error)
+	LogQuizCompleted(eventID, playerID uuid.UUID, score int) error
+	LogPostcardEvent(eventID uuid.UUID, playerID *uuid.UUID, postcardID uuid.UUID, eventType string) error
+}
+
 // Handler maneja las peticiones HTTP
 type Handler struct {
 	playerRepo       *repository.PlayerRepository
@@ -69,13 +79,17 @@ type Handler struct {
 	postcardRepo     PostcardRepo
 	scorer           *services.Scorer
 	hub              BroadcastHub
-	uploadsDir       string
+	media            services.MediaStore
 	driveRepo        *repository.DriveRepository
 	backupWorker     BackupWorkerEnqueuer
+	playerTokens     *services.PlayerTokenService
+	telemetry        TelemetryRepo
+	mediaJobs        MediaJobEnqueuer
+	uploads          UploadSessionRepo
 }
 
 // NewHandler crea un nuevo handler
-func NewHandler(playerRepo *repository.PlayerRepository, quizRepo *repository.QuizRepository, quizQuestionRepo *repository.QuizQuestionRepository, postcardRepo *repository.PostcardRepository, hub *websocket.Hub, uploadsDir string) *Handler {
+func NewHandler(playerRepo *repository.PlayerRepository, quizRepo *repository.QuizRepository, quizQuestionRepo *repository.QuizQuestionRepository, postcardRepo *repository.PostcardRepository, hub *websocket.Hub, media services.MediaStore, playerTokens *services.PlayerTokenService) *Handler {
 	return &Handler{
 		playerRepo:       playerRepo,
 		quizRepo:         quizRepo,
@@ -83,7 +97,8 @@ func NewHandler(playerRepo *repository.PlayerRepository, quizRepo *repository.Qu
 		postcardRepo:     postcardRepo,
 		scorer:           services.NewScorer(),
 		hub:              hub,
-		uploadsDir:       uploadsDir,
+		media:            media,
+		playerTokens:     playerTokens,
 	}
 }
 
@@ -94,9 +109,10 @@ func NewHandlerWithDrive(
 	quizQuestionRepo *repository.QuizQuestionRepository,
 	postcardRepo *repository.PostcardRepository,
 	hub *websocket.Hub,
-	uploadsDir string,
+	media services.MediaStore,
 	driveRepo *repository.DriveRepository,
 	backupWorker BackupWorkerEnqueuer,
+	playerTokens *services.PlayerTokenService,
 ) *Handler {
 	return &Handler{
 		playerRepo:       playerRepo,
@@ -105,13 +121,30 @@ func NewHandlerWithDrive(
 		postcardRepo:     postcardRepo,
 		scorer:           services.NewScorer(),
 		hub:              hub,
-		uploadsDir:       uploadsDir,
+		media:            media,
 		driveRepo:        driveRepo,
 		backupWorker:     backupWorker,
+		playerTokens:     playerTokens,
 	}
 }
 
-// CreatePlayer crea un nuevo jugador (legacy - sin evento)
+// SetMediaJobs configura la cola de procesamiento de videos. Sin cola los videos se rechazan.
+func (h *Handler) SetMediaJobs(mediaJobs MediaJobEnqueuer) {
+	h.mediaJobs = mediaJobs
+}
+
+// SetUploads habilita crear postales desde subidas reanudables (upload_id)
+func (h *Handler) SetUploads(uploads UploadSessionRepo) {
+	h.uploads = uploads
+}
+
+// SetTelemetry habilita el registro de analytics del quiz y las postales
+func (h *Handler) SetTelemetry(telemetry TelemetryRepo) {
+	h.telemetry = telemetry
+}
+
+// CreatePlayer crea un nuevo jugador (legacy - sin evento).
+// También devuelve player_token para que los clientes legacy dejen de usar X-Player-ID.
 func (h *Handler) CreatePlayer(c *gin.Context) {
 	var req models.CreatePlayerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -131,7 +164,14 @@ func (h *Handler) CreatePlayer(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, player)
+	// Sin evento el token lleva uuid.Nil: vale en las rutas legacy pero no en las de un evento
+	token, err := h.playerTokens.Issue(player.ID, player.EventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue player token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.CreatePlayerResponse{Player: *player, PlayerToken: token})
 }
 
 // CreatePlayerScoped crea un jugador scopado al evento actual
@@ -161,7 +201,24 @@ func (h *Handler) CreatePlayerScoped(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, player)
+	// Token de sesión: el cliente lo envía en X-Player-Token en quiz, postales y analytics
+	token, err := h.playerTokens.Issue(player.ID, player.EventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue player token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.CreatePlayerResponse{Player: *player, PlayerToken: token})
+}
+
+// sessionPlayerID retorna el jugador autenticado por PlayerSessionMiddleware
+func sessionPlayerID(c *gin.Context) (uuid.UUID, bool) {
+	playerID, exists := c.Get("player_id")
+	if !exists {
+		return uuid.Nil, false
+	}
+	id, ok := playerID.(uuid.UUID)
+	return id, ok
 }
 
 // GetPlayer obtiene un jugador por ID
@@ -172,7 +229,7 @@ func (h *Handler) GetPlayer(c *gin.Context) {
 		return
 	}
 
-	player, err := h.playerRepo.GetByID(id)
+	player, err := h.findVisiblePlayer(c, id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
 		return
@@ -181,7 +238,26 @@ func (h *Handler) GetPlayer(c *gin.Context) {
 	c.JSON(http.StatusOK, player)
 }
 
-// ListPlayers lista todos los jugadores (legacy - sin filtro de evento)
+// findVisiblePlayer busca un jugador visible desde la ruta: con slug tiene que ser del
+// evento del contexto (ya pasó EventAccessMiddleware); en las rutas legacy, de un evento
+// sin código de acceso.
+func (h *Handler) findVisiblePlayer(c *gin.Context, id uuid.UUID) (*models.Player, error) {
+	eventID, exists := c.Get("event_id")
+	if !exists {
+		return h.playerRepo.GetPublicByID(id)
+	}
+
+	player, err := h.playerRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if player.EventID != eventID.(uuid.UUID) {
+		return nil, repository.ErrPlayerNotFound
+	}
+	return player, nil
+}
+
+// ListPlayers lista los jugadores de los eventos sin código de acceso (legacy - sin slug)
 func (h *Handler) ListPlayers(c *gin.Context) {
 	players, err := h.playerRepo.List()
 	if err != nil {
@@ -209,6 +285,38 @@ func (h *Handler) ListPlayersScoped(c *gin.Context) {
 	c.JSON(http.StatusOK, players)
 }
 
+// StartQuiz POST /api/events/:slug/quiz/start
+// Registra que el jugador empezó el quiz, para medir el tiempo hasta el envío.
+// Repetir la llamada no crea otro inicio: devuelve el original.
+func (h *Handler) StartQuiz(c *gin.Context) {
+	playerID, ok := sessionPlayerID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Player token required"})
+		return
+	}
+
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	start, err := h.telemetry.LogQuizStarted(event.ID, playerID)
+	if err == repository.ErrPlayerNotInEvent {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Player does not belong to this event"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start quiz"})
+		return
+	}
+
+	status := http.StatusCreated
+	if start.AlreadyStarted {
+		status = http.StatusOK
+	}
+	c.JSON(status, start)
+}
+
 // SubmitQuiz envía las respuestas del quiz
 func (h *Handler) SubmitQuiz(c *gin.Context) {
 	var req models.SubmitQuizRequest
@@ -217,16 +325,10 @@ func (h *Handler) SubmitQuiz(c *gin.Context) {
 		return
 	}
 
-	// Obtener playerID del header o del body
-	playerIDStr := c.GetHeader("X-Player-ID")
-	if playerIDStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Player ID required"})
-		return
-	}
-
-	playerID, err := uuid.Parse(playerIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+	// Obtener el jugador de la sesión (X-Player-Token, o X-Player-ID en modo legacy)
+	playerID, ok := sessionPlayerID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Player token required"})
 		return
 	}
 
@@ -296,8 +398,15 @@ func (h *Handler) SubmitQuiz(c *gin.Context) {
 		}
 	}
 
+	// Respetar las respuestas que el host aceptó manualmente (si no cambiaron)
+	accepted, err := h.quizRepo.ListAcceptedByPlayer(playerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load accepted answers"})
+		return
+	}
+
 	// Calcular puntaje usando las respuestas YA NORMALIZADAS
-	score = scorer.Calculate(normalizedFavorites, normalizedPreferences)
+	score = scorer.ScoreWithOverrides(normalizedFavorites, normalizedPreferences, accepted).Total
 
 	// Actualizar puntaje del jugador
 	if err := h.playerRepo.UpdateScore(playerID, score); err != nil {
@@ -305,6 +414,11 @@ func (h *Handler) SubmitQuiz(c *gin.Context) {
 		return
 	}
 
+	// Analytics: puntaje y tiempo desde el inicio (no falla el envío)
+	if eventID != uuid.Nil {
+		h.logQuizCompleted(eventID, playerID, score)
+	}
+
 	// Obtener ranking actualizado y broadcastear por WebSocket
 	// Si hay event_id en el contexto, usar ListByEvent, sino List
 	var players []models.Player
@@ -344,6 +458,11 @@ func (h *Handler) GetQuizAnswers(c *gin.Context) {
 		return
 	}
 
+	if _, err := h.findVisiblePlayer(c, playerID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Answers not found"})
+		return
+	}
+
 	answers, err := h.quizRepo.GetAnswersByPlayerID(playerID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Answers not found"})
@@ -502,6 +621,29 @@ func (h *Handler) enqueueBackupIfEnabled(postcard *models.Postcard) {
 	}
 }
 
+// logQuizCompleted registra el envío del quiz en analytics. Los errores solo se
+// loguean: la telemetría nunca hace fallar el request.
+func (h *Handler) logQuizCompleted(eventID, playerID uuid.UUID, score int) {
+	if h.telemetry == nil {
+		return
+	}
+	if err := h.telemetry.LogQuizCompleted(eventID, playerID, score); err != nil {
+		fmt.Printf("[WARN] Failed to log quiz completion for player %s: %v\n", playerID, err)
+	}
+}
+
+// logPostcardEvent registra la creación o visualización de una postal en analytics
+func (h *Handler) logPostcardEvent(postcard *models.Postcard, playerID *uuid.UUID, eventType string) error {
+	if h.telemetry == nil || postcard.EventID == uuid.Nil {
+		return nil
+	}
+	err := h.telemetry.LogPostcardEvent(postcard.EventID, playerID, postcard.ID, eventType)
+	if err != nil {
+		fmt.Printf("[WARN] Failed to log postcard %s event for %s: %v\n", eventType, postcard.ID, err)
+	}
+	return err
+}
+
 func buildPostcardMediaHash(postcard *models.Postcard) string {
 	thumbnail := ""
 	if postcard.ThumbnailPath != nil {
@@ -515,20 +657,36 @@ func buildPostcardMediaHash(postcard *models.Postcard) string {
 
 // MediaResult contiene el resultado de validar y guardar media (imagen o video)
 type MediaResult struct {
-	PublicPath    string  // ruta pública para acceder al archivo
-	DiskPath      string  // ruta en disco
-	MediaType     string  // "image" o "video"
-	ThumbnailPath *string // solo para videos
-	DurationMs    *int    // solo para videos
+	PublicPath    string                  // URL pública para acceder al archivo (rendition full en imágenes)
+	Keys          []string                // claves guardadas en el MediaStore
+	MediaType     string                  // "image" o "video"
+	Renditions    *models.ImageRenditions // solo para imágenes
+	ThumbnailPath *string                 // solo para videos ya procesados
+	DurationMs    *int                    // solo para videos ya procesados
+	SourceKey     string                  // video original que procesa el MediaWorker
+	UploadID      *uuid.UUID              // sesión de upload a borrar una vez creada la postal
 }
 
-// validateAndSaveMedia valida y guarda imagen o video.
+// videoContentTypes Content-Type con el que se guarda cada formato de video
+var videoContentTypes = map[string]string{
+	".mp4":  "video/mp4",
+	".webm": "video/webm",
+	".m4v":  "video/x-m4v",
+}
+
+// validateAndSaveMedia valida y guarda imagen o video en el MediaStore.
 // Detecta automáticamente el tipo de archivo por magic bytes.
-// Para videos, genera thumbnail y extrae duración.
+// Para imágenes, guarda las renditions procesadas (sin EXIF); los videos se
+// guardan tal cual y el MediaWorker genera MP4, thumbnail y duración.
 func (h *Handler) validateAndSaveMedia(c *gin.Context) (*MediaResult, *struct {
 	Code    int
 	Message string
 }) {
+	// Subida reanudable: el archivo ya llegó en chunks a una sesión de upload
+	if uploadID := c.Request.FormValue("upload_id"); uploadID != "" {
+		return h.saveUploadedMedia(c, uploadID)
+	}
+
 	// Intentar obtener "media" primero (nuevo), luego "image" (legacy)
 	file, header, err := c.Request.FormFile("media")
 	if err != nil {
@@ -543,6 +701,14 @@ func (h *Handler) validateAndSaveMedia(c *gin.Context) (*MediaResult, *struct {
 	}
 	defer file.Close()
 
+	return h.saveMedia(c.Request.Context(), file, header.Size)
+}
+
+// saveMedia valida el archivo por magic bytes y lo guarda en el MediaStore
+func (h *Handler) saveMedia(ctx context.Context, file io.ReadSeeker, size int64) (*MediaResult, *struct {
+	Code    int
+	Message string
+}) {
 	// Leer los primeros 512 bytes para detectar tipo real
 	buffer := make([]byte, 512)
 	if _, err := file.Read(buffer); err != nil && err != io.EOF {
@@ -571,125 +737,100 @@ func (h *Handler) validateAndSaveMedia(c *gin.Context) (*MediaResult, *struct {
 	// Tipos de video válidos (magic bytes)
 	isVideo, videoExt := detectVideoMagicBytes(buffer)
 
-	var mediaType string
-	var publicPath, diskPath string
-	var thumbnailPath *string
-	var durationMs *int
+	result := &MediaResult{}
 
 	if isVideo {
 		// Es video
-		mediaType = "video"
+		result.MediaType = "video"
 
-		if header.Size > 50*1024*1024 {
+		if size > 50*1024*1024 {
 			return nil, &struct {
 				Code    int
 				Message string
 			}{http.StatusRequestEntityTooLarge, "Video too large (max 50MB)"}
 		}
 
-		filename := fmt.Sprintf("%s%s", uuid.New().String(), videoExt)
-
-		// Videos se guardan en la misma carpeta que las imágenes: /app/uploads/postcards
-		baseDir := h.uploadsDir
-		if baseDir == "" {
-			baseDir = os.Getenv("UPLOADS_DIR")
-		}
-		uploadsDir := filepath.Join(baseDir, "postcards")
-		if err := os.MkdirAll(uploadsDir, 0755); err != nil {
+		if h.mediaJobs == nil {
 			return nil, &struct {
 				Code    int
 				Message string
-			}{http.StatusInternalServerError, "Failed to create uploads directory"}
+			}{http.StatusServiceUnavailable, "Video processing is not available"}
 		}
 
-		diskPath = filepath.Join(uploadsDir, filename)
-		dst, err := os.Create(diskPath)
-		if err != nil {
-			return nil, &struct {
-				Code    int
-				Message string
-			}{http.StatusInternalServerError, "Failed to save video"}
-		}
-		defer dst.Close()
-
-		if _, err := io.Copy(dst, file); err != nil {
-			os.Remove(diskPath)
+		// El original queda aparte hasta que el MediaWorker lo transcodifique
+		result.SourceKey = "postcards/originals/" + uuid.New().String() + videoExt
+		if err := h.media.Put(ctx, result.SourceKey, file, size, videoContentTypes[videoExt]); err != nil {
+			fmt.Printf("[ERROR] Failed to store video %s: %v\n", result.SourceKey, err)
 			return nil, &struct {
 				Code    int
 				Message string
 			}{http.StatusInternalServerError, "Failed to write video"}
 		}
-
-		// Videos se guardan en la misma carpeta que las imágenes
-		publicPath = "/uploads/postcards/" + filename
-
-		// Generar thumbnail con ffmpeg
-		thumb, thumbErr := h.generateVideoThumbnail(diskPath)
-		if thumbErr != nil {
-			// Log warning pero no fallar - thumbnail es opcional
-			fmt.Printf("[WARN] Failed to generate thumbnail for %s: %v\n", diskPath, thumbErr)
-		} else {
-			thumbnailPath = &thumb
-		}
-
-		// Extraer duración
-		dur, durErr := extractVideoDuration(diskPath)
-		if durErr != nil {
-			fmt.Printf("[WARN] Failed to extract duration for %s: %v\n", diskPath, durErr)
-		} else {
-			durationMs = &dur
-		}
+		result.Keys = append(result.Keys, result.SourceKey)
+		result.PublicPath = h.media.URL(result.SourceKey)
 
 	} else if validImageTypes[detectedType] {
 		// Es imagen
-		mediaType = "image"
+		result.MediaType = "image"
 
-		if header.Size > 10*1024*1024 {
+		if size > 10*1024*1024 {
 			return nil, &struct {
 				Code    int
 				Message string
 			}{http.StatusBadRequest, "Image too large (max 10MB)"}
 		}
 
-		ext := ".jpg"
-		if detectedType == "image/png" {
-			ext = ".png"
-		} else if detectedType == "image/webp" {
-			ext = ".webp"
-		}
-		filename := fmt.Sprintf("%s%s", uuid.New().String(), ext)
-
-		baseDir := h.uploadsDir
-		if baseDir == "" {
-			baseDir = "/app/uploads"
-		}
-		uploadsDir := filepath.Join(baseDir, "postcards")
-		if err := os.MkdirAll(uploadsDir, 0755); err != nil {
+		data, err := io.ReadAll(file)
+		if err != nil {
 			return nil, &struct {
 				Code    int
 				Message string
-			}{http.StatusInternalServerError, "Failed to create uploads directory"}
+			}{http.StatusInternalServerError, "Failed to read file"}
 		}
 
-		diskPath = filepath.Join(uploadsDir, filename)
-		dst, err := os.Create(diskPath)
-		if err != nil {
+		// Sin EXIF (ubicación), rotada según la orientación y en tres tamaños
+		renditions, err := services.ProcessImage(data)
+		if err == services.ErrImageTooLarge {
 			return nil, &struct {
 				Code    int
 				Message string
-			}{http.StatusInternalServerError, "Failed to save image"}
+			}{http.StatusBadRequest, "Image dimensions too large"}
 		}
-		defer dst.Close()
-
-		if _, err := io.Copy(dst, file); err != nil {
-			os.Remove(diskPath)
+		if err != nil {
 			return nil, &struct {
 				Code    int
 				Message string
-			}{http.StatusInternalServerError, "Failed to write image"}
+			}{http.StatusBadRequest, "Invalid image"}
 		}
 
-		publicPath = "/uploads/postcards/" + filename
+		result.Renditions = &models.ImageRenditions{}
+		id := uuid.New().String()
+		for _, rendition := range renditions {
+			key := "postcards/" + id + rendition.Ext
+			if rendition.Variant != models.ImageVariantFull {
+				key = "postcards/" + rendition.Variant + "/" + id + rendition.Ext
+			}
+
+			if err := h.media.Put(ctx, key, bytes.NewReader(rendition.Data), int64(len(rendition.Data)), rendition.ContentType); err != nil {
+				fmt.Printf("[ERROR] Failed to store image %s: %v\n", key, err)
+				h.discardMedia(ctx, result)
+				return nil, &struct {
+					Code    int
+					Message string
+				}{http.StatusInternalServerError, "Failed to write image"}
+			}
+			result.Keys = append(result.Keys, key)
+
+			switch rendition.Variant {
+			case models.ImageVariantThumb:
+				result.Renditions.Thumb = h.media.URL(key)
+			case models.ImageVariantBoard:
+				result.Renditions.Board = h.media.URL(key)
+			case models.ImageVariantFull:
+				result.Renditions.Full = h.media.URL(key)
+			}
+		}
+		result.PublicPath = result.Renditions.Full
 
 	} else {
 		return nil, &struct {
@@ -698,75 +839,73 @@ func (h *Handler) validateAndSaveMedia(c *gin.Context) (*MediaResult, *struct {
 		}{http.StatusBadRequest, "Invalid file type. Supported: JPEG, PNG, WebP, MP4, WebM, MOV"}
 	}
 
-	return &MediaResult{
-		PublicPath:    publicPath,
-		DiskPath:      diskPath,
-		MediaType:     mediaType,
-		ThumbnailPath: thumbnailPath,
-		DurationMs:    durationMs,
-	}, nil
+	return result, nil
 }
 
-// detectVideoMagicBytes detecta si el buffer contiene un video y retorna el formato
-func detectVideoMagicBytes(buffer []byte) (bool, string) {
-	// MP4/MOV: starts with ftyp box
-	if len(buffer) >= 8 && buffer[4] == 'f' && buffer[5] == 't' && buffer[6] == 'y' && buffer[7] == 'p' {
-		return true, ".mp4"
+// discardMedia borra la media subida cuando no se pudo crear la postal
+// y libera la sesión de upload reanudable, si la hay
+func (h *Handler) discardMedia(ctx context.Context, result *MediaResult) {
+	for _, key := range result.Keys {
+		if err := h.media.Delete(ctx, key); err != nil {
+			fmt.Printf("[WARN] Failed to delete media %s: %v\n", key, err)
+		}
 	}
-	// WebM: EBML header (1a 45 df a3)
-	if len(buffer) >= 4 && buffer[0] == 0x1a && buffer[1] == 0x45 && buffer[2] == 0xdf && buffer[3] == 0xa3 {
-		return true, ".webm"
+	if result.UploadID != nil {
+		h.releaseUpload(*result.UploadID)
 	}
-	// M4V (iOS video): ftypM4V
-	if len(buffer) >= 8 && buffer[4] == 'M' && buffer[5] == '4' && buffer[6] == 'V' {
-		return true, ".m4v"
-	}
-	return false, ""
 }
 
-// generateVideoThumbnail genera un thumbnail JPEG de un video usando ffmpeg
-func (h *Handler) generateVideoThumbnail(videoPath string) (string, error) {
-	thumbnailDir := filepath.Dir(videoPath)
-	thumbnailPath := filepath.Join(thumbnailDir, "thumbnails", filepath.Base(videoPath)+".jpg")
-
-	// Crear directorio de thumbnails
-	if err := os.MkdirAll(filepath.Dir(thumbnailPath), 0755); err != nil {
-		return "", err
+// enqueueMediaProcessing encola el procesamiento del video de una postal recién
+// creada. Si no se puede encolar, borra la postal y su media para que no quede
+// para siempre en 'processing'.
+func (h *Handler) enqueueMediaProcessing(ctx context.Context, postcard *models.Postcard, result *MediaResult) error {
+	if result.SourceKey == "" {
+		return nil
 	}
 
-	// Comando ffmpeg: extraer frame en segundo 1 y escalar manteniendo aspect ratio
-	cmd := exec.Command("ffmpeg", "-y", "-i", videoPath, "-ss", "00:00:01", "-vframes", "1", "-vf", "scale='min(400,iw)':'min(400,ih)':force_original_aspect_ratio=decrease", "-q:v", "2", thumbnailPath)
+	err := h.mediaJobs.EnqueueMediaJob(postcard.ID, result.SourceKey)
+	if err == nil {
+		return nil
+	}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("ffmpeg error: %v, output: %s", err, string(output))
+	fmt.Printf("[ERROR] Failed to enqueue media job for postcard %s: %v\n", postcard.ID, err)
+	if delErr := h.postcardRepo.Delete(postcard.ID); delErr != nil {
+		fmt.Printf("[WARN] Failed to delete postcard %s: %v\n", postcard.ID, delErr)
 	}
+	h.discardMedia(ctx, result)
+	return err
+}
 
-	// Verificar que el thumbnail se creó
-	if _, err := os.Stat(thumbnailPath); err != nil {
-		return "", fmt.Errorf("thumbnail not created: %v", err)
+// PostcardMediaReady se llama cuando el MediaWorker terminó de procesar el video
+// de una postal: la publica en el corkboard (si ya es pública) y encola el backup.
+// Las postales legacy no tienen evento: se publican a todos los clientes.
+func (h *Handler) PostcardMediaReady(postcard *models.Postcard, eventSlug string) {
+	if h.hub != nil && postcard.IsPublic() {
+		if eventSlug == "" {
+			h.hub.BroadcastPostcard(*postcard)
+		} else {
+			h.hub.BroadcastPostcardToRoom(eventSlug, *postcard)
+		}
 	}
 
-	// Retornar ruta pública
-	publicPath := strings.Replace(thumbnailPath, h.uploadsDir, "/uploads", 1)
-	return publicPath, nil
+	h.enqueueBackupIfEnabled(postcard)
 }
 
-// extractVideoDuration extrae la duración de un video en milisegundos
-func extractVideoDuration(videoPath string) (int, error) {
-	cmd := exec.Command("ffprobe", "-v", "error", "-show_entries", "format=duration", "-of", "default=noprint_wrappers=1:nokey=1", videoPath)
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return 0, fmt.Errorf("ffprobe error: %v, output: %s", err, string(output))
+// detectVideoMagicBytes detecta si el buffer contiene un video y retorna el formato
+func detectVideoMagicBytes(buffer []byte) (bool, string) {
+	// MP4/MOV: starts with ftyp box
+	if len(buffer) >= 8 && buffer[4] == 'f' && buffer[5] == 't' && buffer[6] == 'y' && buffer[7] == 'p' {
+		return true, ".mp4"
 	}
-
-	duration, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse duration: %v", err)
+	// WebM: EBML header (1a 45 df a3)
+	if len(buffer) >= 4 && buffer[0] == 0x1a && buffer[1] == 0x45 && buffer[2] == 0xdf && buffer[3] == 0xa3 {
+		return true, ".webm"
 	}
-
-	return int(duration * 1000), nil
+	// M4V (iOS video): ftypM4V
+	if len(buffer) >= 8 && buffer[4] == 'M' && buffer[5] == '4' && buffer[6] == 'V' {
+		return true, ".m4v"
+	}
+	return false, ""
 }
 
 // ==========================================
@@ -775,23 +914,16 @@ func extractVideoDuration(videoPath string) (int, error) {
 
 // CreatePostcard crea una nueva postal regular.
 // Soporta dos modos:
-//  1. Con X-Player-ID header: usa el jugador existente
-//  2. Sin player_id: crea un jugador inline desde name+avatar en form data
-//     (permite usar el corkboard sin haber jugado el quiz)
+//  1. Con sesión de jugador (X-Player-Token): usa el jugador existente
+//  2. Sin sesión: crea un jugador inline desde name+avatar en form data
+//     (permite usar el corkboard sin haber jugado el quiz). El token del
+//     nuevo jugador se devuelve en el header X-Player-Token.
 func (h *Handler) CreatePostcard(c *gin.Context) {
-	// Obtener playerID del header (opcional)
-	playerIDStr := c.GetHeader("X-Player-ID")
-
 	var playerID *uuid.UUID
 	var err error
 
-	if playerIDStr != "" {
+	if id, ok := sessionPlayerID(c); ok {
 		// Modo 1: jugador existente
-		id, parseErr := uuid.Parse(playerIDStr)
-		if parseErr != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
-			return
-		}
 		playerID = &id
 
 		// Verificar que el jugador existe
@@ -840,6 +972,13 @@ func (h *Handler) CreatePostcard(c *gin.Context) {
 		}
 
 		playerID = &player.ID
+
+		// Sin token la postal igual se crea: el jugador ya existe y el invitado puede volver a registrarse
+		if token, tokenErr := h.playerTokens.Issue(player.ID, player.EventID); tokenErr != nil {
+			fmt.Printf("[WARN] Failed to issue player token for inline player %s: %v\n", player.ID, tokenErr)
+		} else {
+			c.Header("X-Player-Token", token)
+		}
 	}
 
 	mediaResult, httpErr := h.validateAndSaveMedia(c)
@@ -862,19 +1001,27 @@ func (h *Handler) CreatePostcard(c *gin.Context) {
 	// Si hay event_id en el contexto, usar CreateWithEvent
 	var postcard *models.Postcard
 	if eventID, exists := c.Get("event_id"); exists {
-		postcard, err = h.postcardRepo.CreateWithEvent(eventID.(uuid.UUID), playerID, mediaResult.PublicPath, message, rotation, senderName, mediaResult.MediaType, mediaResult.ThumbnailPath, mediaResult.DurationMs)
+		postcard, err = h.postcardRepo.CreateWithEvent(eventID.(uuid.UUID), playerID, mediaResult.PublicPath, message, rotation, senderName, mediaResult.MediaType, mediaResult.ThumbnailPath, mediaResult.DurationMs, mediaResult.Renditions)
 	} else {
-		postcard, err = h.postcardRepo.Create(*playerID, mediaResult.PublicPath, message, rotation, senderName, mediaResult.MediaType, mediaResult.ThumbnailPath, mediaResult.DurationMs)
+		postcard, err = h.postcardRepo.Create(*playerID, mediaResult.PublicPath, message, rotation, senderName, mediaResult.MediaType, mediaResult.ThumbnailPath, mediaResult.DurationMs, mediaResult.Renditions)
 	}
 
 	if err != nil {
-		os.Remove(mediaResult.DiskPath)
+		h.discardMedia(c.Request.Context(), mediaResult)
 		fmt.Printf("[ERROR] CreatePostcard repo.Create failed: %v\n", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create postcard"})
 		return
 	}
 
-	if h.hub != nil {
+	if err := h.enqueueMediaProcessing(c.Request.Context(), postcard, mediaResult); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create postcard"})
+		return
+	}
+	h.finishUpload(c.Request.Context(), mediaResult)
+
+	// Con pre-moderación la postal queda pendiente: se publica cuando el owner la apruebe.
+	// Los videos se publican cuando el MediaWorker termina (PostcardMediaReady).
+	if h.hub != nil && postcard.IsPublic() {
 		// Usar broadcast por room si hay event_slug
 		if eventSlug, exists := c.Get("event_slug"); exists {
 			h.hub.BroadcastPostcardToRoom(eventSlug.(string), *postcard)
@@ -883,8 +1030,13 @@ func (h *Handler) CreatePostcard(c *gin.Context) {
 		}
 	}
 
-	// Enqueue backup job if Drive is configured and connected
-	h.enqueueBackupIfEnabled(postcard)
+	// Enqueue backup job if Drive is configured and connected.
+	// Videos are backed up once processed (PostcardMediaReady).
+	if postcard.MediaStatus == models.MediaStatusReady {
+		h.enqueueBackupIfEnabled(postcard)
+	}
+
+	h.logPostcardEvent(postcard, playerID, models.PostcardEventCreated)
 
 	c.JSON(http.StatusCreated, postcard)
 }
@@ -928,20 +1080,27 @@ func (h *Handler) CreateSecretPostcard(c *gin.Context) {
 	message := truncateMessage(c.Request.FormValue("message"), 500)
 	rotation := (rand.Float64() * 60) - 30
 
-	postcard, err := h.postcardRepo.CreateSecret(eventModel.ID, senderName, mediaResult.PublicPath, message, rotation, mediaResult.MediaType, mediaResult.ThumbnailPath, mediaResult.DurationMs)
+	postcard, err := h.postcardRepo.CreateSecret(eventModel.ID, senderName, mediaResult.PublicPath, message, rotation, mediaResult.MediaType, mediaResult.ThumbnailPath, mediaResult.DurationMs, mediaResult.Renditions)
 	if err != nil {
-		os.Remove(mediaResult.DiskPath)
+		h.discardMedia(c.Request.Context(), mediaResult)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create secret postcard"})
+		return
+	}
+
+	if err := h.enqueueMediaProcessing(c.Request.Context(), postcard, mediaResult); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create secret postcard"})
 		return
 	}
+	h.finishUpload(c.Request.Context(), mediaResult)
 
 	// Si la Secret Box ya fue revelada, auto-revelar esta postal y broadcastearla
-	// como postal regular (el momento de sorpresa ya pasó, que aparezca nomás)
+	// como postal regular (el momento de sorpresa ya pasó, que aparezca nomás).
+	// Durante un reveal uno por uno (quedan otras en la cola) se suma a la cola.
 	status, statusErr := h.postcardRepo.GetSecretBoxStatusByEvent(eventModel.ID)
-	if statusErr == nil && status.Revealed {
+	if statusErr == nil && status.Revealed && othersInRevealQueue(status, postcard) <= 0 {
 		if revealed, revealErr := h.postcardRepo.RevealPostcard(postcard.ID); revealErr == nil {
 			postcard = revealed
-			if h.hub != nil {
+			if h.hub != nil && postcard.IsPublic() {
 				// Secret postcards get broadcast to the room if available
 				if eventSlug, exists := c.Get("event_slug"); exists {
 					h.hub.BroadcastPostcardToRoom(eventSlug.(string), *postcard)
@@ -954,9 +1113,19 @@ func (h *Handler) CreateSecretPostcard(c *gin.Context) {
 	}
 	// Si aún no fue revelada: NO broadcast — sigue siendo una sorpresa 🎁
 
+	h.logPostcardEvent(postcard, nil, models.PostcardEventCreated)
+
 	c.JSON(http.StatusCreated, postcard)
 }
 
+// othersInRevealQueue cuenta las secretas por revelar sin contar la postal recién creada
+func othersInRevealQueue(status *models.SecretBoxStatus, created *models.Postcard) int {
+	if created.ModerationStatus == models.ModerationApproved && created.MediaStatus == models.MediaStatusReady {
+		return status.Remaining - 1
+	}
+	return status.Remaining
+}
+
 // GetSecretBoxStatus devuelve el estado de la Secret Box (total de secretas, si fue revelada)
 func (h *Handler) GetSecretBoxStatus(c *gin.Context) {
 	// Si hay event_id en el contexto, usar versión scoped
@@ -980,8 +1149,14 @@ func (h *Handler) GetSecretBoxStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, status)
 }
 
-// ListSecretPostcards devuelve todas las postales secretas (para preview del admin)
+// ListSecretPostcards devuelve todas las postales secretas (para preview del admin).
+// Por defecto con la rendition thumb.
 func (h *Handler) ListSecretPostcards(c *gin.Context) {
+	variant, ok := imageVariantFromQuery(c, models.ImageVariantThumb)
+	if !ok {
+		return
+	}
+
 	var postcards []models.Postcard
 	var err error
 
@@ -1001,6 +1176,7 @@ func (h *Handler) ListSecretPostcards(c *gin.Context) {
 	if postcards == nil {
 		postcards = []models.Postcard{}
 	}
+	useImageVariant(postcards, variant)
 
 	// Verificar si ya fue revelada (alguna postal tiene revealed_at)
 	revealed := false
@@ -1041,13 +1217,15 @@ func (h *Handler) RevealSecretBox(c *gin.Context) {
 		postcards = []models.Postcard{}
 	}
 
-	// Broadcast a todos los clientes conectados — dispara la animación
-	if h.hub != nil && len(postcards) > 0 {
+	// Broadcast a todos los clientes conectados — dispara la animación.
+	// Las pendientes o rechazadas por moderación no se muestran.
+	public := models.PublicPostcards(postcards)
+	if h.hub != nil && len(public) > 0 {
 		// Usar broadcast por room si hay event_slug
 		if eventSlug, exists := c.Get("event_slug"); exists {
-			h.hub.BroadcastSecretRevealToRoom(eventSlug.(string), postcards)
+			h.hub.BroadcastSecretRevealToRoom(eventSlug.(string), public)
 		} else {
-			h.hub.BroadcastSecretReveal(postcards)
+			h.hub.BroadcastSecretReveal(public)
 		}
 	}
 
@@ -1090,8 +1268,15 @@ func (h *Handler) ResetSecretBox(c *gin.Context) {
 	})
 }
 
-// ListPostcards obtiene todas las postales
+// ListPostcards obtiene todas las postales.
+// ?variant=thumb | board | full elige la rendition de image_path (default board).
 func (h *Handler) ListPostcards(c *gin.Context) {
+	// El corkboard muestra la rendition board salvo que pidan otra (?variant=full)
+	variant, ok := imageVariantFromQuery(c, models.ImageVariantBoard)
+	if !ok {
+		return
+	}
+
 	// Si hay event_id en el contexto, usar ListByEvent, sino List
 	var postcards []models.Postcard
 	var err error
@@ -1110,10 +1295,69 @@ func (h *Handler) ListPostcards(c *gin.Context) {
 	if postcards == nil {
 		postcards = []models.Postcard{}
 	}
+	useImageVariant(postcards, variant)
 
 	c.JSON(http.StatusOK, postcards)
 }
 
+// imageVariantFromQuery lee ?variant= (thumb | board | full), con defaultVariant si no viene.
+// Escribe el 400 si el valor es inválido.
+func imageVariantFromQuery(c *gin.Context, defaultVariant string) (string, bool) {
+	variant := c.DefaultQuery("variant", defaultVariant)
+	if !models.IsValidImageVariant(variant) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid variant. Must be thumb, board or full"})
+		return "", false
+	}
+	return variant, true
+}
+
+// useImageVariant pone en image_path la rendition pedida de cada postal.
+// renditions sigue trayendo las tres URLs.
+func useImageVariant(postcards []models.Postcard, variant string) {
+	for i := range postcards {
+		postcards[i].UseImageVariant(variant)
+	}
+}
+
+// ViewPostcard POST /api/events/:slug/postcards/:id/view
+// Registra que alguien abrió una postal (llamado desde frontend). Las secretas
+// sin revelar y las no aprobadas por moderación no se pueden ver.
+func (h *Handler) ViewPostcard(c *gin.Context) {
+	postcardID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid postcard ID"})
+		return
+	}
+
+	postcard, err := h.postcardRepo.GetByID(postcardID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Postcard not found"})
+		return
+	}
+	if eventID, exists := c.Get("event_id"); exists && postcard.EventID != eventID.(uuid.UUID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Postcard not found"})
+		return
+	}
+	if !postcard.IsPublic() {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Postcard not found"})
+		return
+	}
+
+	// Jugador opcional, resuelto por PlayerSessionMiddleware
+	var playerID *uuid.UUID
+	if id, ok := sessionPlayerID(c); ok {
+		playerID = &id
+	}
+
+	if err := h.logPostcardEvent(postcard, playerID, models.PostcardEventViewed); err != nil {
+		// No fallar el request por analytics
+		c.JSON(http.StatusOK, gin.H{"logged": false})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"logged": h.telemetry != nil})
+}
+
 // ==========================================
 // Descriptions (Estampillas del Corkboard)
 // ==========================================
@@ -3,15 +3,23 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"image"
+	"image/jpeg"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -195,15 +203,20 @@ func TestSubmitQuizValidation(t *testing.T) {
 
 type mockState struct {
 	secretBoxRevealed bool
+	revealQueue       int // secretas por revelar (incluida la recién creada)
 	broadcastCalled   bool
 }
 
 type mockPostcardRepo struct {
-	state           *mockState
-	createdPostcard *models.Postcard
+	state             *mockState
+	createdPostcard   *models.Postcard
+	createdRenditions *models.ImageRenditions
+	eventPostcards    []models.Postcard
+	deleted           []uuid.UUID
 }
 
-func (r *mockPostcardRepo) CreateSecret(eventID uuid.UUID, senderName, imagePath, message string, rotation float64, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error) {
+func (r *mockPostcardRepo) CreateSecret(eventID uuid.UUID, senderName, imagePath, message string, rotation float64, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error) {
+	r.createdRenditions = renditions
 	return r.createdPostcard, nil
 }
 
@@ -216,8 +229,9 @@ func (r *mockPostcardRepo) GetSecretBoxStatus() (*models.SecretBoxStatus, error)
 
 func (r *mockPostcardRepo) GetSecretBoxStatusByEvent(eventID uuid.UUID) (*models.SecretBoxStatus, error) {
 	return &models.SecretBoxStatus{
-		Total:    1,
-		Revealed: r.state.secretBoxRevealed,
+		Total:     1,
+		Revealed:  r.state.secretBoxRevealed,
+		Remaining: r.state.revealQueue,
 	}, nil
 }
 
@@ -227,16 +241,18 @@ func (r *mockPostcardRepo) RevealPostcard(id uuid.UUID) (*models.Postcard, error
 	return r.createdPostcard, nil
 }
 
-func (r *mockPostcardRepo) Create(playerID uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error) {
+func (r *mockPostcardRepo) Create(playerID uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error) {
+	r.createdRenditions = renditions
 	return r.createdPostcard, nil
 }
-func (r *mockPostcardRepo) CreateWithEvent(eventID uuid.UUID, playerID *uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error) {
+func (r *mockPostcardRepo) CreateWithEvent(eventID uuid.UUID, playerID *uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error) {
+	r.createdRenditions = renditions
 	return r.createdPostcard, nil
 }
 func (r *mockPostcardRepo) GetByID(id uuid.UUID) (*models.Postcard, error) { return nil, nil }
 func (r *mockPostcardRepo) List() ([]models.Postcard, error)               { return nil, nil }
 func (r *mockPostcardRepo) ListByEvent(eventID uuid.UUID) ([]models.Postcard, error) {
-	return nil, nil
+	return r.eventPostcards, nil
 }
 func (r *mockPostcardRepo) ListSecret() ([]models.Postcard, error) { return nil, nil }
 func (r *mockPostcardRepo) ListSecretByEvent(eventID uuid.UUID) ([]models.Postcard, error) {
@@ -252,6 +268,10 @@ func (r *mockPostcardRepo) ResetSecretBoxByEvent(eventID uuid.UUID) (int64, erro
 func (r *mockPostcardRepo) UpdateBackupStatus(postcardID uuid.UUID, status models.BackupStatus, backupJobID *uuid.UUID) error {
 	return nil
 }
+func (r *mockPostcardRepo) Delete(id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
 
 type mockHub struct {
 	state *mockState
@@ -278,24 +298,17 @@ func (h *mockHub) BroadcastSecretResetToRoom(eventSlug string, count int64)
 func TestSecretPostcardAutoRevealLogic(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	// Create minimal JPEG bytes for image validation (SOI marker + APP0 marker)
-	jpegHeader := []byte{
-		0xFF, 0xD8, // SOI
-		0xFF, 0xE0, // APP0 marker
-		0x00, 0x10, // length
-		0x4A, 0x46, 0x49, 0x46, 0x00, // "JFIF\0"
-		0x01, 0x01, // version 1.1
-		0x00,                   // aspect ratio units
-		0x00, 0x01, 0x00, 0x01, // X/Y density
-		0x00, 0x00, // thumbnail size
-	}
-	// Pad to 512 bytes so http.DetectContentType has enough data
-	imageData := make([]byte, 512)
-	copy(imageData, jpegHeader)
+	// JPEG real: las imágenes se decodifican para generar las renditions
+	var jpegBuf bytes.Buffer
+	if err := jpeg.Encode(&jpegBuf, image.NewRGBA(image.Rect(0, 0, 64, 48)), nil); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	imageData := jpegBuf.Bytes()
 
 	tests := []struct {
 		name              string
 		secretBoxRevealed bool
+		revealQueue       int
 		wantBroadcast     bool
 		wantRevealedAt    bool
 	}{
@@ -308,26 +321,36 @@ func TestSecretPostcardAutoRevealLogic(t *testing.T) {
 		{
 			name:              "Secret Box already revealed — auto-reveal and broadcast",
 			secretBoxRevealed: true,
+			revealQueue:       1,
 			wantBroadcast:     true,
 			wantRevealedAt:    true,
 		},
+		{
+			name:              "Staged reveal in progress — joins the queue",
+			secretBoxRevealed: true,
+			revealQueue:       3,
+			wantBroadcast:     false,
+			wantRevealedAt:    false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			state := &mockState{secretBoxRevealed: tt.secretBoxRevealed}
+			state := &mockState{secretBoxRevealed: tt.secretBoxRevealed, revealQueue: tt.revealQueue}
 
 			postcard := &models.Postcard{
-				ID:       uuid.New(),
-				Message:  "Feliz cumple!",
-				IsSecret: true,
+				ID:               uuid.New(),
+				Message:          "Feliz cumple!",
+				IsSecret:         true,
+				ModerationStatus: models.ModerationApproved,
+				MediaStatus:      models.MediaStatusReady,
 			}
 
 			repo := &mockPostcardRepo{state: state, createdPostcard: postcard}
 			hub := &mockHub{state: state}
 
 			tmpDir := t.TempDir()
-			h := &Handler{postcardRepo: repo, hub: hub, uploadsDir: tmpDir}
+			h := &Handler{postcardRepo: repo, hub: hub, media: services.NewLocalMediaStore(tmpDir)}
 			eventToken := "test-token"
 			event := &models.Event{ID: uuid.New(), Slug: "test-event", IsActive: true, SecretBoxToken: &eventToken}
 
@@ -362,6 +385,10 @@ func TestSecretPostcardAutoRevealLogic(t *testing.T) {
 				t.Errorf("broadcastCalled = %v, want %v", state.broadcastCalled, tt.wantBroadcast)
 			}
 
+			if repo.createdRenditions == nil || repo.createdRenditions.Thumb == "" || repo.createdRenditions.Board == "" {
+				t.Errorf("Expected image renditions to be stored, got %+v", repo.createdRenditions)
+			}
+
 			var resp map[string]interface{}
 			json.Unmarshal(w.Body.Bytes(), &resp)
 			if tt.wantRevealedAt && resp["revealed_at"] == nil {
@@ -374,6 +401,180 @@ func TestSecretPostcardAutoRevealLogic(t *testing.T) {
 	}
 }
 
+func TestListPostcardsImageVariant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newPostcards := func() []models.Postcard {
+		return []models.Postcard{
+			{
+				ID:        uuid.New(),
+				ImagePath: "/uploads/postcards/a.jpg",
+				Renditions: &models.ImageRenditions{
+					Thumb: "/uploads/postcards/thumb/a.jpg",
+					Board: "/uploads/postcards/board/a.jpg",
+					Full:  "/uploads/postcards/a.jpg",
+				},
+			},
+			// Postal anterior al pipeline de renditions: siempre la original
+			{ID: uuid.New(), ImagePath: "/uploads/postcards/legacy.jpg"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantImage  string
+	}{
+		{"default is board", "", http.StatusOK, "/uploads/postcards/board/a.jpg"},
+		{"thumb", "?variant=thumb", http.StatusOK, "/uploads/postcards/thumb/a.jpg"},
+		{"full", "?variant=full", http.StatusOK, "/uploads/postcards/a.jpg"},
+		{"invalid", "?variant=huge", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPostcardRepo{state: &mockState{}, eventPostcards: newPostcards()}
+			h := &Handler{postcardRepo: repo}
+
+			r := gin.New()
+			r.GET("/api/postcards", func(c *gin.Context) {
+				c.Set("event_id", uuid.New())
+				h.ListPostcards(c)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/postcards"+tt.query, nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d — body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp []models.Postcard
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if resp[0].ImagePath != tt.wantImage {
+				t.Errorf("image_path = %q, want %q", resp[0].ImagePath, tt.wantImage)
+			}
+			if resp[0].Renditions == nil || resp[0].Renditions.Full != "/uploads/postcards/a.jpg" {
+				t.Errorf("renditions should keep all URLs, got %+v", resp[0].Renditions)
+			}
+			if resp[1].ImagePath != "/uploads/postcards/legacy.jpg" {
+				t.Errorf("legacy image_path = %q", resp[1].ImagePath)
+			}
+		})
+	}
+}
+
+type mockMediaJobs struct {
+	postcardIDs []uuid.UUID
+	sourceKeys  []string
+	err         error
+}
+
+func (m *mockMediaJobs) EnqueueMediaJob(postcardID uuid.UUID, sourceKey string) error {
+	m.postcardIDs = append(m.postcardIDs, postcardID)
+	m.sourceKeys = append(m.sourceKeys, sourceKey)
+	return m.err
+}
+
+// TestCreatePostcardVideoIsProcessedAsync verifica que los videos se guardan sin
+// procesar, se encolan para el MediaWorker y no se publican hasta estar listos.
+func TestCreatePostcardVideoIsProcessedAsync(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Cabecera MP4 (caja ftyp) suficiente para la detección por magic bytes
+	videoData := append([]byte{0x00, 0x00, 0x00, 0x18}, []byte("ftypisom\x00\x00\x02\x00isomiso2")...)
+	videoData = append(videoData, make([]byte, 512)...)
+
+	tests := []struct {
+		name        string
+		jobs        *mockMediaJobs
+		wantStatus  int
+		wantQueued  bool
+		wantDeleted bool
+	}{
+		{"queued for processing", &mockMediaJobs{}, http.StatusCreated, true, false},
+		{"queue failure removes the postcard", &mockMediaJobs{err: errors.New("db down")}, http.StatusInternalServerError, true, true},
+		{"no media worker", nil, http.StatusServiceUnavailable, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Secret Box ya revelada: una imagen se publicaría en el acto
+			state := &mockState{secretBoxRevealed: true}
+			postcard := &models.Postcard{
+				ID:               uuid.New(),
+				IsSecret:         true,
+				MediaType:        "video",
+				ModerationStatus: models.ModerationApproved,
+				MediaStatus:      models.MediaStatusProcessing,
+			}
+			repo := &mockPostcardRepo{state: state, createdPostcard: postcard}
+
+			tmpDir := t.TempDir()
+			h := &Handler{postcardRepo: repo, hub: &mockHub{state: state}, media: services.NewLocalMediaStore(tmpDir)}
+			if tt.jobs != nil {
+				h.SetMediaJobs(tt.jobs)
+			}
+			eventToken := "test-token"
+			event := &models.Event{ID: uuid.New(), Slug: "test-event", IsActive: true, SecretBoxToken: &eventToken}
+
+			r := gin.New()
+			r.POST("/api/postcards/secret", func(c *gin.Context) {
+				c.Set("event", event)
+				c.Set("event_id", event.ID)
+				c.Set("event_slug", event.Slug)
+				h.CreateSecretPostcard(c)
+			})
+
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			mw.WriteField("sender_name", "Tío Juan")
+			fw, _ := mw.CreateFormFile("media", "clip.mp4")
+			fw.Write(videoData)
+			mw.Close()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/postcards/secret", &body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			req.Header.Set("X-Secret-Token", "test-token")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d — body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if state.broadcastCalled {
+				t.Error("a processing video must not be broadcast")
+			}
+
+			if tt.wantQueued {
+				if len(tt.jobs.sourceKeys) != 1 || tt.jobs.postcardIDs[0] != postcard.ID {
+					t.Fatalf("expected one media job for the postcard, got %v", tt.jobs.postcardIDs)
+				}
+				if !strings.HasPrefix(tt.jobs.sourceKeys[0], "postcards/originals/") {
+					t.Errorf("source key = %q, want postcards/originals/...", tt.jobs.sourceKeys[0])
+				}
+			}
+
+			if tt.wantDeleted != (len(repo.deleted) == 1) {
+				t.Errorf("deleted postcards = %v, want deleted=%v", repo.deleted, tt.wantDeleted)
+			}
+
+			// El original solo queda guardado si el job se encoló
+			originals, _ := os.ReadDir(filepath.Join(tmpDir, "postcards", "originals"))
+			if wantFiles := tt.wantStatus == http.StatusCreated; wantFiles != (len(originals) == 1) {
+				t.Errorf("stored originals = %d, want stored=%v", len(originals), wantFiles)
+			}
+		})
+	}
+}
+
 func TestCreateSecretPostcardMissingToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	eventToken := "test-secret-token"
@@ -591,3 +792,202 @@ func TestGetQuizQuestions(t *testing.T) {
 		t.Errorf("Expected 2 options for coffee question, got %d", len(options))
 	}
 }
+
+// mockTelemetryRepo registra en memoria los eventos de analytics
+type mockTelemetryRepo struct {
+	starts         map[uuid.UUID]*models.QuizStart
+	completed      map[uuid.UUID]int
+	postcardEvents []string
+	playerEvent    uuid.UUID // único evento al que pertenecen los jugadores
+	fail           bool
+}
+
+func newMockTelemetryRepo(eventID uuid.UUID) *mockTelemetryRepo {
+	return &mockTelemetryRepo{
+		starts:      make(map[uuid.UUID]*models.QuizStart),
+		completed:   make(map[uuid.UUID]int),
+		playerEvent: eventID,
+	}
+}
+
+func (m *mockTelemetryRepo) LogQuizStarted(eventID, playerID uuid.UUID) (*models.QuizStart, error) {
+	if eventID != m.playerEvent {
+		return nil, repository.ErrPlayerNotInEvent
+	}
+	if start, ok := m.starts[playerID]; ok {
+		return &models.QuizStart{StartedAt: start.StartedAt, AlreadyStarted: true}, nil
+	}
+	start := &models.QuizStart{StartedAt: time.Now()}
+	m.starts[playerID] = start
+	return start, nil
+}
+
+func (m *mockTelemetryRepo) LogQuizCompleted(eventID, playerID uuid.UUID, score int) error {
+	m.completed[playerID] = score
+	return nil
+}
+
+func (m *mockTelemetryRepo) LogPostcardEvent(eventID uuid.UUID, playerID *uuid.UUID, postcardID uuid.UUID, eventType string) error {
+	if m.fail {
+		return errors.New("db down")
+	}
+	m.postcardEvents = append(m.postcardEvents, eventType+":"+postcardID.String())
+	return nil
+}
+
+// viewPostcardRepo devuelve postales conocidas por ID
+type viewPostcardRepo struct {
+	mockPostcardRepo
+	postcards map[uuid.UUID]*models.Postcard
+}
+
+func (r *viewPostcardRepo) GetByID(id uuid.UUID) (*models.Postcard, error) {
+	if p, ok := r.postcards[id]; ok {
+		return p, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestStartQuiz(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	event := &models.Event{ID: uuid.New(), Slug: "quiz-event", Features: models.EventFeatures{Quiz: true}}
+	telemetry := newMockTelemetryRepo(event.ID)
+	h := &Handler{telemetry: telemetry}
+	playerID := uuid.New()
+
+	r := gin.New()
+	r.POST("/api/events/:slug/quiz/start", func(c *gin.Context) {
+		c.Set("event", event)
+		c.Set("event_id", event.ID)
+		if c.GetHeader("X-Test-Player") != "" {
+			c.Set("player_id", uuid.MustParse(c.GetHeader("X-Test-Player")))
+		}
+		h.StartQuiz(c)
+	})
+
+	start := func(player string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/events/quiz-event/quiz/start", nil)
+		if player != "" {
+			req.Header.Set("X-Test-Player", player)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := start("")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("without session: expected 401, got %d", w.Code)
+	}
+
+	w = start(playerID.String())
+	if w.Code != http.StatusCreated {
+		t.Fatalf("first start: expected 201, got %d — body: %s", w.Code, w.Body.String())
+	}
+	var first models.QuizStart
+	json.Unmarshal(w.Body.Bytes(), &first)
+	if first.AlreadyStarted {
+		t.Error("first start should not be marked as already started")
+	}
+
+	// Un segundo inicio no crea otro registro: devuelve el original
+	w = start(playerID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("duplicate start: expected 200, got %d", w.Code)
+	}
+	var second models.QuizStart
+	json.Unmarshal(w.Body.Bytes(), &second)
+	if !second.AlreadyStarted || !second.StartedAt.Equal(first.StartedAt) {
+		t.Errorf("duplicate start = %+v, want original start %v", second, first.StartedAt)
+	}
+	if len(telemetry.starts) != 1 {
+		t.Errorf("starts recorded = %d, want 1", len(telemetry.starts))
+	}
+
+	// Jugador de otro evento
+	telemetry.playerEvent = uuid.New()
+	w = start(uuid.New().String())
+	if w.Code != http.StatusForbidden {
+		t.Errorf("foreign player: expected 403, got %d", w.Code)
+	}
+}
+
+func TestViewPostcard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	eventID := uuid.New()
+	now := time.Now()
+	approved := models.ModerationApproved
+	ready := models.MediaStatusReady
+	visible := &models.Postcard{ID: uuid.New(), EventID: eventID, ModerationStatus: approved, MediaStatus: ready}
+	hiddenSecret := &models.Postcard{ID: uuid.New(), EventID: eventID, IsSecret: true, ModerationStatus: approved, MediaStatus: ready}
+	revealedSecret := &models.Postcard{ID: uuid.New(), EventID: eventID, IsSecret: true, RevealedAt: &now, ModerationStatus: approved, MediaStatus: ready}
+	pending := &models.Postcard{ID: uuid.New(), EventID: eventID, ModerationStatus: models.ModerationPending, MediaStatus: ready}
+	otherEvent := &models.Postcard{ID: uuid.New(), EventID: uuid.New(), ModerationStatus: approved, MediaStatus: ready}
+	processing := &models.Postcard{ID: uuid.New(), EventID: eventID, ModerationStatus: approved, MediaStatus: models.MediaStatusProcessing}
+
+	repo := &viewPostcardRepo{postcards: map[uuid.UUID]*models.Postcard{
+		processing.ID:     processing,
+		visible.ID:        visible,
+		hiddenSecret.ID:   hiddenSecret,
+		revealedSecret.ID: revealedSecret,
+		pending.ID:        pending,
+		otherEvent.ID:     otherEvent,
+	}}
+	telemetry := newMockTelemetryRepo(eventID)
+	h := &Handler{postcardRepo: repo, telemetry: telemetry}
+
+	r := gin.New()
+	r.POST("/api/events/:slug/postcards/:id/view", func(c *gin.Context) {
+		c.Set("event_id", eventID)
+		h.ViewPostcard(c)
+	})
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{"visible postcard", visible.ID.String(), http.StatusOK},
+		{"revealed secret", revealedSecret.ID.String(), http.StatusOK},
+		{"unrevealed secret", hiddenSecret.ID.String(), http.StatusNotFound},
+		{"pending moderation", pending.ID.String(), http.StatusNotFound},
+		{"video still processing", processing.ID.String(), http.StatusNotFound},
+		{"postcard from another event", otherEvent.ID.String(), http.StatusNotFound},
+		{"unknown postcard", uuid.New().String(), http.StatusNotFound},
+		{"invalid id", "not-a-uuid", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/events/e/postcards/"+tt.id+"/view", nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+
+	want := []string{
+		models.PostcardEventViewed + ":" + visible.ID.String(),
+		models.PostcardEventViewed + ":" + revealedSecret.ID.String(),
+	}
+	if len(telemetry.postcardEvents) != len(want) {
+		t.Fatalf("postcard events = %v, want %v", telemetry.postcardEvents, want)
+	}
+	for i := range want {
+		if telemetry.postcardEvents[i] != want[i] {
+			t.Errorf("postcard event %d = %s, want %s", i, telemetry.postcardEvents[i], want[i])
+		}
+	}
+
+	// Si falla analytics el request no falla
+	telemetry.fail = true
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/events/e/postcards/"+visible.ID.String()+"/view", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte(`"logged":false`)) {
+		t.Errorf("failed logging: got %d %s, want 200 logged=false", w.Code, w.Body.String())
+	}
+}
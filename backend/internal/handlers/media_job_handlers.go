@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// MediaJobAdminRepo define las operaciones sobre los jobs de procesamiento de video
+type MediaJobAdminRepo interface {
+	GetByID(id uuid.UUID) (*models.MediaJob, error)
+	ListByEvent(eventID uuid.UUID, status string) ([]models.MediaJob, error)
+	ResetForRetry(jobID uuid.UUID) error
+}
+
+// MediaJobWaker despierta al MediaWorker para que tome los jobs re-encolados
+type MediaJobWaker interface {
+	Wake()
+}
+
+// MediaJobHandler permite al owner ver y reintentar el procesamiento de videos
+type MediaJobHandler struct {
+	jobs   MediaJobAdminRepo
+	worker MediaJobWaker
+}
+
+// NewMediaJobHandler crea un nuevo handler de jobs de media
+func NewMediaJobHandler(jobs MediaJobAdminRepo, worker MediaJobWaker) *MediaJobHandler {
+	return &MediaJobHandler{jobs: jobs, worker: worker}
+}
+
+// ListMediaJobs GET /api/admin/events/:slug/media-jobs?status=failed
+// Jobs de procesamiento de video del evento, más recientes primero.
+func (h *MediaJobHandler) ListMediaJobs(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	status := models.MediaJobStatus(c.Query("status"))
+	switch status {
+	case "", models.MediaJobStatusQueued, models.MediaJobStatusInProgress, models.MediaJobStatusDone, models.MediaJobStatusFailed:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be queued, in_progress, done or failed"})
+		return
+	}
+
+	jobs, err := h.jobs.ListByEvent(event.ID, string(status))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list media jobs"})
+		return
+	}
+
+	if jobs == nil {
+		jobs = []models.MediaJob{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs, "total": len(jobs)})
+}
+
+// RetryMediaJob POST /api/admin/events/:slug/media-jobs/:id/retry
+// Vuelve a encolar un job fallido; la postal vuelve a 'processing'.
+func (h *MediaJobHandler) RetryMediaJob(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.jobs.GetByID(jobID)
+	if err == repository.ErrMediaJobNotFound || (err == nil && job.EventID != event.ID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Media job not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get media job"})
+		return
+	}
+
+	if err := h.jobs.ResetForRetry(jobID); err != nil {
+		if err == repository.ErrMediaJobNotFailed {
+			c.JSON(http.StatusConflict, gin.H{"error": "Only failed jobs can be retried"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry media job"})
+		return
+	}
+
+	if h.worker != nil {
+		h.worker.Wake()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job re-queued for retry",
+		"job_id":  jobID,
+	})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// ============== MOCKS ==============
+
+type mockMediaJobAdminRepo struct {
+	jobs map[uuid.UUID]*models.MediaJob
+}
+
+func (m *mockMediaJobAdminRepo) GetByID(id uuid.UUID) (*models.MediaJob, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, repository.ErrMediaJobNotFound
+	}
+	copy := *job
+	return &copy, nil
+}
+
+func (m *mockMediaJobAdminRepo) ListByEvent(eventID uuid.UUID, status string) ([]models.MediaJob, error) {
+	var result []models.MediaJob
+	for _, job := range m.jobs {
+		if job.EventID == eventID && (status == "" || string(job.Status) == status) {
+			result = append(result, *job)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMediaJobAdminRepo) ResetForRetry(jobID uuid.UUID) error {
+	job := m.jobs[jobID]
+	if job.Status != models.MediaJobStatusFailed {
+		return repository.ErrMediaJobNotFailed
+	}
+	job.Status = models.MediaJobStatusQueued
+	job.Attempts = 0
+	return nil
+}
+
+type mockMediaJobWaker struct {
+	woken int
+}
+
+func (w *mockMediaJobWaker) Wake() {
+	w.woken++
+}
+
+// ============== TESTS ==============
+
+func TestMediaJobHandler(t *testing.T) {
+	event := createTestEvent("video-event", "Video Event")
+	failed := &models.MediaJob{ID: uuid.New(), EventID: event.ID, Status: models.MediaJobStatusFailed, Attempts: 3}
+	done := &models.MediaJob{ID: uuid.New(), EventID: event.ID, Status: models.MediaJobStatusDone, Attempts: 1}
+	otherEvent := &models.MediaJob{ID: uuid.New(), EventID: uuid.New(), Status: models.MediaJobStatusFailed, Attempts: 3}
+
+	repo := &mockMediaJobAdminRepo{jobs: map[uuid.UUID]*models.MediaJob{
+		failed.ID: failed, done.ID: done, otherEvent.ID: otherEvent,
+	}}
+	waker := &mockMediaJobWaker{}
+	handler := NewMediaJobHandler(repo, waker)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Next()
+	})
+	router.GET("/api/admin/events/:slug/media-jobs", handler.ListMediaJobs)
+	router.POST("/api/admin/events/:slug/media-jobs/:id/retry", handler.RetryMediaJob)
+	base := "/api/admin/events/video-event/media-jobs"
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, base+path, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("list failed jobs", func(t *testing.T) {
+		w := do("GET", "?status=failed")
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Jobs  []models.MediaJob `json:"jobs"`
+			Total int               `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Jobs, 1)
+		assert.Equal(t, failed.ID, resp.Jobs[0].ID)
+
+		w = do("GET", "?status=broken")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("retry a failed job", func(t *testing.T) {
+		w := do("POST", "/"+failed.ID.String()+"/retry")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, models.MediaJobStatusQueued, failed.Status)
+		assert.Equal(t, 1, waker.woken)
+	})
+
+	t.Run("only failed jobs can be retried", func(t *testing.T) {
+		w := do("POST", "/"+done.ID.String()+"/retry")
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("jobs of other events are not found", func(t *testing.T) {
+		w := do("POST", "/"+otherEvent.ID.String()+"/retry")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, models.MediaJobStatusFailed, otherEvent.Status)
+
+		w = do("POST", "/not-a-uuid/retry")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
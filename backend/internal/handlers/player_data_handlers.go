@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// PlayerDataRepo export y borrado de todos los datos de un jugador
+type PlayerDataRepo interface {
+	ExportData(playerID uuid.UUID) (*models.PlayerDataExport, error)
+	EraseData(playerID uuid.UUID) error
+	ListByEvent(eventID uuid.UUID) ([]models.Player, error)
+}
+
+// PlayerBackupEraser borra las copias de las postales que el BackupWorker subió al
+// Drive del owner. Devuelve services.ErrDriveNotConnected si el owner desconectó Drive.
+type PlayerBackupEraser interface {
+	DeletePostcardBackups(ctx context.Context, ownerID uuid.UUID, postcards []models.Postcard, jobs []models.BackupJob) (int, error)
+}
+
+// PlayerDataBroadcaster saca de las pantallas las postales y el puntaje del jugador borrado
+type PlayerDataBroadcaster interface {
+	BroadcastPostcardRemovedToRoom(eventSlug string, postcardID uuid.UUID)
+	BroadcastRankingToRoom(eventSlug string, ranking []models.RankingEntry)
+}
+
+// PlayerDataHandler export (ZIP) y borrado de los datos de un jugador (GDPR), tanto
+// para el owner del evento como para el propio invitado con su token de sesión
+type PlayerDataHandler struct {
+	players PlayerDataRepo
+	media   services.MediaStore
+	backups PlayerBackupEraser
+	hub     PlayerDataBroadcaster
+}
+
+// NewPlayerDataHandler crea un nuevo handler de datos de jugadores
+func NewPlayerDataHandler(players PlayerDataRepo, media services.MediaStore) *PlayerDataHandler {
+	return &PlayerDataHandler{players: players, media: media}
+}
+
+// SetBackups configura el borrado de las copias en Drive (solo con el backup activado)
+func (h *PlayerDataHandler) SetBackups(backups PlayerBackupEraser) {
+	h.backups = backups
+}
+
+// SetHub configura el hub para actualizar corkboard y ranking tras un borrado
+func (h *PlayerDataHandler) SetHub(hub PlayerDataBroadcaster) {
+	h.hub = hub
+}
+
+// PlayerErasureResult resultado del borrado de un jugador
+type PlayerErasureResult struct {
+	Erased            bool      `json:"erased"`
+	PlayerID          uuid.UUID `json:"player_id"`
+	PostcardsDeleted  int       `json:"postcards_deleted"`
+	MediaFilesDeleted int       `json:"media_files_deleted"`
+	DriveFilesDeleted int       `json:"drive_files_deleted"`
+}
+
+// ExportPlayerData GET /api/admin/events/:slug/players/:id/data
+// ZIP con todos los datos del jugador y la media de sus postales (solo el owner)
+func (h *PlayerDataHandler) ExportPlayerData(c *gin.Context) {
+	event, playerID, ok := h.adminPlayer(c)
+	if !ok {
+		return
+	}
+	h.export(c, event, playerID)
+}
+
+// ErasePlayerData DELETE /api/admin/events/:slug/players/:id/data
+// Borra al jugador, sus datos, su media y las copias en Drive (solo el owner)
+func (h *PlayerDataHandler) ErasePlayerData(c *gin.Context) {
+	event, playerID, ok := h.adminPlayer(c)
+	if !ok {
+		return
+	}
+	h.erase(c, event, playerID)
+}
+
+// ExportMyData GET /api/events/:slug/me/data
+// El invitado descarga sus propios datos. Exige X-Player-Token (no X-Player-ID).
+func (h *PlayerDataHandler) ExportMyData(c *gin.Context) {
+	event, playerID, ok := h.sessionPlayer(c)
+	if !ok {
+		return
+	}
+	h.export(c, event, playerID)
+}
+
+// EraseMyData DELETE /api/events/:slug/me/data
+// El invitado borra su jugador y todos sus datos. Exige X-Player-Token.
+func (h *PlayerDataHandler) EraseMyData(c *gin.Context) {
+	event, playerID, ok := h.sessionPlayer(c)
+	if !ok {
+		return
+	}
+	h.erase(c, event, playerID)
+}
+
+// export arma el ZIP y lo manda en streaming (las postales pueden ser videos)
+func (h *PlayerDataHandler) export(c *gin.Context, event *models.Event, playerID uuid.UUID) {
+	data, ok := h.loadPlayerData(c, event, playerID)
+	if !ok {
+		return
+	}
+	data.ExportedAt = time.Now()
+	data.Event = models.PlayerDataEvent{ID: event.ID, Slug: event.Slug, Name: event.Name}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+event.Slug+`-player-`+playerID.String()+`.zip"`)
+	c.Status(http.StatusOK)
+
+	// Con el status ya enviado, un error solo puede cortar la descarga
+	if err := services.WritePlayerDataArchive(c.Request.Context(), c.Writer, data, h.media); err != nil {
+		fmt.Printf("[ERROR] Player data export failed for player %s: %v\n", playerID, err)
+	}
+}
+
+// erase borra primero las copias en Drive (si falla no se toca nada y se puede reintentar),
+// después las filas en una transacción y por último los archivos de media.
+// Los backup_jobs son el único registro de las copias y se borran en cascada con el
+// jugador, así que sin Drive conectado no se borra nada: 409 hasta que el owner lo reconecte.
+func (h *PlayerDataHandler) erase(c *gin.Context, event *models.Event, playerID uuid.UUID) {
+	data, ok := h.loadPlayerData(c, event, playerID)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	result := PlayerErasureResult{Erased: true, PlayerID: playerID, PostcardsDeleted: len(data.Postcards)}
+	if len(data.BackupJobs) > 0 {
+		if h.backups == nil {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":                    "Drive backups are disabled, so the Drive copies of this player's postcards cannot be deleted. Nothing was erased.",
+				"drive_reconnect_required": true,
+			})
+			return
+		}
+		deleted, err := h.backups.DeletePostcardBackups(ctx, event.OwnerID, data.Postcards, data.BackupJobs)
+		if errors.Is(err, services.ErrDriveNotConnected) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":                    "Reconnect Google Drive to delete the Drive copies of this player's postcards. Nothing was erased.",
+				"drive_reconnect_required": true,
+			})
+			return
+		}
+		if err != nil {
+			fmt.Printf("[ERROR] Failed to delete Drive backups of player %s: %v\n", playerID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to delete Drive backups, nothing was erased. Try again."})
+			return
+		}
+		result.DriveFilesDeleted = deleted
+	}
+
+	if err := h.players.EraseData(playerID); err != nil {
+		if err == repository.ErrPlayerNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to erase player data"})
+		return
+	}
+
+	for _, postcard := range data.Postcards {
+		result.MediaFilesDeleted += removePostcardMedia(ctx, h.media, &postcard)
+	}
+
+	h.broadcastErasure(event, data.Postcards)
+	c.JSON(http.StatusOK, result)
+}
+
+// loadPlayerData lee los datos verificando que el jugador sea del evento
+func (h *PlayerDataHandler) loadPlayerData(c *gin.Context, event *models.Event, playerID uuid.UUID) (*models.PlayerDataExport, bool) {
+	data, err := h.players.ExportData(playerID)
+	if err == repository.ErrPlayerNotFound || (err == nil && data.Player.EventID != event.ID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+		return nil, false
+	}
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to load data of player %s: %v\n", playerID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load player data"})
+		return nil, false
+	}
+	return data, true
+}
+
+// broadcastErasure saca las postales públicas del corkboard y manda el ranking sin el jugador
+func (h *PlayerDataHandler) broadcastErasure(event *models.Event, postcards []models.Postcard) {
+	if h.hub == nil {
+		return
+	}
+	for _, postcard := range postcards {
+		if postcard.IsPublic() {
+			h.hub.BroadcastPostcardRemovedToRoom(event.Slug, postcard.ID)
+		}
+	}
+
+	players, err := h.players.ListByEvent(event.ID)
+	if err != nil {
+		fmt.Printf("[WARN] Failed to list players for ranking after erasure: %v\n", err)
+		return
+	}
+	ranking := make([]models.RankingEntry, len(players))
+	for i, player := range players {
+		ranking[i] = models.RankingEntry{Position: i + 1, Player: player}
+	}
+	h.hub.BroadcastRankingToRoom(event.Slug, ranking)
+}
+
+// adminPlayer evento y jugador del path de las rutas admin
+func (h *PlayerDataHandler) adminPlayer(c *gin.Context) (*models.Event, uuid.UUID, bool) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return nil, uuid.Nil, false
+	}
+	playerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+		return nil, uuid.Nil, false
+	}
+	return event, playerID, true
+}
+
+// sessionPlayer evento y jugador autenticado por su token de sesión
+func (h *PlayerDataHandler) sessionPlayer(c *gin.Context) (*models.Event, uuid.UUID, bool) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return nil, uuid.Nil, false
+	}
+	playerID, ok := sessionPlayerID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Player token required"})
+		return nil, uuid.Nil, false
+	}
+	return event, playerID, true
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// mockPlayerDataRepo guarda los datos de un jugador en memoria y registra el orden de las llamadas
+type mockPlayerDataRepo struct {
+	data  map[uuid.UUID]*models.PlayerDataExport
+	calls *[]string
+}
+
+func (m *mockPlayerDataRepo) ExportData(playerID uuid.UUID) (*models.PlayerDataExport, error) {
+	data, ok := m.data[playerID]
+	if !ok {
+		return nil, repository.ErrPlayerNotFound
+	}
+	copied := *data
+	return &copied, nil
+}
+
+func (m *mockPlayerDataRepo) EraseData(playerID uuid.UUID) error {
+	*m.calls = append(*m.calls, "erase")
+	if _, ok := m.data[playerID]; !ok {
+		return repository.ErrPlayerNotFound
+	}
+	delete(m.data, playerID)
+	return nil
+}
+
+func (m *mockPlayerDataRepo) ListByEvent(eventID uuid.UUID) ([]models.Player, error) {
+	var players []models.Player
+	for _, d := range m.data {
+		if d.Player.EventID == eventID {
+			players = append(players, d.Player)
+		}
+	}
+	return players, nil
+}
+
+type mockPlayerBackupEraser struct {
+	calls   *[]string
+	deleted int
+	err     error
+}
+
+func (m *mockPlayerBackupEraser) DeletePostcardBackups(ctx context.Context, ownerID uuid.UUID, postcards []models.Postcard, jobs []models.BackupJob) (int, error) {
+	*m.calls = append(*m.calls, "drive")
+	return m.deleted, m.err
+}
+
+type mockPlayerDataHub struct {
+	removed []uuid.UUID
+	ranking []models.RankingEntry
+}
+
+func (m *mockPlayerDataHub) BroadcastPostcardRemovedToRoom(eventSlug string, postcardID uuid.UUID) {
+	m.removed = append(m.removed, postcardID)
+}
+
+func (m *mockPlayerDataHub) BroadcastRankingToRoom(eventSlug string, ranking []models.RankingEntry) {
+	m.ranking = ranking
+}
+
+type playerDataFixture struct {
+	event    *models.Event
+	playerID uuid.UUID
+	postcard models.Postcard
+	repo     *mockPlayerDataRepo
+	store    *services.LocalMediaStore
+	hub      *mockPlayerDataHub
+	calls    []string
+}
+
+func newPlayerDataFixture(t *testing.T) *playerDataFixture {
+	f := &playerDataFixture{event: createTestEvent("party", "Party"), playerID: uuid.New(), hub: &mockPlayerDataHub{}}
+	f.event.OwnerID = uuid.New()
+	f.store = services.NewLocalMediaStore(t.TempDir())
+	require.NoError(t, f.store.Put(context.Background(), "postcards/photo.jpg", strings.NewReader("jpeg"), -1, "image/jpeg"))
+	require.NoError(t, f.store.Put(context.Background(), "postcards/board/photo.jpg", strings.NewReader("board"), -1, "image/jpeg"))
+
+	f.postcard = models.Postcard{
+		ID: uuid.New(), EventID: f.event.ID, PlayerID: &f.playerID,
+		ImagePath: f.store.URL("postcards/photo.jpg"), ModerationStatus: models.ModerationApproved, MediaStatus: "ready",
+		Renditions: &models.ImageRenditions{Board: f.store.URL("postcards/board/photo.jpg"), Full: f.store.URL("postcards/photo.jpg")},
+	}
+	driveFileID := "drive-file"
+	f.repo = &mockPlayerDataRepo{calls: &f.calls, data: map[uuid.UUID]*models.PlayerDataExport{
+		f.playerID: {
+			Player:     models.Player{ID: f.playerID, EventID: f.event.ID, Name: "Ana"},
+			Postcards:  []models.Postcard{f.postcard},
+			BackupJobs: []models.BackupJob{{ID: uuid.New(), PostcardID: f.postcard.ID, DriveFileID: &driveFileID}},
+		},
+	}}
+	return f
+}
+
+func (f *playerDataFixture) router(backups PlayerBackupEraser, playerID *uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewPlayerDataHandler(f.repo, f.store)
+	handler.SetHub(f.hub)
+	if backups != nil {
+		handler.SetBackups(backups)
+	}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", f.event)
+		if playerID != nil {
+			c.Set("player_id", *playerID)
+		}
+		c.Next()
+	})
+	router.GET("/admin/players/:id/data", handler.ExportPlayerData)
+	router.DELETE("/admin/players/:id/data", handler.ErasePlayerData)
+	router.GET("/me/data", handler.ExportMyData)
+	router.DELETE("/me/data", handler.EraseMyData)
+	return router
+}
+
+func TestPlayerDataHandler_Export(t *testing.T) {
+	f := newPlayerDataFixture(t)
+
+	t.Run("owner downloads the ZIP", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		f.router(nil, nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/players/"+f.playerID.String()+"/data", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+
+		zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		var names []string
+		var exported models.PlayerDataExport
+		for _, file := range zr.File {
+			names = append(names, file.Name)
+			if file.Name == services.PlayerDataFileName {
+				r, err := file.Open()
+				require.NoError(t, err)
+				require.NoError(t, json.NewDecoder(r).Decode(&exported))
+				r.Close()
+			}
+		}
+		assert.Contains(t, names, "media/"+f.postcard.ID.String()+"/photo.jpg")
+		assert.Equal(t, "party", exported.Event.Slug)
+		assert.Equal(t, "Ana", exported.Player.Name)
+		assert.False(t, exported.ExportedAt.IsZero())
+	})
+
+	t.Run("guest downloads their own data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		f.router(nil, &f.playerID).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me/data", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("guest without player token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		f.router(nil, nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me/data", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("player of another event", func(t *testing.T) {
+		otherID := uuid.New()
+		f.repo.data[otherID] = &models.PlayerDataExport{Player: models.Player{ID: otherID, EventID: uuid.New()}}
+		defer delete(f.repo.data, otherID)
+
+		w := httptest.NewRecorder()
+		f.router(nil, nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/players/"+otherID.String()+"/data", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid player ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		f.router(nil, nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/players/nope/data", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPlayerDataHandler_Erase(t *testing.T) {
+	t.Run("deletes Drive copies, rows and media", func(t *testing.T) {
+		f := newPlayerDataFixture(t)
+		backups := &mockPlayerBackupEraser{calls: &f.calls, deleted: 2}
+
+		w := httptest.NewRecorder()
+		f.router(backups, &f.playerID).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/me/data", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var result PlayerErasureResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.True(t, result.Erased)
+		assert.Equal(t, 1, result.PostcardsDeleted)
+		assert.Equal(t, 2, result.MediaFilesDeleted)
+		assert.Equal(t, 2, result.DriveFilesDeleted)
+
+		// Drive primero: si falla, las filas siguen y se puede reintentar
+		assert.Equal(t, []string{"drive", "erase"}, f.calls)
+		_, err := f.store.Get(context.Background(), "postcards/photo.jpg")
+		assert.ErrorIs(t, err, services.ErrMediaNotFound)
+		assert.Equal(t, []uuid.UUID{f.postcard.ID}, f.hub.removed)
+		assert.NotNil(t, f.hub.ranking)
+		assert.Empty(t, f.hub.ranking)
+	})
+
+	t.Run("Drive failure erases nothing", func(t *testing.T) {
+		f := newPlayerDataFixture(t)
+		backups := &mockPlayerBackupEraser{calls: &f.calls, err: errors.New("drive down")}
+
+		w := httptest.NewRecorder()
+		f.router(backups, nil).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/players/"+f.playerID.String()+"/data", nil))
+
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+		assert.Equal(t, []string{"drive"}, f.calls)
+		assert.Contains(t, f.repo.data, f.playerID)
+		_, err := f.store.Get(context.Background(), "postcards/photo.jpg")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Drive disconnected or backups disabled erases nothing", func(t *testing.T) {
+		for name, backups := range map[string]func(f *playerDataFixture) PlayerBackupEraser{
+			"disconnected": func(f *playerDataFixture) PlayerBackupEraser {
+				return &mockPlayerBackupEraser{calls: &f.calls, err: services.ErrDriveNotConnected}
+			},
+			"disabled": func(f *playerDataFixture) PlayerBackupEraser { return nil },
+		} {
+			t.Run(name, func(t *testing.T) {
+				f := newPlayerDataFixture(t)
+				w := httptest.NewRecorder()
+				f.router(backups(f), nil).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/players/"+f.playerID.String()+"/data", nil))
+
+				// Los backup_jobs se borrarían con el jugador y las copias quedarían sin rastro
+				assert.Equal(t, http.StatusConflict, w.Code)
+				assert.Contains(t, w.Body.String(), `"drive_reconnect_required":true`)
+				assert.NotContains(t, f.calls, "erase")
+				assert.Contains(t, f.repo.data, f.playerID)
+				_, err := f.store.Get(context.Background(), "postcards/photo.jpg")
+				assert.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("unknown player", func(t *testing.T) {
+		f := newPlayerDataFixture(t)
+		w := httptest.NewRecorder()
+		f.router(nil, nil).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/players/"+uuid.New().String()+"/data", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, f.calls)
+	})
+}
@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// PostcardModerationRepo define las operaciones de moderación de postales
+type PostcardModerationRepo interface {
+	GetByID(id uuid.UUID) (*models.Postcard, error)
+	ListForModeration(eventID uuid.UUID, status string) ([]models.Postcard, error)
+	SetModerationStatus(id uuid.UUID, status string, moderatedBy uuid.UUID) (*models.Postcard, error)
+	Delete(id uuid.UUID) error
+}
+
+// ModerationBroadcaster publica o quita postales de las pantallas del evento
+type ModerationBroadcaster interface {
+	BroadcastPostcardToRoom(eventSlug string, postcard models.Postcard)
+	BroadcastPostcardRemovedToRoom(eventSlug string, postcardID uuid.UUID)
+}
+
+// PostcardModerationHandler maneja la moderación de postales por parte del owner
+type PostcardModerationHandler struct {
+	postcardRepo PostcardModerationRepo
+	eventUpdater EventUpdater
+	hub          ModerationBroadcaster
+	media        services.MediaStore
+}
+
+// NewPostcardModerationHandler crea un nuevo handler de moderación
+func NewPostcardModerationHandler(postcardRepo PostcardModerationRepo, eventUpdater EventUpdater, hub ModerationBroadcaster, media services.MediaStore) *PostcardModerationHandler {
+	return &PostcardModerationHandler{
+		postcardRepo: postcardRepo,
+		eventUpdater: eventUpdater,
+		hub:          hub,
+		media:        media,
+	}
+}
+
+// UpdateModerationRequest body para activar o desactivar la pre-moderación
+type UpdateModerationRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// UpdateModeration PUT /api/admin/events/:slug/moderation
+// Activa o desactiva la pre-moderación de postales del evento. Solo afecta a las
+// postales nuevas: las pendientes siguen esperando aprobación.
+func (h *PostcardModerationHandler) UpdateModeration(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event.Settings.PostcardModeration = *req.Enabled
+	if err := h.eventUpdater.Update(event); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update event"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"postcard_moderation": event.Settings.PostcardModeration})
+}
+
+// ListPostcards GET /api/admin/events/:slug/postcards?status=pending&variant=thumb
+// Todas las postales del evento (incluye secretas), pendientes primero.
+func (h *PostcardModerationHandler) ListPostcards(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	variant, ok := imageVariantFromQuery(c, models.ImageVariantThumb)
+	if !ok {
+		return
+	}
+
+	status := c.Query("status")
+	if status != "" && status != models.ModerationPending && status != models.ModerationApproved && status != models.ModerationRejected {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be pending, approved or rejected"})
+		return
+	}
+
+	postcards, err := h.postcardRepo.ListForModeration(event.ID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list postcards"})
+		return
+	}
+
+	if postcards == nil {
+		postcards = []models.Postcard{}
+	}
+	useImageVariant(postcards, variant)
+
+	c.JSON(http.StatusOK, gin.H{"postcards": postcards})
+}
+
+// ApprovePostcard POST /api/admin/events/:slug/postcards/:id/approve
+// Aprueba la postal y la publica en el corkboard (las secretas esperan al reveal).
+func (h *PostcardModerationHandler) ApprovePostcard(c *gin.Context) {
+	h.moderate(c, models.ModerationApproved)
+}
+
+// RejectPostcard POST /api/admin/events/:slug/postcards/:id/reject
+// Rechaza la postal: se oculta de las pantallas pero se conserva para revisión.
+func (h *PostcardModerationHandler) RejectPostcard(c *gin.Context) {
+	h.moderate(c, models.ModerationRejected)
+}
+
+// moderate cambia el estado de moderación y actualiza las pantallas conectadas
+func (h *PostcardModerationHandler) moderate(c *gin.Context, status string) {
+	event, postcard, ok := h.postcardFromRequest(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	wasPublic := postcard.IsPublic()
+	updated, err := h.postcardRepo.SetModerationStatus(postcard.ID, status, userID.(uuid.UUID))
+	if err == repository.ErrPostcardNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Postcard not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to moderate postcard"})
+		return
+	}
+
+	if h.hub != nil {
+		if !wasPublic && updated.IsPublic() {
+			h.hub.BroadcastPostcardToRoom(event.Slug, *updated)
+		} else if wasPublic && !updated.IsPublic() {
+			h.hub.BroadcastPostcardRemovedToRoom(event.Slug, updated.ID)
+		}
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeletePostcard DELETE /api/admin/events/:slug/postcards/:id
+// Elimina la postal y sus archivos de media, y la quita de las pantallas.
+func (h *PostcardModerationHandler) DeletePostcard(c *gin.Context) {
+	event, postcard, ok := h.postcardFromRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.postcardRepo.Delete(postcard.ID); err != nil {
+		if err == repository.ErrPostcardNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Postcard not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete postcard"})
+		return
+	}
+
+	removePostcardMedia(c.Request.Context(), h.media, postcard)
+
+	if h.hub != nil && postcard.IsPublic() {
+		h.hub.BroadcastPostcardRemovedToRoom(event.Slug, postcard.ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": true, "id": postcard.ID})
+}
+
+// moderationMediaURLExpiry validez de las URLs firmadas del panel de moderación
+const moderationMediaURLExpiry = 15 * time.Minute
+
+// PostcardMediaURLs URLs firmadas para ver la media de una postal
+type PostcardMediaURLs struct {
+	ImageURL     string    `json:"image_url"`
+	ThumbnailURL *string   `json:"thumbnail_url,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// GetPostcardMedia GET /api/admin/events/:slug/postcards/:id/media
+// URLs firmadas de la media de la postal, para revisar pendientes y rechazadas
+// cuando el bucket es privado.
+func (h *PostcardModerationHandler) GetPostcardMedia(c *gin.Context) {
+	_, postcard, ok := h.postcardFromRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	resp := PostcardMediaURLs{
+		ImageURL:  postcard.ImagePath,
+		ExpiresAt: time.Now().Add(moderationMediaURLExpiry),
+	}
+
+	signed, err := h.signedURL(ctx, postcard.ImagePath)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign media URL"})
+		return
+	}
+	resp.ImageURL = signed
+
+	thumbPath := postcard.ThumbnailPath
+	if thumbPath == nil && postcard.Renditions != nil {
+		thumbPath = &postcard.Renditions.Thumb
+	}
+	if thumbPath != nil {
+		thumb, err := h.signedURL(ctx, *thumbPath)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign media URL"})
+			return
+		}
+		resp.ThumbnailURL = &thumb
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// signedURL firma una URL pública del MediaStore. Las URLs externas se devuelven tal cual.
+func (h *PostcardModerationHandler) signedURL(ctx context.Context, publicURL string) (string, error) {
+	key, ok := h.media.KeyFromURL(publicURL)
+	if !ok {
+		return publicURL, nil
+	}
+	return h.media.SignedURL(ctx, key, moderationMediaURLExpiry)
+}
+
+// postcardFromRequest obtiene la postal del path verificando que sea del evento.
+// Escribe la respuesta de error si falla.
+func (h *PostcardModerationHandler) postcardFromRequest(c *gin.Context) (*models.Event, *models.Postcard, bool) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	postcardID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid postcard ID"})
+		return nil, nil, false
+	}
+
+	postcard, err := h.postcardRepo.GetByID(postcardID)
+	if err != nil || postcard.EventID != event.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Postcard not found"})
+		return nil, nil, false
+	}
+
+	return event, postcard, true
+}
+
+// postcardMediaURLs URLs de todos los archivos de una postal: la media original,
+// el thumbnail del video y las renditions de la imagen
+func postcardMediaURLs(postcard *models.Postcard) []string {
+	urls := []string{postcard.ImagePath}
+	if postcard.ThumbnailPath != nil {
+		urls = append(urls, *postcard.ThumbnailPath)
+	}
+	if postcard.Renditions != nil {
+		urls = append(urls, postcard.Renditions.Thumb, postcard.Renditions.Board)
+	}
+	return urls
+}
+
+// removePostcardMedia borra todos los archivos de la postal; devuelve cuántos borró
+func removePostcardMedia(ctx context.Context, media services.MediaStore, postcard *models.Postcard) int {
+	removed := 0
+	for _, url := range postcardMediaURLs(postcard) {
+		if removeMedia(ctx, media, url) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// removeMedia borra un archivo subido a partir de su URL pública.
+// Ignora URLs que no pertenecen al MediaStore y archivos que ya no existen.
+func removeMedia(ctx context.Context, media services.MediaStore, publicURL string) bool {
+	key, ok := media.KeyFromURL(publicURL)
+	if !ok {
+		return false
+	}
+
+	if err := media.Delete(ctx, key); err != nil {
+		fmt.Printf("[WARN] Failed to remove postcard media %s: %v\n", key, err)
+		return false
+	}
+	return true
+}
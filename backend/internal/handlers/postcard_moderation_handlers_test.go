@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// ============== MOCKS ==============
+
+type mockModerationRepo struct {
+	postcards map[uuid.UUID]*models.Postcard
+}
+
+func newMockModerationRepo(postcards ...*models.Postcard) *mockModerationRepo {
+	m := &mockModerationRepo{postcards: make(map[uuid.UUID]*models.Postcard)}
+	for _, p := range postcards {
+		m.postcards[p.ID] = p
+	}
+	return m
+}
+
+func (m *mockModerationRepo) GetByID(id uuid.UUID) (*models.Postcard, error) {
+	p, ok := m.postcards[id]
+	if !ok {
+		return nil, repository.ErrPostcardNotFound
+	}
+	copy := *p
+	return &copy, nil
+}
+
+func (m *mockModerationRepo) ListForModeration(eventID uuid.UUID, status string) ([]models.Postcard, error) {
+	var result []models.Postcard
+	for _, p := range m.postcards {
+		if p.EventID == eventID && (status == "" || p.ModerationStatus == status) {
+			result = append(result, *p)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockModerationRepo) SetModerationStatus(id uuid.UUID, status string, moderatedBy uuid.UUID) (*models.Postcard, error) {
+	p, ok := m.postcards[id]
+	if !ok {
+		return nil, repository.ErrPostcardNotFound
+	}
+	p.ModerationStatus = status
+	return m.GetByID(id)
+}
+
+func (m *mockModerationRepo) Delete(id uuid.UUID) error {
+	if _, ok := m.postcards[id]; !ok {
+		return repository.ErrPostcardNotFound
+	}
+	delete(m.postcards, id)
+	return nil
+}
+
+type mockModerationHub struct {
+	published []uuid.UUID
+	removed   []uuid.UUID
+}
+
+func (h *mockModerationHub) BroadcastPostcardToRoom(eventSlug string, postcard models.Postcard) {
+	h.published = append(h.published, postcard.ID)
+}
+
+func (h *mockModerationHub) BroadcastPostcardRemovedToRoom(eventSlug string, postcardID uuid.UUID) {
+	h.removed = append(h.removed, postcardID)
+}
+
+func setupModerationRouter(handler *PostcardModerationHandler, event *models.Event) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Set("user_id", event.OwnerID)
+		c.Next()
+	})
+
+	admin := router.Group("/api/admin/events/:slug")
+	admin.PUT("/moderation", handler.UpdateModeration)
+	admin.GET("/postcards", handler.ListPostcards)
+	admin.POST("/postcards/:id/approve", handler.ApprovePostcard)
+	admin.POST("/postcards/:id/reject", handler.RejectPostcard)
+	admin.DELETE("/postcards/:id", handler.DeletePostcard)
+	admin.GET("/postcards/:id/media", handler.GetPostcardMedia)
+
+	return router
+}
+
+// ============== TESTS ==============
+
+func TestPostcardModerationHandler(t *testing.T) {
+	event := createTestEvent("mod-event", "Moderation Event")
+	now := time.Now()
+	pending := &models.Postcard{ID: uuid.New(), EventID: event.ID, ModerationStatus: models.ModerationPending, MediaStatus: models.MediaStatusReady}
+	published := &models.Postcard{ID: uuid.New(), EventID: event.ID, ModerationStatus: models.ModerationApproved, MediaStatus: models.MediaStatusReady}
+	hiddenSecret := &models.Postcard{ID: uuid.New(), EventID: event.ID, IsSecret: true, ModerationStatus: models.ModerationPending, MediaStatus: models.MediaStatusReady}
+	revealedSecret := &models.Postcard{ID: uuid.New(), EventID: event.ID, IsSecret: true, RevealedAt: &now, ModerationStatus: models.ModerationApproved, MediaStatus: models.MediaStatusReady}
+	otherEvent := &models.Postcard{ID: uuid.New(), EventID: uuid.New(), ModerationStatus: models.ModerationApproved, MediaStatus: models.MediaStatusReady}
+
+	uploadsDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(uploadsDir, "postcards", "thumbnails"), 0755))
+	videoFile := filepath.Join(uploadsDir, "postcards", "clip.mp4")
+	thumbFile := filepath.Join(uploadsDir, "postcards", "thumbnails", "clip.mp4.jpg")
+	require.NoError(t, os.WriteFile(videoFile, []byte("video"), 0644))
+	require.NoError(t, os.WriteFile(thumbFile, []byte("thumb"), 0644))
+	thumb := "/uploads/postcards/thumbnails/clip.mp4.jpg"
+	published.ImagePath = "/uploads/postcards/clip.mp4"
+	published.ThumbnailPath = &thumb
+
+	repo := newMockModerationRepo(pending, published, hiddenSecret, revealedSecret, otherEvent)
+	eventUpdater := newMockEventUpdater()
+	eventUpdater.AddEvent(event)
+	hub := &mockModerationHub{}
+	router := setupModerationRouter(NewPostcardModerationHandler(repo, eventUpdater, hub, services.NewLocalMediaStore(uploadsDir)), event)
+	base := "/api/admin/events/mod-event"
+
+	do := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, base+path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("enable pre-moderation", func(t *testing.T) {
+		w := do("PUT", "/moderation", []byte(`{"enabled": true}`))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, eventUpdater.events[event.ID].Settings.PostcardModeration)
+
+		w = do("PUT", "/moderation", []byte(`{}`))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("list pending postcards", func(t *testing.T) {
+		w := do("GET", "/postcards?status=pending", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Postcards []models.Postcard `json:"postcards"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp.Postcards, 2)
+
+		w = do("GET", "/postcards?status=unknown", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("approve publishes the postcard", func(t *testing.T) {
+		w := do("POST", "/postcards/"+pending.ID.String()+"/approve", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, models.ModerationApproved, repo.postcards[pending.ID].ModerationStatus)
+		assert.Equal(t, []uuid.UUID{pending.ID}, hub.published)
+	})
+
+	t.Run("approving an unrevealed secret waits for the reveal", func(t *testing.T) {
+		w := do("POST", "/postcards/"+hiddenSecret.ID.String()+"/approve", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, hub.published, 1)
+	})
+
+	t.Run("reject removes a visible postcard from screens", func(t *testing.T) {
+		w := do("POST", "/postcards/"+revealedSecret.ID.String()+"/reject", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, models.ModerationRejected, repo.postcards[revealedSecret.ID].ModerationStatus)
+		assert.Equal(t, []uuid.UUID{revealedSecret.ID}, hub.removed)
+	})
+
+	t.Run("media URLs for review", func(t *testing.T) {
+		w := do("GET", "/postcards/"+published.ID.String()+"/media", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp PostcardMediaURLs
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, published.ImagePath, resp.ImageURL)
+		require.NotNil(t, resp.ThumbnailURL)
+		assert.Equal(t, thumb, *resp.ThumbnailURL)
+	})
+
+	t.Run("delete removes row, media files and card", func(t *testing.T) {
+		w := do("DELETE", "/postcards/"+published.ID.String(), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, repo.postcards, published.ID)
+		assert.NoFileExists(t, videoFile)
+		assert.NoFileExists(t, thumbFile)
+		assert.Equal(t, []uuid.UUID{revealedSecret.ID, published.ID}, hub.removed)
+	})
+
+	t.Run("postcards of other events are not found", func(t *testing.T) {
+		w := do("POST", "/postcards/"+otherEvent.ID.String()+"/reject", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = do("DELETE", "/postcards/"+otherEvent.ID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, repo.postcards, otherEvent.ID)
+	})
+
+	t.Run("invalid postcard ID", func(t *testing.T) {
+		w := do("POST", "/postcards/not-a-uuid/approve", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPostcardModerationHandler_RemoveMediaStaysInUploads(t *testing.T) {
+	root := t.TempDir()
+	uploadsDir := filepath.Join(root, "uploads")
+	require.NoError(t, os.MkdirAll(uploadsDir, 0755))
+	outside := filepath.Join(root, "secret.txt")
+	require.NoError(t, os.WriteFile(outside, []byte("keep"), 0644))
+
+	handler := NewPostcardModerationHandler(newMockModerationRepo(), nil, nil, services.NewLocalMediaStore(uploadsDir))
+	removeMedia(context.Background(), handler.media, "/uploads/../secret.txt")
+	removeMedia(context.Background(), handler.media, "/etc/passwd")
+
+	assert.FileExists(t, outside)
+}
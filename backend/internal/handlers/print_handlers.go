@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// PrintThemeGetter obtiene el tema del evento (el default si no tiene uno guardado)
+type PrintThemeGetter interface {
+	GetThemeForEvent(eventID string) (*models.Theme, error)
+}
+
+// PrintGuestLister lista los invitados para imprimir sus invitaciones personales
+type PrintGuestLister interface {
+	ListByEvent(eventID uuid.UUID) ([]models.GuestInvitation, error)
+}
+
+// Destinos de los QR impresos
+const (
+	printTargetJoin      = "join"
+	printTargetCorkboard = "corkboard"
+	printTargetSecretBox = "secret-box"
+)
+
+// Mensaje por defecto de las tarjetas según el destino
+var printTargetMessages = map[string]string{
+	printTargetJoin:      "Escaneá el código para sumarte",
+	printTargetCorkboard: "Escaneá y dejá tu postal",
+	printTargetSecretBox: "Escaneá y mandá tu sorpresa",
+}
+
+const (
+	printInvitationMessage = "Tu invitación personal"
+	defaultTableCardCount  = 4
+	defaultTableCardLabel  = "Mesa"
+	maxPrintMessageLength  = 80
+	maxPrintLogoBytes      = 10 << 20
+)
+
+// PrintHandler genera los QR y las tarjetas imprimibles del evento. Se arman en cada
+// pedido con el slug y el token de Secret Box actuales, así nunca quedan desactualizados.
+type PrintHandler struct {
+	themes PrintThemeGetter
+	guests PrintGuestLister
+	media  services.MediaStore
+	appURL string
+}
+
+// NewPrintHandler crea un nuevo handler de impresión. appURL es el origen público del
+// frontend (PUBLIC_APP_URL); vacío usa el origen del pedido.
+func NewPrintHandler(themes PrintThemeGetter, guests PrintGuestLister, media services.MediaStore, appURL string) *PrintHandler {
+	return &PrintHandler{
+		themes: themes,
+		guests: guests,
+		media:  media,
+		appURL: strings.TrimRight(appURL, "/"),
+	}
+}
+
+// buildEventURL link público del evento (el que se comparte para sumarse)
+func buildEventURL(slug string) string {
+	return "/e/" + slug
+}
+
+// buildCorkboardURL link público de la cartelera del evento
+func buildCorkboardURL(slug string) string {
+	return "/e/" + slug + "/corkboard"
+}
+
+// GetQRCode GET /api/admin/events/:slug/qr/:target
+// QR (PNG o SVG) del link para sumarse (join), de la cartelera (corkboard) o de la
+// Secret Box (secret-box). Query: format=png|svg, size en px para PNG.
+func (h *PrintHandler) GetQRCode(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	target := c.Param("target")
+	path, ok := h.targetPath(c, event, target)
+	if !ok {
+		return
+	}
+	content := h.appBaseURL(c) + path
+
+	filename := event.Slug + "-" + target + "-qr"
+	c.Header("Cache-Control", "no-store")
+	switch c.DefaultQuery("format", "png") {
+	case "svg":
+		svg, err := services.QRCodeSVG(content)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate QR code"})
+			return
+		}
+		c.Header("Content-Disposition", `inline; filename="`+filename+`.svg"`)
+		c.Data(http.StatusOK, "image/svg+xml", svg)
+	case "png":
+		size := services.DefaultQRSize
+		if raw := c.Query("size"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < services.MinQRSize || parsed > services.MaxQRSize {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "size must be between 128 and 2048"})
+				return
+			}
+			size = parsed
+		}
+		png, err := services.QRCodePNG(content, size)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate QR code"})
+			return
+		}
+		c.Header("Content-Disposition", `inline; filename="`+filename+`.png"`)
+		c.Data(http.StatusOK, "image/png", png)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be png or svg"})
+	}
+}
+
+// GetTableCards GET /api/admin/events/:slug/print/table-cards
+// PDF con tarjetas para las mesas (cuatro A6 por hoja A4) con los colores y el logo del tema.
+// Query: target (join, corkboard, secret-box), count, numbered, label, message.
+func (h *PrintHandler) GetTableCards(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	target := c.DefaultQuery("target", printTargetJoin)
+	path, ok := h.targetPath(c, event, target)
+	if !ok {
+		return
+	}
+
+	count := defaultTableCardCount
+	if raw := c.Query("count"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > services.MaxTableCards {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "count must be between 1 and 200"})
+			return
+		}
+		count = parsed
+	}
+	numbered := c.DefaultQuery("numbered", "true") != "false"
+	label := truncateMessage(strings.TrimSpace(c.DefaultQuery("label", defaultTableCardLabel)), 30)
+
+	content := h.appBaseURL(c) + path
+	cards := make([]services.TableCard, count)
+	for i := range cards {
+		cards[i] = services.TableCard{URL: content}
+		if numbered {
+			cards[i].Label = strings.TrimSpace(label + " " + strconv.Itoa(i+1))
+		}
+	}
+
+	h.renderCards(c, event, cards, h.printMessage(c, printTargetMessages[target]), event.Slug+"-table-cards.pdf")
+}
+
+// GetInvitationCards GET /api/admin/events/:slug/print/invitations
+// PDF con una invitación por invitado: su nombre y el QR de su link personal
+func (h *PrintHandler) GetInvitationCards(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	guests, err := h.guests.ListByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list guests"})
+		return
+	}
+	if len(guests) == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "The guest list is empty"})
+		return
+	}
+	if len(guests) > services.MaxTableCards {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Too many guests for one PDF (max 200)"})
+		return
+	}
+
+	base := h.appBaseURL(c)
+	cards := make([]services.TableCard, len(guests))
+	for i, g := range guests {
+		cards[i] = services.TableCard{Label: g.Name, URL: base + buildGuestJoinURL(event.Slug, g.Token)}
+	}
+
+	h.renderCards(c, event, cards, h.printMessage(c, printInvitationMessage), event.Slug+"-invitations.pdf")
+}
+
+// renderCards arma el PDF con el tema del evento y lo devuelve como descarga
+func (h *PrintHandler) renderCards(c *gin.Context, event *models.Event, cards []services.TableCard, message, filename string) {
+	doc := services.TableCardsDocument{
+		Title:        event.Name,
+		Message:      message,
+		Cards:        cards,
+		PrimaryColor: event.Settings.PrimaryColor,
+	}
+	logoURL := event.Settings.LogoURL
+
+	theme, err := h.themes.GetThemeForEvent(event.ID.String())
+	if err != nil {
+		fmt.Printf("[WARN] Print: failed to load theme for event %s: %v\n", event.Slug, err)
+	} else if theme != nil {
+		if theme.PrimaryColor != "" {
+			doc.PrimaryColor = theme.PrimaryColor
+		}
+		doc.AccentColor = theme.AccentColor
+		doc.BackgroundColor = theme.BgColor
+		doc.TextColor = theme.TextColor
+		if theme.LogoPath != nil && *theme.LogoPath != "" {
+			logoURL = *theme.LogoPath
+		}
+	}
+	doc.Logo = h.loadLogo(c, logoURL)
+
+	var buf bytes.Buffer
+	if err := services.RenderTableCardsPDF(doc, &buf); err != nil {
+		fmt.Printf("[ERROR] Print: failed to render cards for event %s: %v\n", event.Slug, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate PDF"})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// loadLogo lee el logo del MediaStore; sin logo o si falla, las tarjetas salen sin él
+func (h *PrintHandler) loadLogo(c *gin.Context, logoURL string) []byte {
+	if logoURL == "" || h.media == nil {
+		return nil
+	}
+	key, ok := h.media.KeyFromURL(logoURL)
+	if !ok {
+		return nil
+	}
+	r, err := h.media.Get(c.Request.Context(), key)
+	if err != nil {
+		fmt.Printf("[WARN] Print: failed to open logo %s: %v\n", key, err)
+		return nil
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, maxPrintLogoBytes+1))
+	if err != nil || len(data) > maxPrintLogoBytes {
+		return nil
+	}
+	return data
+}
+
+// targetPath devuelve el link relativo del destino; si el evento no lo tiene ya respondió
+func (h *PrintHandler) targetPath(c *gin.Context, event *models.Event, target string) (string, bool) {
+	switch target {
+	case printTargetJoin:
+		return buildEventURL(event.Slug), true
+	case printTargetCorkboard:
+		if !event.Features.Corkboard {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Corkboard feature not enabled for this event"})
+			return "", false
+		}
+		return buildCorkboardURL(event.Slug), true
+	case printTargetSecretBox:
+		if !event.Features.SecretBox {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Secret Box feature not enabled for this event"})
+			return "", false
+		}
+		// El link lleva el token de la Secret Box: mismo permiso que verlo
+		if role, _ := c.Get("event_role"); role == nil || !role.(models.EventRole).Can(models.PermissionManage) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Your role in this event does not allow this action", "role": role})
+			return "", false
+		}
+		if event.SecretBoxToken == nil || *event.SecretBoxToken == "" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Secret Box link not generated yet"})
+			return "", false
+		}
+		return buildSecretBoxURL(event.Slug, *event.SecretBoxToken), true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "target must be join, corkboard or secret-box"})
+		return "", false
+	}
+}
+
+// printMessage el mensaje de la query (recortado) o el default
+func (h *PrintHandler) printMessage(c *gin.Context, fallback string) string {
+	if message, ok := c.GetQuery("message"); ok {
+		return truncateMessage(strings.TrimSpace(message), maxPrintMessageLength)
+	}
+	return fallback
+}
+
+// appBaseURL origen del frontend para las URLs absolutas de los QR: PUBLIC_APP_URL,
+// o el Origin del pedido, o el host al que llegó (respetando el proxy)
+func (h *PrintHandler) appBaseURL(c *gin.Context) string {
+	if h.appURL != "" {
+		return h.appURL
+	}
+	if origin, err := url.Parse(c.GetHeader("Origin")); err == nil && origin.Host != "" &&
+		(origin.Scheme == "http" || origin.Scheme == "https") {
+		return origin.Scheme + "://" + origin.Host
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	host := c.Request.Host
+	if forwarded := c.GetHeader("X-Forwarded-Host"); forwarded != "" {
+		host = forwarded
+	}
+	return scheme + "://" + host
+}
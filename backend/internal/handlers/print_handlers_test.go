@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+type mockPrintThemeGetter struct{}
+
+func (m *mockPrintThemeGetter) GetThemeForEvent(eventID string) (*models.Theme, error) {
+	return &models.Theme{PrimaryColor: "#b70049", AccentColor: "#f0a", BgColor: "#fff8f9", TextColor: "#2d1b1f"}, nil
+}
+
+func setupPrintRouter(event *models.Event, role models.EventRole, guests *mockGuestRepo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewPrintHandler(&mockPrintThemeGetter{}, guests, services.NewLocalMediaStore(""), "https://fiesta.example.com/")
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Set("event_role", role)
+		c.Next()
+	})
+	router.GET("/qr/:target", handler.GetQRCode)
+	router.GET("/print/table-cards", handler.GetTableCards)
+	router.GET("/print/invitations", handler.GetInvitationCards)
+	return router
+}
+
+func TestPrintHandler_GetQRCode(t *testing.T) {
+	event := createTestEventForFeatures("party", "Party", uuid.New(), models.EventFeatures{Corkboard: true, SecretBox: true})
+
+	t.Run("png by default", func(t *testing.T) {
+		router := setupPrintRouter(event, models.EventRoleViewer, newMockGuestRepo())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/qr/join?size=256", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("svg encodes the public event URL", func(t *testing.T) {
+		router := setupPrintRouter(event, models.EventRoleViewer, newMockGuestRepo())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/qr/corkboard?format=svg", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+		want, err := services.QRCodeSVG("https://fiesta.example.com/e/party/corkboard")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), w.Body.String())
+	})
+
+	t.Run("invalid size and format", func(t *testing.T) {
+		router := setupPrintRouter(event, models.EventRoleViewer, newMockGuestRepo())
+		for _, path := range []string{"/qr/join?size=64", "/qr/join?format=gif", "/qr/unknown"} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		}
+	})
+
+	t.Run("secret box link requires manage permission", func(t *testing.T) {
+		token := uuid.New().String()
+		withToken := *event
+		withToken.SecretBoxToken = &token
+
+		w := httptest.NewRecorder()
+		setupPrintRouter(&withToken, models.EventRoleViewer, newMockGuestRepo()).
+			ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/qr/secret-box", nil))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = httptest.NewRecorder()
+		setupPrintRouter(&withToken, models.EventRoleCoHost, newMockGuestRepo()).
+			ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/qr/secret-box", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("secret box without token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		setupPrintRouter(event, models.EventRoleOwner, newMockGuestRepo()).
+			ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/qr/secret-box", nil))
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("disabled feature", func(t *testing.T) {
+		noCorkboard := createTestEventForFeatures("party", "Party", uuid.New(), models.EventFeatures{})
+		w := httptest.NewRecorder()
+		setupPrintRouter(noCorkboard, models.EventRoleOwner, newMockGuestRepo()).
+			ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/qr/corkboard", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPrintHandler_GetTableCards(t *testing.T) {
+	event := createTestEventForFeatures("party", "Cumpleaños de Mile", uuid.New(), models.EventFeatures{Corkboard: true})
+	router := setupPrintRouter(event, models.EventRoleViewer, newMockGuestRepo())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/print/table-cards?count=6&label=Table&message=Hola", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `attachment; filename="party-table-cards.pdf"`)
+	assert.True(t, strings.HasPrefix(w.Body.String(), "%PDF-"))
+
+	for _, path := range []string{"/print/table-cards?count=0", "/print/table-cards?count=201", "/print/table-cards?target=nope"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
+
+func TestPrintHandler_GetInvitationCards(t *testing.T) {
+	event := createTestEvent("party", "Party")
+	guests := newMockGuestRepo()
+
+	w := httptest.NewRecorder()
+	setupPrintRouter(event, models.EventRoleViewer, guests).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/print/invitations", nil))
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	_, err := guests.CreateGuests(event.ID, []models.GuestInput{{Name: "Ana"}, {Name: "José"}})
+	require.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	setupPrintRouter(event, models.EventRoleViewer, guests).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/print/invitations", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "%PDF-"))
+}
@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// QuizReviewQuestionRepo define las operaciones de preguntas usadas en la revisión del quiz.
+type QuizReviewQuestionRepo interface {
+	ListByEvent(eventID uuid.UUID) ([]models.QuizQuestion, error)
+	Update(question *models.QuizQuestion) error
+}
+
+// QuizReviewAnswerRepo define las operaciones sobre respuestas guardadas y aceptaciones del host.
+type QuizReviewAnswerRepo interface {
+	ListAnswersByEvent(eventID uuid.UUID) ([]models.PlayerQuizAnswers, error)
+	ListAcceptedByEvent(eventID uuid.UUID) (map[uuid.UUID]map[string]string, error)
+	AcceptAnswer(playerID uuid.UUID, questionKey, answer string, acceptedBy uuid.UUID) error
+	RemoveAcceptedAnswer(eventID, playerID uuid.UUID, questionKey string) error
+}
+
+// EventRescorer recalcula los puntajes de un evento y broadcastea el ranking.
+type EventRescorer interface {
+	RescoreEvent(eventID uuid.UUID, eventSlug string) (*models.RescoreResult, error)
+}
+
+// QuizReviewHandler maneja la revisión manual de respuestas del quiz por parte del host
+type QuizReviewHandler struct {
+	questionRepo QuizReviewQuestionRepo
+	answerRepo   QuizReviewAnswerRepo
+	rescorer     EventRescorer
+}
+
+// NewQuizReviewHandler crea un nuevo handler de revisión del quiz
+func NewQuizReviewHandler(questionRepo QuizReviewQuestionRepo, answerRepo QuizReviewAnswerRepo, rescorer EventRescorer) *QuizReviewHandler {
+	return &QuizReviewHandler{
+		questionRepo: questionRepo,
+		answerRepo:   answerRepo,
+		rescorer:     rescorer,
+	}
+}
+
+// AcceptAnswerForAllRequest body para agregar una respuesta válida a una pregunta
+type AcceptAnswerForAllRequest struct {
+	Answer string `json:"answer" binding:"required"`
+}
+
+// ListAnswers GET /api/admin/events/:slug/quiz/answers
+// Respuestas de todos los jugadores agrupadas por pregunta, con el resultado y
+// el motivo de cada una, para que el host detecte respuestas válidas rechazadas.
+func (h *QuizReviewHandler) ListAnswers(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	questions, err := h.questionRepo.ListByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list questions"})
+		return
+	}
+
+	answers, err := h.answerRepo.ListAnswersByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list answers"})
+		return
+	}
+
+	accepted, err := h.answerRepo.ListAcceptedByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list accepted answers"})
+		return
+	}
+
+	questionsByKey := make(map[string]models.QuizQuestion, len(questions))
+	for _, q := range questions {
+		questionsByKey[q.Key] = q
+	}
+
+	scorer := services.NewEventScorer(questions)
+	var reviews []models.QuestionReview
+	reviewIndex := make(map[string]int)
+
+	for _, a := range answers {
+		breakdown := scorer.ScoreWithOverrides(a.Favorites, a.Preferences, accepted[a.Player.ID])
+		for _, qs := range breakdown.Questions {
+			idx, exists := reviewIndex[qs.Key]
+			if !exists {
+				q := questionsByKey[qs.Key]
+				reviews = append(reviews, models.QuestionReview{
+					Key:            qs.Key,
+					Section:        qs.Section,
+					QuestionText:   q.QuestionText,
+					CorrectAnswers: q.CorrectAnswers,
+					Points:         qs.MaxPoints,
+					Answers:        []models.AnswerReview{},
+				})
+				idx = len(reviews) - 1
+				reviewIndex[qs.Key] = idx
+			}
+
+			reviews[idx].Answers = append(reviews[idx].Answers, models.AnswerReview{
+				PlayerID:      a.Player.ID,
+				PlayerName:    a.Player.Name,
+				PlayerAvatar:  a.Player.Avatar,
+				QuestionScore: qs,
+				Accepted:      qs.Match == models.MatchOverride,
+			})
+		}
+	}
+
+	if reviews == nil {
+		reviews = []models.QuestionReview{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"questions": reviews})
+}
+
+// AcceptPlayerAnswer POST /api/admin/events/:slug/quiz/players/:playerId/answers/:key/accept
+// Acepta la respuesta actual de un jugador como correcta y recalcula el ranking.
+func (h *QuizReviewHandler) AcceptPlayerAnswer(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	playerID, err := uuid.Parse(c.Param("playerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+		return
+	}
+	key := c.Param("key")
+
+	answers, err := h.answerRepo.ListAnswersByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list answers"})
+		return
+	}
+
+	var playerAnswers *models.PlayerQuizAnswers
+	for i := range answers {
+		if answers[i].Player.ID == playerID {
+			playerAnswers = &answers[i]
+			break
+		}
+	}
+	if playerAnswers == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player has no answers in this event"})
+		return
+	}
+
+	answer := playerAnswers.Favorites[key]
+	if answer == "" {
+		answer = playerAnswers.Preferences[key]
+	}
+	if answer == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Player did not answer this question"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	acceptedBy, _ := userID.(uuid.UUID)
+	if err := h.answerRepo.AcceptAnswer(playerID, key, answer, acceptedBy); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept answer"})
+		return
+	}
+
+	h.respondWithRescore(c, event)
+}
+
+// RevokePlayerAnswer DELETE /api/admin/events/:slug/quiz/players/:playerId/answers/:key/accept
+// Quita la aceptación manual y recalcula el ranking.
+func (h *QuizReviewHandler) RevokePlayerAnswer(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	playerID, err := uuid.Parse(c.Param("playerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+		return
+	}
+
+	if err := h.answerRepo.RemoveAcceptedAnswer(event.ID, playerID, c.Param("key")); err != nil {
+		if err == repository.ErrAnswerOverrideNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Answer was not accepted manually"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke answer"})
+		return
+	}
+
+	h.respondWithRescore(c, event)
+}
+
+// AcceptAnswerForAll POST /api/admin/events/:slug/quiz/questions/:key/accept
+// Agrega la respuesta como válida (alias) de la pregunta para todos los jugadores
+// y recalcula el ranking. Solo aplica a favoritos: las preferencias tienen una
+// única opción correcta.
+func (h *QuizReviewHandler) AcceptAnswerForAll(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req AcceptAnswerForAllRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	questions, err := h.questionRepo.ListByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list questions"})
+		return
+	}
+
+	var question *models.QuizQuestion
+	for i := range questions {
+		if questions[i].Key == c.Param("key") {
+			question = &questions[i]
+			break
+		}
+	}
+	if question == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		return
+	}
+	if question.Section != "favorites" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only favorites questions accept additional answers"})
+		return
+	}
+
+	normalizer := services.NewNormalizer()
+	answer := normalizer.NormalizeForStorage(req.Answer)
+	if answer == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Answer is empty after normalization"})
+		return
+	}
+
+	alreadyValid := false
+	for _, existing := range question.CorrectAnswers {
+		if normalizer.NormalizeForStorage(existing) == answer {
+			alreadyValid = true
+			break
+		}
+	}
+	if !alreadyValid {
+		question.CorrectAnswers = append(question.CorrectAnswers, answer)
+		if err := h.questionRepo.Update(question); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update question"})
+			return
+		}
+	}
+
+	h.respondWithRescore(c, event)
+}
+
+// Rescore POST /api/admin/events/:slug/quiz/rescore
+// Recalcula el puntaje de todos los jugadores con las preguntas vigentes.
+func (h *QuizReviewHandler) Rescore(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	h.respondWithRescore(c, event)
+}
+
+// respondWithRescore recalcula el evento y responde con el resultado
+func (h *QuizReviewHandler) respondWithRescore(c *gin.Context, event *models.Event) {
+	result, err := h.rescorer.RescoreEvent(event.ID, event.Slug)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to recalculate scores"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// eventFromContext obtiene el evento seteado por EventMiddleware
+func eventFromContext(c *gin.Context) (*models.Event, bool) {
+	event, exists := c.Get("event")
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return nil, false
+	}
+	return event.(*models.Event), true
+}
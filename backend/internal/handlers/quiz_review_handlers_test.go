@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/middleware"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// ============== MOCKS ==============
+
+type mockQuizAnswerRepo struct {
+	answers  []models.PlayerQuizAnswers
+	accepted map[uuid.UUID]map[string]string
+}
+
+func newMockQuizAnswerRepo(answers ...models.PlayerQuizAnswers) *mockQuizAnswerRepo {
+	return &mockQuizAnswerRepo{
+		answers:  answers,
+		accepted: make(map[uuid.UUID]map[string]string),
+	}
+}
+
+func (m *mockQuizAnswerRepo) ListAnswersByEvent(eventID uuid.UUID) ([]models.PlayerQuizAnswers, error) {
+	return m.answers, nil
+}
+
+func (m *mockQuizAnswerRepo) ListAcceptedByEvent(eventID uuid.UUID) (map[uuid.UUID]map[string]string, error) {
+	return m.accepted, nil
+}
+
+func (m *mockQuizAnswerRepo) AcceptAnswer(playerID uuid.UUID, questionKey, answer string, acceptedBy uuid.UUID) error {
+	if m.accepted[playerID] == nil {
+		m.accepted[playerID] = make(map[string]string)
+	}
+	m.accepted[playerID][questionKey] = answer
+	return nil
+}
+
+func (m *mockQuizAnswerRepo) RemoveAcceptedAnswer(eventID, playerID uuid.UUID, questionKey string) error {
+	inEvent := false
+	for _, a := range m.answers {
+		if a.Player.ID == playerID && a.Player.EventID == eventID {
+			inEvent = true
+			break
+		}
+	}
+	if _, ok := m.accepted[playerID][questionKey]; !ok || !inEvent {
+		return repository.ErrAnswerOverrideNotFound
+	}
+	delete(m.accepted[playerID], questionKey)
+	return nil
+}
+
+type mockEventRescorer struct {
+	calls int
+	slug  string
+}
+
+func (m *mockEventRescorer) RescoreEvent(eventID uuid.UUID, eventSlug string) (*models.RescoreResult, error) {
+	m.calls++
+	m.slug = eventSlug
+	return &models.RescoreResult{Ranking: []models.RankingEntry{}}, nil
+}
+
+func setupQuizReviewRouter(handler *QuizReviewHandler, event *models.Event) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Set("user_id", event.OwnerID)
+		c.Next()
+	})
+
+	quiz := router.Group("/api/admin/events/:slug/quiz")
+	quiz.GET("/answers", handler.ListAnswers)
+	quiz.POST("/players/:playerId/answers/:key/accept", handler.AcceptPlayerAnswer)
+	quiz.DELETE("/players/:playerId/answers/:key/accept", handler.RevokePlayerAnswer)
+	quiz.POST("/questions/:key/accept", handler.AcceptAnswerForAll)
+	quiz.POST("/rescore", handler.Rescore)
+
+	return router
+}
+
+// ============== TESTS ==============
+
+func TestQuizReviewHandler(t *testing.T) {
+	event := createTestEvent("review-event", "Review Event")
+	questionRepo := newMockQuizQuestionRepo()
+	question, _ := questionRepo.Create(event.ID, "favorites", "flower", "Flor favorita?", []string{"Girasol"}, nil, 1, true, models.QuestionScoring{Points: 2})
+	questionRepo.Create(event.ID, "preferences", "coffee", "Café o té?", []string{"te"}, []string{"cafe", "te"}, 2, true, models.QuestionScoring{Points: 1})
+
+	ana := models.Player{ID: uuid.New(), EventID: event.ID, Name: "Ana"}
+	beto := models.Player{ID: uuid.New(), EventID: event.ID, Name: "Beto"}
+	answerRepo := newMockQuizAnswerRepo(
+		models.PlayerQuizAnswers{Player: ana, Favorites: map[string]string{"flower": "girasol"}, Preferences: map[string]string{"coffee": "te"}},
+		models.PlayerQuizAnswers{Player: beto, Favorites: map[string]string{"flower": "girasoles amarillos"}, Preferences: map[string]string{}},
+	)
+	rescorer := &mockEventRescorer{}
+
+	router := setupQuizReviewRouter(NewQuizReviewHandler(questionRepo, answerRepo, rescorer), event)
+	base := "/api/admin/events/review-event/quiz"
+
+	t.Run("list answers grouped by question", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", base+"/answers", nil)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Questions []models.QuestionReview `json:"questions"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Questions, 2)
+
+		flower := resp.Questions[0]
+		assert.Equal(t, "flower", flower.Key)
+		assert.Equal(t, "Flor favorita?", flower.QuestionText)
+		require.Len(t, flower.Answers, 2)
+		assert.Equal(t, models.ScoreResultCorrect, flower.Answers[0].Result)
+		assert.Equal(t, models.ScoreResultIncorrect, flower.Answers[1].Result)
+		assert.Equal(t, "Beto", flower.Answers[1].PlayerName)
+	})
+
+	t.Run("accept answer for a single player", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", base+"/players/"+beto.ID.String()+"/answers/flower/accept", nil)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "girasoles amarillos", answerRepo.accepted[beto.ID]["flower"])
+		assert.Equal(t, 1, rescorer.calls)
+		assert.Equal(t, "review-event", rescorer.slug)
+
+		// El listado refleja la aceptación manual
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", base+"/answers", nil)
+		router.ServeHTTP(w, req)
+		var resp struct {
+			Questions []models.QuestionReview `json:"questions"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		betoAnswer := resp.Questions[0].Answers[1]
+		assert.True(t, betoAnswer.Accepted)
+		assert.Equal(t, models.MatchOverride, betoAnswer.Match)
+	})
+
+	t.Run("accept unanswered question fails", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", base+"/players/"+beto.ID.String()+"/answers/coffee/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("accept for player outside event", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", base+"/players/"+uuid.New().String()+"/answers/flower/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("revoke accepted answer", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", base+"/players/"+beto.ID.String()+"/answers/flower/accept", nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("DELETE", base+"/players/"+beto.ID.String()+"/answers/flower/accept", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("revoke for player of another event", func(t *testing.T) {
+		// Aceptación de otro evento: no se puede borrar desde este slug
+		outsider := uuid.New()
+		answerRepo.accepted[outsider] = map[string]string{"flower": "rosa"}
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", base+"/players/"+outsider.String()+"/answers/flower/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "rosa", answerRepo.accepted[outsider]["flower"])
+	})
+
+	t.Run("accept answer for everyone", func(t *testing.T) {
+		calls := rescorer.calls
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", base+"/questions/flower/accept", bytes.NewBufferString(`{"answer": "Girasoles Amarillos"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		updated, _ := questionRepo.GetByID(question.ID)
+		assert.Equal(t, []string{"Girasol", "girasoles amarillos"}, updated.CorrectAnswers)
+		assert.Equal(t, calls+1, rescorer.calls)
+	})
+
+	t.Run("accept answer for preference question fails", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", base+"/questions/coffee/accept", bytes.NewBufferString(`{"answer": "cafe"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rescore", func(t *testing.T) {
+		calls := rescorer.calls
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", base+"/rescore", nil)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, calls+1, rescorer.calls)
+	})
+}
+
+// Mismos permisos que main.go: aceptar para todos edita el quiz (manage), el resto es moderate
+func TestQuizReviewHandler_Permissions(t *testing.T) {
+	event := createTestEvent("review-event", "Review Event")
+	event.OwnerID = uuid.New()
+	moderator := uuid.New()
+
+	do := func(userID uuid.UUID, method, path, body string) int {
+		questionRepo := newMockQuizQuestionRepo()
+		questionRepo.Create(event.ID, "favorites", "flower", "Flor favorita?", []string{"Girasol"}, nil, 1, true, models.QuestionScoring{Points: 2})
+		beto := models.Player{ID: uuid.New(), EventID: event.ID, Name: "Beto"}
+		answerRepo := newMockQuizAnswerRepo(models.PlayerQuizAnswers{Player: beto, Favorites: map[string]string{"flower": "girasoles amarillos"}})
+		handler := NewQuizReviewHandler(questionRepo, answerRepo, &mockEventRescorer{})
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("event", event)
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		canModerate := middleware.RequireEventPermission(models.PermissionModerate)
+		canManage := middleware.RequireEventPermission(models.PermissionManage)
+		quiz := router.Group("/quiz", middleware.EventMemberMiddleware(mockRoleFinder{moderator: models.EventRoleModerator}))
+		quiz.POST("/players/:playerId/answers/:key/accept", canModerate, handler.AcceptPlayerAnswer)
+		quiz.POST("/questions/:key/accept", canManage, handler.AcceptAnswerForAll)
+		quiz.POST("/rescore", canModerate, handler.Rescore)
+
+		path = strings.Replace(path, ":playerId", beto.ID.String(), 1)
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	acceptForAll := `{"answer": "Girasoles Amarillos"}`
+	assert.Equal(t, http.StatusForbidden, do(moderator, "POST", "/quiz/questions/flower/accept", acceptForAll))
+	assert.Equal(t, http.StatusOK, do(event.OwnerID, "POST", "/quiz/questions/flower/accept", acceptForAll))
+	assert.Equal(t, http.StatusOK, do(moderator, "POST", "/quiz/players/:playerId/answers/flower/accept", ""))
+	assert.Equal(t, http.StatusOK, do(moderator, "POST", "/quiz/rescore", ""))
+}
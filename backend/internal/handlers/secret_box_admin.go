@@ -41,7 +41,7 @@ func (h *SecretBoxAdminHandler) GetSecretBoxToken(c *gin.Context) {
 	if event.SecretBoxToken != nil && *event.SecretBoxToken != "" {
 		c.JSON(http.StatusOK, gin.H{
 			"token":     *event.SecretBoxToken,
-			"share_url": buildSecretBoxURL(slug, *event.SecretBoxToken),
+			"share_url": buildSecretBoxURL(event.Slug, *event.SecretBoxToken),
 		})
 		return
 	}
@@ -58,7 +58,7 @@ func (h *SecretBoxAdminHandler) GetSecretBoxToken(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"token":     newToken,
-		"share_url": buildSecretBoxURL(slug, newToken),
+		"share_url": buildSecretBoxURL(event.Slug, newToken),
 	})
 }
 
@@ -85,7 +85,7 @@ func (h *SecretBoxAdminHandler) RegenerateSecretBoxToken(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"token":     newToken,
-		"share_url": buildSecretBoxURL(slug, newToken),
+		"share_url": buildSecretBoxURL(event.Slug, newToken),
 	})
 }
 
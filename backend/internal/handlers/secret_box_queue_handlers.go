@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// SecretRevealQueueRepo define las operaciones del reveal uno por uno
+type SecretRevealQueueRepo interface {
+	ListSecretRevealQueue(eventID uuid.UUID) ([]models.Postcard, error)
+	ReorderSecretRevealQueue(eventID uuid.UUID, postcardIDs []uuid.UUID) error
+	RevealNextSecret(eventID uuid.UUID) (*models.Postcard, error)
+	GetSecretBoxStatusByEvent(eventID uuid.UUID) (*models.SecretBoxStatus, error)
+}
+
+// SecretRevealQueueHub envía cada postal revelada a la pantalla grande
+type SecretRevealQueueHub interface {
+	BroadcastSecretRevealNextToRoom(eventSlug string, postcard models.Postcard, revealed, remaining int)
+}
+
+// SecretBoxQueueHandler maneja el reveal de la Secret Box de a una postal (slideshow
+// en el proyector), como alternativa al reveal de todas juntas.
+type SecretBoxQueueHandler struct {
+	postcards SecretRevealQueueRepo
+	hub       SecretRevealQueueHub
+}
+
+// NewSecretBoxQueueHandler crea un nuevo handler del reveal uno por uno
+func NewSecretBoxQueueHandler(postcards SecretRevealQueueRepo, hub SecretRevealQueueHub) *SecretBoxQueueHandler {
+	return &SecretBoxQueueHandler{postcards: postcards, hub: hub}
+}
+
+// ReorderRevealQueueRequest nuevo orden de la cola: estas postales primero, en este orden
+type ReorderRevealQueueRequest struct {
+	PostcardIDs []uuid.UUID `json:"postcard_ids" binding:"required"`
+}
+
+// GetRevealQueue GET /api/admin/events/:slug/secret-box/queue
+// Secretas por revelar en orden, con cuántas se revelaron y cuántas faltan.
+func (h *SecretBoxQueueHandler) GetRevealQueue(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	queue, err := h.postcards.ListSecretRevealQueue(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list reveal queue"})
+		return
+	}
+	if queue == nil {
+		queue = []models.Postcard{}
+	}
+
+	status, err := h.postcards.GetSecretBoxStatusByEvent(event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get secret box status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"queue":     queue,
+		"revealed":  status.RevealedCount,
+		"remaining": len(queue),
+	})
+}
+
+// ReorderRevealQueue PUT /api/admin/events/:slug/secret-box/queue
+// Las postales no incluidas quedan al final de la cola.
+func (h *SecretBoxQueueHandler) ReorderRevealQueue(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req ReorderRevealQueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "postcard_ids is required"})
+		return
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.PostcardIDs))
+	for _, id := range req.PostcardIDs {
+		if seen[id] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Duplicate postcard %s", id)})
+			return
+		}
+		seen[id] = true
+	}
+
+	if err := h.postcards.ReorderSecretRevealQueue(event.ID, req.PostcardIDs); err != nil {
+		if err == repository.ErrPostcardNotInRevealQueue {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Every postcard must be an unrevealed secret postcard of this event"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder reveal queue"})
+		return
+	}
+
+	h.GetRevealQueue(c)
+}
+
+// RevealNext POST /api/admin/events/:slug/secret-box/reveal-next
+// Revela la siguiente postal de la cola y la envía a las pantallas (secret_box_reveal_next).
+func (h *SecretBoxQueueHandler) RevealNext(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	postcard, err := h.postcards.RevealNextSecret(event.ID)
+	if err == repository.ErrRevealQueueEmpty {
+		c.JSON(http.StatusConflict, gin.H{"error": "No secret postcards left to reveal"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reveal postcard"})
+		return
+	}
+
+	status, err := h.postcards.GetSecretBoxStatusByEvent(event.ID)
+	if err != nil {
+		// La postal ya se reveló: informar el progreso es secundario
+		fmt.Printf("[WARN] Failed to get secret box status for %s: %v\n", event.Slug, err)
+		status = &models.SecretBoxStatus{}
+	}
+
+	if h.hub != nil && postcard.IsPublic() {
+		h.hub.BroadcastSecretRevealNextToRoom(event.Slug, *postcard, status.RevealedCount, status.Remaining)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"postcard":  postcard,
+		"revealed":  status.RevealedCount,
+		"remaining": status.Remaining,
+	})
+}
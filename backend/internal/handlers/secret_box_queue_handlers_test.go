@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// ============== MOCKS ==============
+
+// mockRevealQueueRepo keeps the unrevealed secrets in queue order
+type mockRevealQueueRepo struct {
+	queue    []*models.Postcard
+	revealed int
+}
+
+func (m *mockRevealQueueRepo) ListSecretRevealQueue(eventID uuid.UUID) ([]models.Postcard, error) {
+	var result []models.Postcard
+	for _, p := range m.queue {
+		result = append(result, *p)
+	}
+	return result, nil
+}
+
+func (m *mockRevealQueueRepo) ReorderSecretRevealQueue(eventID uuid.UUID, postcardIDs []uuid.UUID) error {
+	var ordered []*models.Postcard
+	rest := append([]*models.Postcard{}, m.queue...)
+	for _, id := range postcardIDs {
+		found := false
+		for i, p := range rest {
+			if p.ID == id {
+				ordered = append(ordered, p)
+				rest = append(rest[:i], rest[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return repository.ErrPostcardNotInRevealQueue
+		}
+	}
+	m.queue = append(ordered, rest...)
+	return nil
+}
+
+func (m *mockRevealQueueRepo) RevealNextSecret(eventID uuid.UUID) (*models.Postcard, error) {
+	if len(m.queue) == 0 {
+		return nil, repository.ErrRevealQueueEmpty
+	}
+	next := m.queue[0]
+	m.queue = m.queue[1:]
+	now := time.Now()
+	next.RevealedAt = &now
+	m.revealed++
+	return next, nil
+}
+
+func (m *mockRevealQueueRepo) GetSecretBoxStatusByEvent(eventID uuid.UUID) (*models.SecretBoxStatus, error) {
+	return &models.SecretBoxStatus{
+		Total:         m.revealed + len(m.queue),
+		Revealed:      m.revealed > 0,
+		RevealedCount: m.revealed,
+		Remaining:     len(m.queue),
+	}, nil
+}
+
+type revealNextBroadcast struct {
+	postcardID uuid.UUID
+	revealed   int
+	remaining  int
+}
+
+type mockRevealQueueHub struct {
+	broadcasts []revealNextBroadcast
+}
+
+func (h *mockRevealQueueHub) BroadcastSecretRevealNextToRoom(eventSlug string, postcard models.Postcard, revealed, remaining int) {
+	h.broadcasts = append(h.broadcasts, revealNextBroadcast{postcard.ID, revealed, remaining})
+}
+
+// ============== TESTS ==============
+
+func TestSecretBoxQueueHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	event := createTestEvent("slideshow", "Slideshow")
+	newSecret := func() *models.Postcard {
+		return &models.Postcard{ID: uuid.New(), EventID: event.ID, IsSecret: true, ModerationStatus: models.ModerationApproved, MediaStatus: models.MediaStatusReady}
+	}
+	first, second, third := newSecret(), newSecret(), newSecret()
+
+	repo := &mockRevealQueueRepo{queue: []*models.Postcard{first, second, third}}
+	hub := &mockRevealQueueHub{}
+	handler := NewSecretBoxQueueHandler(repo, hub)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Next()
+	})
+	router.GET("/queue", handler.GetRevealQueue)
+	router.PUT("/queue", handler.ReorderRevealQueue)
+	router.POST("/reveal-next", handler.RevealNext)
+
+	type queueResponse struct {
+		Queue     []models.Postcard `json:"queue"`
+		Postcard  *models.Postcard  `json:"postcard"`
+		Revealed  int               `json:"revealed"`
+		Remaining int               `json:"remaining"`
+	}
+	do := func(method, path, body string) (*httptest.ResponseRecorder, queueResponse) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		var resp queueResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return w, resp
+	}
+
+	t.Run("reorder puts the chosen postcard first", func(t *testing.T) {
+		w, resp := do("PUT", "/queue", `{"postcard_ids": ["`+third.ID.String()+`"]}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Len(t, resp.Queue, 3)
+		assert.Equal(t, []uuid.UUID{third.ID, first.ID, second.ID}, []uuid.UUID{resp.Queue[0].ID, resp.Queue[1].ID, resp.Queue[2].ID})
+		assert.Equal(t, 3, resp.Remaining)
+	})
+
+	t.Run("invalid reorders", func(t *testing.T) {
+		w, _ := do("PUT", "/queue", `{"postcard_ids": ["`+uuid.New().String()+`"]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w, _ = do("PUT", "/queue", `{"postcard_ids": ["`+first.ID.String()+`", "`+first.ID.String()+`"]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("reveal next follows the queue and broadcasts progress", func(t *testing.T) {
+		w, resp := do("POST", "/reveal-next", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NotNil(t, resp.Postcard)
+		assert.Equal(t, third.ID, resp.Postcard.ID)
+		assert.Equal(t, 1, resp.Revealed)
+		assert.Equal(t, 2, resp.Remaining)
+		assert.Equal(t, []revealNextBroadcast{{third.ID, 1, 2}}, hub.broadcasts)
+
+		_, resp = do("GET", "/queue", "")
+		assert.Equal(t, 1, resp.Revealed)
+		assert.Equal(t, 2, resp.Remaining)
+	})
+
+	t.Run("empty queue conflicts", func(t *testing.T) {
+		do("POST", "/reveal-next", "")
+		do("POST", "/reveal-next", "")
+		w, _ := do("POST", "/reveal-next", "")
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Len(t, hub.broadcasts, 3)
+	})
+}
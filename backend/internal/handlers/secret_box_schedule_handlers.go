@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SecretBoxScheduleRepo guarda el reveal programado de la Secret Box
+type SecretBoxScheduleRepo interface {
+	SetSecretBoxRevealAt(eventID uuid.UUID, revealAt *time.Time, atEventEnd bool) error
+}
+
+// SecretBoxScheduleHandler permite al owner programar el reveal de la Secret Box,
+// así no tiene que estar mirando el reloj durante su propia fiesta.
+// El SecretBoxScheduler ejecuta el reveal a la hora indicada.
+type SecretBoxScheduleHandler struct {
+	events SecretBoxScheduleRepo
+}
+
+// NewSecretBoxScheduleHandler crea un nuevo handler de reveal programado
+func NewSecretBoxScheduleHandler(events SecretBoxScheduleRepo) *SecretBoxScheduleHandler {
+	return &SecretBoxScheduleHandler{events: events}
+}
+
+// ScheduleRevealRequest cuerpo para programar el reveal: una hora o el fin del evento
+type ScheduleRevealRequest struct {
+	RevealAt   *time.Time `json:"reveal_at"`
+	AtEventEnd bool       `json:"at_event_end"`
+}
+
+// GetRevealSchedule GET /api/admin/events/:slug/secret-box/schedule
+func (h *SecretBoxScheduleHandler) GetRevealSchedule(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scheduled":    event.SecretBoxRevealAt != nil,
+		"reveal_at":    event.SecretBoxRevealAt,
+		"at_event_end": event.SecretBoxRevealAt != nil && event.SecretBoxRevealAtEventEnd,
+	})
+}
+
+// ScheduleReveal PUT /api/admin/events/:slug/secret-box/schedule
+// Programa (o reprograma) el reveal automático. Con at_event_end usa el ends_at del evento
+// y lo sigue si después cambia.
+func (h *SecretBoxScheduleHandler) ScheduleReveal(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req ScheduleRevealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if (req.RevealAt == nil) == !req.AtEventEnd {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide either reveal_at or at_event_end"})
+		return
+	}
+
+	revealAt := req.RevealAt
+	if req.AtEventEnd {
+		if event.EndsAt == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Event has no end time"})
+			return
+		}
+		revealAt = event.EndsAt
+	}
+
+	if !revealAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reveal time must be in the future"})
+		return
+	}
+
+	// La columna es TIMESTAMP (sin zona): Postgres descartaría el offset del cliente
+	utc := revealAt.UTC()
+	if err := h.events.SetSecretBoxRevealAt(event.ID, &utc, req.AtEventEnd); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to schedule reveal"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scheduled":    true,
+		"reveal_at":    utc,
+		"at_event_end": req.AtEventEnd,
+	})
+}
+
+// CancelReveal DELETE /api/admin/events/:slug/secret-box/schedule
+// Cancela el reveal programado; la Secret Box vuelve a revelarse a mano.
+func (h *SecretBoxScheduleHandler) CancelReveal(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.events.SetSecretBoxRevealAt(event.ID, nil, false); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel reveal"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scheduled":    false,
+		"reveal_at":    nil,
+		"at_event_end": false,
+	})
+}
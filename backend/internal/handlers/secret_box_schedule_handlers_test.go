@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+type mockSecretBoxScheduleRepo struct {
+	revealAt   map[uuid.UUID]*time.Time
+	atEventEnd map[uuid.UUID]bool
+}
+
+func (m *mockSecretBoxScheduleRepo) SetSecretBoxRevealAt(eventID uuid.UUID, revealAt *time.Time, atEventEnd bool) error {
+	m.revealAt[eventID] = revealAt
+	m.atEventEnd[eventID] = atEventEnd
+	return nil
+}
+
+func TestSecretBoxScheduleHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	endsAt := time.Now().Add(6 * time.Hour).UTC().Truncate(time.Second)
+	event := createTestEvent("party", "Party")
+	event.EndsAt = &endsAt
+	noEnd := createTestEvent("no-end", "No End")
+
+	repo := &mockSecretBoxScheduleRepo{revealAt: map[uuid.UUID]*time.Time{}, atEventEnd: map[uuid.UUID]bool{}}
+	handler := NewSecretBoxScheduleHandler(repo)
+
+	do := func(ev *models.Event, method, body string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("event", ev)
+			c.Next()
+		})
+		router.GET("/schedule", handler.GetRevealSchedule)
+		router.PUT("/schedule", handler.ScheduleReveal)
+		router.DELETE("/schedule", handler.CancelReveal)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, "/schedule", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("schedule at a time", func(t *testing.T) {
+		at := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		w := do(event, "PUT", `{"reveal_at": "`+at.Format(time.RFC3339)+`"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NotNil(t, repo.revealAt[event.ID])
+		assert.True(t, at.Equal(*repo.revealAt[event.ID]))
+		assert.False(t, repo.atEventEnd[event.ID])
+	})
+
+	t.Run("schedule with a non-UTC offset is stored in UTC", func(t *testing.T) {
+		// secret_box_reveal_at es TIMESTAMP: con el offset del cliente el reveal saldría 3 horas antes
+		argentina := time.FixedZone("ART", -3*60*60)
+		at := time.Now().Add(24 * time.Hour).In(argentina).Truncate(time.Second)
+		w := do(event, "PUT", `{"reveal_at": "`+at.Format(time.RFC3339)+`"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		stored := repo.revealAt[event.ID]
+		require.NotNil(t, stored)
+		assert.Equal(t, time.UTC, stored.Location())
+		assert.True(t, at.Equal(*stored))
+		assert.Equal(t, at.Add(3*time.Hour).Hour(), stored.Hour())
+	})
+
+	t.Run("reschedule at the end of the event", func(t *testing.T) {
+		w := do(event, "PUT", `{"at_event_end": true}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.True(t, endsAt.Equal(*repo.revealAt[event.ID]))
+		assert.True(t, repo.atEventEnd[event.ID], "the reveal must follow later ends_at changes")
+
+		w = do(noEnd, "PUT", `{"at_event_end": true}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid schedules", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		assert.Equal(t, http.StatusBadRequest, do(event, "PUT", `{"reveal_at": "`+past+`"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, do(event, "PUT", `{}`).Code)
+		future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		assert.Equal(t, http.StatusBadRequest, do(event, "PUT", `{"reveal_at": "`+future+`", "at_event_end": true}`).Code)
+	})
+
+	t.Run("get and cancel", func(t *testing.T) {
+		scheduled := *repo.revealAt[event.ID]
+		event.SecretBoxRevealAt = &scheduled
+		event.SecretBoxRevealAtEventEnd = true
+		w := do(event, "GET", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Scheduled  bool       `json:"scheduled"`
+			RevealAt   *time.Time `json:"reveal_at"`
+			AtEventEnd bool       `json:"at_event_end"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.True(t, resp.Scheduled)
+		assert.True(t, resp.AtEventEnd)
+
+		w = do(event, "DELETE", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, repo.revealAt[event.ID])
+		assert.False(t, repo.atEventEnd[event.ID])
+	})
+}
@@ -145,7 +145,7 @@ func TestUpdateTheme_Success(t *testing.T) {
 		Slug: "event-123",
 	}
 
-	// Middleware to set event and user in context (simulating EventMiddleware + OwnerMiddleware)
+	// Middleware to set event and user in context (simulating EventMiddleware + EventMemberMiddleware)
 	r.Use(func(c *gin.Context) {
 		c.Set("event", testEvent) // Use pointer like real middleware does
 		c.Set("user_id", testEvent.ID)
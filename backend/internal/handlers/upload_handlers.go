@@ -0,0 +1,402 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+const (
+	// MaxUploadLength tamaño máximo de una subida reanudable (el mismo límite que los videos)
+	MaxUploadLength = 50 * 1024 * 1024
+	// MaxUploadChunkSize tamaño máximo de cada chunk (PATCH)
+	MaxUploadChunkSize = 8 * 1024 * 1024
+	// UploadSessionTTL tiempo para terminar una subida; después se borran sus chunks
+	UploadSessionTTL = 24 * time.Hour
+)
+
+// UploadSessionRepo define las operaciones sobre sesiones de subida reanudable
+type UploadSessionRepo interface {
+	Create(eventID uuid.UUID, length int64, ttl time.Duration) (*models.UploadSession, error)
+	GetByID(id uuid.UUID) (*models.UploadSession, error)
+	AppendChunk(sessionID uuid.UUID, expectedOffset int64, chunk models.UploadChunk) (*models.UploadSession, error)
+	ListChunks(sessionID uuid.UUID) ([]models.UploadChunk, error)
+	Claim(id uuid.UUID) (*models.UploadSession, error)
+	Release(id uuid.UUID) error
+	Delete(id uuid.UUID) error
+}
+
+// UploadHandler implementa las subidas reanudables: los invitados suben videos
+// grandes en chunks y, si se corta el wifi, retoman desde el último offset.
+// La subida terminada se convierte en postal con upload_id en POST /postcards
+// o POST /secret-box.
+type UploadHandler struct {
+	uploads UploadSessionRepo
+	media   services.MediaStore
+}
+
+// NewUploadHandler crea un nuevo handler de subidas reanudables
+func NewUploadHandler(uploads UploadSessionRepo, media services.MediaStore) *UploadHandler {
+	return &UploadHandler{uploads: uploads, media: media}
+}
+
+// CreateUploadRequest cuerpo para abrir una sesión de subida
+type CreateUploadRequest struct {
+	Length int64 `json:"length" binding:"required,gt=0"`
+}
+
+// setUploadHeaders agrega los headers de estado de la sesión (nombres de tus)
+func setUploadHeaders(c *gin.Context, session *models.UploadSession) {
+	c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(session.Length, 10))
+	c.Header("Upload-Expires", session.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-store")
+}
+
+// CreateUpload POST /api/events/:slug/uploads
+// Abre una sesión de subida de length bytes.
+func (h *UploadHandler) CreateUpload(c *gin.Context) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req CreateUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "length is required"})
+		return
+	}
+	if req.Length > MaxUploadLength {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Upload too large (max 50MB)"})
+		return
+	}
+
+	session, err := h.uploads.Create(event.ID, req.Length, UploadSessionTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload"})
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.Header("Location", fmt.Sprintf("/api/events/%s/uploads/%s", event.Slug, session.ID))
+	c.JSON(http.StatusCreated, session)
+}
+
+// uploadFromRequest busca la sesión de :id dentro del evento. Escribe el error si no existe.
+func (h *UploadHandler) uploadFromRequest(c *gin.Context) (*models.UploadSession, bool) {
+	event, ok := eventFromContext(c)
+	if !ok {
+		return nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload ID"})
+		return nil, false
+	}
+
+	session, err := h.uploads.GetByID(id)
+	if err == repository.ErrUploadSessionNotFound || (err == nil && session.EventID != event.ID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found or expired"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get upload"})
+		return nil, false
+	}
+	return session, true
+}
+
+// GetUpload GET|HEAD /api/events/:slug/uploads/:id
+// Offset actual, para retomar una subida cortada.
+func (h *UploadHandler) GetUpload(c *gin.Context) {
+	session, ok := h.uploadFromRequest(c)
+	if !ok {
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.JSON(http.StatusOK, session)
+}
+
+// UploadChunk PATCH /api/events/:slug/uploads/:id
+// Agrega un chunk que empieza en el header Upload-Offset. El chunk se guarda
+// entero o no se guarda: si la conexión se corta, se reenvía desde el offset.
+func (h *UploadHandler) UploadChunk(c *gin.Context) {
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Upload-Offset header required"})
+		return
+	}
+
+	session, ok := h.uploadFromRequest(c)
+	if !ok {
+		return
+	}
+
+	if session.IsComplete() {
+		setUploadHeaders(c, session)
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload already complete"})
+		return
+	}
+	if offset != session.Offset {
+		setUploadHeaders(c, session)
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload-Offset does not match the current offset"})
+		return
+	}
+
+	limit := session.Length - session.Offset
+	if limit > MaxUploadChunkSize {
+		limit = MaxUploadChunkSize
+	}
+	if c.Request.ContentLength > limit {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Chunk too large"})
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, limit))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Chunk too large"})
+		return
+	}
+	if err != nil || (c.Request.ContentLength >= 0 && int64(len(data)) != c.Request.ContentLength) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incomplete chunk"})
+		return
+	}
+	if len(data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Empty chunk"})
+		return
+	}
+
+	// Clave única por intento: un PATCH concurrente con el mismo offset no pisa este chunk
+	ctx := c.Request.Context()
+	chunk := models.UploadChunk{
+		Offset: offset,
+		Size:   int64(len(data)),
+		Key:    fmt.Sprintf("uploads/%s/%012d-%s", session.ID, offset, uuid.New().String()),
+	}
+	if err := h.media.Put(ctx, chunk.Key, bytes.NewReader(data), chunk.Size, "application/octet-stream"); err != nil {
+		fmt.Printf("[ERROR] Failed to store upload chunk %s: %v\n", chunk.Key, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store chunk"})
+		return
+	}
+
+	updated, err := h.uploads.AppendChunk(session.ID, offset, chunk)
+	if err != nil {
+		h.media.Delete(ctx, chunk.Key)
+		if err == repository.ErrUploadOffsetMismatch {
+			c.JSON(http.StatusConflict, gin.H{"error": "Upload-Offset does not match the current offset"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store chunk"})
+		return
+	}
+
+	setUploadHeaders(c, updated)
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteUpload DELETE /api/events/:slug/uploads/:id
+// Cancela la subida y borra los chunks recibidos.
+func (h *UploadHandler) DeleteUpload(c *gin.Context) {
+	session, ok := h.uploadFromRequest(c)
+	if !ok {
+		return
+	}
+	// Los chunks se están armando en una postal
+	if session.ClaimedAt != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload is being used by a postcard"})
+		return
+	}
+
+	if err := deleteUploadSession(c.Request.Context(), h.uploads, h.media, session.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete upload"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// deleteUploadSession borra los chunks del MediaStore y la sesión
+func deleteUploadSession(ctx context.Context, uploads UploadSessionRepo, media services.MediaStore, id uuid.UUID) error {
+	chunks, err := uploads.ListChunks(id)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if err := media.Delete(ctx, chunk.Key); err != nil {
+			fmt.Printf("[WARN] Failed to delete upload chunk %s: %v\n", chunk.Key, err)
+		}
+	}
+	if err := uploads.Delete(id); err != nil && err != repository.ErrUploadSessionNotFound {
+		return err
+	}
+	return nil
+}
+
+// assembleUpload concatena los chunks de una subida completa en un archivo temporal.
+// El caller cierra y borra el archivo.
+func assembleUpload(ctx context.Context, media services.MediaStore, session *models.UploadSession, chunks []models.UploadChunk) (*os.File, error) {
+	var next int64
+	for _, chunk := range chunks {
+		if chunk.Offset != next {
+			return nil, fmt.Errorf("upload %s: missing bytes at offset %d", session.ID, next)
+		}
+		next += chunk.Size
+	}
+	if next != session.Length {
+		return nil, fmt.Errorf("upload %s: got %d of %d bytes", session.ID, next, session.Length)
+	}
+
+	file, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chunk := range chunks {
+		if err := copyMediaObject(ctx, media, chunk.Key, file); err != nil {
+			file.Close()
+			os.Remove(file.Name())
+			return nil, err
+		}
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return file, nil
+}
+
+// copyMediaObject escribe un objeto del MediaStore en w
+func copyMediaObject(ctx context.Context, media services.MediaStore, key string, w io.Writer) error {
+	r, err := media.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to read upload chunk %s: %w", key, err)
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(w, r); err != nil {
+		return fmt.Errorf("failed to copy upload chunk %s: %w", key, err)
+	}
+	return nil
+}
+
+// saveUploadedMedia arma una subida reanudable completa del evento y la guarda
+// como la media de una postal (mismas validaciones que un multipart)
+func (h *Handler) saveUploadedMedia(c *gin.Context, uploadID string) (*MediaResult, *struct {
+	Code    int
+	Message string
+}) {
+	if h.uploads == nil {
+		return nil, &struct {
+			Code    int
+			Message string
+		}{http.StatusServiceUnavailable, "Resumable uploads are not available"}
+	}
+
+	id, err := uuid.Parse(uploadID)
+	if err != nil {
+		return nil, &struct {
+			Code    int
+			Message string
+		}{http.StatusBadRequest, "Invalid upload_id"}
+	}
+
+	session, err := h.uploads.GetByID(id)
+	if err != nil && err != repository.ErrUploadSessionNotFound {
+		return nil, &struct {
+			Code    int
+			Message string
+		}{http.StatusInternalServerError, "Failed to get upload"}
+	}
+	eventID, hasEvent := c.Get("event_id")
+	if err == repository.ErrUploadSessionNotFound || !hasEvent || session.EventID != eventID.(uuid.UUID) {
+		return nil, &struct {
+			Code    int
+			Message string
+		}{http.StatusNotFound, "Upload not found or expired"}
+	}
+
+	if !session.IsComplete() {
+		return nil, &struct {
+			Code    int
+			Message string
+		}{http.StatusConflict, "Upload incomplete"}
+	}
+
+	// Dos requests con el mismo upload_id crearían dos postales: solo una se queda con la sesión
+	if _, err := h.uploads.Claim(session.ID); err != nil {
+		if err == repository.ErrUploadSessionClaimed {
+			return nil, &struct {
+				Code    int
+				Message string
+			}{http.StatusConflict, "Upload already used by another request"}
+		}
+		return nil, &struct {
+			Code    int
+			Message string
+		}{http.StatusInternalServerError, "Failed to get upload"}
+	}
+
+	ctx := c.Request.Context()
+	chunks, err := h.uploads.ListChunks(session.ID)
+	if err != nil {
+		h.releaseUpload(session.ID)
+		return nil, &struct {
+			Code    int
+			Message string
+		}{http.StatusInternalServerError, "Failed to read upload"}
+	}
+
+	file, err := assembleUpload(ctx, h.media, session, chunks)
+	if err != nil {
+		h.releaseUpload(session.ID)
+		fmt.Printf("[ERROR] Failed to assemble upload %s: %v\n", session.ID, err)
+		return nil, &struct {
+			Code    int
+			Message string
+		}{http.StatusInternalServerError, "Failed to read upload"}
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	result, httpErr := h.saveMedia(ctx, file, session.Length)
+	if httpErr != nil {
+		h.releaseUpload(session.ID)
+		return nil, httpErr
+	}
+	result.UploadID = &session.ID
+	return result, nil
+}
+
+// releaseUpload libera la sesión si no se pudo crear la postal, para que el invitado reintente
+func (h *Handler) releaseUpload(id uuid.UUID) {
+	if err := h.uploads.Release(id); err != nil {
+		fmt.Printf("[WARN] Failed to release upload %s: %v\n", id, err)
+	}
+}
+
+// finishUpload borra la sesión de upload una vez creada la postal
+func (h *Handler) finishUpload(ctx context.Context, result *MediaResult) {
+	if result.UploadID == nil {
+		return
+	}
+	if err := deleteUploadSession(ctx, h.uploads, h.media, *result.UploadID); err != nil {
+		fmt.Printf("[WARN] Failed to delete upload %s: %v\n", *result.UploadID, err)
+	}
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// ============== MOCKS ==============
+
+type mockUploadRepo struct {
+	sessions map[uuid.UUID]*models.UploadSession
+	chunks   map[uuid.UUID][]models.UploadChunk
+}
+
+func newMockUploadRepo() *mockUploadRepo {
+	return &mockUploadRepo{
+		sessions: make(map[uuid.UUID]*models.UploadSession),
+		chunks:   make(map[uuid.UUID][]models.UploadChunk),
+	}
+}
+
+func (m *mockUploadRepo) Create(eventID uuid.UUID, length int64, ttl time.Duration) (*models.UploadSession, error) {
+	session := &models.UploadSession{ID: uuid.New(), EventID: eventID, Length: length, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(ttl)}
+	m.sessions[session.ID] = session
+	copy := *session
+	return &copy, nil
+}
+
+func (m *mockUploadRepo) GetByID(id uuid.UUID) (*models.UploadSession, error) {
+	session, ok := m.sessions[id]
+	if !ok || session.ExpiresAt.Before(time.Now()) {
+		return nil, repository.ErrUploadSessionNotFound
+	}
+	copy := *session
+	return &copy, nil
+}
+
+func (m *mockUploadRepo) AppendChunk(sessionID uuid.UUID, expectedOffset int64, chunk models.UploadChunk) (*models.UploadSession, error) {
+	session := m.sessions[sessionID]
+	if session.Offset != expectedOffset || session.Offset+chunk.Size > session.Length {
+		return nil, repository.ErrUploadOffsetMismatch
+	}
+	session.Offset += chunk.Size
+	m.chunks[sessionID] = append(m.chunks[sessionID], chunk)
+	copy := *session
+	return &copy, nil
+}
+
+func (m *mockUploadRepo) ListChunks(sessionID uuid.UUID) ([]models.UploadChunk, error) {
+	return m.chunks[sessionID], nil
+}
+
+func (m *mockUploadRepo) Claim(id uuid.UUID) (*models.UploadSession, error) {
+	session, ok := m.sessions[id]
+	if !ok || session.ClaimedAt != nil || !session.IsComplete() {
+		return nil, repository.ErrUploadSessionClaimed
+	}
+	now := time.Now()
+	session.ClaimedAt = &now
+	copy := *session
+	return &copy, nil
+}
+
+func (m *mockUploadRepo) Release(id uuid.UUID) error {
+	if session, ok := m.sessions[id]; ok {
+		session.ClaimedAt = nil
+	}
+	return nil
+}
+
+func (m *mockUploadRepo) Delete(id uuid.UUID) error {
+	if _, ok := m.sessions[id]; !ok {
+		return repository.ErrUploadSessionNotFound
+	}
+	delete(m.sessions, id)
+	delete(m.chunks, id)
+	return nil
+}
+
+// ============== TESTS ==============
+
+func TestResumableUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Cabecera MP4 (caja ftyp) suficiente para la detección por magic bytes
+	videoData := append([]byte{0x00, 0x00, 0x00, 0x18}, []byte("ftypisom\x00\x00\x02\x00isomiso2")...)
+	videoData = append(videoData, bytes.Repeat([]byte{0x42}, 2048)...)
+
+	token := "test-token"
+	event := &models.Event{ID: uuid.New(), Slug: "boda", IsActive: true, SecretBoxToken: &token}
+	uploadsDir := t.TempDir()
+	media := services.NewLocalMediaStore(uploadsDir)
+	uploads := newMockUploadRepo()
+
+	state := &mockState{}
+	postcard := &models.Postcard{ID: uuid.New(), IsSecret: true, MediaType: "video", MediaStatus: models.MediaStatusProcessing}
+	postcards := &mockPostcardRepo{state: state, createdPostcard: postcard}
+	jobs := &mockMediaJobs{}
+	h := &Handler{postcardRepo: postcards, hub: &mockHub{state: state}, media: media}
+	h.SetMediaJobs(jobs)
+	h.SetUploads(uploads)
+	uploadHandler := NewUploadHandler(uploads, media)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("event", event)
+		c.Set("event_id", event.ID)
+		c.Set("event_slug", event.Slug)
+		c.Next()
+	})
+	router.POST("/api/events/:slug/uploads", uploadHandler.CreateUpload)
+	router.GET("/api/events/:slug/uploads/:id", uploadHandler.GetUpload)
+	router.HEAD("/api/events/:slug/uploads/:id", uploadHandler.GetUpload)
+	router.PATCH("/api/events/:slug/uploads/:id", uploadHandler.UploadChunk)
+	router.DELETE("/api/events/:slug/uploads/:id", uploadHandler.DeleteUpload)
+	router.POST("/api/events/:slug/secret-box", h.CreateSecretPostcard)
+
+	do := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	patch := func(path string, offset int, chunk []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PATCH", path, bytes.NewReader(chunk))
+		req.Header.Set("Content-Type", "application/offset+octet-stream")
+		req.Header.Set("Upload-Offset", strconv.Itoa(offset))
+		return do(req)
+	}
+	finish := func(uploadID string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		mw.WriteField("sender_name", "Tío Juan")
+		mw.WriteField("upload_id", uploadID)
+		mw.Close()
+		req, _ := http.NewRequest("POST", "/api/events/boda/secret-box", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.Header.Set("X-Secret-Token", token)
+		return do(req)
+	}
+
+	// Abrir la sesión
+	req, _ := http.NewRequest("POST", "/api/events/boda/uploads", bytes.NewReader([]byte(`{"length": `+strconv.Itoa(len(videoData))+`}`)))
+	w := do(req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var session models.UploadSession
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+	path := "/api/events/boda/uploads/" + session.ID.String()
+	assert.Equal(t, path, w.Header().Get("Location"))
+	assert.Equal(t, "0", w.Header().Get("Upload-Offset"))
+
+	half := len(videoData) / 2
+
+	t.Run("first chunk advances the offset", func(t *testing.T) {
+		w := patch(path, 0, videoData[:half])
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		assert.Equal(t, strconv.Itoa(half), w.Header().Get("Upload-Offset"))
+	})
+
+	t.Run("finishing an incomplete upload is rejected", func(t *testing.T) {
+		w := finish(session.ID.String())
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Empty(t, jobs.postcardIDs)
+	})
+
+	t.Run("resending from a stale offset conflicts", func(t *testing.T) {
+		w := patch(path, 0, videoData[:half])
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, strconv.Itoa(half), w.Header().Get("Upload-Offset"))
+	})
+
+	t.Run("HEAD reports the offset to resume from", func(t *testing.T) {
+		req, _ := http.NewRequest("HEAD", path, nil)
+		w := do(req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, strconv.Itoa(half), w.Header().Get("Upload-Offset"))
+		assert.Equal(t, strconv.Itoa(len(videoData)), w.Header().Get("Upload-Length"))
+	})
+
+	t.Run("chunks past the declared length are rejected", func(t *testing.T) {
+		w := patch(path, half, append(append([]byte{}, videoData[half:]...), 0x00))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("an upload being finished by another request conflicts", func(t *testing.T) {
+		w := patch(path, half, videoData[half:])
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		// Otro POST con el mismo upload_id se quedó con la sesión
+		_, err := uploads.Claim(session.ID)
+		require.NoError(t, err)
+		w = finish(session.ID.String())
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Empty(t, jobs.postcardIDs)
+
+		req, _ := http.NewRequest("DELETE", path, nil)
+		assert.Equal(t, http.StatusConflict, do(req).Code)
+		assert.Contains(t, uploads.sessions, session.ID)
+		require.NoError(t, uploads.Release(session.ID))
+	})
+
+	t.Run("a failed finish releases the upload for a retry", func(t *testing.T) {
+		jobs.err = errors.New("queue down")
+		defer func() { jobs.err, jobs.postcardIDs, jobs.sourceKeys = nil, nil, nil }()
+
+		w := finish(session.ID.String())
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, uploads.sessions, session.ID)
+		assert.Nil(t, uploads.sessions[session.ID].ClaimedAt)
+	})
+
+	t.Run("finish creates the postcard and removes the session", func(t *testing.T) {
+		w := finish(session.ID.String())
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.Len(t, jobs.sourceKeys, 1)
+
+		stored, err := os.ReadFile(filepath.Join(uploadsDir, filepath.FromSlash(jobs.sourceKeys[0])))
+		require.NoError(t, err)
+		assert.Equal(t, videoData, stored)
+
+		assert.NotContains(t, uploads.sessions, session.ID)
+		chunks, _ := os.ReadDir(filepath.Join(uploadsDir, "uploads", session.ID.String()))
+		assert.Empty(t, chunks)
+
+		w = finish(session.ID.String())
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("sessions of other events are not found", func(t *testing.T) {
+		other, _ := uploads.Create(uuid.New(), 10, time.Hour)
+		w := patch("/api/events/boda/uploads/"+other.ID.String(), 0, []byte("0123456789"))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = finish(other.ID.String())
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delete cancels the upload", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/api/events/boda/uploads", bytes.NewReader([]byte(`{"length": 100}`)))
+		w := do(req)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var cancelled models.UploadSession
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cancelled))
+		cancelledPath := "/api/events/boda/uploads/" + cancelled.ID.String()
+		require.Equal(t, http.StatusNoContent, patch(cancelledPath, 0, make([]byte, 40)).Code)
+
+		req, _ = http.NewRequest("DELETE", cancelledPath, nil)
+		assert.Equal(t, http.StatusNoContent, do(req).Code)
+		assert.NotContains(t, uploads.sessions, cancelled.ID)
+		chunks, _ := os.ReadDir(filepath.Join(uploadsDir, "uploads", cancelled.ID.String()))
+		assert.Empty(t, chunks)
+	})
+
+	t.Run("uploads over the limit are refused", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/api/events/boda/uploads", bytes.NewReader([]byte(`{"length": `+strconv.Itoa(MaxUploadLength+1)+`}`)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, do(req).Code)
+	})
+}
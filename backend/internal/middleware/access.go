@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// EventAccessHeader header con el token de acceso a un evento protegido con código
+const EventAccessHeader = "X-Event-Access"
+
+// EventAccessMiddleware exige el token de acceso en los eventos protegidos con código.
+// Va después de EventMiddleware; los eventos sin código pasan sin token.
+// La respuesta 401 lleva access_required para que el cliente pida el código.
+func EventAccessMiddleware(accessTokens *services.EventAccessTokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		event, exists := c.Get("event")
+		if !exists {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Event not in context"})
+			c.Abort()
+			return
+		}
+
+		err := accessTokens.Authorize(c.GetHeader(EventAccessHeader), event.(*models.Event))
+		if err == services.ErrEventAccessRequired {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Event access code required", "access_required": true})
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired event access token", "access_required": true})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// setupEventRouter arma un router con el evento ya resuelto (como EventMiddleware)
+// seguido de los middlewares a probar; la ruta responde 200 si todos dejan pasar.
+func setupEventRouter(event *models.Event, middlewares ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		setEvent(c, event)
+		c.Next()
+	})
+	router.Use(middlewares...)
+	router.Any("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return router
+}
+
+func protectedEvent(version int) *models.Event {
+	hash := "hash"
+	return &models.Event{ID: uuid.New(), Slug: "mile-30", Status: models.EventStatusLive,
+		AccessCodeHash: &hash, AccessProtected: true, AccessVersion: version}
+}
+
+func TestEventAccessMiddleware(t *testing.T) {
+	tokens := services.NewEventAccessTokenService("secret")
+
+	do := func(event *models.Event, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		if token != "" {
+			req.Header.Set(EventAccessHeader, token)
+		}
+		setupEventRouter(event, EventAccessMiddleware(tokens)).ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("event without code needs no token", func(t *testing.T) {
+		w := do(&models.Event{ID: uuid.New(), Status: models.EventStatusLive}, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		event := protectedEvent(1)
+		token, _, err := tokens.Issue(event)
+		require.NoError(t, err)
+
+		w := do(event, token)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		w := do(protectedEvent(1), "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), `"access_required":true`)
+		assert.Contains(t, w.Body.String(), "Event access code required")
+	})
+
+	t.Run("token of another event", func(t *testing.T) {
+		token, _, err := tokens.Issue(protectedEvent(1))
+		require.NoError(t, err)
+
+		w := do(protectedEvent(1), token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), `"access_required":true`)
+	})
+
+	t.Run("token of a previous access version", func(t *testing.T) {
+		event := protectedEvent(1)
+		token, _, err := tokens.Issue(event)
+		require.NoError(t, err)
+
+		// Rotar el código o revocar sesiones incrementa la versión
+		event.AccessVersion = 2
+		w := do(event, token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid or expired event access token")
+	})
+
+	t.Run("garbage token", func(t *testing.T) {
+		w := do(protectedEvent(1), "nope")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
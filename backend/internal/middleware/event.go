@@ -4,49 +4,170 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/the-mile-game/backend/internal/models"
 	"github.com/the-mile-game/backend/internal/repository"
 )
 
-// EventMiddleware crea un middleware que resuelve el evento por slug
-func EventMiddleware(eventRepo *repository.EventRepository) gin.HandlerFunc {
+// EventLookup busca eventos por slug o ID (EventRepository)
+type EventLookup interface {
+	GetBySlug(slug string) (*models.Event, error)
+	GetByID(id uuid.UUID) (*models.Event, error)
+}
+
+// PlayerLookup busca jugadores por ID (PlayerRepository)
+type PlayerLookup interface {
+	GetByID(id uuid.UUID) (*models.Player, error)
+}
+
+// EventMiddleware crea un middleware que resuelve el evento por slug para las rutas
+// de invitados: los eventos en draft no existen para ellos y los archivados ya no están.
+func EventMiddleware(eventRepo EventLookup) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		slug := c.Param("slug")
-		if slug == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Event slug is required"})
+		event, ok := loadEvent(c, eventRepo)
+		if !ok {
+			return
+		}
+
+		// Verificar que el evento sea visible para los invitados
+		switch event.Status {
+		case models.EventStatusDraft:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			c.Abort()
+			return
+		case models.EventStatusArchived:
+			c.JSON(http.StatusGone, gin.H{"error": "Event has been archived"})
 			c.Abort()
 			return
 		}
 
-		// Buscar evento por slug
-		event, err := eventRepo.GetBySlug(slug)
-		if err != nil {
-			if err == repository.ErrEventNotFound {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
-				c.Abort()
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event"})
+		setEvent(c, event)
+		c.Next()
+	}
+}
+
+// AdminEventMiddleware resuelve el evento por slug sin importar su estado:
+// los miembros gestionan el evento en draft, terminado y archivado.
+// Va seguido de AuthMiddleware, EventMemberMiddleware y RequireEventPermission.
+func AdminEventMiddleware(eventRepo EventLookup) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		event, ok := loadEvent(c, eventRepo)
+		if !ok {
+			return
+		}
+
+		setEvent(c, event)
+		c.Next()
+	}
+}
+
+// EventStatusMiddleware deja pasar solo si el evento está en alguno de los estados dados
+// (ej. quiz y corkboard solo con el evento live, corkboard de solo lectura al terminar)
+func EventStatusMiddleware(allowed ...models.EventStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		event, exists := c.Get("event")
+		if !exists {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Event not in context"})
 			c.Abort()
 			return
 		}
 
-		// Verificar que el evento esté activo
-		if !event.IsActive {
-			c.JSON(http.StatusGone, gin.H{"error": "Event has ended"})
+		status := event.(*models.Event).Status
+		if !statusAllowed(status, allowed) {
+			abortEventStatus(c, status)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// PlayerEventStatusMiddleware aplica el ciclo de vida en las rutas legacy (sin slug),
+// donde el evento es el del jugador de la sesión. Va después de PlayerSessionMiddleware.
+// Sin jugador o con un jugador legacy sin evento no hay ciclo de vida que aplicar.
+func PlayerEventStatusMiddleware(players PlayerLookup, events EventLookup, allowed ...models.EventStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		playerID, exists := c.Get("player_id")
+		if !exists {
+			c.Next()
+			return
+		}
+
+		// Si el jugador no existe lo resuelve el handler
+		player, err := players.GetByID(playerID.(uuid.UUID))
+		if err != nil || player.EventID == uuid.Nil {
+			c.Next()
+			return
+		}
+
+		event, err := events.GetByID(player.EventID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event"})
 			c.Abort()
 			return
 		}
 
-		// Agregar evento al contexto
-		c.Set("event", event)
-		c.Set("event_id", event.ID)
-		c.Set("event_slug", event.Slug)
+		if !statusAllowed(event.Status, allowed) {
+			abortEventStatus(c, event.Status)
+			return
+		}
 
 		c.Next()
 	}
 }
 
+// statusAllowed indica si el estado está entre los permitidos
+func statusAllowed(status models.EventStatus, allowed []models.EventStatus) bool {
+	for _, s := range allowed {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// abortEventStatus responde 410 si el evento ya terminó y 403 si todavía no empezó
+func abortEventStatus(c *gin.Context, status models.EventStatus) {
+	if status == models.EventStatusEnded || status == models.EventStatusArchived {
+		c.JSON(http.StatusGone, gin.H{"error": "Event has ended", "status": status})
+	} else {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Event has not started yet", "status": status})
+	}
+	c.Abort()
+}
+
+// loadEvent busca el evento del slug de la ruta; si falla ya respondió y abortó
+func loadEvent(c *gin.Context, eventRepo EventLookup) (*models.Event, bool) {
+	slug := c.Param("slug")
+	if slug == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Event slug is required"})
+		c.Abort()
+		return nil, false
+	}
+
+	// Buscar evento por slug
+	event, err := eventRepo.GetBySlug(slug)
+	if err != nil {
+		if err == repository.ErrEventNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			c.Abort()
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event"})
+		c.Abort()
+		return nil, false
+	}
+
+	return event, true
+}
+
+// setEvent agrega el evento al contexto
+func setEvent(c *gin.Context, event *models.Event) {
+	c.Set("event", event)
+	c.Set("event_id", event.ID)
+	c.Set("event_slug", event.Slug)
+}
+
 // QuizFeatureMiddleware verifica que el quiz esté habilitado para el evento
 func QuizFeatureMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// fakeEventLookup resuelve eventos en memoria
+type fakeEventLookup struct {
+	events []*models.Event
+}
+
+func (f *fakeEventLookup) GetBySlug(slug string) (*models.Event, error) {
+	for _, e := range f.events {
+		if e.Slug == slug {
+			return e, nil
+		}
+	}
+	return nil, repository.ErrEventNotFound
+}
+
+func (f *fakeEventLookup) GetByID(id uuid.UUID) (*models.Event, error) {
+	for _, e := range f.events {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, repository.ErrEventNotFound
+}
+
+// fakePlayerLookup resuelve jugadores en memoria
+type fakePlayerLookup struct {
+	players map[uuid.UUID]*models.Player
+}
+
+func (f *fakePlayerLookup) GetByID(id uuid.UUID) (*models.Player, error) {
+	if p, ok := f.players[id]; ok {
+		return p, nil
+	}
+	return nil, errors.New("player not found")
+}
+
+func TestEventMiddleware(t *testing.T) {
+	repo := &fakeEventLookup{events: []*models.Event{
+		{ID: uuid.New(), Slug: "draft", Status: models.EventStatusDraft},
+		{ID: uuid.New(), Slug: "scheduled", Status: models.EventStatusScheduled},
+		{ID: uuid.New(), Slug: "live", Status: models.EventStatusLive},
+		{ID: uuid.New(), Slug: "ended", Status: models.EventStatusEnded},
+		{ID: uuid.New(), Slug: "archived", Status: models.EventStatusArchived},
+	}}
+
+	do := func(middleware gin.HandlerFunc, slug string) *httptest.ResponseRecorder {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET("/events/:slug", middleware, func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"slug": c.GetString("event_slug")})
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/events/"+slug, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		slug  string
+		guest int
+		admin int
+	}{
+		{"draft", http.StatusNotFound, http.StatusOK},
+		{"scheduled", http.StatusOK, http.StatusOK},
+		{"live", http.StatusOK, http.StatusOK},
+		{"ended", http.StatusOK, http.StatusOK},
+		{"archived", http.StatusGone, http.StatusOK},
+		{"missing", http.StatusNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			assert.Equal(t, tt.guest, do(EventMiddleware(repo), tt.slug).Code)
+			assert.Equal(t, tt.admin, do(AdminEventMiddleware(repo), tt.slug).Code)
+		})
+	}
+}
+
+func TestEventStatusMiddleware(t *testing.T) {
+	// Mismas instancias que main.go
+	eventOpen := EventStatusMiddleware(models.EventStatusScheduled, models.EventStatusLive)
+	eventLive := EventStatusMiddleware(models.EventStatusLive)
+
+	do := func(status models.EventStatus, middleware gin.HandlerFunc) *httptest.ResponseRecorder {
+		event := &models.Event{ID: uuid.New(), Slug: "mile-30", Status: status}
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/test", nil)
+		setupEventRouter(event, middleware).ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("live passes live-only routes", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(models.EventStatusLive, eventLive).Code)
+	})
+
+	t.Run("scheduled on live-only routes", func(t *testing.T) {
+		w := do(models.EventStatusScheduled, eventLive)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "Event has not started yet")
+		assert.Contains(t, w.Body.String(), `"status":"scheduled"`)
+	})
+
+	t.Run("scheduled passes open routes", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(models.EventStatusScheduled, eventOpen).Code)
+	})
+
+	t.Run("ended on writes", func(t *testing.T) {
+		for _, middleware := range []gin.HandlerFunc{eventOpen, eventLive} {
+			w := do(models.EventStatusEnded, middleware)
+			assert.Equal(t, http.StatusGone, w.Code)
+			assert.Contains(t, w.Body.String(), "Event has ended")
+			assert.Contains(t, w.Body.String(), `"status":"ended"`)
+		}
+	})
+
+	t.Run("archived on writes", func(t *testing.T) {
+		assert.Equal(t, http.StatusGone, do(models.EventStatusArchived, eventLive).Code)
+	})
+
+	t.Run("without event in context", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/test", eventLive, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/test", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestPlayerEventStatusMiddleware(t *testing.T) {
+	live := &models.Event{ID: uuid.New(), Slug: "live", Status: models.EventStatusLive}
+	ended := &models.Event{ID: uuid.New(), Slug: "ended", Status: models.EventStatusEnded}
+	scheduled := &models.Event{ID: uuid.New(), Slug: "scheduled", Status: models.EventStatusScheduled}
+	events := &fakeEventLookup{events: []*models.Event{live, ended, scheduled}}
+
+	livePlayer := &models.Player{ID: uuid.New(), EventID: live.ID}
+	endedPlayer := &models.Player{ID: uuid.New(), EventID: ended.ID}
+	scheduledPlayer := &models.Player{ID: uuid.New(), EventID: scheduled.ID}
+	legacyPlayer := &models.Player{ID: uuid.New()}
+	players := &fakePlayerLookup{players: map[uuid.UUID]*models.Player{
+		livePlayer.ID:      livePlayer,
+		endedPlayer.ID:     endedPlayer,
+		scheduledPlayer.ID: scheduledPlayer,
+		legacyPlayer.ID:    legacyPlayer,
+	}}
+
+	// do simula PlayerSessionMiddleware dejando el player_id en el contexto
+	do := func(playerID uuid.UUID) *httptest.ResponseRecorder {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/postcards", func(c *gin.Context) {
+			if playerID != uuid.Nil {
+				c.Set("player_id", playerID)
+			}
+			c.Next()
+		}, PlayerEventStatusMiddleware(players, events, models.EventStatusLive), func(c *gin.Context) {
+			c.Status(http.StatusCreated)
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/postcards", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("player of a live event", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, do(livePlayer.ID).Code)
+	})
+
+	t.Run("player of an ended event", func(t *testing.T) {
+		w := do(endedPlayer.ID)
+		assert.Equal(t, http.StatusGone, w.Code)
+		assert.Contains(t, w.Body.String(), "Event has ended")
+	})
+
+	t.Run("player of a scheduled event", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, do(scheduledPlayer.ID).Code)
+	})
+
+	t.Run("legacy player without event", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, do(legacyPlayer.ID).Code)
+	})
+
+	t.Run("unknown player is left to the handler", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, do(uuid.New()).Code)
+	})
+
+	t.Run("no player", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, do(uuid.Nil).Code)
+	})
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// EventMemberRoles resuelve el rol de un usuario en un evento (EventMemberRepository)
+type EventMemberRoles interface {
+	GetRole(eventID, userID uuid.UUID) (models.EventRole, error)
+}
+
+// EventMemberMiddleware verifica que el usuario autenticado sea miembro del evento
+// y deja su rol en el contexto ("event_role"). Cada ruta exige después su permiso
+// con RequireEventPermission.
+func EventMemberMiddleware(members EventMemberRoles) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Obtener user_id del contexto (seteado por AuthMiddleware)
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			c.Abort()
+			return
+		}
+
+		// Obtener evento del contexto (seteado por AdminEventMiddleware)
+		event, exists := c.Get("event")
+		if !exists {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Event not in context"})
+			c.Abort()
+			return
+		}
+
+		eventModel := event.(*models.Event)
+		currentUserID := userID.(uuid.UUID)
+
+		// El owner del evento no necesita consultar la membresía
+		role := models.EventRoleOwner
+		if currentUserID != eventModel.OwnerID {
+			var err error
+			role, err = members.GetRole(eventModel.ID, currentUserID)
+			if err == repository.ErrNotEventMember {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized. You are not a member of this event"})
+				c.Abort()
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check event membership"})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set("event_role", role)
+		c.Next()
+	}
+}
+
+// RequireEventPermission verifica que el rol del usuario en el evento tenga el permiso
+// (ej. un moderator modera postales pero no cambia settings ni borra el evento)
+func RequireEventPermission(permission models.EventPermission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, exists := c.Get("event_role")
+		if !exists {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Event role not in context"})
+			c.Abort()
+			return
+		}
+
+		if !role.(models.EventRole).Can(permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Your role in this event does not allow this action", "role": role})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
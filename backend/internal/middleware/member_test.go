@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// fakeEventMemberRoles roles por usuario; cuenta las consultas para ver el atajo del owner
+type fakeEventMemberRoles struct {
+	roles   map[uuid.UUID]models.EventRole
+	err     error
+	lookups int
+}
+
+func (f *fakeEventMemberRoles) GetRole(eventID, userID uuid.UUID) (models.EventRole, error) {
+	f.lookups++
+	if f.err != nil {
+		return "", f.err
+	}
+	role, ok := f.roles[userID]
+	if !ok {
+		return "", repository.ErrNotEventMember
+	}
+	return role, nil
+}
+
+func TestEventMemberMiddleware(t *testing.T) {
+	ownerID, moderatorID, viewerID := uuid.New(), uuid.New(), uuid.New()
+	event := &models.Event{ID: uuid.New(), Slug: "mile-30", OwnerID: ownerID}
+	members := &fakeEventMemberRoles{roles: map[uuid.UUID]models.EventRole{
+		moderatorID: models.EventRoleModerator,
+		viewerID:    models.EventRoleViewer,
+	}}
+
+	// do corre AuthMiddleware simulado + los middlewares reales con el permiso pedido
+	do := func(userID *uuid.UUID, permission models.EventPermission) *httptest.ResponseRecorder {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			setEvent(c, event)
+			if userID != nil {
+				c.Set("user_id", *userID)
+			}
+			c.Next()
+		})
+		router.GET("/test", EventMemberMiddleware(members), RequireEventPermission(permission), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"role": c.MustGet("event_role")})
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("owner is allowed everything without a membership lookup", func(t *testing.T) {
+		lookups := members.lookups
+		for _, p := range []models.EventPermission{models.PermissionView, models.PermissionManage, models.PermissionDelete, models.PermissionManageMembers} {
+			w := do(&ownerID, p)
+			assert.Equal(t, http.StatusOK, w.Code, "owner should be able to %s", p)
+			assert.Contains(t, w.Body.String(), `"role":"owner"`)
+		}
+		assert.Equal(t, lookups, members.lookups)
+	})
+
+	t.Run("non member is forbidden", func(t *testing.T) {
+		outsider := uuid.New()
+		w := do(&outsider, models.PermissionView)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "not a member")
+	})
+
+	t.Run("moderator moderates but cannot manage or delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(&moderatorID, models.PermissionModerate).Code)
+		for _, p := range []models.EventPermission{models.PermissionManage, models.PermissionDelete} {
+			w := do(&moderatorID, p)
+			assert.Equal(t, http.StatusForbidden, w.Code, "moderator should not be able to %s", p)
+			assert.Contains(t, w.Body.String(), `"role":"moderator"`)
+		}
+	})
+
+	t.Run("viewer only views", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(&viewerID, models.PermissionView).Code)
+		assert.Equal(t, http.StatusForbidden, do(&viewerID, models.PermissionModerate).Code)
+	})
+
+	t.Run("missing authentication", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, do(nil, models.PermissionView).Code)
+	})
+
+	t.Run("membership lookup failure", func(t *testing.T) {
+		failing := &fakeEventMemberRoles{err: errors.New("db down")}
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			setEvent(c, event)
+			c.Set("user_id", moderatorID)
+			c.Next()
+		})
+		router.GET("/test", EventMemberMiddleware(failing), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestRequireEventPermission_WithoutRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/test", RequireEventPermission(models.PermissionView), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
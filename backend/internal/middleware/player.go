@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// PlayerTokenHeader header con el token de sesión firmado del jugador
+const PlayerTokenHeader = "X-Player-Token"
+
+// LegacyPlayerIDHeader header con el UUID crudo del jugador (clientes viejos)
+const LegacyPlayerIDHeader = "X-Player-ID"
+
+// PlayerSessionMiddleware resuelve la identidad del jugador en endpoints de escritura de invitados.
+//
+//   - Con X-Player-Token: valida firma y que el token pertenezca al evento del contexto.
+//   - Sin token pero con X-Player-ID: solo se acepta si allowLegacy es true (modo
+//     compatibilidad para clientes viejos); la respuesta lleva el header Deprecation.
+//   - Sin ninguno: continúa sin jugador; cada handler decide si es obligatorio.
+//
+// Setea "player_id" (uuid.UUID) en el contexto cuando hay jugador.
+func PlayerSessionMiddleware(playerTokens *services.PlayerTokenService, allowLegacy bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.GetHeader(PlayerTokenHeader); token != "" {
+			claims, err := playerTokens.Validate(token)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired player token"})
+				c.Abort()
+				return
+			}
+
+			// El token solo vale para el evento con el que fue emitido
+			if eventID, exists := c.Get("event_id"); exists && claims.EventID != eventID.(uuid.UUID) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Player token does not belong to this event"})
+				c.Abort()
+				return
+			}
+
+			c.Set("player_id", claims.PlayerID)
+			c.Next()
+			return
+		}
+
+		if playerIDStr := c.GetHeader(LegacyPlayerIDHeader); playerIDStr != "" {
+			if !allowLegacy {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Player token required"})
+				c.Abort()
+				return
+			}
+
+			playerID, err := uuid.Parse(playerIDStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+				c.Abort()
+				return
+			}
+
+			log.Printf("[WARN] Legacy %s header used on %s %s", LegacyPlayerIDHeader, c.Request.Method, c.FullPath())
+			c.Header("Deprecation", "true")
+			c.Set("player_id", playerID)
+		}
+
+		c.Next()
+	}
+}
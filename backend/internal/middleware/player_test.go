@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// expiredPlayerToken firma un token de jugador ya vencido con la misma clave derivada
+// que PlayerTokenService (el servicio no permite emitirlos desde afuera del paquete)
+func expiredPlayerToken(t *testing.T, secret string, playerID, eventID uuid.UUID) string {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("player-session-token"))
+
+	past := time.Now().Add(-time.Hour)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"player_id": playerID,
+		"event_id":  eventID,
+		"aud":       []string{"player"},
+		"sub":       playerID.String(),
+		"exp":       past.Unix(),
+		"iat":       past.Add(-time.Hour).Unix(),
+	}).SignedString(mac.Sum(nil))
+	require.NoError(t, err)
+	return token
+}
+
+func TestPlayerSessionMiddleware(t *testing.T) {
+	tokens := services.NewPlayerTokenService("secret")
+	event := &models.Event{ID: uuid.New(), Slug: "mile-30", Status: models.EventStatusLive}
+	playerID := uuid.New()
+
+	// do devuelve la respuesta y el player_id que vio el handler (uuid.Nil si no hubo)
+	do := func(allowLegacy bool, headers map[string]string) (*httptest.ResponseRecorder, uuid.UUID) {
+		var seen uuid.UUID
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			setEvent(c, event)
+			c.Next()
+		})
+		router.POST("/test", PlayerSessionMiddleware(tokens, allowLegacy), func(c *gin.Context) {
+			if id, ok := c.Get("player_id"); ok {
+				seen = id.(uuid.UUID)
+			}
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/test", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		router.ServeHTTP(w, req)
+		return w, seen
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		token, err := tokens.Issue(playerID, event.ID)
+		require.NoError(t, err)
+
+		w, seen := do(false, map[string]string{PlayerTokenHeader: token})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, playerID, seen)
+		assert.Empty(t, w.Header().Get("Deprecation"))
+	})
+
+	t.Run("token wins over the legacy header", func(t *testing.T) {
+		token, err := tokens.Issue(playerID, event.ID)
+		require.NoError(t, err)
+
+		w, seen := do(true, map[string]string{PlayerTokenHeader: token, LegacyPlayerIDHeader: uuid.New().String()})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, playerID, seen)
+	})
+
+	t.Run("tampered token", func(t *testing.T) {
+		token, err := tokens.Issue(playerID, event.ID)
+		require.NoError(t, err)
+		tampered := token[:len(token)-2] + "xx"
+
+		w, seen := do(true, map[string]string{PlayerTokenHeader: tampered})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, uuid.Nil, seen)
+	})
+
+	t.Run("token signed with another secret", func(t *testing.T) {
+		token, err := services.NewPlayerTokenService("other-secret").Issue(playerID, event.ID)
+		require.NoError(t, err)
+
+		w, _ := do(true, map[string]string{PlayerTokenHeader: token})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		w, _ := do(true, map[string]string{PlayerTokenHeader: expiredPlayerToken(t, "secret", playerID, event.ID)})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("token of another event", func(t *testing.T) {
+		token, err := tokens.Issue(playerID, uuid.New())
+		require.NoError(t, err)
+
+		w, seen := do(true, map[string]string{PlayerTokenHeader: token})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, uuid.Nil, seen)
+	})
+
+	t.Run("legacy header allowed in compat mode", func(t *testing.T) {
+		w, seen := do(true, map[string]string{LegacyPlayerIDHeader: playerID.String()})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, playerID, seen)
+		assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	})
+
+	t.Run("legacy header rejected in strict mode", func(t *testing.T) {
+		w, seen := do(false, map[string]string{LegacyPlayerIDHeader: playerID.String()})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, uuid.Nil, seen)
+		assert.Empty(t, w.Header().Get("Deprecation"))
+	})
+
+	t.Run("invalid legacy player ID", func(t *testing.T) {
+		w, _ := do(true, map[string]string{LegacyPlayerIDHeader: "not-a-uuid"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("no identity continues without player", func(t *testing.T) {
+		w, seen := do(false, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, uuid.Nil, seen)
+	})
+}
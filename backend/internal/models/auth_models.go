@@ -32,6 +32,13 @@ type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+// LogoutRequest body opcional para logout.
+// Si se envía el refresh token solo se revoca su familia (esta sesión);
+// si no, se revocan todas las sesiones del usuario.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 // JWTClaims claims del token JWT
 type JWTClaims struct {
 	UserID uuid.UUID `json:"user_id"`
@@ -40,9 +47,17 @@ type JWTClaims struct {
 
 // RefreshToken representa un refresh token almacenado
 type RefreshToken struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	UserID    uuid.UUID `json:"user_id" db:"user_id"`
-	TokenHash string    `json:"-" db:"token_hash"`
-	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID         uuid.UUID  `json:"id" db:"id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	FamilyID   uuid.UUID  `json:"family_id" db:"family_id"` // Cadena de rotación a la que pertenece
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`   // nil = activo
+	ReplacedBy *uuid.UUID `json:"replaced_by,omitempty" db:"replaced_by"` // Token que lo reemplazó al rotar
+}
+
+// IsActive indica si el token no fue revocado ni expiró
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
 }
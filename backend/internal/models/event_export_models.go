@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventExportStatus estado de un export del evento
+type EventExportStatus string
+
+const (
+	EventExportStatusQueued     EventExportStatus = "queued"
+	EventExportStatusInProgress EventExportStatus = "in_progress"
+	EventExportStatusDone       EventExportStatus = "done"
+	EventExportStatusFailed     EventExportStatus = "failed"
+	EventExportStatusExpired    EventExportStatus = "expired" // El ZIP ya se borró
+)
+
+// EventExport job que arma el ZIP con todo el evento en background
+type EventExport struct {
+	ID             uuid.UUID         `json:"id" db:"id"`
+	EventID        uuid.UUID         `json:"event_id" db:"event_id"`
+	RequestedBy    *uuid.UUID        `json:"requested_by,omitempty" db:"requested_by"`
+	Status         EventExportStatus `json:"status" db:"status"`
+	ProcessedItems int               `json:"processed_items" db:"processed_items"`
+	TotalItems     int               `json:"total_items" db:"total_items"` // 0 hasta que el worker lo empieza
+	Progress       int               `json:"progress"`                     // 0-100, computado
+	ArchiveKey     *string           `json:"-" db:"archive_key"`           // Key del ZIP en el MediaStore
+	SizeBytes      *int64            `json:"size_bytes,omitempty" db:"size_bytes"`
+	Attempts       int               `json:"attempts" db:"attempts"`
+	LastError      *string           `json:"last_error,omitempty" db:"last_error"`
+	QueuedAt       time.Time         `json:"queued_at" db:"queued_at"`
+	StartedAt      *time.Time        `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt     *time.Time        `json:"finished_at,omitempty" db:"finished_at"`
+	ExpiresAt      *time.Time        `json:"expires_at,omitempty" db:"expires_at"` // Cuándo se borra el ZIP
+	// Link firmado de descarga (solo en exports terminados, se genera en cada consulta)
+	DownloadURL       string     `json:"download_url,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+}
+
+// SetProgress calcula el porcentaje a partir de los items procesados
+func (e *EventExport) SetProgress() {
+	switch {
+	case e.Status == EventExportStatusDone:
+		e.Progress = 100
+	case e.TotalItems > 0:
+		e.Progress = e.ProcessedItems * 100 / e.TotalItems
+	default:
+		e.Progress = 0
+	}
+}
+
+// EventArchiveData todo lo que va al ZIP del evento, salvo la media
+type EventArchiveData struct {
+	Event           *Event
+	Theme           *Theme
+	Postcards       []Postcard // Todas: secretas, pendientes y rechazadas incluidas
+	Ranking         []RankingEntry
+	QuizAnswers     []PlayerQuizAnswers
+	AcceptedAnswers map[uuid.UUID]map[string]string // jugador → pregunta → respuesta aceptada
+}
+
+// EventArchivePostcard fila de postcards.json / postcards.csv del ZIP
+type EventArchivePostcard struct {
+	ID               uuid.UUID  `json:"id"`
+	Sender           string     `json:"sender"`
+	PlayerID         *uuid.UUID `json:"player_id,omitempty"`
+	Message          string     `json:"message"`
+	MediaType        string     `json:"media_type"`
+	IsSecret         bool       `json:"is_secret"`
+	RevealedAt       *time.Time `json:"revealed_at,omitempty"`
+	ModerationStatus string     `json:"moderation_status"`
+	CreatedAt        time.Time  `json:"created_at"`
+	Media            string     `json:"media"`               // Path dentro del ZIP, vacío si falta
+	Thumbnail        string     `json:"thumbnail,omitempty"` // Path dentro del ZIP
+}
+
+// EventArchiveQuizAnswers respuestas de un jugador en quiz_answers.json
+type EventArchiveQuizAnswers struct {
+	PlayerQuizAnswers
+	AcceptedAnswers map[string]string `json:"accepted_answers,omitempty"`
+}
+
+// EventArchiveSettings event.json del ZIP: datos, features, settings y tema
+type EventArchiveSettings struct {
+	Event *Event `json:"event"`
+	Theme *Theme `json:"theme"`
+}
+
+// EventArchiveManifest manifest.json del ZIP: resumen y archivos de media que faltan
+type EventArchiveManifest struct {
+	ExportedAt   time.Time         `json:"exported_at"`
+	Event        PlayerDataEvent   `json:"event"`
+	Postcards    int               `json:"postcards"`
+	Players      int               `json:"players"`
+	QuizAnswers  int               `json:"quiz_answers"`
+	MediaFiles   int               `json:"media_files"`
+	MissingMedia []PlayerMediaFile `json:"missing_media"`
+	Files        []string          `json:"files"`
+}
@@ -29,6 +29,68 @@ type Event struct {
 	EndsAt         *time.Time    `json:"ends_at,omitempty" db:"ends_at"`
 	IsActive       bool          `json:"is_active" db:"is_active"`
 	CreatedAt      time.Time     `json:"created_at" db:"created_at"`
+	// Reveal automático de la Secret Box programado por el owner (nil = manual)
+	SecretBoxRevealAt *time.Time `json:"secret_box_reveal_at,omitempty" db:"secret_box_reveal_at"`
+	// El reveal sigue a ends_at (programado con at_event_end)
+	SecretBoxRevealAtEventEnd bool `json:"secret_box_reveal_at_event_end" db:"secret_box_reveal_at_event_end"`
+	// Ciclo de vida: draft → scheduled → live → ended → archived
+	Status          EventStatus `json:"status" db:"status"`
+	StatusChangedAt time.Time   `json:"status_changed_at" db:"status_changed_at"`
+	// Código de acceso (hash bcrypt, nil = evento público) y versión de los tokens de acceso
+	AccessCodeHash  *string `json:"-" db:"access_code_hash"`
+	AccessVersion   int     `json:"-" db:"access_version"`
+	AccessProtected bool    `json:"access_protected"`
+	// Rol del usuario que lista sus eventos (solo en GET /users/me/events)
+	Role EventRole `json:"role,omitempty" db:"role"`
+}
+
+// EventStatus estado del ciclo de vida de un evento
+type EventStatus string
+
+const (
+	EventStatusDraft     EventStatus = "draft"     // Solo lo ve el owner
+	EventStatusScheduled EventStatus = "scheduled" // Publicado, antes de starts_at
+	EventStatusLive      EventStatus = "live"      // En curso: quiz y corkboard abiertos
+	EventStatusEnded     EventStatus = "ended"     // Terminado: solo lectura
+	EventStatusArchived  EventStatus = "archived"  // Fuera de línea para los invitados
+)
+
+// IsPublic indica si los invitados pueden ver el evento
+func (s EventStatus) IsPublic() bool {
+	return s == EventStatusScheduled || s == EventStatusLive || s == EventStatusEnded
+}
+
+// IsActive indica si el evento acepta participación (se guarda en is_active)
+func (s EventStatus) IsActive() bool {
+	return s == EventStatusScheduled || s == EventStatusLive
+}
+
+// DueStatus devuelve el estado que le corresponde al evento en now según sus fechas.
+// Solo avanza: draft y archived nunca cambian solos, y un evento terminado a mano
+// no vuelve a live. Con archiveAfter > 0 los eventos terminados se archivan pasado ese tiempo.
+func (e *Event) DueStatus(now time.Time, archiveAfter time.Duration) EventStatus {
+	switch e.Status {
+	case EventStatusScheduled, EventStatusLive:
+		if e.EndsAt != nil && !e.EndsAt.After(now) {
+			return EventStatusEnded
+		}
+		if e.Status == EventStatusScheduled && (e.StartsAt == nil || !e.StartsAt.After(now)) {
+			return EventStatusLive
+		}
+	case EventStatusEnded:
+		if archiveAfter > 0 && !e.StatusChangedAt.Add(archiveAfter).After(now) {
+			return EventStatusArchived
+		}
+	}
+	return e.Status
+}
+
+// EventStatusChange transición de estado de un evento, para avisar a sus pantallas
+type EventStatusChange struct {
+	EventID   uuid.UUID
+	EventSlug string
+	From      EventStatus
+	To        EventStatus
 }
 
 // EventFeatures flags de features habilitadas para el evento
@@ -45,6 +107,8 @@ type EventSettings struct {
 	BackgroundImage string `json:"background_image,omitempty"`
 	LogoURL         string `json:"logo_url,omitempty"`       // URL del logo/imagen representativa del evento
 	BackgroundURL   string `json:"background_url,omitempty"` // URL del fondo custom del corkboard
+	// Pre-moderación: las postales nuevas quedan pendientes hasta que el owner las apruebe
+	PostcardModeration bool `json:"postcard_moderation,omitempty"`
 }
 
 // QuizQuestion representa una pregunta del quiz configurable por evento
@@ -58,7 +122,39 @@ type QuizQuestion struct {
 	Options        []string  `json:"options,omitempty" db:"options"`       // ["Café", "Té"] para preferences
 	SortOrder      int       `json:"sort_order" db:"sort_order"`
 	IsScorable     bool      `json:"is_scorable" db:"is_scorable"`
-	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	QuestionScoring
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// DefaultQuestionPoints puntos de una pregunta cuando no se especifican
+const DefaultQuestionPoints = 1
+
+// QuestionScoring configuración de puntuación de una pregunta
+type QuestionScoring struct {
+	Points        int                `json:"points" db:"points"`                           // Puntos por respuesta correcta
+	PartialCredit *PartialCreditRule `json:"partial_credit,omitempty" db:"partial_credit"` // nil = sin crédito parcial
+	Fuzzy         *FuzzyMatchRule    `json:"fuzzy,omitempty" db:"fuzzy_match"`             // nil = solo coincidencia exacta
+}
+
+// PartialCreditRule otorga puntos parciales a respuestas "casi correctas".
+// Ej: pregunta de 3 puntos "girasol" con {"answers": ["margarita"], "points": 1}
+type PartialCreditRule struct {
+	Answers []string `json:"answers"` // Respuestas aceptadas con puntaje parcial
+	Points  int      `json:"points"`  // Puntos otorgados (menos que los de la pregunta)
+}
+
+// FuzzyMatchRule habilita coincidencia aproximada para una pregunta (opt-in).
+// Se aplica después de normalizar y solo si no hubo coincidencia exacta o alias.
+// Cada criterio en cero/false queda desactivado.
+type FuzzyMatchRule struct {
+	MaxEditDistance    int     `json:"max_edit_distance"`    // Levenshtein máximo: "ricardo arjna" ≈ "ricardo arjona" con 1
+	TokenSetSimilarity float64 `json:"token_set_similarity"` // Similitud mínima entre conjuntos de palabras (0-1): "bestia y bella" ≈ "bella y bestia"
+	StemPlurals        bool    `json:"stem_plurals"`         // Ignorar plurales: "girasoles" ≈ "girasol"
+}
+
+// IsEnabled indica si la regla tiene algún criterio activo
+func (r *FuzzyMatchRule) IsEnabled() bool {
+	return r != nil && (r.MaxEditDistance > 0 || r.TokenSetSimilarity > 0 || r.StemPlurals)
 }
 
 // DateOnly es un tipo custom para fechas en formato YYYY-MM-DD
@@ -84,6 +180,15 @@ func (d *DateOnly) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// TimePtr devuelve la fecha como *time.Time (nil si no se envió)
+func (d *DateOnly) TimePtr() *time.Time {
+	if d == nil {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
+
 // CreateEventRequest body para crear evento
 type CreateEventRequest struct {
 	Slug        *string       `json:"slug"` // Opcional - si está vacío se autogenera
@@ -93,17 +198,31 @@ type CreateEventRequest struct {
 	Settings    EventSettings `json:"settings"`
 	StartsAt    *DateOnly     `json:"starts_at,omitempty"`
 	EndsAt      *DateOnly     `json:"ends_at,omitempty"`
+	Draft       bool          `json:"draft"` // true = queda en draft hasta que el owner lo publique
+}
+
+// UpdateEventRequest body para editar los datos del evento.
+// Reemplaza nombre, descripción y fechas (una fecha sin enviar queda vacía).
+type UpdateEventRequest struct {
+	Slug        *string   `json:"slug"` // Opcional - sin slug se mantiene el actual
+	Name        string    `json:"name" binding:"required"`
+	Description string    `json:"description"`
+	StartsAt    *DateOnly `json:"starts_at,omitempty"`
+	EndsAt      *DateOnly `json:"ends_at,omitempty"`
 }
 
 // CreateQuizQuestionRequest body para crear pregunta
 type CreateQuizQuestionRequest struct {
-	Section        string   `json:"section" binding:"required"`
-	Key            string   `json:"key" binding:"required"`
-	QuestionText   string   `json:"question_text" binding:"required"`
-	CorrectAnswers []string `json:"correct_answers"`
-	Options        []string `json:"options,omitempty"`
-	SortOrder      int      `json:"sort_order"`
-	IsScorable     *bool    `json:"is_scorable"` // nil means default true
+	Section        string             `json:"section" binding:"required"`
+	Key            string             `json:"key" binding:"required"`
+	QuestionText   string             `json:"question_text" binding:"required"`
+	CorrectAnswers []string           `json:"correct_answers"`
+	Options        []string           `json:"options,omitempty"`
+	SortOrder      int                `json:"sort_order"`
+	IsScorable     *bool              `json:"is_scorable"` // nil means default true
+	Points         *int               `json:"points"`      // nil means DefaultQuestionPoints
+	PartialCredit  *PartialCreditRule `json:"partial_credit,omitempty"`
+	Fuzzy          *FuzzyMatchRule    `json:"fuzzy,omitempty"`
 }
 
 // UpdateQuizQuestionRequest body para actualizar pregunta
@@ -115,6 +234,11 @@ type UpdateQuizQuestionRequest struct {
 	Options        []string `json:"options,omitempty"`
 	SortOrder      *int     `json:"sort_order,omitempty"`
 	IsScorable     *bool    `json:"is_scorable,omitempty"`
+	Points         *int     `json:"points,omitempty"`
+	// PartialCredit reemplaza la regla; una regla sin answers la elimina
+	PartialCredit *PartialCreditRule `json:"partial_credit,omitempty"`
+	// Fuzzy reemplaza la regla; una regla sin criterios activos la elimina
+	Fuzzy *FuzzyMatchRule `json:"fuzzy,omitempty"`
 }
 
 // ReorderRequest body para reordenar preguntas
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RSVPStatus respuesta de un invitado a la invitación
+type RSVPStatus string
+
+const (
+	RSVPPending RSVPStatus = "pending" // Todavía no respondió
+	RSVPYes     RSVPStatus = "yes"
+	RSVPNo      RSVPStatus = "no"
+	RSVPMaybe   RSVPStatus = "maybe"
+)
+
+// IsAnswer indica si es una respuesta válida del invitado (pending no lo es)
+func (s RSVPStatus) IsAnswer() bool {
+	return s == RSVPYes || s == RSVPNo || s == RSVPMaybe
+}
+
+// GuestInvitation invitado de la lista del host, con su link personal
+type GuestInvitation struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	EventID     uuid.UUID  `json:"event_id" db:"event_id"`
+	Name        string     `json:"name" db:"name"`
+	Email       string     `json:"email,omitempty" db:"email"`
+	Phone       string     `json:"phone,omitempty" db:"phone"`
+	Token       string     `json:"token" db:"token"`
+	MaxPlusOnes int        `json:"max_plus_ones" db:"max_plus_ones"` // Acompañantes permitidos
+	RSVPStatus  RSVPStatus `json:"rsvp_status" db:"rsvp_status"`
+	PlusOnes    int        `json:"plus_ones" db:"plus_ones"` // Acompañantes confirmados
+	RSVPAt      *time.Time `json:"rsvp_at,omitempty" db:"rsvp_at"`
+	PlayerID    *uuid.UUID `json:"player_id,omitempty" db:"player_id"` // Jugador creado desde el link
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+
+	// Actividad del jugador vinculado (solo en el listado del host)
+	Played        bool   `json:"played"`
+	PostcardCount int    `json:"postcard_count"`
+	JoinURL       string `json:"join_url,omitempty"`
+}
+
+// GuestInvitationView lo que ve el invitado desde su link personal
+type GuestInvitationView struct {
+	Name        string     `json:"name"`
+	EventName   string     `json:"event_name"`
+	MaxPlusOnes int        `json:"max_plus_ones"`
+	RSVPStatus  RSVPStatus `json:"rsvp_status"`
+	PlusOnes    int        `json:"plus_ones"`
+	RSVPAt      *time.Time `json:"rsvp_at,omitempty"`
+	PlayerID    *uuid.UUID `json:"player_id,omitempty"`
+}
+
+// GuestListSummary totales de la lista de invitados para el panel del host
+type GuestListSummary struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Yes       int `json:"yes"`
+	No        int `json:"no"`
+	Maybe     int `json:"maybe"`
+	Attending int `json:"attending"` // Confirmados más sus acompañantes
+	Joined    int `json:"joined"`    // Entraron con su link
+	Played    int `json:"played"`    // Completaron el quiz
+	Posted    int `json:"posted"`    // Dejaron al menos una postal
+}
+
+// SummarizeGuests calcula los totales de la lista de invitados
+func SummarizeGuests(guests []GuestInvitation) GuestListSummary {
+	summary := GuestListSummary{Total: len(guests)}
+	for _, g := range guests {
+		switch g.RSVPStatus {
+		case RSVPYes:
+			summary.Yes++
+			summary.Attending += 1 + g.PlusOnes
+		case RSVPNo:
+			summary.No++
+		case RSVPMaybe:
+			summary.Maybe++
+		default:
+			summary.Pending++
+		}
+		if g.PlayerID != nil {
+			summary.Joined++
+		}
+		if g.Played {
+			summary.Played++
+		}
+		if g.PostcardCount > 0 {
+			summary.Posted++
+		}
+	}
+	return summary
+}
+
+// GuestInput datos de un invitado al importar la lista o editarlo
+type GuestInput struct {
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	MaxPlusOnes int    `json:"max_plus_ones"`
+}
+
+// ImportGuestsRequest body JSON para importar invitados (también se acepta CSV)
+type ImportGuestsRequest struct {
+	Guests []GuestInput `json:"guests"`
+}
+
+// UpdateGuestRequest body para que el host edite un invitado.
+// rsvp_status y plus_ones permiten cargar respuestas que llegaron por otro medio.
+type UpdateGuestRequest struct {
+	GuestInput
+	RSVPStatus *RSVPStatus `json:"rsvp_status"`
+	PlusOnes   *int        `json:"plus_ones"`
+}
+
+// RSVPRequest respuesta del invitado desde su link personal
+type RSVPRequest struct {
+	Status   RSVPStatus `json:"status" binding:"required"`
+	PlusOnes int        `json:"plus_ones"`
+}
+
+// JoinWithInvitationRequest body para entrar al evento desde el link personal
+type JoinWithInvitationRequest struct {
+	Avatar string `json:"avatar"`
+}
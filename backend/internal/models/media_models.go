@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MediaJobStatus represents the status of a video processing job
+type MediaJobStatus string
+
+const (
+	MediaJobStatusQueued     MediaJobStatus = "queued"
+	MediaJobStatusInProgress MediaJobStatus = "in_progress"
+	MediaJobStatusDone       MediaJobStatus = "done"
+	MediaJobStatusFailed     MediaJobStatus = "failed"
+)
+
+// MediaJob represents the background processing of an uploaded postcard video
+type MediaJob struct {
+	ID         uuid.UUID      `json:"id" db:"id"`
+	PostcardID uuid.UUID      `json:"postcard_id" db:"postcard_id"`
+	EventID    uuid.UUID      `json:"event_id" db:"event_id"`     // from the postcard (uuid.Nil for legacy postcards)
+	EventSlug  string         `json:"event_slug" db:"event_slug"` // from the postcard's event ("" for legacy postcards)
+	SourceKey  string         `json:"source_key" db:"source_key"` // MediaStore key of the original upload
+	Status     MediaJobStatus `json:"status" db:"status"`
+	Attempts   int            `json:"attempts" db:"attempts"`
+	LastError  *string        `json:"last_error,omitempty" db:"last_error"`
+	QueuedAt   time.Time      `json:"queued_at" db:"queued_at"`
+	StartedAt  *time.Time     `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// ProcessedVideo holds the outputs of a finished media job
+type ProcessedVideo struct {
+	VideoPath     string  // public URL of the H.264/MP4 rendition
+	ThumbnailPath *string // nil if the thumbnail could not be generated
+	DurationMs    *int    // nil if the duration could not be measured
+}
+
+// UploadSession is a resumable upload: the client sends the file in chunks and
+// finishes it into a postcard with the session ID
+type UploadSession struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	EventID   uuid.UUID `json:"event_id" db:"event_id"`
+	Length    int64     `json:"length" db:"upload_length"` // total size in bytes
+	Offset    int64     `json:"offset" db:"upload_offset"` // bytes received so far
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	// Set while a postcard is being created from the upload (see Claim)
+	ClaimedAt *time.Time `json:"-" db:"claimed_at"`
+}
+
+// IsComplete reports whether every byte of the upload was received
+func (s *UploadSession) IsComplete() bool {
+	return s.Offset == s.Length
+}
+
+// UploadChunk is a stored piece of an upload session
+type UploadChunk struct {
+	Offset int64  `db:"chunk_offset"`
+	Size   int64  `db:"chunk_size"`
+	Key    string `db:"media_key"` // MediaStore key of the chunk
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventRole rol de un usuario en un evento
+type EventRole string
+
+const (
+	EventRoleOwner     EventRole = "owner"     // Todo, incluido borrar el evento y gestionar miembros
+	EventRoleCoHost    EventRole = "co_host"   // Configura y conduce el evento
+	EventRoleModerator EventRole = "moderator" // Modera postales y respuestas
+	EventRoleViewer    EventRole = "viewer"    // Solo ve el panel admin
+)
+
+// EventPermission acción sobre un evento que requiere un rol
+type EventPermission string
+
+const (
+	PermissionView          EventPermission = "view"           // Panel admin, analytics, listados
+	PermissionModerate      EventPermission = "moderate"       // Aprobar/rechazar/borrar postales, revisar respuestas
+	PermissionManage        EventPermission = "manage"         // Settings, tema, preguntas, Secret Box, ciclo de vida
+	PermissionManageMembers EventPermission = "manage_members" // Invitar, cambiar roles y quitar miembros
+	PermissionDelete        EventPermission = "delete"         // Borrar el evento
+)
+
+var rolePermissions = map[EventRole][]EventPermission{
+	EventRoleOwner:     {PermissionView, PermissionModerate, PermissionManage, PermissionManageMembers, PermissionDelete},
+	EventRoleCoHost:    {PermissionView, PermissionModerate, PermissionManage},
+	EventRoleModerator: {PermissionView, PermissionModerate},
+	EventRoleViewer:    {PermissionView},
+}
+
+// IsValid indica si el rol existe
+func (r EventRole) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can indica si el rol tiene el permiso
+func (r EventRole) Can(permission EventPermission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// EventMember usuario con un rol en un evento
+type EventMember struct {
+	EventID   uuid.UUID  `json:"event_id" db:"event_id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	Email     string     `json:"email" db:"email"` // del usuario
+	Name      string     `json:"name" db:"name"`   // del usuario
+	Role      EventRole  `json:"role" db:"role"`
+	InvitedBy *uuid.UUID `json:"invited_by,omitempty" db:"invited_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// EventMemberInvitation invitación pendiente a un email para sumarse a un evento
+type EventMemberInvitation struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	EventID   uuid.UUID  `json:"event_id" db:"event_id"`
+	EventSlug string     `json:"event_slug" db:"event_slug"` // del evento
+	EventName string     `json:"event_name" db:"event_name"` // del evento
+	Email     string     `json:"email" db:"email"`
+	Role      EventRole  `json:"role" db:"role"`
+	InvitedBy *uuid.UUID `json:"invited_by,omitempty" db:"invited_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// InviteMemberRequest body para invitar a un organizador por email
+type InviteMemberRequest struct {
+	Email string    `json:"email" binding:"required,email"`
+	Role  EventRole `json:"role" binding:"required"`
+}
+
+// UpdateMemberRoleRequest body para cambiar el rol de un miembro
+type UpdateMemberRoleRequest struct {
+	Role EventRole `json:"role" binding:"required"`
+}
@@ -38,6 +38,13 @@ type CreatePlayerRequest struct {
 	Avatar string `json:"avatar"`
 }
 
+// CreatePlayerResponse jugador recién creado junto a su token de sesión.
+// El token se envía luego en el header X-Player-Token de los endpoints de escritura.
+type CreatePlayerResponse struct {
+	Player
+	PlayerToken string `json:"player_token"`
+}
+
 // SubmitQuizRequest representa el body de envío de respuestas
 type SubmitQuizRequest struct {
 	Favorites   map[string]string `json:"favorites" binding:"required"`
@@ -45,6 +52,129 @@ type SubmitQuizRequest struct {
 	Description string            `json:"description"`
 }
 
+// Tipos de eventos de telemetría del quiz (tabla quiz_events)
+const (
+	QuizEventStarted   = "started"
+	QuizEventCompleted = "completed"
+)
+
+// Tipos de eventos de telemetría de postales (tabla postcard_events)
+const (
+	PostcardEventCreated = "created"
+	PostcardEventViewed  = "viewed"
+)
+
+// QuizStart inicio del quiz registrado para un jugador
+type QuizStart struct {
+	StartedAt      time.Time `json:"started_at"`
+	AlreadyStarted bool      `json:"already_started"` // true si el inicio ya estaba registrado
+}
+
+// Resultados posibles al puntuar una pregunta
+const (
+	ScoreResultCorrect    = "correct"
+	ScoreResultPartial    = "partial"
+	ScoreResultIncorrect  = "incorrect"
+	ScoreResultUnanswered = "unanswered"
+)
+
+// Motivo por el que una respuesta fue aceptada
+const (
+	MatchExact    = "exact"    // Igual a la respuesta principal
+	MatchAlias    = "alias"    // Igual a otra respuesta válida
+	MatchFuzzy    = "fuzzy"    // Aceptada por la regla de coincidencia aproximada
+	MatchOverride = "override" // Aceptada manualmente por el host para ese jugador
+)
+
+// QuestionScore puntaje obtenido en una pregunta
+type QuestionScore struct {
+	Key           string `json:"key"`
+	Section       string `json:"section"`
+	Answer        string `json:"answer"`                   // Respuesta normalizada del jugador
+	Result        string `json:"result"`                   // correct | partial | incorrect | unanswered
+	Match         string `json:"match,omitempty"`          // exact | alias | fuzzy | override (si fue aceptada)
+	MatchedAnswer string `json:"matched_answer,omitempty"` // Respuesta válida con la que coincidió
+	Points        int    `json:"points"`
+	MaxPoints     int    `json:"max_points"`
+}
+
+// ScoreBreakdown puntaje total con el desglose por pregunta
+type ScoreBreakdown struct {
+	Total     int             `json:"total"`
+	MaxTotal  int             `json:"max_total"`
+	Questions []QuestionScore `json:"questions"`
+}
+
+// PlayerQuizAnswers respuestas guardadas de un jugador junto a sus datos
+type PlayerQuizAnswers struct {
+	Player      Player            `json:"player"`
+	Favorites   map[string]string `json:"favorites"`
+	Preferences map[string]string `json:"preferences"`
+}
+
+// AnswerReview respuesta de un jugador a una pregunta, para la revisión del host
+type AnswerReview struct {
+	PlayerID     uuid.UUID `json:"player_id"`
+	PlayerName   string    `json:"player_name"`
+	PlayerAvatar string    `json:"player_avatar"`
+	QuestionScore
+	Accepted bool `json:"accepted"` // El host aceptó esta respuesta para el jugador
+}
+
+// QuestionReview respuestas de todos los jugadores a una pregunta
+type QuestionReview struct {
+	Key            string         `json:"key"`
+	Section        string         `json:"section"`
+	QuestionText   string         `json:"question_text"`
+	CorrectAnswers []string       `json:"correct_answers"`
+	Points         int            `json:"points"`
+	Answers        []AnswerReview `json:"answers"`
+}
+
+// RescoreResult resultado de recalcular los puntajes de un evento
+type RescoreResult struct {
+	Players int            `json:"players"` // Jugadores con respuestas recalculados
+	Changed int            `json:"changed"` // Jugadores cuyo puntaje cambió
+	Ranking []RankingEntry `json:"ranking"`
+}
+
+// Dificultad de una pregunta según el porcentaje de jugadores que la acertaron
+const (
+	DifficultyEasy   = "easy"   // 70% o más
+	DifficultyMedium = "medium" // entre 30% y 70%
+	DifficultyHard   = "hard"   // menos de 30%
+)
+
+// Alertas sobre preguntas mal configuradas o que nadie acierta
+const (
+	QuestionWarningNoValidAnswer = "no_valid_answer" // No tiene respuestas correctas cargadas
+	QuestionWarningNobodyCorrect = "nobody_correct"  // Hubo respuestas pero ninguna correcta
+)
+
+// AnswerCount cantidad de jugadores que dieron una misma respuesta (normalizada)
+type AnswerCount struct {
+	Answer string `json:"answer"`
+	Count  int    `json:"count"`
+}
+
+// QuestionStats estadísticas de aciertos de una pregunta del quiz
+type QuestionStats struct {
+	Key             string        `json:"key"`
+	Section         string        `json:"section"`
+	QuestionText    string        `json:"question_text"`
+	CorrectAnswers  []string      `json:"correct_answers"`
+	Points          int           `json:"points"`
+	Respondents     int           `json:"respondents"` // Jugadores que enviaron el quiz
+	Answered        int           `json:"answered"`
+	Correct         int           `json:"correct"` // Incluye respuestas aceptadas por el host
+	Partial         int           `json:"partial"`
+	Incorrect       int           `json:"incorrect"`
+	CorrectRate     float64       `json:"correct_rate"`         // % de respondents que la acertaron
+	Difficulty      string        `json:"difficulty,omitempty"` // Vacío si nadie envió el quiz
+	TopWrongAnswers []AnswerCount `json:"top_wrong_answers"`
+	Warnings        []string      `json:"warnings"`
+}
+
 // Postcard representa una postal en la cartelera de corcho
 type Postcard struct {
 	ID           uuid.UUID  `json:"id" db:"id"`
@@ -63,9 +193,81 @@ type Postcard struct {
 	MediaType       string  `json:"media_type" db:"media_type"`                   // "image" | "video"
 	ThumbnailPath   *string `json:"thumbnail_path,omitempty" db:"thumbnail_path"` // para videos
 	MediaDurationMs *int    `json:"media_duration_ms,omitempty" db:"media_duration_ms"`
+	MediaStatus     string  `json:"media_status" db:"media_status"` // "processing" | "ready" | "failed"
 	// Drive backup fields
 	BackupStatus BackupStatus `json:"backup_status" db:"backup_status"`           // "pending" | "queued" | "synced" | "failed"
 	BackupJobID  *uuid.UUID   `json:"backup_job_id,omitempty" db:"backup_job_id"` // FK to backup_jobs.id
+	// Moderación (settings.postcard_moderation del evento)
+	ModerationStatus string `json:"moderation_status" db:"moderation_status"` // "pending" | "approved" | "rejected"
+	// Renditions de imágenes procesadas (nil en videos y postales anteriores al pipeline)
+	Renditions *ImageRenditions `json:"renditions,omitempty"`
+}
+
+// Tamaños de las renditions de imagen (lado mayor en px)
+const (
+	ImageVariantThumb = "thumb" // 320px, grillas del panel admin
+	ImageVariantBoard = "board" // 1024px, la que muestra el corkboard
+	ImageVariantFull  = "full"  // 2048px, vista ampliada
+)
+
+// ImageRenditions URLs de las versiones procesadas de una imagen
+// (sin EXIF, rotadas según la orientación y redimensionadas)
+type ImageRenditions struct {
+	Thumb string `json:"thumb" db:"image_thumb_path"`
+	Board string `json:"board" db:"image_board_path"`
+	Full  string `json:"full" db:"image_path"`
+}
+
+// IsValidImageVariant indica si variant es un tamaño de rendition conocido
+func IsValidImageVariant(variant string) bool {
+	return variant == ImageVariantThumb || variant == ImageVariantBoard || variant == ImageVariantFull
+}
+
+// UseImageVariant reemplaza image_path por la rendition pedida. Los videos y
+// las postales sin renditions quedan como están.
+func (p *Postcard) UseImageVariant(variant string) {
+	if p.Renditions == nil {
+		return
+	}
+	switch variant {
+	case ImageVariantThumb:
+		p.ImagePath = p.Renditions.Thumb
+	case ImageVariantBoard:
+		p.ImagePath = p.Renditions.Board
+	case ImageVariantFull:
+		p.ImagePath = p.Renditions.Full
+	}
+}
+
+// Estados de moderación de una postal
+const (
+	ModerationPending  = "pending"  // Esperando aprobación del owner
+	ModerationApproved = "approved" // Visible en el corkboard
+	ModerationRejected = "rejected" // Oculta, se conserva para revisión
+)
+
+// Estados del procesamiento de media de una postal (los videos se procesan en background)
+const (
+	MediaStatusProcessing = "processing" // Video subido, esperando al MediaWorker
+	MediaStatusReady      = "ready"      // Lista para mostrar
+	MediaStatusFailed     = "failed"     // El procesamiento falló; se puede reintentar desde el admin
+)
+
+// IsPublic indica si la postal se puede mostrar a los invitados: aprobada, con la
+// media lista y, si es secreta, ya revelada
+func (p *Postcard) IsPublic() bool {
+	return p.ModerationStatus == ModerationApproved && p.MediaStatus == MediaStatusReady && (!p.IsSecret || p.RevealedAt != nil)
+}
+
+// PublicPostcards filtra las postales que no se pueden mostrar (ver IsPublic)
+func PublicPostcards(postcards []Postcard) []Postcard {
+	public := make([]Postcard, 0, len(postcards))
+	for _, p := range postcards {
+		if p.IsPublic() {
+			public = append(public, p)
+		}
+	}
+	return public
 }
 
 // CreatePostcardResponse respuesta al crear una postal
@@ -78,7 +280,17 @@ type CreatePostcardResponse struct {
 
 // SecretBoxStatus estado de la Secret Box para el panel admin
 type SecretBoxStatus struct {
-	Total      int        `json:"total"`
-	Revealed   bool       `json:"revealed"`
-	RevealedAt *time.Time `json:"revealed_at,omitempty"`
+	Total             int        `json:"total"`
+	Revealed          bool       `json:"revealed"`
+	RevealedAt        *time.Time `json:"revealed_at,omitempty"`
+	ScheduledRevealAt *time.Time `json:"scheduled_reveal_at,omitempty"` // reveal automático pendiente
+	RevealedCount     int        `json:"revealed_count"`                // secretas ya reveladas
+	Remaining         int        `json:"remaining"`                     // secretas en la cola del reveal uno por uno
+}
+
+// SecretBoxReveal Secret Box revelada por el scheduler, lista para el broadcast
+type SecretBoxReveal struct {
+	EventID   uuid.UUID
+	EventSlug string
+	Postcards []Postcard
 }
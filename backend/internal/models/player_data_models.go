@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlayerDataExport todo lo que se guarda de un jugador en un evento (export GDPR).
+// Es el data.json del ZIP; la media de sus postales va aparte en el mismo ZIP.
+type PlayerDataExport struct {
+	ExportedAt       time.Time              `json:"exported_at"`
+	Event            PlayerDataEvent        `json:"event"`
+	Player           Player                 `json:"player"`
+	Invitation       *GuestInvitation       `json:"invitation,omitempty"`   // Si se sumó desde un link personal
+	QuizAnswers      *QuizAnswers           `json:"quiz_answers,omitempty"` // nil si no jugó el quiz
+	AcceptedAnswers  map[string]string      `json:"accepted_answers"`       // Respuestas aceptadas por el host
+	QuizActivity     []PlayerQuizActivity   `json:"quiz_activity"`
+	Postcards        []Postcard             `json:"postcards"`
+	PostcardActivity []PlayerPostcardAction `json:"postcard_activity"`
+	PageViews        []PlayerPageView       `json:"page_views"`
+	BackupJobs       []BackupJob            `json:"backup_jobs"` // Copias de sus postales en el Drive del host
+	MediaFiles       []PlayerMediaFile      `json:"media_files"`
+}
+
+// PlayerDataEvent evento al que pertenecen los datos exportados
+type PlayerDataEvent struct {
+	ID   uuid.UUID `json:"id"`
+	Slug string    `json:"slug"`
+	Name string    `json:"name"`
+}
+
+// PlayerQuizActivity registro de telemetría del quiz (started / completed)
+type PlayerQuizActivity struct {
+	Type             string    `json:"type"`
+	Score            *int      `json:"score,omitempty"`
+	TimeSpentSeconds *int      `json:"time_spent_seconds,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// PlayerPostcardAction registro de telemetría de postales (creada, vista)
+type PlayerPostcardAction struct {
+	Type       string     `json:"type"`
+	PostcardID *uuid.UUID `json:"postcard_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// PlayerPageView página visitada por el jugador
+type PlayerPageView struct {
+	Path      string    `json:"path"`
+	VisitedAt time.Time `json:"visited_at"`
+}
+
+// PlayerMediaFile archivo de una postal incluido en el ZIP. Path vacío si no se pudo
+// leer (Missing) y entonces solo queda la URL original.
+type PlayerMediaFile struct {
+	PostcardID uuid.UUID `json:"postcard_id"`
+	URL        string    `json:"url"`
+	Path       string    `json:"path,omitempty"` // Ruta dentro del ZIP
+	Missing    bool      `json:"missing,omitempty"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventTemplate template personal de un usuario para crear eventos parecidos.
+// Guarda la configuración de un evento (no sus invitados ni postales).
+type EventTemplate struct {
+	ID          uuid.UUID          `json:"id" db:"id"`
+	OwnerID     uuid.UUID          `json:"owner_id" db:"owner_id"`
+	Name        string             `json:"name" db:"name"`
+	Description string             `json:"description" db:"description"`
+	Features    EventFeatures      `json:"features" db:"features"`     // JSONB
+	Settings    EventSettings      `json:"settings" db:"settings"`     // JSONB, con URLs propias del template
+	Theme       *ThemePreset       `json:"theme,omitempty" db:"theme"` // JSONB, nil = theme por defecto
+	Questions   []TemplateQuestion `json:"questions" db:"questions"`   // JSONB
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+}
+
+// TemplateQuestion pregunta del quiz guardada en un template (sin IDs de evento)
+type TemplateQuestion struct {
+	Section        string   `json:"section"`
+	Key            string   `json:"key"`
+	QuestionText   string   `json:"question_text"`
+	CorrectAnswers []string `json:"correct_answers"`
+	Options        []string `json:"options,omitempty"`
+	SortOrder      int      `json:"sort_order"`
+	IsScorable     bool     `json:"is_scorable"`
+	QuestionScoring
+}
+
+// CloneOptions qué datos de los invitados se copian al duplicar un evento
+type CloneOptions struct {
+	ExcludeGuests    bool // Sin jugadores
+	ExcludePostcards bool // Sin postales
+}
+
+// CloneEventRequest body para duplicar un evento.
+// El duplicado queda en draft; features, settings, theme, preguntas y media se copian siempre.
+type CloneEventRequest struct {
+	Slug             *string   `json:"slug"` // Opcional - si está vacío se autogenera
+	Name             string    `json:"name"` // Opcional - por defecto "<nombre> (copia)"
+	StartsAt         *DateOnly `json:"starts_at,omitempty"`
+	EndsAt           *DateOnly `json:"ends_at,omitempty"`
+	ExcludeGuests    bool      `json:"exclude_guests"`
+	ExcludePostcards bool      `json:"exclude_postcards"`
+}
+
+// SaveTemplateRequest body para guardar un evento como template
+type SaveTemplateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// CreateEventFromTemplateRequest body para crear un evento desde un template
+type CreateEventFromTemplateRequest struct {
+	Slug        *string   `json:"slug"` // Opcional - si está vacío se autogenera
+	Name        string    `json:"name" binding:"required"`
+	Description *string   `json:"description"` // nil = la del template
+	StartsAt    *DateOnly `json:"starts_at,omitempty"`
+	EndsAt      *DateOnly `json:"ends_at,omitempty"`
+	Draft       bool      `json:"draft"`
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// EventExportRepository maneja los jobs de export del evento (ZIP en background)
+type EventExportRepository struct {
+	db *sql.DB
+}
+
+// NewEventExportRepository crea un nuevo repositorio de exports
+func NewEventExportRepository(db *sql.DB) *EventExportRepository {
+	return &EventExportRepository{db: db}
+}
+
+// eventExportCols columnas leídas por scanEventExport
+const eventExportCols = `
+	id, event_id, requested_by, status, processed_items, total_items, archive_key,
+	size_bytes, attempts, last_error, queued_at, started_at, finished_at, expires_at`
+
+func scanEventExport(row interface {
+	Scan(...any) error
+}) (*models.EventExport, error) {
+	var export models.EventExport
+	var requestedBy uuid.NullUUID
+	var archiveKey, lastError sql.NullString
+	var sizeBytes sql.NullInt64
+	var startedAt, finishedAt, expiresAt sql.NullTime
+
+	err := row.Scan(
+		&export.ID, &export.EventID, &requestedBy, &export.Status, &export.ProcessedItems, &export.TotalItems, &archiveKey,
+		&sizeBytes, &export.Attempts, &lastError, &export.QueuedAt, &startedAt, &finishedAt, &expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if requestedBy.Valid {
+		export.RequestedBy = &requestedBy.UUID
+	}
+	if archiveKey.Valid {
+		export.ArchiveKey = &archiveKey.String
+	}
+	if sizeBytes.Valid {
+		export.SizeBytes = &sizeBytes.Int64
+	}
+	if lastError.Valid {
+		export.LastError = &lastError.String
+	}
+	if startedAt.Valid {
+		export.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		export.FinishedAt = &finishedAt.Time
+	}
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+	export.SetProgress()
+	return &export, nil
+}
+
+// scanEventExports lee todas las filas de un listado de exports
+func scanEventExports(rows *sql.Rows) ([]models.EventExport, error) {
+	defer rows.Close()
+
+	exports := []models.EventExport{}
+	for rows.Next() {
+		export, err := scanEventExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, *export)
+	}
+	return exports, rows.Err()
+}
+
+// Create encola un export del evento. Devuelve ErrEventExportInProgress si el evento
+// ya tiene uno encolado o en curso.
+func (r *EventExportRepository) Create(eventID uuid.UUID, requestedBy *uuid.UUID) (*models.EventExport, error) {
+	export := &models.EventExport{
+		ID:          uuid.New(),
+		EventID:     eventID,
+		RequestedBy: requestedBy,
+		Status:      models.EventExportStatusQueued,
+		QueuedAt:    time.Now(),
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO event_exports (id, event_id, requested_by, status, queued_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, export.ID, export.EventID, export.RequestedBy, export.Status, export.QueuedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrEventExportInProgress
+		}
+		return nil, err
+	}
+	return export, nil
+}
+
+// GetByID obtiene un export
+func (r *EventExportRepository) GetByID(id uuid.UUID) (*models.EventExport, error) {
+	export, err := scanEventExport(r.db.QueryRow(`SELECT`+eventExportCols+`
+		FROM event_exports WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrEventExportNotFound
+	}
+	return export, err
+}
+
+// ListByEvent lista los exports del evento, más recientes primero
+func (r *EventExportRepository) ListByEvent(eventID uuid.UUID) ([]models.EventExport, error) {
+	rows, err := r.db.Query(`SELECT`+eventExportCols+`
+		FROM event_exports
+		WHERE event_id = $1
+		ORDER BY queued_at DESC`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return scanEventExports(rows)
+}
+
+// ClaimQueued marca como in_progress hasta limit exports encolados y los devuelve.
+// SKIP LOCKED evita que dos instancias de la API armen el mismo ZIP.
+func (r *EventExportRepository) ClaimQueued(limit int) ([]models.EventExport, error) {
+	rows, err := r.db.Query(`
+		UPDATE event_exports
+		SET status = 'in_progress', started_at = NOW(), finished_at = NULL,
+			attempts = attempts + 1, processed_items = 0
+		WHERE id IN (
+			SELECT id FROM event_exports
+			WHERE status = 'queued' AND queued_at <= NOW()
+			ORDER BY queued_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING`+eventExportCols, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanEventExports(rows)
+}
+
+// RequeueStale vuelve a encolar los exports que quedaron in_progress más de
+// olderThan (la instancia que los armaba se reinició)
+func (r *EventExportRepository) RequeueStale(olderThan time.Duration) (int64, error) {
+	result, err := r.db.Exec(`
+		UPDATE event_exports
+		SET status = 'queued', queued_at = NOW()
+		WHERE status = 'in_progress' AND started_at < $1
+	`, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// UpdateProgress guarda cuántos items del ZIP ya se escribieron
+func (r *EventExportRepository) UpdateProgress(id uuid.UUID, processed, total int) error {
+	_, err := r.db.Exec(`
+		UPDATE event_exports SET processed_items = $1, total_items = $2
+		WHERE id = $3 AND status = 'in_progress'
+	`, processed, total, id)
+	return err
+}
+
+// Complete registra el ZIP terminado y hasta cuándo se puede descargar
+func (r *EventExportRepository) Complete(id uuid.UUID, archiveKey string, sizeBytes int64, expiresAt time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE event_exports
+		SET status = 'done', archive_key = $1, size_bytes = $2, expires_at = $3,
+			processed_items = total_items, last_error = NULL, finished_at = NOW()
+		WHERE id = $4
+	`, archiveKey, sizeBytes, expiresAt, id)
+	return err
+}
+
+// Requeue programa otro intento del export después de delay, guardando el error
+func (r *EventExportRepository) Requeue(id uuid.UUID, lastError string, delay time.Duration) error {
+	_, err := r.db.Exec(`
+		UPDATE event_exports
+		SET status = 'queued', last_error = $1, queued_at = $2
+		WHERE id = $3
+	`, lastError, time.Now().Add(delay), id)
+	return err
+}
+
+// Fail marca el export como fallido
+func (r *EventExportRepository) Fail(id uuid.UUID, lastError string) error {
+	_, err := r.db.Exec(`
+		UPDATE event_exports
+		SET status = 'failed', last_error = $1, finished_at = NOW()
+		WHERE id = $2
+	`, lastError, id)
+	return err
+}
+
+// ListExpired lista hasta limit exports terminados cuyo ZIP ya venció
+func (r *EventExportRepository) ListExpired(limit int) ([]models.EventExport, error) {
+	rows, err := r.db.Query(`SELECT`+eventExportCols+`
+		FROM event_exports
+		WHERE status = 'done' AND expires_at <= NOW()
+		ORDER BY expires_at ASC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanEventExports(rows)
+}
+
+// MarkExpired marca el export como expirado una vez borrado su ZIP
+func (r *EventExportRepository) MarkExpired(id uuid.UUID) error {
+	_, err := r.db.Exec(`
+		UPDATE event_exports SET status = 'expired', archive_key = NULL
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+// ErrEventExportNotFound el export no existe
+var ErrEventExportNotFound = errors.New("event export not found")
+
+// ErrEventExportInProgress el evento ya tiene un export encolado o en curso
+var ErrEventExportInProgress = errors.New("event export already in progress")
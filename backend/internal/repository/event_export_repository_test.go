@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestEventExportRepository_Lifecycle(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	event, err := NewEventRepository(db).Create(user.ID, "export-event", "Export Event", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	repo := NewEventExportRepository(db)
+	export, err := repo.Create(event.ID, &user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := repo.Create(event.ID, &user.ID); err != ErrEventExportInProgress {
+		t.Errorf("Expected ErrEventExportInProgress, got: %v", err)
+	}
+
+	claimed, err := repo.ClaimQueued(5)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != export.ID || claimed[0].Attempts != 1 {
+		t.Fatalf("Expected the export claimed once, got %+v", claimed)
+	}
+
+	if err := repo.UpdateProgress(export.ID, 3, 4); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	got, err := repo.GetByID(export.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got.Status != models.EventExportStatusInProgress || got.Progress != 75 {
+		t.Errorf("Expected in_progress at 75%%, got %s at %d%%", got.Status, got.Progress)
+	}
+
+	// Ya expirado: el cleanup lo tiene que encontrar
+	if err := repo.Complete(export.ID, "exports/archive.zip", 2048, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	got, _ = repo.GetByID(export.ID)
+	if got.Status != models.EventExportStatusDone || got.Progress != 100 || got.ArchiveKey == nil || *got.SizeBytes != 2048 {
+		t.Errorf("Expected a finished export, got %+v", got)
+	}
+
+	// Terminado el anterior, se puede pedir otro
+	if _, err := repo.Create(event.ID, nil); err != nil {
+		t.Errorf("Expected a new export after the previous finished, got: %v", err)
+	}
+	if exports, _ := repo.ListByEvent(event.ID); len(exports) != 2 {
+		t.Errorf("Expected 2 exports, got %d", len(exports))
+	}
+
+	expired, err := repo.ListExpired(10)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != export.ID {
+		t.Fatalf("Expected the finished export to be expired, got %+v", expired)
+	}
+	if err := repo.MarkExpired(export.ID); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	got, _ = repo.GetByID(export.ID)
+	if got.Status != models.EventExportStatusExpired || got.ArchiveKey != nil {
+		t.Errorf("Expected an expired export without archive, got %+v", got)
+	}
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// EventMemberRepository maneja los organizadores de cada evento y sus invitaciones
+type EventMemberRepository struct {
+	db *sql.DB
+}
+
+// NewEventMemberRepository crea un nuevo repositorio de miembros
+func NewEventMemberRepository(db *sql.DB) *EventMemberRepository {
+	return &EventMemberRepository{db: db}
+}
+
+// GetRole devuelve el rol del usuario en el evento (ErrNotEventMember si no es miembro)
+func (r *EventMemberRepository) GetRole(eventID, userID uuid.UUID) (models.EventRole, error) {
+	var role models.EventRole
+	err := r.db.QueryRow(`SELECT role FROM event_members WHERE event_id = $1 AND user_id = $2`, eventID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", ErrNotEventMember
+	}
+	return role, err
+}
+
+// ListByEvent lista los miembros del evento, el owner primero
+func (r *EventMemberRepository) ListByEvent(eventID uuid.UUID) ([]models.EventMember, error) {
+	rows, err := r.db.Query(`
+		SELECT m.event_id, m.user_id, u.email, u.name, m.role, m.invited_by, m.created_at
+		FROM event_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.event_id = $1
+		ORDER BY m.role = 'owner' DESC, m.created_at ASC
+	`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.EventMember
+	for rows.Next() {
+		var m models.EventMember
+		if err := rows.Scan(&m.EventID, &m.UserID, &m.Email, &m.Name, &m.Role, &m.InvitedBy, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// UpdateRole cambia el rol de un miembro. El owner no cambia de rol ni se puede asignar owner.
+func (r *EventMemberRepository) UpdateRole(eventID, userID uuid.UUID, role models.EventRole) error {
+	if role == models.EventRoleOwner {
+		return ErrOwnerRoleImmutable
+	}
+	return r.changeMember(`UPDATE event_members SET role = $3 WHERE event_id = $1 AND user_id = $2 AND role <> 'owner'`, eventID, userID, role)
+}
+
+// Remove quita a un miembro del evento. El owner no se puede quitar.
+func (r *EventMemberRepository) Remove(eventID, userID uuid.UUID) error {
+	return r.changeMember(`DELETE FROM event_members WHERE event_id = $1 AND user_id = $2 AND role <> 'owner'`, eventID, userID)
+}
+
+// changeMember ejecuta un cambio sobre un miembro que no sea el owner
+func (r *EventMemberRepository) changeMember(query string, eventID, userID uuid.UUID, args ...any) error {
+	result, err := r.db.Exec(query, append([]any{eventID, userID}, args...)...)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	role, err := r.GetRole(eventID, userID)
+	if err != nil {
+		return err
+	}
+	if role == models.EventRoleOwner {
+		return ErrOwnerRoleImmutable
+	}
+	return ErrNotEventMember
+}
+
+// Invite crea una invitación para el email. Falla si ya es miembro o ya está invitado.
+func (r *EventMemberRepository) Invite(eventID uuid.UUID, email string, role models.EventRole, invitedBy uuid.UUID) (*models.EventMemberInvitation, error) {
+	if role == models.EventRoleOwner {
+		return nil, ErrOwnerRoleImmutable
+	}
+	email = strings.TrimSpace(email)
+
+	var isMember bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM event_members m JOIN users u ON u.id = m.user_id
+			WHERE m.event_id = $1 AND LOWER(u.email) = LOWER($2)
+		)
+	`, eventID, email).Scan(&isMember)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, ErrAlreadyEventMember
+	}
+
+	invitation := &models.EventMemberInvitation{EventID: eventID, Email: email, Role: role, InvitedBy: &invitedBy}
+	err = r.db.QueryRow(`
+		WITH inserted AS (
+			INSERT INTO event_member_invitations (event_id, email, role, invited_by)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at
+		)
+		SELECT inserted.id, inserted.created_at, e.slug, e.name
+		FROM inserted, events e WHERE e.id = $1
+	`, eventID, email, role, invitedBy).Scan(&invitation.ID, &invitation.CreatedAt, &invitation.EventSlug, &invitation.EventName)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrAlreadyInvited
+		}
+		return nil, err
+	}
+	return invitation, nil
+}
+
+const invitationCols = `i.id, i.event_id, e.slug, e.name, i.email, i.role, i.invited_by, i.created_at`
+
+func scanInvitations(rows *sql.Rows) ([]models.EventMemberInvitation, error) {
+	defer rows.Close()
+
+	var invitations []models.EventMemberInvitation
+	for rows.Next() {
+		var i models.EventMemberInvitation
+		if err := rows.Scan(&i.ID, &i.EventID, &i.EventSlug, &i.EventName, &i.Email, &i.Role, &i.InvitedBy, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, i)
+	}
+	return invitations, rows.Err()
+}
+
+// ListInvitationsByEvent lista las invitaciones pendientes del evento
+func (r *EventMemberRepository) ListInvitationsByEvent(eventID uuid.UUID) ([]models.EventMemberInvitation, error) {
+	rows, err := r.db.Query(`
+		SELECT `+invitationCols+`
+		FROM event_member_invitations i JOIN events e ON e.id = i.event_id
+		WHERE i.event_id = $1
+		ORDER BY i.created_at ASC
+	`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return scanInvitations(rows)
+}
+
+// ListInvitationsByEmail lista las invitaciones pendientes para un email (las del usuario logueado)
+func (r *EventMemberRepository) ListInvitationsByEmail(email string) ([]models.EventMemberInvitation, error) {
+	rows, err := r.db.Query(`
+		SELECT `+invitationCols+`
+		FROM event_member_invitations i JOIN events e ON e.id = i.event_id
+		WHERE LOWER(i.email) = LOWER($1)
+		ORDER BY i.created_at DESC
+	`, email)
+	if err != nil {
+		return nil, err
+	}
+	return scanInvitations(rows)
+}
+
+// CancelInvitation elimina una invitación pendiente del evento
+func (r *EventMemberRepository) CancelInvitation(eventID, invitationID uuid.UUID) error {
+	return deleteInvitation(r.db, `DELETE FROM event_member_invitations WHERE id = $1 AND event_id = $2`, invitationID, eventID)
+}
+
+// DeclineInvitation elimina una invitación dirigida al email del usuario
+func (r *EventMemberRepository) DeclineInvitation(invitationID uuid.UUID, email string) error {
+	return deleteInvitation(r.db, `DELETE FROM event_member_invitations WHERE id = $1 AND LOWER(email) = LOWER($2)`, invitationID, email)
+}
+
+func deleteInvitation(db *sql.DB, query string, args ...any) error {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation suma al usuario al evento con el rol de la invitación, si está dirigida a su email
+func (r *EventMemberRepository) AcceptInvitation(invitationID, userID uuid.UUID, email string) (*models.EventMember, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	member := &models.EventMember{UserID: userID, Email: email}
+	err = tx.QueryRow(`
+		DELETE FROM event_member_invitations
+		WHERE id = $1 AND LOWER(email) = LOWER($2)
+		RETURNING event_id, role, invited_by
+	`, invitationID, email).Scan(&member.EventID, &member.Role, &member.InvitedBy)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Si ya era miembro (ej. invitado dos veces con emails distintos) conserva su rol
+	err = tx.QueryRow(`
+		INSERT INTO event_members (event_id, user_id, role, invited_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, user_id) DO UPDATE SET role = event_members.role
+		RETURNING role, created_at
+	`, member.EventID, userID, member.Role, member.InvitedBy).Scan(&member.Role, &member.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// ErrNotEventMember error cuando el usuario no es miembro del evento
+var ErrNotEventMember = errors.New("user is not a member of this event")
+
+// ErrOwnerRoleImmutable error al intentar cambiar, quitar o asignar el rol owner
+var ErrOwnerRoleImmutable = errors.New("the owner role cannot be changed")
+
+// ErrAlreadyEventMember error cuando el email invitado ya es miembro del evento
+var ErrAlreadyEventMember = errors.New("user is already a member of this event")
+
+// ErrAlreadyInvited error cuando el email ya tiene una invitación pendiente al evento
+var ErrAlreadyInvited = errors.New("email already invited to this event")
+
+// ErrInvitationNotFound error cuando la invitación no existe (o no es para este usuario/evento)
+var ErrInvitationNotFound = errors.New("invitation not found")
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestEventMembersAndInvitations(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	owner := createTestUser(t, db)
+	guest := createTestUser(t, db)
+	events := NewEventRepository(db)
+	members := NewEventMemberRepository(db)
+
+	event, err := events.Create(owner.ID, "members-event", "Members", "", models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	// El creador queda como owner
+	if role, err := members.GetRole(event.ID, owner.ID); err != nil || role != models.EventRoleOwner {
+		t.Fatalf("Expected owner role, got %q (%v)", role, err)
+	}
+	if _, err := members.GetRole(event.ID, guest.ID); err != ErrNotEventMember {
+		t.Errorf("Expected ErrNotEventMember, got %v", err)
+	}
+
+	// Invitación por email (sin distinguir mayúsculas) y aceptación
+	invitation, err := members.Invite(event.ID, strings.ToUpper(guest.Email), models.EventRoleModerator, owner.ID)
+	if err != nil {
+		t.Fatalf("Failed to invite: %v", err)
+	}
+	if _, err := members.Invite(event.ID, guest.Email, models.EventRoleViewer, owner.ID); err != ErrAlreadyInvited {
+		t.Errorf("Expected ErrAlreadyInvited, got %v", err)
+	}
+	if _, err := members.AcceptInvitation(invitation.ID, owner.ID, owner.Email); err != ErrInvitationNotFound {
+		t.Errorf("Expected ErrInvitationNotFound for another email, got %v", err)
+	}
+	member, err := members.AcceptInvitation(invitation.ID, guest.ID, guest.Email)
+	if err != nil || member.Role != models.EventRoleModerator {
+		t.Fatalf("Expected moderator membership, got %+v (%v)", member, err)
+	}
+	if _, err := members.Invite(event.ID, guest.Email, models.EventRoleViewer, owner.ID); err != ErrAlreadyEventMember {
+		t.Errorf("Expected ErrAlreadyEventMember, got %v", err)
+	}
+
+	// El invitado ve el evento en su lista con su rol
+	list, err := events.ListByMember(guest.ID)
+	if err != nil || len(list) != 1 || list[0].Role != models.EventRoleModerator {
+		t.Errorf("Expected the event with role moderator, got %+v (%v)", list, err)
+	}
+
+	// El owner no se puede quitar ni cambiar de rol
+	if err := members.Remove(event.ID, owner.ID); err != ErrOwnerRoleImmutable {
+		t.Errorf("Expected ErrOwnerRoleImmutable, got %v", err)
+	}
+	if err := members.UpdateRole(event.ID, guest.ID, models.EventRoleCoHost); err != nil {
+		t.Errorf("Failed to update role: %v", err)
+	}
+	if err := members.Remove(event.ID, guest.ID); err != nil {
+		t.Errorf("Failed to remove member: %v", err)
+	}
+	if err := members.Remove(event.ID, guest.ID); err != ErrNotEventMember {
+		t.Errorf("Expected ErrNotEventMember, got %v", err)
+	}
+}
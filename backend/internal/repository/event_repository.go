@@ -21,116 +21,206 @@ func NewEventRepository(db *sql.DB) *EventRepository {
 	return &EventRepository{db: db}
 }
 
-// Create crea un nuevo evento
+// eventCols columnas de events en el orden que espera scanEvent
+const eventCols = `id, slug, owner_id, name, description, features, settings, starts_at, ends_at, is_active, created_at,
+	secret_box_token, secret_box_reveal_at, secret_box_reveal_at_event_end, status, status_changed_at,
+	access_code_hash, access_version`
+
+// publicEventFilter condición sobre la columna event_id para las rutas legacy (sin slug):
+// no pasan por EventAccessMiddleware, así que solo ven los eventos sin código de acceso
+// y las filas legacy sin evento
+func publicEventFilter(column string) string {
+	return `(` + column + ` IS NULL OR ` + column + ` IN (SELECT id FROM events WHERE access_code_hash IS NULL))`
+}
+
+// scanEvent lee una fila con eventCols
+func scanEvent(row interface {
+	Scan(...any) error
+}) (*models.Event, error) {
+	event := &models.Event{}
+	var featuresJSON, settingsJSON []byte
+
+	err := row.Scan(
+		&event.ID, &event.Slug, &event.OwnerID, &event.Name, &event.Description,
+		&featuresJSON, &settingsJSON, &event.StartsAt, &event.EndsAt, &event.IsActive, &event.CreatedAt,
+		&event.SecretBoxToken, &event.SecretBoxRevealAt, &event.SecretBoxRevealAtEventEnd, &event.Status, &event.StatusChangedAt,
+		&event.AccessCodeHash, &event.AccessVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deserializar JSONB
+	json.Unmarshal(featuresJSON, &event.Features)
+	json.Unmarshal(settingsJSON, &event.Settings)
+	event.AccessProtected = event.AccessCodeHash != nil
+
+	return event, nil
+}
+
+// Create crea un nuevo evento publicado: según sus fechas queda scheduled, live o ended
 func (r *EventRepository) Create(ownerID uuid.UUID, slug, name, description string,
 	features models.EventFeatures, settings models.EventSettings,
 	startsAt, endsAt *time.Time) (*models.Event, error) {
+	return r.create(ownerID, slug, name, description, features, settings, startsAt, endsAt, models.EventStatusScheduled)
+}
 
+// CreateDraft crea un nuevo evento en draft: solo el owner lo ve hasta publicarlo
+func (r *EventRepository) CreateDraft(ownerID uuid.UUID, slug, name, description string,
+	features models.EventFeatures, settings models.EventSettings,
+	startsAt, endsAt *time.Time) (*models.Event, error) {
+	return r.create(ownerID, slug, name, description, features, settings, startsAt, endsAt, models.EventStatusDraft)
+}
+
+func (r *EventRepository) create(ownerID uuid.UUID, slug, name, description string,
+	features models.EventFeatures, settings models.EventSettings,
+	startsAt, endsAt *time.Time, status models.EventStatus) (*models.Event, error) {
+
+	event := newEvent(ownerID, slug, name, description, features, settings, startsAt, endsAt, status)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := insertEventTx(tx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// newEvent arma un evento nuevo; un status publicado se ajusta a sus fechas
+func newEvent(ownerID uuid.UUID, slug, name, description string,
+	features models.EventFeatures, settings models.EventSettings,
+	startsAt, endsAt *time.Time, status models.EventStatus) *models.Event {
+
+	now := time.Now()
 	event := &models.Event{
-		ID:          uuid.New(),
-		Slug:        slug,
-		OwnerID:     ownerID,
-		Name:        name,
-		Description: description,
-		Features:    features,
-		Settings:    settings,
-		StartsAt:    startsAt,
-		EndsAt:      endsAt,
-		IsActive:    true,
-		CreatedAt:   time.Now(),
+		ID:              uuid.New(),
+		Slug:            slug,
+		OwnerID:         ownerID,
+		Name:            name,
+		Description:     description,
+		Features:        features,
+		Settings:        settings,
+		StartsAt:        startsAt,
+		EndsAt:          endsAt,
+		CreatedAt:       now,
+		Status:          status,
+		StatusChangedAt: now,
+	}
+	event.Status = event.DueStatus(now, 0)
+	event.IsActive = event.Status.IsActive()
+	return event
+}
+
+// insertEventTx inserta el evento y a su owner en event_members dentro de tx
+func insertEventTx(tx *sql.Tx, event *models.Event) error {
+	// Los slugs anteriores de otros eventos siguen en uso por sus links compartidos
+	var isAlias bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM event_slug_aliases WHERE slug = $1)`, event.Slug).Scan(&isAlias); err != nil {
+		return err
+	}
+	if isAlias {
+		return ErrDuplicateSlug
 	}
 
 	// Serializar JSONB
-	featuresJSON, _ := json.Marshal(features)
-	settingsJSON, _ := json.Marshal(settings)
+	featuresJSON, _ := json.Marshal(event.Features)
+	settingsJSON, _ := json.Marshal(event.Settings)
 
 	query := `
-		INSERT INTO events (id, slug, owner_id, name, description, features, settings, starts_at, ends_at, is_active, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO events (id, slug, owner_id, name, description, features, settings, starts_at, ends_at, is_active, created_at, status, status_changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
-	_, err := r.db.Exec(query,
+	_, err := tx.Exec(query,
 		event.ID, event.Slug, event.OwnerID, event.Name, event.Description,
-		featuresJSON, settingsJSON, event.StartsAt, event.EndsAt, event.IsActive, event.CreatedAt)
+		featuresJSON, settingsJSON, event.StartsAt, event.EndsAt, event.IsActive, event.CreatedAt,
+		event.Status, event.StatusChangedAt)
 
 	if err != nil {
 		// Verificar si es error de duplicado (slug único)
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
-			return nil, ErrDuplicateSlug
+			return ErrDuplicateSlug
 		}
-		return nil, err
+		return err
 	}
 
-	return event, nil
+	// El creador es el owner del evento también en event_members
+	_, err = tx.Exec(`
+		INSERT INTO event_members (event_id, user_id, role, created_at) VALUES ($1, $2, 'owner', $3)
+	`, event.ID, event.OwnerID, event.CreatedAt)
+	return err
 }
 
-// GetBySlug obtiene un evento por su slug
+// GetBySlug obtiene un evento por su slug o por un slug anterior (alias).
+// El evento devuelto siempre trae el slug actual.
 func (r *EventRepository) GetBySlug(slug string) (*models.Event, error) {
-	event := &models.Event{}
-	var featuresJSON, settingsJSON []byte
-
-	query := `
-		SELECT id, slug, owner_id, name, description, features, settings, starts_at, ends_at, is_active, created_at, secret_box_token
-		FROM events
-		WHERE slug = $1
-	`
-
-	err := r.db.QueryRow(query, slug).Scan(
-		&event.ID, &event.Slug, &event.OwnerID, &event.Name, &event.Description,
-		&featuresJSON, &settingsJSON, &event.StartsAt, &event.EndsAt, &event.IsActive, &event.CreatedAt, &event.SecretBoxToken,
-	)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrEventNotFound
-		}
-		return nil, err
+	event, err := scanEvent(r.db.QueryRow(`
+		SELECT `+eventCols+` FROM events
+		WHERE slug = $1 OR id = (SELECT event_id FROM event_slug_aliases WHERE slug = $1)
+		ORDER BY slug = $1 DESC
+		LIMIT 1
+	`, slug))
+	if err == sql.ErrNoRows {
+		return nil, ErrEventNotFound
 	}
-
-	// Deserializar JSONB
-	json.Unmarshal(featuresJSON, &event.Features)
-	json.Unmarshal(settingsJSON, &event.Settings)
-
-	return event, nil
+	return event, err
 }
 
 // GetByID obtiene un evento por su ID
 func (r *EventRepository) GetByID(id uuid.UUID) (*models.Event, error) {
-	event := &models.Event{}
-	var featuresJSON, settingsJSON []byte
+	event, err := scanEvent(r.db.QueryRow(`SELECT `+eventCols+` FROM events WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrEventNotFound
+	}
+	return event, err
+}
 
+// ListByOwner obtiene todos los eventos de un usuario
+func (r *EventRepository) ListByOwner(ownerID uuid.UUID) ([]models.Event, error) {
 	query := `
-		SELECT id, slug, owner_id, name, description, features, settings, starts_at, ends_at, is_active, created_at, secret_box_token
+		SELECT ` + eventCols + `
 		FROM events
-		WHERE id = $1
+		WHERE owner_id = $1
+		ORDER BY created_at DESC
 	`
 
-	err := r.db.QueryRow(query, id).Scan(
-		&event.ID, &event.Slug, &event.OwnerID, &event.Name, &event.Description,
-		&featuresJSON, &settingsJSON, &event.StartsAt, &event.EndsAt, &event.IsActive, &event.CreatedAt, &event.SecretBoxToken,
-	)
+	rows, err := r.db.Query(query, ownerID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrEventNotFound
-		}
 		return nil, err
 	}
+	defer rows.Close()
 
-	json.Unmarshal(featuresJSON, &event.Features)
-	json.Unmarshal(settingsJSON, &event.Settings)
+	var events []models.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
 
-	return event, nil
+	return events, nil
 }
 
-// ListByOwner obtiene todos los eventos de un usuario
-func (r *EventRepository) ListByOwner(ownerID uuid.UUID) ([]models.Event, error) {
+// ListByMember obtiene los eventos donde el usuario es miembro, con su rol en cada uno
+func (r *EventRepository) ListByMember(userID uuid.UUID) ([]models.Event, error) {
 	query := `
-		SELECT id, slug, owner_id, name, description, features, settings, starts_at, ends_at, is_active, created_at, secret_box_token
+		SELECT ` + eventCols + `,
+		       (SELECT role FROM event_members m WHERE m.event_id = events.id AND m.user_id = $1)
 		FROM events
-		WHERE owner_id = $1
+		WHERE id IN (SELECT event_id FROM event_members WHERE user_id = $1)
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.Query(query, ownerID)
+	rows, err := r.db.Query(query, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -138,24 +228,26 @@ func (r *EventRepository) ListByOwner(ownerID uuid.UUID) ([]models.Event, error)
 
 	var events []models.Event
 	for rows.Next() {
-		var event models.Event
-		var featuresJSON, settingsJSON []byte
-
-		err := rows.Scan(
-			&event.ID, &event.Slug, &event.OwnerID, &event.Name, &event.Description,
-			&featuresJSON, &settingsJSON, &event.StartsAt, &event.EndsAt, &event.IsActive, &event.CreatedAt, &event.SecretBoxToken,
-		)
+		var role models.EventRole
+		event, err := scanEvent(rowWithExtra{rows, []any{&role}})
 		if err != nil {
 			return nil, err
 		}
+		event.Role = role
+		events = append(events, *event)
+	}
 
-		json.Unmarshal(featuresJSON, &event.Features)
-		json.Unmarshal(settingsJSON, &event.Settings)
+	return events, rows.Err()
+}
 
-		events = append(events, event)
-	}
+// rowWithExtra escanea columnas extra después de las que lee scanEvent
+type rowWithExtra struct {
+	row   interface{ Scan(...any) error }
+	extra []any
+}
 
-	return events, nil
+func (r rowWithExtra) Scan(dest ...any) error {
+	return r.row.Scan(append(dest, r.extra...)...)
 }
 
 // Update actualiza un evento
@@ -177,6 +269,196 @@ func (r *EventRepository) Update(event *models.Event) error {
 	return err
 }
 
+// UpdateDetails actualiza slug, nombre, descripción y fechas del evento.
+// Si el slug cambió, previousSlug queda como alias para los links y QR ya compartidos.
+// Un reveal de la Secret Box programado con at_event_end se mueve con ends_at.
+func (r *EventRepository) UpdateDetails(event *models.Event, previousSlug string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		UPDATE events SET slug = $1, name = $2, description = $3, starts_at = $4, ends_at = $5,
+		    secret_box_reveal_at = CASE
+		        WHEN secret_box_reveal_at IS NOT NULL AND secret_box_reveal_at_event_end
+		        THEN COALESCE($5, secret_box_reveal_at)
+		        ELSE secret_box_reveal_at
+		    END
+		WHERE id = $6
+		RETURNING secret_box_reveal_at
+	`, event.Slug, event.Name, event.Description, event.StartsAt, event.EndsAt, event.ID).Scan(&event.SecretBoxRevealAt)
+	if err == sql.ErrNoRows {
+		return ErrEventNotFound
+	}
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrDuplicateSlug
+		}
+		return err
+	}
+
+	if event.Slug != previousSlug {
+		// El slug nuevo puede ser un alias de este mismo evento (vuelve a un slug anterior),
+		// pero no de otro: sus links compartidos dejarían de llevar a él
+		var aliasOf uuid.UUID
+		err := tx.QueryRow(`SELECT event_id FROM event_slug_aliases WHERE slug = $1`, event.Slug).Scan(&aliasOf)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return err
+		case aliasOf != event.ID:
+			return ErrDuplicateSlug
+		default:
+			if _, err := tx.Exec(`DELETE FROM event_slug_aliases WHERE slug = $1`, event.Slug); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO event_slug_aliases (slug, event_id) VALUES ($1, $2)
+		`, previousSlug, event.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetSecretBoxRevealAt programa (o cancela, con nil) el reveal automático de la Secret Box.
+// atEventEnd indica que el reveal sigue a ends_at (ver UpdateDetails).
+// No pasa por Update para no pisar un reveal que el scheduler acaba de ejecutar.
+func (r *EventRepository) SetSecretBoxRevealAt(eventID uuid.UUID, revealAt *time.Time, atEventEnd bool) error {
+	result, err := r.db.Exec(`
+		UPDATE events SET secret_box_reveal_at = $1, secret_box_reveal_at_event_end = $2 WHERE id = $3
+	`, revealAt, atEventEnd && revealAt != nil, eventID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrEventNotFound
+	}
+	return nil
+}
+
+// SetAccessCode protege el evento con un código (hash bcrypt) o lo vuelve público con nil.
+// Incrementa access_version, así los tokens de acceso ya emitidos dejan de valer.
+// Devuelve la nueva versión.
+func (r *EventRepository) SetAccessCode(eventID uuid.UUID, codeHash *string) (int, error) {
+	var version int
+	err := r.db.QueryRow(`
+		UPDATE events SET access_code_hash = $1, access_version = access_version + 1
+		WHERE id = $2
+		RETURNING access_version
+	`, codeHash, eventID).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, ErrEventNotFound
+	}
+	return version, err
+}
+
+// RevokeAccess invalida los tokens de acceso emitidos sin cambiar el código
+func (r *EventRepository) RevokeAccess(eventID uuid.UUID) (int, error) {
+	var version int
+	err := r.db.QueryRow(`
+		UPDATE events SET access_version = access_version + 1 WHERE id = $1 RETURNING access_version
+	`, eventID).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, ErrEventNotFound
+	}
+	return version, err
+}
+
+// SetStatus cambia el estado del evento (acción manual del owner) y mantiene is_active.
+// Solo aplica si el estado sigue siendo from, para no pisar una transición automática.
+func (r *EventRepository) SetStatus(eventID uuid.UUID, from, to models.EventStatus) error {
+	result, err := r.db.Exec(`
+		UPDATE events SET status = $1, status_changed_at = NOW(), is_active = $2
+		WHERE id = $3 AND status = $4
+	`, to, to.IsActive(), eventID, from)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrEventStatusChanged
+	}
+	return nil
+}
+
+// AdvanceDueStatuses aplica las transiciones automáticas que vencieron (ver Event.DueStatus)
+// y devuelve los cambios. Los eventos se toman con SKIP LOCKED, así con varias instancias
+// cada transición se aplica (y se avisa) una sola vez.
+func (r *EventRepository) AdvanceDueStatuses(archiveAfter time.Duration, limit int) ([]models.EventStatusChange, error) {
+	now := time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT `+eventCols+`
+		FROM events
+		WHERE (status = 'scheduled' AND (starts_at IS NULL OR starts_at <= $1 OR ends_at <= $1))
+		   OR (status = 'live' AND ends_at <= $1)
+		   OR (status = 'ended' AND $2 AND status_changed_at <= $3)
+		ORDER BY status_changed_at
+		LIMIT $4
+		FOR UPDATE SKIP LOCKED
+	`, now, archiveAfter > 0, now.Add(-archiveAfter), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []*models.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		due = append(due, event)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var changes []models.EventStatusChange
+	for _, event := range due {
+		next := event.DueStatus(now, archiveAfter)
+		if next == event.Status {
+			continue
+		}
+		if _, err := tx.Exec(`
+			UPDATE events SET status = $1, status_changed_at = $2, is_active = $3 WHERE id = $4
+		`, next, now, next.IsActive(), event.ID); err != nil {
+			return nil, err
+		}
+		changes = append(changes, models.EventStatusChange{
+			EventID:   event.ID,
+			EventSlug: event.Slug,
+			From:      event.Status,
+			To:        next,
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
 // Delete elimina un evento (cascade elimina todo lo relacionado)
 func (r *EventRepository) Delete(id uuid.UUID) error {
 	query := `DELETE FROM events WHERE id = $1`
@@ -189,3 +471,6 @@ var ErrDuplicateSlug = errors.New("event slug already exists")
 
 // ErrEventNotFound error cuando el evento no existe
 var ErrEventNotFound = errors.New("event not found")
+
+// ErrEventStatusChanged error cuando el estado del evento cambió antes de aplicar la transición
+var ErrEventStatusChanged = errors.New("event status changed")
@@ -257,6 +257,105 @@ func TestGetEventBySlugNotFound(t *testing.T) {
 	}
 }
 
+func TestUpdateEventDetailsRenameKeepsAlias(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	repo := NewEventRepository(db)
+
+	event, _ := repo.Create(user.ID, "old-slug", "Old Name", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	other, _ := repo.Create(user.ID, "other-slug", "Other", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, nil)
+
+	event.Slug = "new-slug"
+	event.Name = "New Name"
+	if err := repo.UpdateDetails(event, "old-slug"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// El slug anterior sigue resolviendo al evento, con el slug actual
+	found, err := repo.GetBySlug("old-slug")
+	if err != nil {
+		t.Fatalf("Expected old slug to resolve, got: %v", err)
+	}
+	if found.ID != event.ID || found.Slug != "new-slug" || found.Name != "New Name" {
+		t.Errorf("Expected renamed event, got %s (%s)", found.Slug, found.Name)
+	}
+
+	// El alias no se puede usar en otro evento ni para un evento nuevo
+	other.Slug = "old-slug"
+	if err := repo.UpdateDetails(other, "other-slug"); err != ErrDuplicateSlug {
+		t.Errorf("Expected ErrDuplicateSlug renaming to an alias, got: %v", err)
+	}
+	if _, err := repo.Create(user.ID, "old-slug", "Copy", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, nil); err != ErrDuplicateSlug {
+		t.Errorf("Expected ErrDuplicateSlug creating with an alias, got: %v", err)
+	}
+
+	// Pero el mismo evento puede volver a su slug anterior
+	event.Slug = "old-slug"
+	if err := repo.UpdateDetails(event, "new-slug"); err != nil {
+		t.Fatalf("Expected no error going back to the old slug, got: %v", err)
+	}
+	found, err = repo.GetBySlug("new-slug")
+	if err != nil || found.ID != event.ID || found.Slug != "old-slug" {
+		t.Errorf("Expected new-slug to be an alias of the event, got %v", err)
+	}
+}
+
+func TestUpdateEventDetailsMovesRevealAtEventEnd(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	repo := NewEventRepository(db)
+
+	endsAt := time.Now().Add(6 * time.Hour).UTC().Truncate(time.Second)
+	linked, _ := repo.Create(user.ID, "linked-reveal", "Linked", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, &endsAt)
+	fixed, _ := repo.Create(user.ID, "fixed-reveal", "Fixed", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, &endsAt)
+
+	fixedAt := endsAt.Add(-time.Hour)
+	if err := repo.SetSecretBoxRevealAt(linked.ID, &endsAt, true); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := repo.SetSecretBoxRevealAt(fixed.ID, &fixedAt, false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// El owner corre el fin de ambos eventos dos horas
+	later := endsAt.Add(2 * time.Hour)
+	for _, event := range []*models.Event{linked, fixed} {
+		event.EndsAt = &later
+		if err := repo.UpdateDetails(event, event.Slug); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	found, _ := repo.GetByID(linked.ID)
+	if found.SecretBoxRevealAt == nil || !found.SecretBoxRevealAt.Equal(later) || !found.SecretBoxRevealAtEventEnd {
+		t.Errorf("Expected the at_event_end reveal to follow ends_at to %v, got %v", later, found.SecretBoxRevealAt)
+	}
+	found, _ = repo.GetByID(fixed.ID)
+	if found.SecretBoxRevealAt == nil || !found.SecretBoxRevealAt.Equal(fixedAt) {
+		t.Errorf("Expected the fixed reveal to stay at %v, got %v", fixedAt, found.SecretBoxRevealAt)
+	}
+
+	// Cancelar borra también el vínculo con ends_at
+	if err := repo.SetSecretBoxRevealAt(linked.ID, nil, false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	found, _ = repo.GetByID(linked.ID)
+	if found.SecretBoxRevealAt != nil || found.SecretBoxRevealAtEventEnd {
+		t.Errorf("Expected no reveal after cancel, got %v", found.SecretBoxRevealAt)
+	}
+}
+
 func TestListEventsByOwner(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -286,6 +385,53 @@ func TestListEventsByOwner(t *testing.T) {
 
 // ========== TESTS PARA QuizQuestionRepository ==========
 
+func TestAdvanceDueEventStatuses(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	repo := NewEventRepository(db)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	// Publicado con fechas futuras: scheduled; draft no avanza solo
+	scheduled, _ := repo.Create(user.ID, "scheduled-event", "Scheduled", "", models.EventFeatures{}, models.EventSettings{}, &future, nil)
+	if scheduled.Status != models.EventStatusScheduled || !scheduled.IsActive {
+		t.Fatalf("Expected a scheduled, active event, got %s", scheduled.Status)
+	}
+	draft, _ := repo.CreateDraft(user.ID, "draft-event", "Draft", "", models.EventFeatures{}, models.EventSettings{}, &past, &past)
+	live, _ := repo.Create(user.ID, "live-event", "Live", "", models.EventFeatures{}, models.EventSettings{}, &past, nil)
+
+	// Mover las fechas al pasado: le toca empezar y terminar
+	db.Exec(`UPDATE events SET starts_at = $1, ends_at = $1 WHERE id = $2`, past, scheduled.ID)
+
+	changes, err := repo.AdvanceDueStatuses(30*24*time.Hour, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(changes) != 1 || changes[0].EventID != scheduled.ID || changes[0].To != models.EventStatusEnded {
+		t.Fatalf("Expected only scheduled-event to end, got %+v", changes)
+	}
+
+	ended, _ := repo.GetByID(scheduled.ID)
+	if ended.Status != models.EventStatusEnded || ended.IsActive {
+		t.Errorf("Expected ended and inactive, got %s (active=%v)", ended.Status, ended.IsActive)
+	}
+	for _, e := range []*models.Event{draft, live} {
+		got, _ := repo.GetByID(e.ID)
+		if got.Status != e.Status {
+			t.Errorf("Expected %s to stay %s, got %s", e.Slug, e.Status, got.Status)
+		}
+	}
+
+	// La acción manual no pisa un estado que ya cambió
+	if err := repo.SetStatus(scheduled.ID, models.EventStatusLive, models.EventStatusArchived); err != ErrEventStatusChanged {
+		t.Errorf("Expected ErrEventStatusChanged, got %v", err)
+	}
+}
+
 func TestCreateQuizQuestion(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -299,7 +445,7 @@ func TestCreateQuizQuestion(t *testing.T) {
 		models.EventFeatures{Quiz: true}, models.EventSettings{}, nil, nil)
 
 	question, err := questionRepo.Create(event.ID, "favorites", "singer", "¿Cantante favorito?",
-		[]string{"Taylor Swift", "taylor"}, nil, 1, true)
+		[]string{"Taylor Swift", "taylor"}, nil, 1, true, defaultScoring)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
@@ -333,11 +479,11 @@ func TestListQuizQuestionsByEvent(t *testing.T) {
 		models.EventFeatures{Quiz: true}, models.EventSettings{}, nil, nil)
 
 	// Crear preguntas para event1
-	questionRepo.Create(event1.ID, "favorites", "singer", "¿Cantante?", []string{"Taylor"}, nil, 1, true)
-	questionRepo.Create(event1.ID, "preferences", "coffee_or_tea", "¿Café o té?", []string{"Café"}, []string{"Café", "Té"}, 2, true)
+	questionRepo.Create(event1.ID, "favorites", "singer", "¿Cantante?", []string{"Taylor"}, nil, 1, true, defaultScoring)
+	questionRepo.Create(event1.ID, "preferences", "coffee_or_tea", "¿Café o té?", []string{"Café"}, []string{"Café", "Té"}, 2, true, defaultScoring)
 
 	// Crear pregunta para event2
-	questionRepo.Create(event2.ID, "favorites", "flower", "¿Flor?", []string{"Rosa"}, nil, 1, true)
+	questionRepo.Create(event2.ID, "favorites", "flower", "¿Flor?", []string{"Rosa"}, nil, 1, true, defaultScoring)
 
 	// Listar preguntas de event1
 	questions, err := questionRepo.ListByEvent(event1.ID)
@@ -365,7 +511,7 @@ func TestQuizQuestionsIsolation(t *testing.T) {
 		models.EventFeatures{Quiz: true}, models.EventSettings{}, nil, nil)
 
 	// Crear pregunta específica para eventA
-	questionRepo.Create(eventA.ID, "favorites", "only-in-a", "¿Solo en A?", []string{"Sí"}, nil, 1, true)
+	questionRepo.Create(eventA.ID, "favorites", "only-in-a", "¿Solo en A?", []string{"Sí"}, nil, 1, true, defaultScoring)
 
 	// Listar preguntas de eventB
 	questions, _ := questionRepo.ListByEvent(eventB.ID)
@@ -427,8 +573,8 @@ func TestListPostcardsByEvent(t *testing.T) {
 	player2, _ := playerRepo.CreateWithEvent(event2.ID, "Player 2", "👤")
 
 	// Crear postcards
-	postcardRepo.CreateWithEvent(event1.ID, &player1.ID, "/uploads/1.jpg", "Message 1", 0, nil, "image", nil, nil)
-	postcardRepo.CreateWithEvent(event2.ID, &player2.ID, "/uploads/2.jpg", "Message 2", 0, nil, "image", nil, nil)
+	postcardRepo.CreateWithEvent(event1.ID, &player1.ID, "/uploads/1.jpg", "Message 1", 0, nil, "image", nil, nil, nil)
+	postcardRepo.CreateWithEvent(event2.ID, &player2.ID, "/uploads/2.jpg", "Message 2", 0, nil, "image", nil, nil, nil)
 
 	// Listar postcards de event1
 	postcards, err := postcardRepo.ListByEvent(event1.ID)
@@ -459,3 +605,50 @@ func TestMigrationBackfill(t *testing.T) {
 	// Por ahora, este test es un placeholder que documenta el requisito
 	t.Log("Migration backfill test: Verifies existing data is assigned to legacy event")
 }
+
+func TestEventAccessCode(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	repo := NewEventRepository(db)
+
+	event, _ := repo.Create(user.ID, "private-party", "Private Party", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	if event.AccessProtected {
+		t.Fatal("Expected new events to be public")
+	}
+
+	hash := "$2a$10$hash"
+	version, err := repo.SetAccessCode(event.ID, &hash)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	found, _ := repo.GetBySlug("private-party")
+	if !found.AccessProtected || found.AccessCodeHash == nil || *found.AccessCodeHash != hash {
+		t.Errorf("Expected event protected with the stored hash, got %+v", found.AccessCodeHash)
+	}
+	if found.AccessVersion != version {
+		t.Errorf("Expected access version %d, got %d", version, found.AccessVersion)
+	}
+
+	// Revocar sesiones sube la versión sin tocar el código
+	revoked, err := repo.RevokeAccess(event.ID)
+	if err != nil || revoked != version+1 {
+		t.Errorf("Expected version %d, got %d (%v)", version+1, revoked, err)
+	}
+
+	if _, err := repo.SetAccessCode(event.ID, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	found, _ = repo.GetBySlug("private-party")
+	if found.AccessProtected {
+		t.Error("Expected event to be public after removing the code")
+	}
+
+	if _, err := repo.RevokeAccess(uuid.New()); err != ErrEventNotFound {
+		t.Errorf("Expected ErrEventNotFound, got: %v", err)
+	}
+}
@@ -0,0 +1,427 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// EventTemplateRepository duplica eventos y maneja los templates personales.
+// Los archivos de media los copia quien llama: acá solo se reemplazan sus URLs.
+type EventTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewEventTemplateRepository crea un nuevo repositorio de templates
+func NewEventTemplateRepository(db *sql.DB) *EventTemplateRepository {
+	return &EventTemplateRepository{db: db}
+}
+
+// remapURL devuelve la URL de la copia de un archivo, o la original si no se copió
+func remapURL(mediaURLs map[string]string, url string) string {
+	if copied, ok := mediaURLs[url]; ok {
+		return copied
+	}
+	return url
+}
+
+// remapSettings reemplaza las URLs de logo y fondo por las de sus copias
+func remapSettings(settings models.EventSettings, mediaURLs map[string]string) models.EventSettings {
+	settings.LogoURL = remapURL(mediaURLs, settings.LogoURL)
+	settings.BackgroundURL = remapURL(mediaURLs, settings.BackgroundURL)
+	settings.BackgroundImage = remapURL(mediaURLs, settings.BackgroundImage)
+	return settings
+}
+
+// CloneEvent duplica source en un evento draft nuevo de ownerID: features, settings,
+// theme y preguntas, y según opts también jugadores (sin respuestas ni puntaje) y
+// postales con la media lista. mediaURLs mapea cada URL de media a la de su copia.
+func (r *EventTemplateRepository) CloneEvent(source *models.Event, ownerID uuid.UUID, slug, name string,
+	startsAt, endsAt *time.Time, opts models.CloneOptions, mediaURLs map[string]string) (*models.Event, error) {
+
+	event := newEvent(ownerID, slug, name, source.Description, source.Features,
+		remapSettings(source.Settings, mediaURLs), startsAt, endsAt, models.EventStatusDraft)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := insertEventTx(tx, event); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO themes (event_id, primary_color, secondary_color, accent_color, bg_color, text_color,
+			display_font, heading_font, body_font, logo_path, hero_image_path, background_style)
+		SELECT $2, primary_color, secondary_color, accent_color, bg_color, text_color,
+			display_font, heading_font, body_font, logo_path, hero_image_path, background_style
+		FROM themes WHERE event_id = $1
+	`, source.ID, event.ID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO quiz_questions (id, event_id, section, key, question_text, correct_answers, options,
+			sort_order, is_scorable, points, partial_credit, fuzzy_match, created_at)
+		SELECT gen_random_uuid(), $2, section, key, question_text, correct_answers, options,
+			sort_order, is_scorable, points, partial_credit, fuzzy_match, $3
+		FROM quiz_questions WHERE event_id = $1
+	`, source.ID, event.ID, event.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	// Jugador original → copia, para que las postales sigan siendo de su autor
+	players := make(map[uuid.UUID]uuid.UUID)
+	if !opts.ExcludeGuests {
+		if players, err = clonePlayersTx(tx, source.ID, event.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.ExcludePostcards {
+		if err := clonePostcardsTx(tx, source.ID, event.ID, players, mediaURLs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// clonePlayersTx copia los jugadores de un evento a otro con puntaje 0.
+// Devuelve el ID de cada copia indexado por el ID original.
+func clonePlayersTx(tx *sql.Tx, sourceID, eventID uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	rows, err := tx.Query(`SELECT id, name, avatar, created_at FROM players WHERE event_id = $1`, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	type player struct {
+		id        uuid.UUID
+		name      string
+		avatar    sql.NullString
+		createdAt sql.NullTime
+	}
+	var players []player
+	for rows.Next() {
+		var p player
+		if err := rows.Scan(&p.id, &p.name, &p.avatar, &p.createdAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[uuid.UUID]uuid.UUID, len(players))
+	for _, p := range players {
+		id := uuid.New()
+		if _, err := tx.Exec(`
+			INSERT INTO players (id, event_id, name, avatar, score, created_at) VALUES ($1, $2, $3, $4, 0, $5)
+		`, id, eventID, p.name, p.avatar, p.createdAt); err != nil {
+			return nil, err
+		}
+		ids[p.id] = id
+	}
+	return ids, nil
+}
+
+// clonePostcardsTx copia las postales con la media lista de un evento a otro.
+// Sin la copia del jugador, la postal queda firmada con su nombre (sender_name).
+func clonePostcardsTx(tx *sql.Tx, sourceID, eventID uuid.UUID, players map[uuid.UUID]uuid.UUID, mediaURLs map[string]string) error {
+	rows, err := tx.Query(`
+		SELECT p.player_id, COALESCE(p.sender_name, pl.name), p.image_path, p.message, p.rotation,
+			p.is_secret, p.revealed_at, p.created_at, p.media_type, p.thumbnail_path, p.media_duration_ms,
+			p.moderation_status, p.moderated_at, p.image_thumb_path, p.image_board_path, p.reveal_position
+		FROM postcards p
+		LEFT JOIN players pl ON p.player_id = pl.id
+		WHERE p.event_id = $1 AND p.media_status = 'ready'
+		ORDER BY p.created_at
+	`, sourceID)
+	if err != nil {
+		return err
+	}
+
+	type postcard struct {
+		playerID                       *uuid.UUID
+		senderName                     sql.NullString
+		imagePath, message             string
+		rotation                       float64
+		isSecret                       bool
+		revealedAt, createdAt          sql.NullTime
+		moderatedAt                    sql.NullTime
+		mediaType, moderationStatus    string
+		thumbnailPath                  sql.NullString
+		imageThumbPath, imageBoardPath sql.NullString
+		mediaDurationMs                sql.NullInt64
+		revealPosition                 sql.NullInt64
+	}
+	var postcards []postcard
+	for rows.Next() {
+		var p postcard
+		if err := rows.Scan(&p.playerID, &p.senderName, &p.imagePath, &p.message, &p.rotation,
+			&p.isSecret, &p.revealedAt, &p.createdAt, &p.mediaType, &p.thumbnailPath, &p.mediaDurationMs,
+			&p.moderationStatus, &p.moderatedAt, &p.imageThumbPath, &p.imageBoardPath, &p.revealPosition); err != nil {
+			rows.Close()
+			return err
+		}
+		postcards = append(postcards, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	remapNull := func(path sql.NullString) sql.NullString {
+		if path.Valid {
+			path.String = remapURL(mediaURLs, path.String)
+		}
+		return path
+	}
+
+	for _, p := range postcards {
+		var playerID *uuid.UUID
+		if p.playerID != nil {
+			if id, ok := players[*p.playerID]; ok {
+				playerID = &id
+			}
+		}
+		// sender_name solo queda en las secretas o sin jugador copiado
+		senderName := p.senderName
+		if playerID != nil && !p.isSecret {
+			senderName = sql.NullString{}
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO postcards (id, event_id, player_id, sender_name, image_path, message, rotation,
+				is_secret, revealed_at, created_at, media_type, thumbnail_path, media_duration_ms,
+				moderation_status, moderated_at, image_thumb_path, image_board_path, reveal_position, media_status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, 'ready')
+		`, uuid.New(), eventID, playerID, senderName, remapURL(mediaURLs, p.imagePath), p.message, p.rotation,
+			p.isSecret, p.revealedAt, p.createdAt, p.mediaType, remapNull(p.thumbnailPath), p.mediaDurationMs,
+			p.moderationStatus, p.moderatedAt, remapNull(p.imageThumbPath), remapNull(p.imageBoardPath), p.revealPosition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTemplate guarda el evento como template de ownerID: features, settings
+// (con las URLs de mediaURLs), theme y preguntas actuales del evento
+func (r *EventTemplateRepository) CreateTemplate(ownerID uuid.UUID, source *models.Event, name, description string,
+	mediaURLs map[string]string) (*models.EventTemplate, error) {
+
+	template := &models.EventTemplate{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Name:        name,
+		Description: description,
+		Features:    source.Features,
+		Settings:    remapSettings(source.Settings, mediaURLs),
+		Questions:   []models.TemplateQuestion{},
+		CreatedAt:   time.Now(),
+	}
+
+	theme := &models.ThemePreset{Name: source.Settings.Theme}
+	err := r.db.QueryRow(`
+		SELECT primary_color, secondary_color, accent_color, bg_color, text_color,
+			display_font, heading_font, body_font, background_style
+		FROM themes WHERE event_id = $1
+	`, source.ID).Scan(&theme.PrimaryColor, &theme.SecondaryColor, &theme.AccentColor, &theme.BgColor,
+		&theme.TextColor, &theme.DisplayFont, &theme.HeadingFont, &theme.BodyFont, &theme.BackgroundStyle)
+	if err == nil {
+		template.Theme = theme
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT `+quizQuestionCols+` FROM quiz_questions WHERE event_id = $1 ORDER BY section, sort_order`, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		q, err := scanQuizQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		template.Questions = append(template.Questions, models.TemplateQuestion{
+			Section:         q.Section,
+			Key:             q.Key,
+			QuestionText:    q.QuestionText,
+			CorrectAnswers:  q.CorrectAnswers,
+			Options:         q.Options,
+			SortOrder:       q.SortOrder,
+			IsScorable:      q.IsScorable,
+			QuestionScoring: q.QuestionScoring,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	featuresJSON, _ := json.Marshal(template.Features)
+	settingsJSON, _ := json.Marshal(template.Settings)
+	questionsJSON, _ := json.Marshal(template.Questions)
+	var themeJSON []byte
+	if template.Theme != nil {
+		themeJSON, _ = json.Marshal(template.Theme)
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO event_templates (id, owner_id, name, description, features, settings, theme, questions, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, template.ID, template.OwnerID, template.Name, template.Description,
+		featuresJSON, settingsJSON, themeJSON, questionsJSON, template.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// templateCols columnas seleccionadas por scanTemplate
+const templateCols = `id, owner_id, name, description, features, settings, theme, questions, created_at`
+
+// scanTemplate escanea una fila con templateCols y deserializa los JSONB
+func scanTemplate(row interface{ Scan(...any) error }) (*models.EventTemplate, error) {
+	template := &models.EventTemplate{}
+	var featuresJSON, settingsJSON, themeJSON, questionsJSON []byte
+
+	err := row.Scan(&template.ID, &template.OwnerID, &template.Name, &template.Description,
+		&featuresJSON, &settingsJSON, &themeJSON, &questionsJSON, &template.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(featuresJSON, &template.Features)
+	json.Unmarshal(settingsJSON, &template.Settings)
+	if len(themeJSON) > 0 {
+		json.Unmarshal(themeJSON, &template.Theme)
+	}
+	json.Unmarshal(questionsJSON, &template.Questions)
+	if template.Questions == nil {
+		template.Questions = []models.TemplateQuestion{}
+	}
+
+	return template, nil
+}
+
+// GetTemplate obtiene un template por ID
+func (r *EventTemplateRepository) GetTemplate(id uuid.UUID) (*models.EventTemplate, error) {
+	template, err := scanTemplate(r.db.QueryRow(`SELECT `+templateCols+` FROM event_templates WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// ListTemplatesByOwner obtiene los templates de un usuario, los más nuevos primero
+func (r *EventTemplateRepository) ListTemplatesByOwner(ownerID uuid.UUID) ([]models.EventTemplate, error) {
+	rows, err := r.db.Query(`
+		SELECT `+templateCols+` FROM event_templates WHERE owner_id = $1 ORDER BY created_at DESC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []models.EventTemplate
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *template)
+	}
+
+	return templates, rows.Err()
+}
+
+// DeleteTemplate elimina un template. Los archivos de media los borra quien llama.
+func (r *EventTemplateRepository) DeleteTemplate(id uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM event_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// CreateEventFromTemplate crea un evento de ownerID con la configuración del template:
+// features, settings (con las URLs de mediaURLs), theme y preguntas
+func (r *EventTemplateRepository) CreateEventFromTemplate(template *models.EventTemplate, ownerID uuid.UUID,
+	slug, name, description string, startsAt, endsAt *time.Time, draft bool, mediaURLs map[string]string) (*models.Event, error) {
+
+	status := models.EventStatusScheduled
+	if draft {
+		status = models.EventStatusDraft
+	}
+	event := newEvent(ownerID, slug, name, description, template.Features,
+		remapSettings(template.Settings, mediaURLs), startsAt, endsAt, status)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := insertEventTx(tx, event); err != nil {
+		return nil, err
+	}
+
+	if theme := template.Theme; theme != nil {
+		if _, err := tx.Exec(`
+			INSERT INTO themes (event_id, primary_color, secondary_color, accent_color, bg_color, text_color,
+				display_font, heading_font, body_font, background_style)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, event.ID, theme.PrimaryColor, theme.SecondaryColor, theme.AccentColor, theme.BgColor, theme.TextColor,
+			theme.DisplayFont, theme.HeadingFont, theme.BodyFont, theme.BackgroundStyle); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, q := range template.Questions {
+		correctAnswersJSON, _ := json.Marshal(q.CorrectAnswers)
+		optionsJSON, _ := json.Marshal(q.Options)
+		if _, err := tx.Exec(`
+			INSERT INTO quiz_questions (id, event_id, section, key, question_text, correct_answers, options,
+				sort_order, is_scorable, points, partial_credit, fuzzy_match, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		`, uuid.New(), event.ID, q.Section, q.Key, q.QuestionText, correctAnswersJSON, optionsJSON,
+			q.SortOrder, q.IsScorable, q.Points, marshalPartialCredit(q.PartialCredit), marshalFuzzyMatch(q.Fuzzy),
+			event.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ErrTemplateNotFound el template no existe
+var ErrTemplateNotFound = errors.New("event template not found")
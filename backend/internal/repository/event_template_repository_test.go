@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestCloneEventAndTemplates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	owner := createTestUser(t, db)
+	other := createTestUser(t, db)
+	events := NewEventRepository(db)
+	questions := NewQuizQuestionRepository(db)
+	players := NewPlayerRepository(db)
+	postcards := NewPostcardRepository(db, "")
+	templates := NewEventTemplateRepository(db)
+
+	source, err := events.Create(owner.ID, "clone-source", "Source", "Desc",
+		models.EventFeatures{Quiz: true, Corkboard: true}, models.EventSettings{LogoURL: "/uploads/logos/a.png"}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	if _, err := questions.Create(source.ID, "favorites", "singer", "¿Cantante?", []string{"Taylor"}, nil, 1, true,
+		models.QuestionScoring{Points: 2}); err != nil {
+		t.Fatalf("Failed to create question: %v", err)
+	}
+	player, err := players.CreateWithEvent(source.ID, "Ana", "🎉")
+	if err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+	if _, err := postcards.CreateWithEvent(source.ID, &player.ID, "/uploads/postcards/p.jpg", "Hola", 0, nil, "image", nil, nil, nil); err != nil {
+		t.Fatalf("Failed to create postcard: %v", err)
+	}
+
+	mediaURLs := map[string]string{
+		"/uploads/logos/a.png":     "/uploads/logos/b.png",
+		"/uploads/postcards/p.jpg": "/uploads/postcards/q.jpg",
+	}
+
+	// Copia completa: draft del nuevo owner, con preguntas, jugadores y postales
+	clone, err := templates.CloneEvent(source, other.ID, "clone-copy", "Copy", nil, nil, models.CloneOptions{}, mediaURLs)
+	if err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+	if clone.Status != models.EventStatusDraft || clone.OwnerID != other.ID || clone.Settings.LogoURL != "/uploads/logos/b.png" {
+		t.Errorf("Unexpected clone: %+v", clone)
+	}
+	if n, _ := questions.CountByEvent(clone.ID); n != 1 {
+		t.Errorf("Expected 1 question, got %d", n)
+	}
+	if list, _ := players.ListByEvent(clone.ID); len(list) != 1 || list[0].Score != 0 {
+		t.Errorf("Expected 1 player with score 0, got %+v", list)
+	}
+	paths, _ := postcards.ListMediaPathsByEvent(clone.ID)
+	if len(paths) != 1 || paths[0] != "/uploads/postcards/q.jpg" {
+		t.Errorf("Expected remapped postcard media, got %v", paths)
+	}
+	if _, err := templates.CloneEvent(source, other.ID, "clone-copy", "Copy", nil, nil, models.CloneOptions{}, nil); err != ErrDuplicateSlug {
+		t.Errorf("Expected ErrDuplicateSlug, got %v", err)
+	}
+
+	// Copia sin invitados ni postales
+	bare, err := templates.CloneEvent(source, owner.ID, "clone-bare", "Bare", nil, nil,
+		models.CloneOptions{ExcludeGuests: true, ExcludePostcards: true}, nil)
+	if err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+	if list, _ := players.ListByEvent(bare.ID); len(list) != 0 {
+		t.Errorf("Expected no players, got %d", len(list))
+	}
+	if paths, _ := postcards.ListMediaPathsByEvent(bare.ID); len(paths) != 0 {
+		t.Errorf("Expected no postcards, got %v", paths)
+	}
+
+	// Template y evento creado desde él
+	template, err := templates.CreateTemplate(owner.ID, source, "Mi template", "", nil)
+	if err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	if len(template.Questions) != 1 || template.Questions[0].Points != 2 {
+		t.Errorf("Expected the question in the template, got %+v", template.Questions)
+	}
+	if list, _ := templates.ListTemplatesByOwner(owner.ID); len(list) != 1 {
+		t.Errorf("Expected 1 template, got %d", len(list))
+	}
+
+	fromTemplate, err := templates.CreateEventFromTemplate(template, owner.ID, "from-template", "New", "", nil, nil, false, nil)
+	if err != nil {
+		t.Fatalf("Failed to create from template: %v", err)
+	}
+	if !fromTemplate.Features.Quiz || fromTemplate.Status != models.EventStatusLive {
+		t.Errorf("Unexpected event from template: %+v", fromTemplate)
+	}
+	if n, _ := questions.CountByEvent(fromTemplate.ID); n != 1 {
+		t.Errorf("Expected 1 question, got %d", n)
+	}
+
+	if err := templates.DeleteTemplate(template.ID); err != nil {
+		t.Fatalf("Failed to delete template: %v", err)
+	}
+	if _, err := templates.GetTemplate(template.ID); err != ErrTemplateNotFound {
+		t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+	}
+}
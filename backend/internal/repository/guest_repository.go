@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// GuestRepository maneja la lista de invitados de los eventos y sus RSVPs
+type GuestRepository struct {
+	db *sql.DB
+}
+
+// NewGuestRepository crea un nuevo repositorio de invitados
+func NewGuestRepository(db *sql.DB) *GuestRepository {
+	return &GuestRepository{db: db}
+}
+
+// guestCols columnas de guest_invitations en el orden que espera scanGuest
+const guestCols = `id, event_id, name, email, phone, token, max_plus_ones, rsvp_status, plus_ones, rsvp_at, player_id, created_at`
+
+// scanGuest lee una fila con guestCols
+func scanGuest(row interface{ Scan(...any) error }) (*models.GuestInvitation, error) {
+	g := &models.GuestInvitation{}
+	err := row.Scan(&g.ID, &g.EventID, &g.Name, &g.Email, &g.Phone, &g.Token, &g.MaxPlusOnes,
+		&g.RSVPStatus, &g.PlusOnes, &g.RSVPAt, &g.PlayerID, &g.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// newGuestToken genera el token del link personal (128 bits, hex)
+func newGuestToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// CreateGuests agrega invitados al evento en una sola transacción, cada uno con su token
+func (r *GuestRepository) CreateGuests(eventID uuid.UUID, guests []models.GuestInput) ([]models.GuestInvitation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	created := make([]models.GuestInvitation, 0, len(guests))
+	for _, input := range guests {
+		token, err := newGuestToken()
+		if err != nil {
+			return nil, err
+		}
+		g := models.GuestInvitation{
+			ID:          uuid.New(),
+			EventID:     eventID,
+			Name:        input.Name,
+			Email:       input.Email,
+			Phone:       input.Phone,
+			Token:       token,
+			MaxPlusOnes: input.MaxPlusOnes,
+			RSVPStatus:  models.RSVPPending,
+			CreatedAt:   now,
+		}
+		_, err = tx.Exec(`
+			INSERT INTO guest_invitations (id, event_id, name, email, phone, token, max_plus_ones, rsvp_status, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`, g.ID, g.EventID, g.Name, g.Email, g.Phone, g.Token, g.MaxPlusOnes, g.RSVPStatus, g.CreatedAt)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return nil, ErrDuplicateGuestEmail
+			}
+			return nil, err
+		}
+		created = append(created, g)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListByEvent obtiene los invitados del evento con la actividad de su jugador:
+// si completó el quiz y cuántas postales dejó
+func (r *GuestRepository) ListByEvent(eventID uuid.UUID) ([]models.GuestInvitation, error) {
+	rows, err := r.db.Query(`
+		SELECT `+guestCols+`,
+		       EXISTS (SELECT 1 FROM quiz_answers qa WHERE qa.player_id = g.player_id),
+		       (SELECT COUNT(*) FROM postcards p WHERE p.player_id = g.player_id)
+		FROM guest_invitations g
+		WHERE event_id = $1
+		ORDER BY name, created_at
+	`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	guests := []models.GuestInvitation{}
+	for rows.Next() {
+		var played bool
+		var postcards int
+		g, err := scanGuest(rowWithExtra{rows, []any{&played, &postcards}})
+		if err != nil {
+			return nil, err
+		}
+		g.Played = played
+		g.PostcardCount = postcards
+		guests = append(guests, *g)
+	}
+	return guests, rows.Err()
+}
+
+// GetByID obtiene un invitado por su ID
+func (r *GuestRepository) GetByID(id uuid.UUID) (*models.GuestInvitation, error) {
+	g, err := scanGuest(r.db.QueryRow(`SELECT `+guestCols+` FROM guest_invitations WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrGuestNotFound
+	}
+	return g, err
+}
+
+// GetByToken obtiene la invitación de un link personal del evento
+func (r *GuestRepository) GetByToken(eventID uuid.UUID, token string) (*models.GuestInvitation, error) {
+	g, err := scanGuest(r.db.QueryRow(`
+		SELECT `+guestCols+` FROM guest_invitations WHERE event_id = $1 AND token = $2
+	`, eventID, token))
+	if err == sql.ErrNoRows {
+		return nil, ErrGuestNotFound
+	}
+	return g, err
+}
+
+// Update guarda los datos y el RSVP de un invitado editados por el host
+func (r *GuestRepository) Update(g *models.GuestInvitation) error {
+	result, err := r.db.Exec(`
+		UPDATE guest_invitations
+		SET name = $1, email = $2, phone = $3, max_plus_ones = $4, rsvp_status = $5, plus_ones = $6, rsvp_at = $7
+		WHERE id = $8
+	`, g.Name, g.Email, g.Phone, g.MaxPlusOnes, g.RSVPStatus, g.PlusOnes, g.RSVPAt, g.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrDuplicateGuestEmail
+		}
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrGuestNotFound
+	}
+	return nil
+}
+
+// SetRSVP registra la respuesta del invitado desde su link personal
+func (r *GuestRepository) SetRSVP(id uuid.UUID, status models.RSVPStatus, plusOnes int) (*models.GuestInvitation, error) {
+	g, err := scanGuest(r.db.QueryRow(`
+		UPDATE guest_invitations SET rsvp_status = $1, plus_ones = $2, rsvp_at = NOW()
+		WHERE id = $3
+		RETURNING `+guestCols, status, plusOnes, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrGuestNotFound
+	}
+	return g, err
+}
+
+// JoinAsPlayer devuelve el jugador vinculado a la invitación, creándolo con el nombre del
+// invitado si todavía no entró (created = true). La fila se bloquea para que dos dispositivos
+// abriendo el mismo link a la vez no creen dos jugadores.
+func (r *GuestRepository) JoinAsPlayer(id uuid.UUID, avatar string) (player *models.Player, created bool, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	g, err := scanGuest(tx.QueryRow(`SELECT `+guestCols+` FROM guest_invitations WHERE id = $1 FOR UPDATE`, id))
+	if err == sql.ErrNoRows {
+		return nil, false, ErrGuestNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if g.PlayerID != nil {
+		player = &models.Player{}
+		err := tx.QueryRow(`
+			SELECT id, event_id, name, avatar, score, created_at FROM players WHERE id = $1
+		`, *g.PlayerID).Scan(&player.ID, &player.EventID, &player.Name, &player.Avatar, &player.Score, &player.CreatedAt)
+		if err == nil {
+			return player, false, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, false, err
+		}
+		// El jugador se borró: se crea uno nuevo
+	}
+
+	player = &models.Player{
+		ID:        uuid.New(),
+		EventID:   g.EventID,
+		Name:      g.Name,
+		Avatar:    avatar,
+		CreatedAt: time.Now(),
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO players (id, event_id, name, avatar, score, created_at) VALUES ($1, $2, $3, $4, 0, $5)
+	`, player.ID, player.EventID, player.Name, player.Avatar, player.CreatedAt); err != nil {
+		return nil, false, err
+	}
+	if _, err := tx.Exec(`UPDATE guest_invitations SET player_id = $1 WHERE id = $2`, player.ID, g.ID); err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return player, true, nil
+}
+
+// Delete elimina un invitado (su jugador, si entró, queda en el evento)
+func (r *GuestRepository) Delete(id uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM guest_invitations WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrGuestNotFound
+	}
+	return nil
+}
+
+// ErrGuestNotFound error cuando el invitado no existe
+var ErrGuestNotFound = errors.New("guest not found")
+
+// ErrDuplicateGuestEmail error cuando el email ya está en la lista de invitados del evento
+var ErrDuplicateGuestEmail = errors.New("guest email already invited")
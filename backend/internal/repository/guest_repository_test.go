@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestGuestInvitationsJoinAndRSVP(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	event, err := NewEventRepository(db).Create(user.ID, "guest-event", "Guest Event", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	repo := NewGuestRepository(db)
+
+	created, err := repo.CreateGuests(event.ID, []models.GuestInput{
+		{Name: "Ana", Email: "ana@example.com", MaxPlusOnes: 1},
+		{Name: "Luis"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(created) != 2 || created[0].Token == "" || created[0].Token == created[1].Token {
+		t.Fatalf("Expected two guests with distinct tokens, got %+v", created)
+	}
+
+	// El email es único por evento (sin importar mayúsculas)
+	if _, err := repo.CreateGuests(event.ID, []models.GuestInput{{Name: "Ana 2", Email: "ANA@example.com"}}); err != ErrDuplicateGuestEmail {
+		t.Errorf("Expected ErrDuplicateGuestEmail, got: %v", err)
+	}
+
+	ana, err := repo.GetByToken(event.ID, created[0].Token)
+	if err != nil {
+		t.Fatalf("Expected guest by token, got: %v", err)
+	}
+	if _, err := repo.SetRSVP(ana.ID, models.RSVPYes, 1); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	player, isNew, err := repo.JoinAsPlayer(ana.ID, "🎉")
+	if err != nil || !isNew || player.Name != "Ana" || player.EventID != event.ID {
+		t.Fatalf("Expected a new player for Ana, got %+v (%v)", player, err)
+	}
+	again, isNew, err := repo.JoinAsPlayer(ana.ID, "🎈")
+	if err != nil || isNew || again.ID != player.ID {
+		t.Errorf("Expected the same player on the second join, got %+v (%v)", again, err)
+	}
+
+	if _, err := db.Exec(`INSERT INTO postcards (id, player_id, event_id, image_path, message, rotation) VALUES (gen_random_uuid(), $1, $2, '/uploads/x.jpg', 'hola', 0)`,
+		player.ID, event.ID); err != nil {
+		t.Fatalf("Failed to create postcard: %v", err)
+	}
+
+	guests, err := repo.ListByEvent(event.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(guests) != 2 {
+		t.Fatalf("Expected 2 guests, got %d", len(guests))
+	}
+	for _, g := range guests {
+		if g.ID != ana.ID {
+			continue
+		}
+		if g.RSVPStatus != models.RSVPYes || g.PlusOnes != 1 || g.RSVPAt == nil {
+			t.Errorf("Expected RSVP yes +1, got %s +%d", g.RSVPStatus, g.PlusOnes)
+		}
+		if g.PlayerID == nil || *g.PlayerID != player.ID || g.Played || g.PostcardCount != 1 {
+			t.Errorf("Expected Ana's activity (player, no quiz, 1 postcard), got %+v", g)
+		}
+	}
+}
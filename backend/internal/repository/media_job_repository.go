@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// MediaJobRepository handles database operations for video processing jobs
+type MediaJobRepository struct {
+	db *sql.DB
+}
+
+// NewMediaJobRepository creates a new media job repository
+func NewMediaJobRepository(db *sql.DB) *MediaJobRepository {
+	return &MediaJobRepository{db: db}
+}
+
+// mediaJobCols columns read by scanMediaJob. Queries must alias media_jobs as mj
+// and join the postcard (p) and its event (e). Legacy postcards have no event, so
+// the event ID and slug may be NULL.
+const mediaJobCols = `
+	mj.id, mj.postcard_id, p.event_id, e.slug, mj.source_key, mj.status,
+	mj.attempts, mj.last_error, mj.queued_at, mj.started_at, mj.finished_at`
+
+// mediaJobJoins joins a media_jobs row (mj) with its postcard and event, if any
+const mediaJobJoins = `
+	JOIN postcards p ON p.id = mj.postcard_id
+	LEFT JOIN events e ON e.id = p.event_id`
+
+func scanMediaJob(row interface {
+	Scan(...any) error
+}) (*models.MediaJob, error) {
+	var job models.MediaJob
+	var eventSlug, lastError sql.NullString
+	var startedAt, finishedAt sql.NullTime
+
+	// A NULL event_id scans as uuid.Nil
+	err := row.Scan(
+		&job.ID, &job.PostcardID, &job.EventID, &eventSlug, &job.SourceKey, &job.Status,
+		&job.Attempts, &lastError, &job.QueuedAt, &startedAt, &finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.EventSlug = eventSlug.String
+	if lastError.Valid {
+		job.LastError = &lastError.String
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
+
+// Create queues the processing of a postcard video stored under sourceKey
+func (r *MediaJobRepository) Create(postcardID uuid.UUID, sourceKey string) (*models.MediaJob, error) {
+	job := &models.MediaJob{
+		ID:         uuid.New(),
+		PostcardID: postcardID,
+		SourceKey:  sourceKey,
+		Status:     models.MediaJobStatusQueued,
+		QueuedAt:   time.Now(),
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO media_jobs (id, postcard_id, source_key, status, queued_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, job.ID, job.PostcardID, job.SourceKey, job.Status, job.QueuedAt)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetByID returns a media job with its postcard's event
+func (r *MediaJobRepository) GetByID(id uuid.UUID) (*models.MediaJob, error) {
+	query := `SELECT` + mediaJobCols + `
+		FROM media_jobs mj` + mediaJobJoins + `
+		WHERE mj.id = $1`
+
+	job, err := scanMediaJob(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrMediaJobNotFound
+	}
+	return job, err
+}
+
+// ListByEvent lists the media jobs of an event's postcards, newest first.
+// An empty status returns every job.
+func (r *MediaJobRepository) ListByEvent(eventID uuid.UUID, status string) ([]models.MediaJob, error) {
+	query := `SELECT` + mediaJobCols + `
+		FROM media_jobs mj` + mediaJobJoins + `
+		WHERE p.event_id = $1 AND ($2 = '' OR mj.status = $2)
+		ORDER BY mj.queued_at DESC`
+
+	rows, err := r.db.Query(query, eventID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []models.MediaJob
+	for rows.Next() {
+		job, err := scanMediaJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+// ClaimQueued atomically marks up to limit due jobs as in_progress and returns
+// them. SKIP LOCKED lets several API instances poll the same table without
+// processing a job twice.
+func (r *MediaJobRepository) ClaimQueued(limit int) ([]models.MediaJob, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE media_jobs
+			SET status = 'in_progress', started_at = NOW(), finished_at = NULL, attempts = attempts + 1
+			WHERE id IN (
+				SELECT id FROM media_jobs
+				WHERE status = 'queued' AND queued_at <= NOW()
+				ORDER BY queued_at ASC
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT` + mediaJobCols + `
+		FROM claimed mj` + mediaJobJoins + `
+		ORDER BY mj.queued_at ASC`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []models.MediaJob
+	for rows.Next() {
+		job, err := scanMediaJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+// RequeueStale puts back in the queue jobs left in_progress for longer than
+// olderThan (the instance processing them died)
+func (r *MediaJobRepository) RequeueStale(olderThan time.Duration) (int64, error) {
+	result, err := r.db.Exec(`
+		UPDATE media_jobs
+		SET status = 'queued', queued_at = NOW()
+		WHERE status = 'in_progress' AND started_at < $1
+	`, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Complete stores the processed video on the postcard, marks it ready and
+// finishes the job in a single transaction. Returns the updated postcard.
+func (r *MediaJobRepository) Complete(job *models.MediaJob, video *models.ProcessedVideo) (*models.Postcard, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE postcards
+		SET image_path = $1, thumbnail_path = $2, media_duration_ms = $3, media_status = 'ready'
+		WHERE id = $4
+	`, video.VideoPath, video.ThumbnailPath, video.DurationMs, job.PostcardID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE media_jobs
+		SET status = 'done', last_error = NULL, finished_at = NOW()
+		WHERE id = $1
+	`, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	postcard, err := scanPostcard(tx.QueryRow(`SELECT`+publicPostcardCols+`
+		WHERE p.id = $1`, job.PostcardID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return postcard, nil
+}
+
+// Requeue schedules another attempt of a job after delay, keeping the error
+func (r *MediaJobRepository) Requeue(jobID uuid.UUID, lastError string, delay time.Duration) error {
+	_, err := r.db.Exec(`
+		UPDATE media_jobs
+		SET status = 'queued', last_error = $1, queued_at = $2
+		WHERE id = $3
+	`, lastError, time.Now().Add(delay), jobID)
+	return err
+}
+
+// Fail marks the job and its postcard as failed
+func (r *MediaJobRepository) Fail(job *models.MediaJob, lastError string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE media_jobs
+		SET status = 'failed', last_error = $1, finished_at = NOW()
+		WHERE id = $2
+	`, lastError, job.ID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE postcards SET media_status = 'failed' WHERE id = $1`, job.PostcardID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResetForRetry re-queues a failed job with a fresh attempt budget and puts its
+// postcard back in 'processing'. last_error is kept until the next attempt ends.
+func (r *MediaJobRepository) ResetForRetry(jobID uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var postcardID uuid.UUID
+	err = tx.QueryRow(`
+		UPDATE media_jobs
+		SET status = 'queued', attempts = 0, queued_at = NOW(), started_at = NULL, finished_at = NULL
+		WHERE id = $1 AND status = 'failed'
+		RETURNING postcard_id
+	`, jobID).Scan(&postcardID)
+	if err == sql.ErrNoRows {
+		return ErrMediaJobNotFailed
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE postcards SET media_status = 'processing' WHERE id = $1`, postcardID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ErrMediaJobNotFound is returned when the media job does not exist
+var ErrMediaJobNotFound = errors.New("media job not found")
+
+// ErrMediaJobNotFailed is returned when retrying a job that is not in failed status
+var ErrMediaJobNotFailed = errors.New("media job is not failed")
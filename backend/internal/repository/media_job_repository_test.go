@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestMediaJobRepository_ClaimQueued_LegacyPostcard(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	// Legacy POST /api/postcards: player and postcard without an event
+	player, err := NewPlayerRepository(db).Create("Tía Marta", "👵")
+	if err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+	postcard, err := NewPostcardRepository(db, "").Create(player.ID, "", "hola", 0, nil, "video", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create postcard: %v", err)
+	}
+
+	repo := NewMediaJobRepository(db)
+	job, err := repo.Create(postcard.ID, "originals/legacy.mp4")
+	if err != nil {
+		t.Fatalf("Failed to create media job: %v", err)
+	}
+
+	claimed, err := repo.ClaimQueued(10)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != job.ID {
+		t.Fatalf("Expected the legacy job to be claimed and returned, got %+v", claimed)
+	}
+	if claimed[0].EventID != uuid.Nil || claimed[0].EventSlug != "" {
+		t.Errorf("Expected no event, got %s / %q", claimed[0].EventID, claimed[0].EventSlug)
+	}
+	if claimed[0].Status != models.MediaJobStatusInProgress {
+		t.Errorf("Expected in_progress, got %s", claimed[0].Status)
+	}
+
+	if _, err := repo.GetByID(job.ID); err != nil {
+		t.Errorf("Expected the legacy job by ID, got: %v", err)
+	}
+}
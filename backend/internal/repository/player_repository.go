@@ -2,6 +2,9 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -61,6 +64,25 @@ func (r *PlayerRepository) GetByID(id uuid.UUID) (*models.Player, error) {
 	return player, nil
 }
 
+// GetPublicByID obtiene un jugador solo si su evento no está protegido con código (rutas legacy)
+func (r *PlayerRepository) GetPublicByID(id uuid.UUID) (*models.Player, error) {
+	player := &models.Player{}
+	query := `
+		SELECT id, event_id, name, avatar, score, created_at
+		FROM players
+		WHERE id = $1 AND ` + publicEventFilter("event_id") + `
+	`
+
+	err := r.db.QueryRow(query, id).Scan(
+		&player.ID, &player.EventID, &player.Name, &player.Avatar, &player.Score, &player.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
+
 // UpdateScore actualiza el puntaje de un jugador
 func (r *PlayerRepository) UpdateScore(id uuid.UUID, score int) error {
 	query := `UPDATE players SET score = $1 WHERE id = $2`
@@ -68,11 +90,36 @@ func (r *PlayerRepository) UpdateScore(id uuid.UUID, score int) error {
 	return err
 }
 
-// List obtiene todos los jugadores ordenados por puntaje
+// UpdateScores actualiza el puntaje de varios jugadores de un evento en una sola transacción.
+// Los jugadores que no pertenecen al evento se ignoran.
+func (r *PlayerRepository) UpdateScores(eventID uuid.UUID, scores map[uuid.UUID]int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE players SET score = $1 WHERE id = $2 AND event_id = $3`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for playerID, score := range scores {
+		if _, err := stmt.Exec(score, playerID, eventID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// List obtiene los jugadores de todos los eventos sin código de acceso, ordenados por puntaje
 func (r *PlayerRepository) List() ([]models.Player, error) {
 	query := `
 		SELECT id, event_id, name, avatar, score, created_at
 		FROM players
+		WHERE ` + publicEventFilter("event_id") + `
 		ORDER BY score DESC
 	`
 
@@ -150,3 +197,202 @@ func (r *PlayerRepository) ListByEvent(eventID uuid.UUID) ([]models.Player, erro
 
 	return players, nil
 }
+
+// ExportData reúne todo lo que se guarda del jugador: invitación, respuestas del quiz,
+// postales (también secretas, pendientes o rechazadas), telemetría, page views y
+// backups en Drive. No incluye el evento ni la media, que arma quien llama.
+func (r *PlayerRepository) ExportData(playerID uuid.UUID) (*models.PlayerDataExport, error) {
+	player, err := r.GetByID(playerID)
+	if err == sql.ErrNoRows {
+		return nil, ErrPlayerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := &models.PlayerDataExport{
+		Player:           *player,
+		AcceptedAnswers:  map[string]string{},
+		QuizActivity:     []models.PlayerQuizActivity{},
+		Postcards:        []models.Postcard{},
+		PostcardActivity: []models.PlayerPostcardAction{},
+		PageViews:        []models.PlayerPageView{},
+		BackupJobs:       []models.BackupJob{},
+		MediaFiles:       []models.PlayerMediaFile{},
+	}
+
+	invitation, err := scanGuest(r.db.QueryRow(`SELECT `+guestCols+` FROM guest_invitations WHERE player_id = $1`, playerID))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to load invitation: %w", err)
+	}
+	data.Invitation = invitation
+
+	if data.QuizAnswers, err = r.exportQuizAnswers(playerID); err != nil {
+		return nil, fmt.Errorf("failed to load quiz answers: %w", err)
+	}
+	if err := r.exportRows(`SELECT question_key, answer FROM quiz_answer_overrides WHERE player_id = $1`, playerID, func(rows *sql.Rows) error {
+		var key, answer string
+		if err := rows.Scan(&key, &answer); err != nil {
+			return err
+		}
+		data.AcceptedAnswers[key] = answer
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to load accepted answers: %w", err)
+	}
+
+	if err := r.exportRows(`
+		SELECT event_type, score, time_spent_seconds, created_at
+		FROM quiz_events WHERE player_id = $1 ORDER BY created_at
+	`, playerID, func(rows *sql.Rows) error {
+		var a models.PlayerQuizActivity
+		if err := rows.Scan(&a.Type, &a.Score, &a.TimeSpentSeconds, &a.CreatedAt); err != nil {
+			return err
+		}
+		data.QuizActivity = append(data.QuizActivity, a)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to load quiz activity: %w", err)
+	}
+
+	if err := r.exportRows(`SELECT`+publicPostcardCols+`
+		WHERE p.player_id = $1 ORDER BY p.created_at
+	`, playerID, func(rows *sql.Rows) error {
+		postcard, err := scanPostcard(rows)
+		if err != nil {
+			return err
+		}
+		data.Postcards = append(data.Postcards, *postcard)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to load postcards: %w", err)
+	}
+
+	if err := r.exportRows(`
+		SELECT event_type, postcard_id, created_at
+		FROM postcard_events WHERE player_id = $1 ORDER BY created_at
+	`, playerID, func(rows *sql.Rows) error {
+		var a models.PlayerPostcardAction
+		if err := rows.Scan(&a.Type, &a.PostcardID, &a.CreatedAt); err != nil {
+			return err
+		}
+		data.PostcardActivity = append(data.PostcardActivity, a)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to load postcard activity: %w", err)
+	}
+
+	if err := r.exportRows(`
+		SELECT page_path, visited_at FROM page_views WHERE player_id = $1 ORDER BY visited_at
+	`, playerID, func(rows *sql.Rows) error {
+		var v models.PlayerPageView
+		if err := rows.Scan(&v.Path, &v.VisitedAt); err != nil {
+			return err
+		}
+		data.PageViews = append(data.PageViews, v)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to load page views: %w", err)
+	}
+
+	if err := r.exportRows(`
+		SELECT bj.id, bj.postcard_id, bj.idempotency_key, bj.status, bj.drive_file_id, bj.retry_count,
+			bj.last_error, bj.queued_at, bj.processed_at, bj.synced_at
+		FROM backup_jobs bj
+		JOIN postcards p ON p.id = bj.postcard_id
+		WHERE p.player_id = $1
+		ORDER BY bj.queued_at
+	`, playerID, func(rows *sql.Rows) error {
+		var job models.BackupJob
+		if err := rows.Scan(&job.ID, &job.PostcardID, &job.IdempotencyKey, &job.Status, &job.DriveFileID,
+			&job.RetryCount, &job.LastError, &job.QueuedAt, &job.ProcessedAt, &job.SyncedAt); err != nil {
+			return err
+		}
+		data.BackupJobs = append(data.BackupJobs, job)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to load backup jobs: %w", err)
+	}
+
+	return data, nil
+}
+
+// exportQuizAnswers respuestas del quiz del jugador; nil si no jugó
+func (r *PlayerRepository) exportQuizAnswers(playerID uuid.UUID) (*models.QuizAnswers, error) {
+	var answers models.QuizAnswers
+	var favoritesJSON, preferencesJSON []byte
+	err := r.db.QueryRow(`
+		SELECT id, player_id, favorites, preferences, description, created_at
+		FROM quiz_answers WHERE player_id = $1
+	`, playerID).Scan(&answers.ID, &answers.PlayerID, &favoritesJSON, &preferencesJSON, &answers.Description, &answers.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(favoritesJSON, &answers.Favorites); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(preferencesJSON, &answers.Preferences); err != nil {
+		return nil, err
+	}
+	return &answers, nil
+}
+
+// exportRows recorre las filas de query llamando a scan por cada una
+func (r *PlayerRepository) exportRows(query string, playerID uuid.UUID, scan func(*sql.Rows) error) error {
+	rows, err := r.db.Query(query, playerID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// EraseData borra al jugador y todo lo suyo en una sola transacción. Respuestas,
+// overrides, telemetría del quiz, postales, backup_jobs y media_jobs caen por cascada;
+// page views, telemetría de postales e invitación (que solo se desvincularían) se
+// borran explícitamente. Los archivos de media y las copias en Drive los borra quien llama.
+func (r *PlayerRepository) EraseData(playerID uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		`DELETE FROM page_views WHERE player_id = $1`,
+		`DELETE FROM postcard_events WHERE player_id = $1
+			OR postcard_id IN (SELECT id FROM postcards WHERE player_id = $1)`,
+		`DELETE FROM guest_invitations WHERE player_id = $1`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt, playerID); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.Exec(`DELETE FROM players WHERE id = $1`, playerID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrPlayerNotFound
+	}
+
+	return tx.Commit()
+}
+
+// ErrPlayerNotFound el jugador no existe
+var ErrPlayerNotFound = errors.New("player not found")
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestPlayerExportAndEraseData(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	event, err := NewEventRepository(db).Create(user.ID, "gdpr-event", "GDPR Event", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	guests := NewGuestRepository(db)
+	created, err := guests.CreateGuests(event.ID, []models.GuestInput{{Name: "Ana", Email: "ana@example.com"}})
+	if err != nil {
+		t.Fatalf("Failed to create guest: %v", err)
+	}
+	player, _, err := guests.JoinAsPlayer(created[0].ID, "🎉")
+	if err != nil {
+		t.Fatalf("Failed to join: %v", err)
+	}
+	other, err := NewPlayerRepository(db).CreateWithEvent(event.ID, "Luis", "🎈")
+	if err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+
+	if err := NewQuizRepository(db).SaveAnswers(player.ID, map[string]string{"color": "rosa"}, map[string]string{}, "Divertida"); err != nil {
+		t.Fatalf("Failed to save answers: %v", err)
+	}
+	postcardID := uuid.New()
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO postcards (id, player_id, event_id, image_path, message, rotation) VALUES ($1, $2, $3, '/uploads/postcards/a.jpg', 'hola', 0)`,
+			[]any{postcardID, player.ID, event.ID}},
+		{`INSERT INTO backup_jobs (postcard_id, idempotency_key, status, drive_file_id) VALUES ($1, $2, 'synced', 'drive-1')`,
+			[]any{postcardID, "gdpr-" + postcardID.String()}},
+		{`INSERT INTO postcard_events (event_id, player_id, postcard_id, event_type) VALUES ($1, $2, $3, 'created')`,
+			[]any{event.ID, player.ID, postcardID}},
+		{`INSERT INTO postcard_events (event_id, player_id, postcard_id, event_type) VALUES ($1, $2, $3, 'viewed')`,
+			[]any{event.ID, other.ID, postcardID}},
+		{`INSERT INTO page_views (event_id, player_id, page_path) VALUES ($1, $2, '/quiz')`,
+			[]any{event.ID, player.ID}},
+	}
+	for _, s := range statements {
+		if _, err := db.Exec(s.query, s.args...); err != nil {
+			t.Fatalf("Failed to seed %q: %v", s.query, err)
+		}
+	}
+	telemetry := NewTelemetryRepository(db)
+	if _, err := telemetry.LogQuizStarted(event.ID, player.ID); err != nil {
+		t.Fatalf("Failed to log quiz start: %v", err)
+	}
+
+	repo := NewPlayerRepository(db)
+	data, err := repo.ExportData(player.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if data.Player.Name != "Ana" || data.Invitation == nil || data.Invitation.Email != "ana@example.com" {
+		t.Errorf("Expected player and invitation, got %+v / %+v", data.Player, data.Invitation)
+	}
+	if data.QuizAnswers == nil || data.QuizAnswers.Favorites["color"] != "rosa" {
+		t.Errorf("Expected quiz answers, got %+v", data.QuizAnswers)
+	}
+	if len(data.Postcards) != 1 || len(data.BackupJobs) != 1 || *data.BackupJobs[0].DriveFileID != "drive-1" {
+		t.Errorf("Expected one postcard with its backup job, got %d / %+v", len(data.Postcards), data.BackupJobs)
+	}
+	if len(data.QuizActivity) != 1 || len(data.PostcardActivity) != 1 || len(data.PageViews) != 1 {
+		t.Errorf("Expected telemetry and page views, got %d / %d / %d",
+			len(data.QuizActivity), len(data.PostcardActivity), len(data.PageViews))
+	}
+
+	if err := repo.EraseData(player.ID); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := repo.ExportData(player.ID); err != ErrPlayerNotFound {
+		t.Errorf("Expected ErrPlayerNotFound after erasing, got: %v", err)
+	}
+	// page_views, postcard_events y guest_invitations no caen en cascada con el jugador
+	counts := []struct {
+		table string
+		query string
+		arg   uuid.UUID
+	}{
+		{"postcards", `SELECT COUNT(*) FROM postcards WHERE id = $1`, postcardID},
+		{"backup_jobs", `SELECT COUNT(*) FROM backup_jobs WHERE postcard_id = $1`, postcardID},
+		{"postcard_events", `SELECT COUNT(*) FROM postcard_events WHERE event_id = $1`, event.ID},
+		{"page_views", `SELECT COUNT(*) FROM page_views WHERE event_id = $1`, event.ID},
+		{"guest_invitations", `SELECT COUNT(*) FROM guest_invitations WHERE id = $1`, created[0].ID},
+	}
+	for _, c := range counts {
+		var count int
+		if err := db.QueryRow(c.query, c.arg).Scan(&count); err != nil {
+			t.Fatalf("Failed to count %s: %v", c.table, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected no %s left, got %d", c.table, count)
+		}
+	}
+
+	// Los demás jugadores no se tocan
+	if _, err := repo.GetByID(other.ID); err != nil {
+		t.Errorf("Expected the other player to remain, got: %v", err)
+	}
+	if err := repo.EraseData(player.ID); err != ErrPlayerNotFound {
+		t.Errorf("Expected ErrPlayerNotFound erasing twice, got: %v", err)
+	}
+}
+
+func TestLegacyListsSkipProtectedEvents(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	events := NewEventRepository(db)
+	public, err := events.Create(user.ID, "public-event", "Public Event", "", models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	protected, err := events.Create(user.ID, "protected-event", "Protected Event", "", models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	hash := "$2a$10$hash"
+	if _, err := events.SetAccessCode(protected.ID, &hash); err != nil {
+		t.Fatalf("Failed to set access code: %v", err)
+	}
+
+	players := NewPlayerRepository(db)
+	visible, _ := players.CreateWithEvent(public.ID, "Ana", "🎉")
+	hidden, _ := players.CreateWithEvent(protected.ID, "Luis", "🎈")
+	legacy, _ := players.Create("Tía Marta", "👵")
+	for _, p := range []struct{ id, eventID uuid.UUID }{{visible.ID, public.ID}, {hidden.ID, protected.ID}} {
+		if _, err := db.Exec(`INSERT INTO postcards (player_id, event_id, image_path, message, rotation) VALUES ($1, $2, '/uploads/postcards/a.jpg', 'hola', 0)`,
+			p.id, p.eventID); err != nil {
+			t.Fatalf("Failed to seed postcard: %v", err)
+		}
+	}
+
+	list, err := players.List()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("Expected the public event's player and the legacy one, got %+v", list)
+	}
+	for _, p := range list {
+		if p.ID == hidden.ID {
+			t.Errorf("Expected the protected event's player to be hidden, got %+v", list)
+		}
+	}
+	if _, err := players.GetPublicByID(legacy.ID); err != nil {
+		t.Errorf("Expected the legacy player, got: %v", err)
+	}
+	if _, err := players.GetPublicByID(visible.ID); err != nil {
+		t.Errorf("Expected the public player, got: %v", err)
+	}
+	if _, err := players.GetPublicByID(hidden.ID); err == nil {
+		t.Error("Expected the protected event's player to be hidden")
+	}
+
+	postcards, err := NewPostcardRepository(db, "").List()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(postcards) != 1 || postcards[0].PlayerID == nil || *postcards[0].PlayerID != visible.ID {
+		t.Errorf("Expected only the public event's postcard, got %+v", postcards)
+	}
+
+	// Secret Box legacy (/admin/status, /admin/secret-box, /admin/reveal)
+	postcardRepo := NewPostcardRepository(db, "")
+	publicSecret, err := postcardRepo.CreateSecret(public.ID, "Ana", "/uploads/postcards/s1.jpg", "sorpresa", 0, "image", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create secret postcard: %v", err)
+	}
+	hiddenSecret, err := postcardRepo.CreateSecret(protected.ID, "Luis", "/uploads/postcards/s2.jpg", "sorpresa", 0, "image", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create secret postcard: %v", err)
+	}
+
+	status, err := postcardRepo.GetSecretBoxStatus()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if status.Total != 1 {
+		t.Errorf("Expected 1 secret postcard in the legacy status, got %d", status.Total)
+	}
+	secrets, err := postcardRepo.ListSecret()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(secrets) != 1 || secrets[0].ID != publicSecret.ID {
+		t.Errorf("Expected only the public event's secret postcard, got %+v", secrets)
+	}
+	revealed, err := postcardRepo.RevealSecretBox()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(revealed) != 1 || revealed[0].ID != publicSecret.ID {
+		t.Errorf("Expected only the public event's secret postcard revealed, got %+v", revealed)
+	}
+	stillSecret, err := postcardRepo.GetByID(hiddenSecret.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if stillSecret.RevealedAt != nil {
+		t.Error("Expected the protected event's secret postcard to stay unrevealed")
+	}
+}
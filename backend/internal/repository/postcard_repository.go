@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,7 +25,8 @@ func NewPostcardRepository(db *sql.DB, uploadPath string) *PostcardRepository {
 //
 //		p.id, p.event_id, p.player_id, p.sender_name, player_name (computed), player_avatar (computed),
 //		p.image_path, p.message, p.rotation, p.is_secret, p.revealed_at, p.created_at,
-//	 p.media_type, p.thumbnail_path, p.media_duration_ms
+//	 p.media_type, p.thumbnail_path, p.media_duration_ms, p.moderation_status,
+//	 p.image_thumb_path, p.image_board_path, p.media_status
 func scanPostcard(row interface {
 	Scan(...any) error
 }) (*models.Postcard, error) {
@@ -35,6 +37,7 @@ func scanPostcard(row interface {
 	var revealedAt sql.NullTime
 	var thumbnailPath sql.NullString
 	var mediaDurationMs sql.NullInt64
+	var imageThumbPath, imageBoardPath sql.NullString
 
 	err := row.Scan(
 		&postcard.ID,
@@ -52,6 +55,10 @@ func scanPostcard(row interface {
 		&postcard.MediaType,
 		&thumbnailPath,
 		&mediaDurationMs,
+		&postcard.ModerationStatus,
+		&imageThumbPath,
+		&imageBoardPath,
+		&postcard.MediaStatus,
 	)
 	if err != nil {
 		return nil, err
@@ -82,6 +89,13 @@ func scanPostcard(row interface {
 		ms := int(mediaDurationMs.Int64)
 		postcard.MediaDurationMs = &ms
 	}
+	if imageThumbPath.Valid && imageBoardPath.Valid {
+		postcard.Renditions = &models.ImageRenditions{
+			Thumb: imageThumbPath.String,
+			Board: imageBoardPath.String,
+			Full:  postcard.ImagePath,
+		}
+	}
 
 	return &postcard, nil
 }
@@ -95,21 +109,44 @@ const publicPostcardCols = `
 	COALESCE(p.sender_name, pl.name, 'Invitado') AS player_name,
 	CASE WHEN p.is_secret = TRUE THEN '🎁' ELSE COALESCE(pl.avatar, '👤') END AS player_avatar,
 	p.image_path, p.message, p.rotation, p.is_secret, p.revealed_at, p.created_at,
-	p.media_type, p.thumbnail_path, p.media_duration_ms
+	p.media_type, p.thumbnail_path, p.media_duration_ms, p.moderation_status,
+	p.image_thumb_path, p.image_board_path, p.media_status
 FROM postcards p
 LEFT JOIN players pl ON p.player_id = pl.id`
 
+// moderationStatusForEvent expresión SQL del estado inicial de una postal: pendiente
+// si el evento ($2) tiene la pre-moderación activada, aprobada si no
+const moderationStatusForEvent = `
+	CASE WHEN COALESCE((SELECT (settings->>'postcard_moderation')::boolean FROM events WHERE id = $2), FALSE)
+		THEN 'pending' ELSE 'approved' END`
+
+// mediaStatusForType expresión SQL del media_status inicial: los videos quedan
+// 'processing' hasta que el MediaWorker los procesa. param es el placeholder de media_type.
+func mediaStatusForType(param string) string {
+	return ` CASE WHEN ` + param + ` = 'video' THEN 'processing' ELSE 'ready' END`
+}
+
+// renditionPaths columnas image_thumb_path e image_board_path (NULL sin renditions).
+// La versión full se guarda en image_path.
+func renditionPaths(renditions *models.ImageRenditions) (*string, *string) {
+	if renditions == nil {
+		return nil, nil
+	}
+	return &renditions.Thumb, &renditions.Board
+}
+
 // Create crea una nueva postal regular (player_id requerido)
-func (r *PostcardRepository) Create(playerID uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error) {
+func (r *PostcardRepository) Create(playerID uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error) {
 	id := uuid.New()
 	createdAt := time.Now()
+	imageThumbPath, imageBoardPath := renditionPaths(renditions)
 
 	query := `
-		INSERT INTO postcards (id, player_id, image_path, message, rotation, sender_name, is_secret, created_at, media_type, thumbnail_path, media_duration_ms)
-		VALUES ($1, $2, $3, $4, $5, $6, FALSE, $7, $8, $9, $10)
+		INSERT INTO postcards (id, player_id, image_path, message, rotation, sender_name, is_secret, created_at, media_type, thumbnail_path, media_duration_ms, image_thumb_path, image_board_path, media_status)
+		VALUES ($1, $2, $3, $4, $5, $6, FALSE, $7, $8, $9, $10, $11, $12,` + mediaStatusForType("$8") + `)
 	`
 
-	_, err := r.db.Exec(query, id, playerID, imagePath, message, rotation, senderName, createdAt, mediaType, thumbnailPath, mediaDurationMs)
+	_, err := r.db.Exec(query, id, playerID, imagePath, message, rotation, senderName, createdAt, mediaType, thumbnailPath, mediaDurationMs, imageThumbPath, imageBoardPath)
 	if err != nil {
 		return nil, err
 	}
@@ -118,16 +155,17 @@ func (r *PostcardRepository) Create(playerID uuid.UUID, imagePath, message strin
 }
 
 // CreateSecret crea una postal secreta (sin player_id, soporta imágenes y videos)
-func (r *PostcardRepository) CreateSecret(eventID uuid.UUID, senderName, imagePath, message string, rotation float64, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error) {
+func (r *PostcardRepository) CreateSecret(eventID uuid.UUID, senderName, imagePath, message string, rotation float64, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error) {
 	id := uuid.New()
 	createdAt := time.Now()
+	imageThumbPath, imageBoardPath := renditionPaths(renditions)
 
 	query := `
-		INSERT INTO postcards (id, event_id, player_id, image_path, message, rotation, sender_name, is_secret, created_at, media_type, thumbnail_path, media_duration_ms)
-		VALUES ($1, $2, NULL, $3, $4, $5, $6, TRUE, $7, $8, $9, $10)
+		INSERT INTO postcards (id, event_id, player_id, image_path, message, rotation, sender_name, is_secret, created_at, media_type, thumbnail_path, media_duration_ms, image_thumb_path, image_board_path, moderation_status, media_status)
+		VALUES ($1, $2, NULL, $3, $4, $5, $6, TRUE, $7, $8, $9, $10, $11, $12,` + moderationStatusForEvent + `,` + mediaStatusForType("$8") + `)
 	`
 
-	_, err := r.db.Exec(query, id, eventID, imagePath, message, rotation, senderName, createdAt, mediaType, thumbnailPath, mediaDurationMs)
+	_, err := r.db.Exec(query, id, eventID, imagePath, message, rotation, senderName, createdAt, mediaType, thumbnailPath, mediaDurationMs, imageThumbPath, imageBoardPath)
 	if err != nil {
 		return nil, err
 	}
@@ -143,10 +181,12 @@ func (r *PostcardRepository) GetByID(id uuid.UUID) (*models.Postcard, error) {
 	return scanPostcard(row)
 }
 
-// List obtiene todas las postales PÚBLICAS: regulares + secretas ya reveladas
+// List obtiene todas las postales PÚBLICAS: regulares + secretas ya reveladas (aprobadas y con la media lista)
+// de los eventos sin código de acceso
 func (r *PostcardRepository) List() ([]models.Postcard, error) {
 	query := `SELECT` + publicPostcardCols + `
-		WHERE p.is_secret = FALSE OR p.revealed_at IS NOT NULL
+		WHERE (p.is_secret = FALSE OR p.revealed_at IS NOT NULL) AND p.moderation_status = 'approved' AND p.media_status = 'ready'
+			AND ` + publicEventFilter("p.event_id") + `
 		ORDER BY
 			CASE WHEN p.is_secret = TRUE AND p.revealed_at IS NOT NULL THEN 0 ELSE 1 END ASC,
 			p.created_at DESC`
@@ -169,10 +209,11 @@ func (r *PostcardRepository) List() ([]models.Postcard, error) {
 	return postcards, nil
 }
 
-// ListSecret devuelve TODAS las postales secretas (para admin, independientemente del reveal)
+// ListSecret devuelve todas las postales secretas (para admin, independientemente del reveal).
+// Es de las rutas legacy: no incluye las de eventos con código de acceso.
 func (r *PostcardRepository) ListSecret() ([]models.Postcard, error) {
 	query := `SELECT` + publicPostcardCols + `
-		WHERE p.is_secret = TRUE
+		WHERE p.is_secret = TRUE AND ` + publicEventFilter("p.event_id") + `
 		ORDER BY p.created_at DESC`
 
 	rows, err := r.db.Query(query)
@@ -195,13 +236,13 @@ func (r *PostcardRepository) ListSecret() ([]models.Postcard, error) {
 
 // RevealSecretBox marca todas las secretas no reveladas como reveladas y devuelve las postales.
 // Idempotente: si ya fueron reveladas, simplemente devuelve las reveladas.
+// Como ListSecret, no toca las postales de eventos con código de acceso.
 func (r *PostcardRepository) RevealSecretBox() ([]models.Postcard, error) {
 	// UPDATE solo las que aún no tienen revealed_at
 	_, err := r.db.Exec(`
 		UPDATE postcards
 		SET revealed_at = NOW()
-		WHERE is_secret = TRUE AND revealed_at IS NULL
-	`)
+		WHERE is_secret = TRUE AND revealed_at IS NULL AND ` + publicEventFilter("event_id"))
 	if err != nil {
 		return nil, err
 	}
@@ -223,7 +264,118 @@ func (r *PostcardRepository) RevealPostcard(id uuid.UUID) (*models.Postcard, err
 	return r.GetByID(id)
 }
 
-// GetSecretBoxStatus devuelve el estado actual de la Secret Box
+// secretRevealQueueWhere secretas que todavía se pueden revelar (aprobadas y con la media lista)
+const secretRevealQueueWhere = `p.event_id = $1 AND p.is_secret = TRUE AND p.revealed_at IS NULL
+		AND p.moderation_status = 'approved' AND p.media_status = 'ready'`
+
+// secretRevealQueueOrder orden del reveal uno por uno: el del owner y después por antigüedad
+const secretRevealQueueOrder = `p.reveal_position ASC NULLS LAST, p.created_at ASC`
+
+// ListSecretRevealQueue devuelve las secretas por revelar de un evento, en el orden del reveal
+func (r *PostcardRepository) ListSecretRevealQueue(eventID uuid.UUID) ([]models.Postcard, error) {
+	query := `SELECT` + publicPostcardCols + `
+		WHERE ` + secretRevealQueueWhere + `
+		ORDER BY ` + secretRevealQueueOrder
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var postcards []models.Postcard
+	for rows.Next() {
+		postcard, err := scanPostcard(rows)
+		if err != nil {
+			return nil, err
+		}
+		postcards = append(postcards, *postcard)
+	}
+
+	return postcards, rows.Err()
+}
+
+// ReorderSecretRevealQueue fija el orden del reveal: postcardIDs primero, en ese orden.
+// Las secretas no incluidas quedan al final. Devuelve ErrPostcardNotInRevealQueue si
+// algún ID no es una secreta sin revelar del evento.
+func (r *PostcardRepository) ReorderSecretRevealQueue(eventID uuid.UUID, postcardIDs []uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE postcards
+		SET reveal_position = NULL
+		WHERE event_id = $1 AND is_secret = TRUE AND revealed_at IS NULL
+	`, eventID); err != nil {
+		return err
+	}
+
+	for i, id := range postcardIDs {
+		result, err := tx.Exec(`
+			UPDATE postcards
+			SET reveal_position = $1
+			WHERE id = $2 AND event_id = $3 AND is_secret = TRUE AND revealed_at IS NULL
+		`, i, id, eventID)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrPostcardNotInRevealQueue
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RevealNextSecret revela la primera secreta de la cola del evento. Los reveals de un
+// mismo evento se serializan con el lock de la fila del evento: un doble click del owner
+// espera al primero y revela la siguiente en orden, nunca dos a la vez ni fuera de orden.
+// Devuelve ErrRevealQueueEmpty si no quedan secretas por revelar.
+func (r *PostcardRepository) RevealNextSecret(eventID uuid.UUID) (*models.Postcard, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM events WHERE id = $1 FOR UPDATE`, eventID); err != nil {
+		return nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(`
+		UPDATE postcards
+		SET revealed_at = NOW()
+		WHERE id = (
+			SELECT p.id FROM postcards p
+			WHERE `+secretRevealQueueWhere+`
+			ORDER BY `+secretRevealQueueOrder+`
+			LIMIT 1
+			FOR UPDATE
+		)
+		RETURNING id
+	`, eventID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, ErrRevealQueueEmpty
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// GetSecretBoxStatus devuelve el estado actual de la Secret Box (sin eventos con código de acceso)
 func (r *PostcardRepository) GetSecretBoxStatus() (*models.SecretBoxStatus, error) {
 	var status models.SecretBoxStatus
 	var revealedAt sql.NullTime
@@ -234,8 +386,7 @@ func (r *PostcardRepository) GetSecretBoxStatus() (*models.SecretBoxStatus, erro
 			COUNT(*) FILTER (WHERE revealed_at IS NOT NULL) > 0 AS revealed,
 			MAX(revealed_at) AS revealed_at
 		FROM postcards
-		WHERE is_secret = TRUE
-	`).Scan(&status.Total, &status.Revealed, &revealedAt)
+		WHERE is_secret = TRUE AND `+publicEventFilter("event_id")).Scan(&status.Total, &status.Revealed, &revealedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -248,16 +399,17 @@ func (r *PostcardRepository) GetSecretBoxStatus() (*models.SecretBoxStatus, erro
 }
 
 // CreateWithEvent crea una nueva postal scopada a un evento (soporta imágenes y videos)
-func (r *PostcardRepository) CreateWithEvent(eventID uuid.UUID, playerID *uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int) (*models.Postcard, error) {
+func (r *PostcardRepository) CreateWithEvent(eventID uuid.UUID, playerID *uuid.UUID, imagePath, message string, rotation float64, senderName *string, mediaType string, thumbnailPath *string, mediaDurationMs *int, renditions *models.ImageRenditions) (*models.Postcard, error) {
 	id := uuid.New()
 	createdAt := time.Now()
+	imageThumbPath, imageBoardPath := renditionPaths(renditions)
 
 	query := `
-		INSERT INTO postcards (id, event_id, player_id, image_path, message, rotation, sender_name, is_secret, created_at, media_type, thumbnail_path, media_duration_ms)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, FALSE, $8, $9, $10, $11)
+		INSERT INTO postcards (id, event_id, player_id, image_path, message, rotation, sender_name, is_secret, created_at, media_type, thumbnail_path, media_duration_ms, image_thumb_path, image_board_path, moderation_status, media_status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, FALSE, $8, $9, $10, $11, $12, $13,` + moderationStatusForEvent + `,` + mediaStatusForType("$9") + `)
 	`
 
-	_, err := r.db.Exec(query, id, eventID, playerID, imagePath, message, rotation, senderName, createdAt, mediaType, thumbnailPath, mediaDurationMs)
+	_, err := r.db.Exec(query, id, eventID, playerID, imagePath, message, rotation, senderName, createdAt, mediaType, thumbnailPath, mediaDurationMs, imageThumbPath, imageBoardPath)
 	if err != nil {
 		return nil, err
 	}
@@ -265,10 +417,10 @@ func (r *PostcardRepository) CreateWithEvent(eventID uuid.UUID, playerID *uuid.U
 	return r.GetByID(id)
 }
 
-// ListByEvent obtiene todas las postales PÚBLICAS de un evento específico
+// ListByEvent obtiene todas las postales PÚBLICAS (aprobadas y con la media lista) de un evento específico
 func (r *PostcardRepository) ListByEvent(eventID uuid.UUID) ([]models.Postcard, error) {
 	query := `SELECT` + publicPostcardCols + `
-		WHERE p.event_id = $1 AND (p.is_secret = FALSE OR p.revealed_at IS NOT NULL)
+		WHERE p.event_id = $1 AND (p.is_secret = FALSE OR p.revealed_at IS NOT NULL) AND p.moderation_status = 'approved' AND p.media_status = 'ready'
 		ORDER BY
 			CASE WHEN p.is_secret = TRUE AND p.revealed_at IS NOT NULL THEN 0 ELSE 1 END ASC,
 			p.created_at DESC`
@@ -315,18 +467,96 @@ func (r *PostcardRepository) ListSecretByEvent(eventID uuid.UUID) ([]models.Post
 	return postcards, nil
 }
 
-// RevealSecretBoxByEvent marca todas las secretas no reveladas de un evento como reveladas
+// RevealSecretBoxByEvent marca todas las secretas no reveladas de un evento como reveladas.
+// Cancela el reveal programado: ya no hace falta.
 func (r *PostcardRepository) RevealSecretBoxByEvent(eventID uuid.UUID) ([]models.Postcard, error) {
-	_, err := r.db.Exec(`
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := revealSecretBoxTx(tx, eventID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.ListSecretByEvent(eventID)
+}
+
+// revealSecretBoxTx revela las secretas del evento y limpia su reveal programado
+func revealSecretBoxTx(tx *sql.Tx, eventID uuid.UUID) error {
+	if _, err := tx.Exec(`
 		UPDATE postcards
 		SET revealed_at = NOW()
 		WHERE event_id = $1 AND is_secret = TRUE AND revealed_at IS NULL
-	`, eventID)
+	`, eventID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`UPDATE events SET secret_box_reveal_at = NULL, secret_box_reveal_at_event_end = FALSE WHERE id = $1`, eventID)
+	return err
+}
+
+// RevealDueSecretBoxes revela las Secret Boxes cuyo reveal programado ya venció
+// (hasta limit eventos) y devuelve las secretas de cada uno para el broadcast.
+// SKIP LOCKED garantiza que con varias instancias cada evento se revela una sola vez.
+// secret_box_reveal_at se guarda en UTC, así que se compara contra NOW() en UTC
+// sin depender de la zona de la sesión.
+func (r *PostcardRepository) RevealDueSecretBoxes(limit int) ([]models.SecretBoxReveal, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT id, slug
+		FROM events
+		WHERE secret_box_reveal_at <= (NOW() AT TIME ZONE 'UTC')
+		ORDER BY secret_box_reveal_at ASC
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.ListSecretByEvent(eventID)
+	var reveals []models.SecretBoxReveal
+	for rows.Next() {
+		var reveal models.SecretBoxReveal
+		if err := rows.Scan(&reveal.EventID, &reveal.EventSlug); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		reveals = append(reveals, reveal)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, reveal := range reveals {
+		if err := revealSecretBoxTx(tx, reveal.EventID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	for i := range reveals {
+		postcards, err := r.ListSecretByEvent(reveals[i].EventID)
+		if err != nil {
+			return nil, err
+		}
+		reveals[i].Postcards = postcards
+	}
+
+	return reveals, nil
 }
 
 // GetSecretBoxStatusByEvent devuelve el estado de la Secret Box para un evento específico
@@ -334,14 +564,19 @@ func (r *PostcardRepository) GetSecretBoxStatusByEvent(eventID uuid.UUID) (*mode
 	var status models.SecretBoxStatus
 	var revealedAt sql.NullTime
 
+	var scheduledAt sql.NullTime
+
 	err := r.db.QueryRow(`
 		SELECT
 			COUNT(*) AS total,
 			COUNT(*) FILTER (WHERE revealed_at IS NOT NULL) > 0 AS revealed,
-			MAX(revealed_at) AS revealed_at
+			MAX(revealed_at) AS revealed_at,
+			(SELECT secret_box_reveal_at FROM events WHERE id = $1) AS scheduled_reveal_at,
+			COUNT(*) FILTER (WHERE revealed_at IS NOT NULL) AS revealed_count,
+			COUNT(*) FILTER (WHERE revealed_at IS NULL AND moderation_status = 'approved' AND media_status = 'ready') AS remaining
 		FROM postcards
 		WHERE event_id = $1 AND is_secret = TRUE
-	`, eventID).Scan(&status.Total, &status.Revealed, &revealedAt)
+	`, eventID).Scan(&status.Total, &status.Revealed, &revealedAt, &scheduledAt, &status.RevealedCount, &status.Remaining)
 	if err != nil {
 		return nil, err
 	}
@@ -349,6 +584,9 @@ func (r *PostcardRepository) GetSecretBoxStatusByEvent(eventID uuid.UUID) (*mode
 	if revealedAt.Valid {
 		status.RevealedAt = &revealedAt.Time
 	}
+	if scheduledAt.Valid {
+		status.ScheduledRevealAt = &scheduledAt.Time
+	}
 
 	return &status, nil
 }
@@ -378,3 +616,110 @@ func (r *PostcardRepository) UpdateBackupStatus(postcardID uuid.UUID, status mod
 	_, err := r.db.Exec(query, status, backupJobID, postcardID)
 	return err
 }
+
+// ListForModeration devuelve todas las postales del evento (regulares y secretas)
+// para el panel de moderación, pendientes primero. status vacío = todos los estados.
+func (r *PostcardRepository) ListForModeration(eventID uuid.UUID, status string) ([]models.Postcard, error) {
+	query := `SELECT` + publicPostcardCols + `
+		WHERE p.event_id = $1 AND ($2 = '' OR p.moderation_status = $2)
+		ORDER BY
+			CASE WHEN p.moderation_status = 'pending' THEN 0 ELSE 1 END ASC,
+			p.created_at DESC`
+
+	rows, err := r.db.Query(query, eventID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var postcards []models.Postcard
+	for rows.Next() {
+		postcard, err := scanPostcard(rows)
+		if err != nil {
+			return nil, err
+		}
+		postcards = append(postcards, *postcard)
+	}
+
+	return postcards, rows.Err()
+}
+
+// SetModerationStatus aprueba o rechaza una postal y registra quién lo hizo
+func (r *PostcardRepository) SetModerationStatus(id uuid.UUID, status string, moderatedBy uuid.UUID) (*models.Postcard, error) {
+	result, err := r.db.Exec(`
+		UPDATE postcards
+		SET moderation_status = $1, moderated_at = NOW(), moderated_by = $2
+		WHERE id = $3
+	`, status, moderatedBy, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, ErrPostcardNotFound
+	}
+
+	return r.GetByID(id)
+}
+
+// ListMediaPathsByEvent obtiene las URLs de media (original, thumbnail y renditions)
+// de las postales con la media lista de un evento, para copiarlas al duplicarlo
+func (r *PostcardRepository) ListMediaPathsByEvent(eventID uuid.UUID) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT image_path, thumbnail_path, image_thumb_path, image_board_path
+		FROM postcards
+		WHERE event_id = $1 AND media_status = 'ready'
+	`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var imagePath string
+		var thumbnailPath, imageThumbPath, imageBoardPath sql.NullString
+		if err := rows.Scan(&imagePath, &thumbnailPath, &imageThumbPath, &imageBoardPath); err != nil {
+			return nil, err
+		}
+		paths = append(paths, imagePath)
+		for _, path := range []sql.NullString{thumbnailPath, imageThumbPath, imageBoardPath} {
+			if path.Valid && path.String != "" {
+				paths = append(paths, path.String)
+			}
+		}
+	}
+
+	return paths, rows.Err()
+}
+
+// Delete elimina una postal. Los archivos de media los borra quien llama.
+func (r *PostcardRepository) Delete(id uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM postcards WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrPostcardNotFound
+	}
+
+	return nil
+}
+
+// ErrPostcardNotFound la postal no existe
+var ErrPostcardNotFound = errors.New("postcard not found")
+
+// ErrPostcardNotInRevealQueue error cuando se reordena una postal que no está por revelar
+var ErrPostcardNotInRevealQueue = errors.New("postcard not in reveal queue")
+
+// ErrRevealQueueEmpty error cuando no quedan secretas por revelar
+var ErrRevealQueueEmpty = errors.New("no secret postcards left to reveal")
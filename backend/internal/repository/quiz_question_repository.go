@@ -20,21 +20,69 @@ func NewQuizQuestionRepository(db *sql.DB) *QuizQuestionRepository {
 	return &QuizQuestionRepository{db: db}
 }
 
+// quizQuestionCols columnas seleccionadas por scanQuizQuestion
+const quizQuestionCols = `id, event_id, section, key, question_text, correct_answers, options, sort_order, is_scorable, points, partial_credit, fuzzy_match, created_at`
+
+// scanQuizQuestion escanea una fila con quizQuestionCols y deserializa los JSONB
+func scanQuizQuestion(row interface{ Scan(...any) error }) (*models.QuizQuestion, error) {
+	question := &models.QuizQuestion{}
+	var correctAnswersJSON, optionsJSON, partialCreditJSON, fuzzyJSON []byte
+
+	err := row.Scan(
+		&question.ID, &question.EventID, &question.Section, &question.Key, &question.QuestionText,
+		&correctAnswersJSON, &optionsJSON, &question.SortOrder, &question.IsScorable,
+		&question.Points, &partialCreditJSON, &fuzzyJSON, &question.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(correctAnswersJSON, &question.CorrectAnswers)
+	json.Unmarshal(optionsJSON, &question.Options)
+	if len(partialCreditJSON) > 0 {
+		json.Unmarshal(partialCreditJSON, &question.PartialCredit)
+	}
+	if len(fuzzyJSON) > 0 {
+		json.Unmarshal(fuzzyJSON, &question.Fuzzy)
+	}
+
+	return question, nil
+}
+
+// marshalPartialCredit serializa la regla de crédito parcial (nil → NULL)
+func marshalPartialCredit(rule *models.PartialCreditRule) interface{} {
+	if rule == nil {
+		return nil
+	}
+	data, _ := json.Marshal(rule)
+	return string(data)
+}
+
+// marshalFuzzyMatch serializa la regla fuzzy (nil → NULL)
+func marshalFuzzyMatch(rule *models.FuzzyMatchRule) interface{} {
+	if rule == nil {
+		return nil
+	}
+	data, _ := json.Marshal(rule)
+	return string(data)
+}
+
 // Create crea una nueva pregunta para un evento
 func (r *QuizQuestionRepository) Create(eventID uuid.UUID, section, key, questionText string,
-	correctAnswers, options []string, sortOrder int, isScorable bool) (*models.QuizQuestion, error) {
+	correctAnswers, options []string, sortOrder int, isScorable bool, scoring models.QuestionScoring) (*models.QuizQuestion, error) {
 
 	question := &models.QuizQuestion{
-		ID:             uuid.New(),
-		EventID:        eventID,
-		Section:        section,
-		Key:            key,
-		QuestionText:   questionText,
-		CorrectAnswers: correctAnswers,
-		Options:        options,
-		SortOrder:      sortOrder,
-		IsScorable:     isScorable,
-		CreatedAt:      time.Now(),
+		ID:              uuid.New(),
+		EventID:         eventID,
+		Section:         section,
+		Key:             key,
+		QuestionText:    questionText,
+		CorrectAnswers:  correctAnswers,
+		Options:         options,
+		SortOrder:       sortOrder,
+		IsScorable:      isScorable,
+		QuestionScoring: scoring,
+		CreatedAt:       time.Now(),
 	}
 
 	// Serializar arrays a JSONB
@@ -42,13 +90,14 @@ func (r *QuizQuestionRepository) Create(eventID uuid.UUID, section, key, questio
 	optionsJSON, _ := json.Marshal(options)
 
 	query := `
-		INSERT INTO quiz_questions (id, event_id, section, key, question_text, correct_answers, options, sort_order, is_scorable, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO quiz_questions (id, event_id, section, key, question_text, correct_answers, options, sort_order, is_scorable, points, partial_credit, fuzzy_match, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	_, err := r.db.Exec(query,
 		question.ID, question.EventID, question.Section, question.Key, question.QuestionText,
-		correctAnswersJSON, optionsJSON, question.SortOrder, question.IsScorable, question.CreatedAt)
+		correctAnswersJSON, optionsJSON, question.SortOrder, question.IsScorable,
+		question.Points, marshalPartialCredit(question.PartialCredit), marshalFuzzyMatch(question.Fuzzy), question.CreatedAt)
 
 	if err != nil {
 		return nil, err
@@ -59,19 +108,9 @@ func (r *QuizQuestionRepository) Create(eventID uuid.UUID, section, key, questio
 
 // GetByID obtiene una pregunta por su ID
 func (r *QuizQuestionRepository) GetByID(id uuid.UUID) (*models.QuizQuestion, error) {
-	question := &models.QuizQuestion{}
-	var correctAnswersJSON, optionsJSON []byte
-
-	query := `
-		SELECT id, event_id, section, key, question_text, correct_answers, options, sort_order, is_scorable, created_at
-		FROM quiz_questions
-		WHERE id = $1
-	`
+	query := `SELECT ` + quizQuestionCols + ` FROM quiz_questions WHERE id = $1`
 
-	err := r.db.QueryRow(query, id).Scan(
-		&question.ID, &question.EventID, &question.Section, &question.Key, &question.QuestionText,
-		&correctAnswersJSON, &optionsJSON, &question.SortOrder, &question.IsScorable, &question.CreatedAt,
-	)
+	question, err := scanQuizQuestion(r.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrQuestionNotFound
@@ -79,16 +118,13 @@ func (r *QuizQuestionRepository) GetByID(id uuid.UUID) (*models.QuizQuestion, er
 		return nil, err
 	}
 
-	json.Unmarshal(correctAnswersJSON, &question.CorrectAnswers)
-	json.Unmarshal(optionsJSON, &question.Options)
-
 	return question, nil
 }
 
 // ListByEvent obtiene todas las preguntas de un evento ordenadas por sort_order
 func (r *QuizQuestionRepository) ListByEvent(eventID uuid.UUID) ([]models.QuizQuestion, error) {
 	query := `
-		SELECT id, event_id, section, key, question_text, correct_answers, options, sort_order, is_scorable, created_at
+		SELECT ` + quizQuestionCols + `
 		FROM quiz_questions
 		WHERE event_id = $1
 		ORDER BY section, sort_order
@@ -102,21 +138,11 @@ func (r *QuizQuestionRepository) ListByEvent(eventID uuid.UUID) ([]models.QuizQu
 
 	var questions []models.QuizQuestion
 	for rows.Next() {
-		var question models.QuizQuestion
-		var correctAnswersJSON, optionsJSON []byte
-
-		err := rows.Scan(
-			&question.ID, &question.EventID, &question.Section, &question.Key, &question.QuestionText,
-			&correctAnswersJSON, &optionsJSON, &question.SortOrder, &question.IsScorable, &question.CreatedAt,
-		)
+		question, err := scanQuizQuestion(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		json.Unmarshal(correctAnswersJSON, &question.CorrectAnswers)
-		json.Unmarshal(optionsJSON, &question.Options)
-
-		questions = append(questions, question)
+		questions = append(questions, *question)
 	}
 
 	return questions, nil
@@ -125,7 +151,7 @@ func (r *QuizQuestionRepository) ListByEvent(eventID uuid.UUID) ([]models.QuizQu
 // ListByEventAndSection obtiene preguntas de un evento filtradas por sección
 func (r *QuizQuestionRepository) ListByEventAndSection(eventID uuid.UUID, section string) ([]models.QuizQuestion, error) {
 	query := `
-		SELECT id, event_id, section, key, question_text, correct_answers, options, sort_order, is_scorable, created_at
+		SELECT ` + quizQuestionCols + `
 		FROM quiz_questions
 		WHERE event_id = $1 AND section = $2
 		ORDER BY sort_order
@@ -139,21 +165,11 @@ func (r *QuizQuestionRepository) ListByEventAndSection(eventID uuid.UUID, sectio
 
 	var questions []models.QuizQuestion
 	for rows.Next() {
-		var question models.QuizQuestion
-		var correctAnswersJSON, optionsJSON []byte
-
-		err := rows.Scan(
-			&question.ID, &question.EventID, &question.Section, &question.Key, &question.QuestionText,
-			&correctAnswersJSON, &optionsJSON, &question.SortOrder, &question.IsScorable, &question.CreatedAt,
-		)
+		question, err := scanQuizQuestion(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		json.Unmarshal(correctAnswersJSON, &question.CorrectAnswers)
-		json.Unmarshal(optionsJSON, &question.Options)
-
-		questions = append(questions, question)
+		questions = append(questions, *question)
 	}
 
 	return questions, nil
@@ -166,14 +182,15 @@ func (r *QuizQuestionRepository) Update(question *models.QuizQuestion) error {
 
 	query := `
 		UPDATE quiz_questions
-		SET section = $1, key = $2, question_text = $3, correct_answers = $4, 
-		    options = $5, sort_order = $6, is_scorable = $7
-		WHERE id = $8
+		SET section = $1, key = $2, question_text = $3, correct_answers = $4,
+		    options = $5, sort_order = $6, is_scorable = $7, points = $8, partial_credit = $9, fuzzy_match = $10
+		WHERE id = $11
 	`
 
 	_, err := r.db.Exec(query,
 		question.Section, question.Key, question.QuestionText,
-		correctAnswersJSON, optionsJSON, question.SortOrder, question.IsScorable, question.ID)
+		correctAnswersJSON, optionsJSON, question.SortOrder, question.IsScorable,
+		question.Points, marshalPartialCredit(question.PartialCredit), marshalFuzzyMatch(question.Fuzzy), question.ID)
 
 	return err
 }
@@ -6,8 +6,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/the-mile-game/backend/internal/models"
 )
 
+// defaultScoring puntuación por defecto para preguntas de test
+var defaultScoring = models.QuestionScoring{Points: models.DefaultQuestionPoints}
+
 func TestQuizQuestionRepository_Create(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -27,6 +31,7 @@ func TestQuizQuestionRepository_Create(t *testing.T) {
 			nil,
 			1,
 			true,
+			defaultScoring,
 		)
 
 		require.NoError(t, err)
@@ -53,7 +58,7 @@ func TestQuizQuestionRepository_GetByID(t *testing.T) {
 	// Create a question first
 	created, err := repo.Create(
 		eventID, "favorites", "test_key", "Test question?",
-		[]string{"Answer"}, nil, 1, true,
+		[]string{"Answer"}, nil, 1, true, defaultScoring,
 	)
 	require.NoError(t, err)
 
@@ -70,6 +75,38 @@ func TestQuizQuestionRepository_GetByID(t *testing.T) {
 	})
 }
 
+func TestQuizQuestionRepository_Scoring(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	repo := NewQuizQuestionRepository(db)
+	eventID := uuid.MustParse(createTestEvent(t, db, "test-event"))
+
+	scoring := models.QuestionScoring{
+		Points:        3,
+		PartialCredit: &models.PartialCreditRule{Answers: []string{"margarita"}, Points: 1},
+	}
+	created, err := repo.Create(eventID, "favorites", "flower", "Flower?", []string{"girasol"}, nil, 1, true, scoring)
+	require.NoError(t, err)
+
+	question, err := repo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, question.Points)
+	require.NotNil(t, question.PartialCredit)
+	assert.Equal(t, []string{"margarita"}, question.PartialCredit.Answers)
+
+	// Quitar la regla de crédito parcial
+	question.PartialCredit = nil
+	question.Points = 2
+	require.NoError(t, repo.Update(question))
+
+	updated, err := repo.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Points)
+	assert.Nil(t, updated.PartialCredit)
+}
+
 func TestQuizQuestionRepository_ListByEvent(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -80,9 +117,9 @@ func TestQuizQuestionRepository_ListByEvent(t *testing.T) {
 	eventID := uuid.MustParse(eventIDStr)
 
 	// Create multiple questions
-	_, err := repo.Create(eventID, "favorites", "q1", "Q1?", []string{"A"}, nil, 1, true)
+	_, err := repo.Create(eventID, "favorites", "q1", "Q1?", []string{"A"}, nil, 1, true, defaultScoring)
 	require.NoError(t, err)
-	_, err = repo.Create(eventID, "preferences", "q2", "Q2?", []string{"B"}, []string{"A", "B"}, 2, true)
+	_, err = repo.Create(eventID, "preferences", "q2", "Q2?", []string{"B"}, []string{"A", "B"}, 2, true, defaultScoring)
 	require.NoError(t, err)
 
 	t.Run("returns all questions", func(t *testing.T) {
@@ -110,11 +147,11 @@ func TestQuizQuestionRepository_ListByEventAndSection(t *testing.T) {
 	eventID := uuid.MustParse(eventIDStr)
 
 	// Create questions in different sections
-	_, err := repo.Create(eventID, "favorites", "f1", "F1?", []string{"A"}, nil, 1, true)
+	_, err := repo.Create(eventID, "favorites", "f1", "F1?", []string{"A"}, nil, 1, true, defaultScoring)
 	require.NoError(t, err)
-	_, err = repo.Create(eventID, "favorites", "f2", "F2?", []string{"B"}, nil, 2, true)
+	_, err = repo.Create(eventID, "favorites", "f2", "F2?", []string{"B"}, nil, 2, true, defaultScoring)
 	require.NoError(t, err)
-	_, err = repo.Create(eventID, "preferences", "p1", "P1?", []string{"A"}, []string{"A", "B"}, 1, true)
+	_, err = repo.Create(eventID, "preferences", "p1", "P1?", []string{"A"}, []string{"A", "B"}, 1, true, defaultScoring)
 	require.NoError(t, err)
 
 	t.Run("filters by section", func(t *testing.T) {
@@ -147,6 +184,7 @@ func TestQuizQuestionRepository_Update(t *testing.T) {
 	created, err := repo.Create(
 		eventID, "favorites", "original_key", "Original?",
 		[]string{"Old"}, nil, 1, true,
+		defaultScoring,
 	)
 	require.NoError(t, err)
 
@@ -180,6 +218,7 @@ func TestQuizQuestionRepository_Delete(t *testing.T) {
 	created, err := repo.Create(
 		eventID, "favorites", "delete_me", "Delete me?",
 		[]string{"Yes"}, nil, 1, true,
+		defaultScoring,
 	)
 	require.NoError(t, err)
 
@@ -201,11 +240,11 @@ func TestQuizQuestionRepository_UpdateSortOrder(t *testing.T) {
 	eventID := uuid.MustParse(eventIDStr)
 
 	// Create questions
-	q1, err := repo.Create(eventID, "favorites", "q1", "Q1?", []string{"A"}, nil, 1, true)
+	q1, err := repo.Create(eventID, "favorites", "q1", "Q1?", []string{"A"}, nil, 1, true, defaultScoring)
 	require.NoError(t, err)
-	q2, err := repo.Create(eventID, "favorites", "q2", "Q2?", []string{"B"}, nil, 2, true)
+	q2, err := repo.Create(eventID, "favorites", "q2", "Q2?", []string{"B"}, nil, 2, true, defaultScoring)
 	require.NoError(t, err)
-	q3, err := repo.Create(eventID, "favorites", "q3", "Q3?", []string{"C"}, nil, 3, true)
+	q3, err := repo.Create(eventID, "favorites", "q3", "Q3?", []string{"C"}, nil, 3, true, defaultScoring)
 	require.NoError(t, err)
 
 	t.Run("updates sort orders", func(t *testing.T) {
@@ -250,11 +289,11 @@ func TestQuizQuestionRepository_CountByEvent(t *testing.T) {
 		assert.Equal(t, 0, count)
 
 		// Add 3 questions
-		_, err = repo.Create(eventID, "favorites", "q1", "Q1?", []string{"A"}, nil, 1, true)
+		_, err = repo.Create(eventID, "favorites", "q1", "Q1?", []string{"A"}, nil, 1, true, defaultScoring)
 		require.NoError(t, err)
-		_, err = repo.Create(eventID, "favorites", "q2", "Q2?", []string{"B"}, nil, 2, true)
+		_, err = repo.Create(eventID, "favorites", "q2", "Q2?", []string{"B"}, nil, 2, true, defaultScoring)
 		require.NoError(t, err)
-		_, err = repo.Create(eventID, "preferences", "q3", "Q3?", []string{"C"}, []string{"A", "B"}, 1, true)
+		_, err = repo.Create(eventID, "preferences", "q3", "Q3?", []string{"C"}, []string{"A", "B"}, 1, true, defaultScoring)
 		require.NoError(t, err)
 
 		count, err = repo.CountByEvent(eventID)
@@ -276,6 +315,7 @@ func TestQuizQuestionRepository_KeyExists(t *testing.T) {
 	_, err := repo.Create(
 		eventID, "favorites", "existing_key", "Existing?",
 		[]string{"Yes"}, nil, 1, true,
+		defaultScoring,
 	)
 	require.NoError(t, err)
 
@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -107,3 +108,137 @@ func (r *QuizRepository) GetAnswersByPlayerID(playerID uuid.UUID) (*models.QuizA
 
 	return &answers, nil
 }
+
+// ListAnswersByEvent obtiene las respuestas de todos los jugadores de un evento
+func (r *QuizRepository) ListAnswersByEvent(eventID uuid.UUID) ([]models.PlayerQuizAnswers, error) {
+	query := `
+		SELECT p.id, p.event_id, p.name, p.avatar, p.score, p.created_at, qa.favorites, qa.preferences
+		FROM quiz_answers qa
+		JOIN players p ON p.id = qa.player_id
+		WHERE p.event_id = $1
+		ORDER BY p.name ASC
+	`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := []models.PlayerQuizAnswers{}
+	for rows.Next() {
+		var a models.PlayerQuizAnswers
+		var favoritesJSON, preferencesJSON []byte
+		err := rows.Scan(
+			&a.Player.ID, &a.Player.EventID, &a.Player.Name, &a.Player.Avatar, &a.Player.Score, &a.Player.CreatedAt,
+			&favoritesJSON, &preferencesJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(favoritesJSON, &a.Favorites); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(preferencesJSON, &a.Preferences); err != nil {
+			return nil, err
+		}
+		answers = append(answers, a)
+	}
+
+	return answers, rows.Err()
+}
+
+// AcceptAnswer registra que el host aceptó la respuesta de un jugador a una pregunta
+func (r *QuizRepository) AcceptAnswer(playerID uuid.UUID, questionKey, answer string, acceptedBy uuid.UUID) error {
+	query := `
+		INSERT INTO quiz_answer_overrides (player_id, question_key, answer, accepted_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (player_id, question_key) DO UPDATE SET
+			answer = EXCLUDED.answer,
+			accepted_by = EXCLUDED.accepted_by,
+			created_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.Exec(query, playerID, questionKey, answer, acceptedBy)
+	return err
+}
+
+// RemoveAcceptedAnswer elimina la aceptación manual de una respuesta.
+// Solo borra si el jugador pertenece al evento indicado.
+func (r *QuizRepository) RemoveAcceptedAnswer(eventID, playerID uuid.UUID, questionKey string) error {
+	query := `
+		DELETE FROM quiz_answer_overrides
+		WHERE player_id = $1 AND question_key = $2
+		  AND player_id IN (SELECT id FROM players WHERE event_id = $3)
+	`
+
+	result, err := r.db.Exec(query, playerID, questionKey, eventID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrAnswerOverrideNotFound
+	}
+
+	return nil
+}
+
+// ListAcceptedByEvent obtiene las respuestas aceptadas por el host en un evento,
+// agrupadas por jugador (player_id → key → respuesta aceptada)
+func (r *QuizRepository) ListAcceptedByEvent(eventID uuid.UUID) (map[uuid.UUID]map[string]string, error) {
+	query := `
+		SELECT o.player_id, o.question_key, o.answer
+		FROM quiz_answer_overrides o
+		JOIN players p ON p.id = o.player_id
+		WHERE p.event_id = $1
+	`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accepted := make(map[uuid.UUID]map[string]string)
+	for rows.Next() {
+		var playerID uuid.UUID
+		var key, answer string
+		if err := rows.Scan(&playerID, &key, &answer); err != nil {
+			return nil, err
+		}
+		if accepted[playerID] == nil {
+			accepted[playerID] = make(map[string]string)
+		}
+		accepted[playerID][key] = answer
+	}
+
+	return accepted, rows.Err()
+}
+
+// ListAcceptedByPlayer obtiene las respuestas aceptadas por el host para un jugador
+func (r *QuizRepository) ListAcceptedByPlayer(playerID uuid.UUID) (map[string]string, error) {
+	rows, err := r.db.Query(`SELECT question_key, answer FROM quiz_answer_overrides WHERE player_id = $1`, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accepted := make(map[string]string)
+	for rows.Next() {
+		var key, answer string
+		if err := rows.Scan(&key, &answer); err != nil {
+			return nil, err
+		}
+		accepted[key] = answer
+	}
+
+	return accepted, rows.Err()
+}
+
+// ErrAnswerOverrideNotFound la respuesta no tenía aceptación manual
+var ErrAnswerOverrideNotFound = errors.New("answer override not found")
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// RefreshTokenRepository maneja los refresh tokens persistidos (solo su hash)
+type RefreshTokenRepository struct {
+	db *sql.DB
+}
+
+// NewRefreshTokenRepository crea un nuevo repositorio de refresh tokens
+func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+const refreshTokenCols = `id, user_id, token_hash, family_id, expires_at, created_at, revoked_at, replaced_by`
+
+func scanRefreshToken(row interface{ Scan(...any) error }) (*models.RefreshToken, error) {
+	t := &models.RefreshToken{}
+	var revokedAt sql.NullTime
+	var replacedBy uuid.NullUUID
+	if err := row.Scan(&t.ID, &t.UserID, &t.TokenHash, &t.FamilyID, &t.ExpiresAt, &t.CreatedAt, &revokedAt, &replacedBy); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	if replacedBy.Valid {
+		t.ReplacedBy = &replacedBy.UUID
+	}
+	return t, nil
+}
+
+// Create guarda un refresh token nuevo
+func (r *RefreshTokenRepository) Create(token *models.RefreshToken) error {
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO refresh_tokens (id, user_id, token_hash, family_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, token.ID, token.UserID, token.TokenHash, token.FamilyID, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+// GetByHash obtiene un refresh token por el hash del token
+func (r *RefreshTokenRepository) GetByHash(tokenHash string) (*models.RefreshToken, error) {
+	t, err := scanRefreshToken(r.db.QueryRow(`SELECT `+refreshTokenCols+` FROM refresh_tokens WHERE token_hash = $1`, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Rotate revoca el token actual y guarda su reemplazo en una sola transacción.
+// Si el token ya había sido revocado (p.ej. dos refresh concurrentes con el
+// mismo token) retorna ErrRefreshTokenRevoked y no inserta nada.
+func (r *RefreshTokenRepository) Rotate(oldID uuid.UUID, next *models.RefreshToken) error {
+	if next.CreatedAt.IsZero() {
+		next.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// El nuevo token debe existir antes de referenciarlo desde replaced_by
+	if _, err := tx.Exec(`
+		INSERT INTO refresh_tokens (id, user_id, token_hash, family_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, next.ID, next.UserID, next.TokenHash, next.FamilyID, next.ExpiresAt, next.CreatedAt); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE refresh_tokens
+		SET revoked_at = $2, replaced_by = $3
+		WHERE id = $1 AND revoked_at IS NULL
+	`, oldID, next.CreatedAt, next.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrRefreshTokenRevoked
+	}
+
+	return tx.Commit()
+}
+
+// RevokeFamily revoca todos los tokens activos de una familia de rotación
+func (r *RefreshTokenRepository) RevokeFamily(familyID uuid.UUID) error {
+	_, err := r.db.Exec(`
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`, familyID)
+	return err
+}
+
+// RevokeAllForUser revoca todos los tokens activos de un usuario
+func (r *RefreshTokenRepository) RevokeAllForUser(userID uuid.UUID) error {
+	_, err := r.db.Exec(`
+		UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`, userID)
+	return err
+}
+
+// ErrRefreshTokenNotFound se retorna cuando el hash no corresponde a ningún token
+var ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+// ErrRefreshTokenRevoked se retorna al intentar rotar un token ya revocado
+var ErrRefreshTokenRevoked = errors.New("refresh token already revoked")
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func newTestRefreshToken(userID, familyID uuid.UUID) *models.RefreshToken {
+	return &models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: uuid.New().String(),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestRefreshTokenRepository_CreateAndGetByHash(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	repo := NewRefreshTokenRepository(db)
+	user := createTestUser(t, db)
+
+	token := newTestRefreshToken(user.ID, uuid.New())
+	if err := repo.Create(token); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got, err := repo.GetByHash(token.TokenHash)
+	if err != nil {
+		t.Fatalf("GetByHash failed: %v", err)
+	}
+	if got.ID != token.ID || got.FamilyID != token.FamilyID {
+		t.Errorf("GetByHash returned %+v, want id %s family %s", got, token.ID, token.FamilyID)
+	}
+	if !got.IsActive(time.Now()) {
+		t.Error("Expected new token to be active")
+	}
+
+	if _, err := repo.GetByHash("does-not-exist"); err != ErrRefreshTokenNotFound {
+		t.Errorf("Expected ErrRefreshTokenNotFound, got %v", err)
+	}
+}
+
+func TestRefreshTokenRepository_Rotate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	repo := NewRefreshTokenRepository(db)
+	user := createTestUser(t, db)
+	familyID := uuid.New()
+
+	first := newTestRefreshToken(user.ID, familyID)
+	if err := repo.Create(first); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	second := newTestRefreshToken(user.ID, familyID)
+	if err := repo.Rotate(first.ID, second); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	old, err := repo.GetByHash(first.TokenHash)
+	if err != nil {
+		t.Fatalf("GetByHash failed: %v", err)
+	}
+	if old.RevokedAt == nil {
+		t.Error("Expected rotated token to be revoked")
+	}
+	if old.ReplacedBy == nil || *old.ReplacedBy != second.ID {
+		t.Errorf("Expected replaced_by %s, got %v", second.ID, old.ReplacedBy)
+	}
+
+	// Rotar de nuevo el mismo token debe fallar sin insertar el reemplazo
+	third := newTestRefreshToken(user.ID, familyID)
+	if err := repo.Rotate(first.ID, third); err != ErrRefreshTokenRevoked {
+		t.Errorf("Expected ErrRefreshTokenRevoked, got %v", err)
+	}
+	if _, err := repo.GetByHash(third.TokenHash); err != ErrRefreshTokenNotFound {
+		t.Errorf("Expected failed rotation to be rolled back, got %v", err)
+	}
+}
+
+func TestRefreshTokenRepository_RevokeFamilyAndUser(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	repo := NewRefreshTokenRepository(db)
+	user := createTestUser(t, db)
+
+	familyA := newTestRefreshToken(user.ID, uuid.New())
+	familyB := newTestRefreshToken(user.ID, uuid.New())
+	for _, tok := range []*models.RefreshToken{familyA, familyB} {
+		if err := repo.Create(tok); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	if err := repo.RevokeFamily(familyA.FamilyID); err != nil {
+		t.Fatalf("RevokeFamily failed: %v", err)
+	}
+	a, _ := repo.GetByHash(familyA.TokenHash)
+	b, _ := repo.GetByHash(familyB.TokenHash)
+	if a.RevokedAt == nil {
+		t.Error("Expected family A to be revoked")
+	}
+	if b.RevokedAt != nil {
+		t.Error("Expected family B to stay active")
+	}
+
+	if err := repo.RevokeAllForUser(user.ID); err != nil {
+		t.Fatalf("RevokeAllForUser failed: %v", err)
+	}
+	b, _ = repo.GetByHash(familyB.TokenHash)
+	if b.RevokedAt == nil {
+		t.Error("Expected all user tokens to be revoked")
+	}
+}
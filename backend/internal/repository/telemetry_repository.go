@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// TelemetryRepository registra los eventos de analytics del quiz y las postales
+// (tablas quiz_events y postcard_events)
+type TelemetryRepository struct {
+	db *sql.DB
+}
+
+// NewTelemetryRepository crea un nuevo repositorio de telemetría
+func NewTelemetryRepository(db *sql.DB) *TelemetryRepository {
+	return &TelemetryRepository{db: db}
+}
+
+// LogQuizStarted registra el inicio del quiz de un jugador. Es idempotente:
+// si el jugador ya había empezado, devuelve el inicio original sin crear otro.
+func (r *TelemetryRepository) LogQuizStarted(eventID, playerID uuid.UUID) (*models.QuizStart, error) {
+	// El SELECT sobre players garantiza que el jugador pertenece al evento
+	query := `
+		INSERT INTO quiz_events (event_id, player_id, event_type)
+		SELECT $1::uuid, id, $3::varchar FROM players WHERE id = $2 AND event_id = $1
+		ON CONFLICT (event_id, player_id) WHERE event_type = 'started' DO NOTHING
+		RETURNING created_at
+	`
+
+	start := &models.QuizStart{}
+	err := r.db.QueryRow(query, eventID, playerID, models.QuizEventStarted).Scan(&start.StartedAt)
+	if err == nil {
+		return start, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to log quiz start: %w", err)
+	}
+
+	// Sin filas: o ya había empezado, o el jugador no es de este evento
+	err = r.db.QueryRow(`
+		SELECT created_at FROM quiz_events
+		WHERE event_id = $1 AND player_id = $2 AND event_type = $3
+	`, eventID, playerID, models.QuizEventStarted).Scan(&start.StartedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrPlayerNotInEvent
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz start: %w", err)
+	}
+
+	start.AlreadyStarted = true
+	return start, nil
+}
+
+// LogQuizCompleted registra que el jugador completó el quiz con su puntaje.
+// El tiempo se mide desde su inicio registrado (nil si nunca llamó a start).
+// Si el jugador reenvía el quiz solo se actualiza el puntaje: el tiempo
+// corresponde al primer envío.
+func (r *TelemetryRepository) LogQuizCompleted(eventID, playerID uuid.UUID, score int) error {
+	query := `
+		INSERT INTO quiz_events (event_id, player_id, event_type, score, time_spent_seconds)
+		VALUES ($1, $2, $4, $3, (
+			SELECT GREATEST(0, EXTRACT(EPOCH FROM (NOW() - created_at)))::int
+			FROM quiz_events
+			WHERE event_id = $1 AND player_id = $2 AND event_type = $5
+		))
+		ON CONFLICT (event_id, player_id) WHERE event_type = 'completed'
+		DO UPDATE SET score = EXCLUDED.score
+	`
+
+	if _, err := r.db.Exec(query, eventID, playerID, score, models.QuizEventCompleted, models.QuizEventStarted); err != nil {
+		return fmt.Errorf("failed to log quiz completion: %w", err)
+	}
+	return nil
+}
+
+// LogPostcardEvent registra la creación o visualización de una postal
+func (r *TelemetryRepository) LogPostcardEvent(eventID uuid.UUID, playerID *uuid.UUID, postcardID uuid.UUID, eventType string) error {
+	query := `
+		INSERT INTO postcard_events (event_id, player_id, postcard_id, event_type)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.Exec(query, eventID, playerID, postcardID, eventType)
+	return err
+}
+
+// ErrPlayerNotInEvent el jugador no existe o no pertenece al evento
+var ErrPlayerNotInEvent = errors.New("player does not belong to this event")
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// UploadSessionRepository handles database operations for resumable uploads
+type UploadSessionRepository struct {
+	db *sql.DB
+}
+
+// NewUploadSessionRepository creates a new upload session repository
+func NewUploadSessionRepository(db *sql.DB) *UploadSessionRepository {
+	return &UploadSessionRepository{db: db}
+}
+
+const uploadSessionCols = `id, event_id, upload_length, upload_offset, created_at, expires_at, claimed_at`
+
+func scanUploadSession(row interface {
+	Scan(...any) error
+}) (*models.UploadSession, error) {
+	var s models.UploadSession
+	if err := row.Scan(&s.ID, &s.EventID, &s.Length, &s.Offset, &s.CreatedAt, &s.ExpiresAt, &s.ClaimedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Create opens an upload session of length bytes that expires after ttl
+func (r *UploadSessionRepository) Create(eventID uuid.UUID, length int64, ttl time.Duration) (*models.UploadSession, error) {
+	now := time.Now()
+	session := &models.UploadSession{
+		ID:        uuid.New(),
+		EventID:   eventID,
+		Length:    length,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO upload_sessions (id, event_id, upload_length, upload_offset, created_at, expires_at)
+		VALUES ($1, $2, $3, 0, $4, $5)
+	`, session.ID, session.EventID, session.Length, session.CreatedAt, session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetByID returns an upload session that has not expired
+func (r *UploadSessionRepository) GetByID(id uuid.UUID) (*models.UploadSession, error) {
+	session, err := scanUploadSession(r.db.QueryRow(`
+		SELECT `+uploadSessionCols+`
+		FROM upload_sessions
+		WHERE id = $1 AND expires_at > NOW()
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrUploadSessionNotFound
+	}
+	return session, err
+}
+
+// AppendChunk records a chunk stored at expectedOffset and advances the session
+// offset. Returns ErrUploadOffsetMismatch if another chunk got there first (the
+// caller must delete its stored chunk).
+func (r *UploadSessionRepository) AppendChunk(sessionID uuid.UUID, expectedOffset int64, chunk models.UploadChunk) (*models.UploadSession, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	session, err := scanUploadSession(tx.QueryRow(`
+		UPDATE upload_sessions
+		SET upload_offset = upload_offset + $1
+		WHERE id = $2 AND upload_offset = $3 AND upload_offset + $1 <= upload_length AND expires_at > NOW()
+		RETURNING `+uploadSessionCols,
+		chunk.Size, sessionID, expectedOffset))
+	if err == sql.ErrNoRows {
+		return nil, ErrUploadOffsetMismatch
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO upload_chunks (session_id, chunk_offset, chunk_size, media_key)
+		VALUES ($1, $2, $3, $4)
+	`, sessionID, expectedOffset, chunk.Size, chunk.Key); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListChunks returns the chunks of a session in upload order
+func (r *UploadSessionRepository) ListChunks(sessionID uuid.UUID) ([]models.UploadChunk, error) {
+	rows, err := r.db.Query(`
+		SELECT chunk_offset, chunk_size, media_key
+		FROM upload_chunks
+		WHERE session_id = $1
+		ORDER BY chunk_offset ASC
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chunks []models.UploadChunk
+	for rows.Next() {
+		var chunk models.UploadChunk
+		if err := rows.Scan(&chunk.Offset, &chunk.Size, &chunk.Key); err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, rows.Err()
+}
+
+// ListExpired returns up to limit sessions whose expiry has passed
+func (r *UploadSessionRepository) ListExpired(limit int) ([]models.UploadSession, error) {
+	rows, err := r.db.Query(`
+		SELECT `+uploadSessionCols+`
+		FROM upload_sessions
+		WHERE expires_at <= NOW()
+		ORDER BY expires_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []models.UploadSession
+	for rows.Next() {
+		session, err := scanUploadSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, rows.Err()
+}
+
+// Claim marks a complete session as being turned into a postcard. Only one
+// caller wins: the rest get ErrUploadSessionClaimed until Release or Delete.
+func (r *UploadSessionRepository) Claim(id uuid.UUID) (*models.UploadSession, error) {
+	session, err := scanUploadSession(r.db.QueryRow(`
+		UPDATE upload_sessions
+		SET claimed_at = NOW()
+		WHERE id = $1 AND claimed_at IS NULL AND upload_offset = upload_length AND expires_at > NOW()
+		RETURNING `+uploadSessionCols, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrUploadSessionClaimed
+	}
+	return session, err
+}
+
+// Release drops the claim of a session whose postcard could not be created,
+// so the guest can retry with the same upload
+func (r *UploadSessionRepository) Release(id uuid.UUID) error {
+	_, err := r.db.Exec(`UPDATE upload_sessions SET claimed_at = NULL WHERE id = $1`, id)
+	return err
+}
+
+// Delete removes a session and its chunk rows. The chunk objects must be
+// deleted from the MediaStore by the caller.
+func (r *UploadSessionRepository) Delete(id uuid.UUID) error {
+	result, err := r.db.Exec(`DELETE FROM upload_sessions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrUploadSessionNotFound
+	}
+	return nil
+}
+
+// ErrUploadSessionNotFound is returned when the upload session does not exist or expired
+var ErrUploadSessionNotFound = errors.New("upload session not found")
+
+// ErrUploadSessionClaimed is returned when another request is already finishing the upload
+var ErrUploadSessionClaimed = errors.New("upload session already claimed")
+
+// ErrUploadOffsetMismatch is returned when a chunk does not start at the session offset
+var ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
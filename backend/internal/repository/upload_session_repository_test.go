@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestUploadSessionRepository_ClaimOnce(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(t, db)
+
+	user := createTestUser(t, db)
+	event, err := NewEventRepository(db).Create(user.ID, "upload-claim", "Upload Claim", "",
+		models.EventFeatures{}, models.EventSettings{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	repo := NewUploadSessionRepository(db)
+	session, err := repo.Create(event.ID, 4, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	// Incomplete uploads cannot be claimed
+	if _, err := repo.Claim(session.ID); err != ErrUploadSessionClaimed {
+		t.Fatalf("Expected ErrUploadSessionClaimed for an incomplete upload, got: %v", err)
+	}
+
+	if _, err := repo.AppendChunk(session.ID, 0, models.UploadChunk{Offset: 0, Size: 4, Key: "uploads/chunk"}); err != nil {
+		t.Fatalf("Failed to append chunk: %v", err)
+	}
+
+	claimed, err := repo.Claim(session.ID)
+	if err != nil {
+		t.Fatalf("Expected first claim to win, got: %v", err)
+	}
+	if claimed.ClaimedAt == nil {
+		t.Error("Expected claimed_at to be set")
+	}
+	if _, err := repo.Claim(session.ID); err != ErrUploadSessionClaimed {
+		t.Errorf("Expected second claim to lose, got: %v", err)
+	}
+
+	// Release lets the guest retry
+	if err := repo.Release(session.ID); err != nil {
+		t.Fatalf("Failed to release: %v", err)
+	}
+	if _, err := repo.Claim(session.ID); err != nil {
+		t.Errorf("Expected claim after release, got: %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -12,18 +14,29 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// RefreshTokenStore persiste los refresh tokens y sus familias de rotación
+type RefreshTokenStore interface {
+	Create(token *models.RefreshToken) error
+	GetByHash(tokenHash string) (*models.RefreshToken, error)
+	Rotate(oldID uuid.UUID, next *models.RefreshToken) error
+	RevokeFamily(familyID uuid.UUID) error
+	RevokeAllForUser(userID uuid.UUID) error
+}
+
 // AuthService maneja la lógica de autenticación
 type AuthService struct {
 	userRepo        *repository.UserRepository
+	refreshRepo     RefreshTokenStore
 	jwtSecret       []byte
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
 }
 
 // NewAuthService crea un nuevo servicio de autenticación
-func NewAuthService(userRepo *repository.UserRepository, jwtSecret string) *AuthService {
+func NewAuthService(userRepo *repository.UserRepository, refreshRepo RefreshTokenStore, jwtSecret string) *AuthService {
 	return &AuthService{
 		userRepo:        userRepo,
+		refreshRepo:     refreshRepo,
 		jwtSecret:       []byte(jwtSecret),
 		accessTokenTTL:  15 * time.Minute,   // 15 minutos
 		refreshTokenTTL: 7 * 24 * time.Hour, // 7 días
@@ -48,8 +61,8 @@ func (s *AuthService) Register(email, password, name string) (*models.AuthRespon
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// Generar tokens
-	return s.generateTokenResponse(user)
+	// Generar tokens (nueva familia de rotación)
+	return s.generateTokenResponse(user, uuid.New())
 }
 
 // Login valida credenciales y retorna tokens
@@ -68,26 +81,97 @@ func (s *AuthService) Login(email, password string) (*models.AuthResponse, error
 		return nil, ErrInvalidCredentials
 	}
 
-	// Generar tokens
-	return s.generateTokenResponse(user)
+	// Generar tokens (nueva familia de rotación)
+	return s.generateTokenResponse(user, uuid.New())
 }
 
-// Refresh genera nuevos tokens a partir de un refresh token válido
+// Refresh rota un refresh token válido: revoca el actual y emite un par nuevo
+// dentro de la misma familia. Presentar un token ya rotado o revocado se trata
+// como robo: se revoca toda la familia y se retorna ErrRefreshTokenReused.
 func (s *AuthService) Refresh(refreshToken string) (*models.AuthResponse, error) {
-	// Validar refresh token
+	// Validar firma y expiración
 	claims, err := s.validateRefreshToken(refreshToken)
 	if err != nil {
 		return nil, ErrInvalidRefreshToken
 	}
 
+	// Buscar el token persistido
+	stored, err := s.refreshRepo.GetByHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if stored.UserID != claims.UserID {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Detección de reuso: el token ya fue rotado o revocado
+	if stored.RevokedAt != nil {
+		if err := s.refreshRepo.RevokeFamily(stored.FamilyID); err != nil {
+			return nil, fmt.Errorf("failed to revoke token family: %w", err)
+		}
+		return nil, ErrRefreshTokenReused
+	}
+	if !stored.IsActive(time.Now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+
 	// Buscar usuario
 	user, err := s.userRepo.GetByID(claims.UserID)
 	if err != nil {
 		return nil, ErrInvalidRefreshToken
 	}
 
-	// Generar nuevos tokens
-	return s.generateTokenResponse(user)
+	// Generar nuevos tokens y reemplazar el actual
+	accessToken, err := s.generateAccessToken(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	newRefreshToken, record, err := s.generateRefreshToken(user, stored.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	if err := s.refreshRepo.Rotate(stored.ID, record); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenRevoked) {
+			// Otro request rotó el mismo token primero: también es reuso
+			if err := s.refreshRepo.RevokeFamily(stored.FamilyID); err != nil {
+				return nil, fmt.Errorf("failed to revoke token family: %w", err)
+			}
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return &models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    int(s.accessTokenTTL.Seconds()),
+		User:         *user,
+	}, nil
+}
+
+// Logout revoca refresh tokens del usuario.
+// Con refreshToken se revoca solo la familia de esa sesión; sin él, todas.
+func (s *AuthService) Logout(userID uuid.UUID, refreshToken string) error {
+	if refreshToken == "" {
+		return s.refreshRepo.RevokeAllForUser(userID)
+	}
+
+	stored, err := s.refreshRepo.GetByHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			// Nada que revocar: logout es idempotente
+			return nil
+		}
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if stored.UserID != userID {
+		return ErrInvalidRefreshToken
+	}
+
+	return s.refreshRepo.RevokeFamily(stored.FamilyID)
 }
 
 // ValidateToken valida un access token y retorna los claims
@@ -114,18 +198,22 @@ func (s *AuthService) ValidateToken(tokenString string) (*models.JWTClaims, erro
 }
 
 // generateTokenResponse genera access y refresh tokens para un usuario
-func (s *AuthService) generateTokenResponse(user *models.User) (*models.AuthResponse, error) {
+// y persiste el refresh token en la familia indicada
+func (s *AuthService) generateTokenResponse(user *models.User, familyID uuid.UUID) (*models.AuthResponse, error) {
 	// Generar access token
 	accessToken, err := s.generateAccessToken(user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	// Generar refresh token
-	refreshToken, err := s.generateRefreshToken(user)
+	// Generar y persistir refresh token
+	refreshToken, record, err := s.generateRefreshToken(user, familyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
+	if err := s.refreshRepo.Create(record); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
 
 	return &models.AuthResponse{
 		AccessToken:  accessToken,
@@ -153,9 +241,11 @@ func (s *AuthService) generateAccessToken(user *models.User) (string, error) {
 	return token.SignedString(s.jwtSecret)
 }
 
-// generateRefreshToken genera un refresh token (JWT simple)
-func (s *AuthService) generateRefreshToken(user *models.User) (string, error) {
+// generateRefreshToken genera un refresh token (JWT) y el registro a persistir.
+// El JTI coincide con el ID del registro; en la base solo se guarda el hash.
+func (s *AuthService) generateRefreshToken(user *models.User, familyID uuid.UUID) (string, *models.RefreshToken, error) {
 	now := time.Now()
+	tokenID := uuid.New()
 	claims := customClaims{
 		UserID: user.ID,
 		Email:  user.Email,
@@ -164,12 +254,30 @@ func (s *AuthService) generateRefreshToken(user *models.User) (string, error) {
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Subject:   user.ID.String(),
-			ID:        uuid.New().String(), // JTI para revocación si se implementa
+			ID:        tokenID.String(),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.jwtSecret)
+	signed, err := token.SignedString(s.jwtSecret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signed, &models.RefreshToken{
+		ID:        tokenID,
+		UserID:    user.ID,
+		TokenHash: hashRefreshToken(signed),
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(s.refreshTokenTTL),
+		CreatedAt: now,
+	}, nil
+}
+
+// hashRefreshToken calcula el hash SHA-256 (hex) con el que se persiste un refresh token
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 // validateRefreshToken valida un refresh token
@@ -207,4 +315,5 @@ var (
 	ErrInvalidCredentials  = errors.New("invalid credentials")
 	ErrInvalidToken        = errors.New("invalid or expired token")
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
 )
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// mockRefreshTokenStore guarda los refresh tokens en memoria y registra las revocaciones
+type mockRefreshTokenStore struct {
+	tokens          map[string]*models.RefreshToken
+	revokedFamilies []uuid.UUID
+	revokedUsers    []uuid.UUID
+}
+
+func newMockRefreshTokenStore() *mockRefreshTokenStore {
+	return &mockRefreshTokenStore{tokens: make(map[string]*models.RefreshToken)}
+}
+
+func (m *mockRefreshTokenStore) Create(token *models.RefreshToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockRefreshTokenStore) GetByHash(tokenHash string) (*models.RefreshToken, error) {
+	token, ok := m.tokens[tokenHash]
+	if !ok {
+		return nil, repository.ErrRefreshTokenNotFound
+	}
+	copied := *token
+	return &copied, nil
+}
+
+func (m *mockRefreshTokenStore) Rotate(oldID uuid.UUID, next *models.RefreshToken) error {
+	for _, token := range m.tokens {
+		if token.ID == oldID {
+			if token.RevokedAt != nil {
+				return repository.ErrRefreshTokenRevoked
+			}
+			now := time.Now()
+			token.RevokedAt = &now
+		}
+	}
+	m.tokens[next.TokenHash] = next
+	return nil
+}
+
+func (m *mockRefreshTokenStore) RevokeFamily(familyID uuid.UUID) error {
+	m.revokedFamilies = append(m.revokedFamilies, familyID)
+	return nil
+}
+
+func (m *mockRefreshTokenStore) RevokeAllForUser(userID uuid.UUID) error {
+	m.revokedUsers = append(m.revokedUsers, userID)
+	return nil
+}
+
+// issueRefreshToken emite y guarda un refresh token de una familia nueva
+func issueRefreshToken(t *testing.T, svc *AuthService, store *mockRefreshTokenStore, user *models.User) (string, *models.RefreshToken) {
+	t.Helper()
+	token, record, err := svc.generateRefreshToken(user, uuid.New())
+	if err != nil {
+		t.Fatalf("generateRefreshToken() error = %v", err)
+	}
+	if err := store.Create(record); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return token, record
+}
+
+func TestAuthService_RefreshReusedTokenRevokesFamily(t *testing.T) {
+	store := newMockRefreshTokenStore()
+	svc := NewAuthService(nil, store, "test-secret")
+	user := &models.User{ID: uuid.New(), Email: "owner@example.com"}
+
+	token, record := issueRefreshToken(t, svc, store, user)
+	rotatedAt := time.Now()
+	store.tokens[record.TokenHash].RevokedAt = &rotatedAt
+
+	if _, err := svc.Refresh(token); err != ErrRefreshTokenReused {
+		t.Fatalf("Refresh() error = %v, want ErrRefreshTokenReused", err)
+	}
+	if len(store.revokedFamilies) != 1 || store.revokedFamilies[0] != record.FamilyID {
+		t.Errorf("revoked families = %v, want [%s]", store.revokedFamilies, record.FamilyID)
+	}
+	if len(store.revokedUsers) != 0 {
+		t.Errorf("reuse must not log the user out everywhere, revoked users = %v", store.revokedUsers)
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "owner@example.com"}
+
+	t.Run("with a refresh token revokes only its family", func(t *testing.T) {
+		store := newMockRefreshTokenStore()
+		svc := NewAuthService(nil, store, "test-secret")
+		token, record := issueRefreshToken(t, svc, store, user)
+		issueRefreshToken(t, svc, store, user) // otra sesión del mismo usuario
+
+		if err := svc.Logout(user.ID, token); err != nil {
+			t.Fatalf("Logout() error = %v", err)
+		}
+		if len(store.revokedFamilies) != 1 || store.revokedFamilies[0] != record.FamilyID {
+			t.Errorf("revoked families = %v, want [%s]", store.revokedFamilies, record.FamilyID)
+		}
+		if len(store.revokedUsers) != 0 {
+			t.Errorf("revoked users = %v, want none", store.revokedUsers)
+		}
+	})
+
+	t.Run("without a refresh token revokes every session", func(t *testing.T) {
+		store := newMockRefreshTokenStore()
+		svc := NewAuthService(nil, store, "test-secret")
+		issueRefreshToken(t, svc, store, user)
+
+		if err := svc.Logout(user.ID, ""); err != nil {
+			t.Fatalf("Logout() error = %v", err)
+		}
+		if len(store.revokedUsers) != 1 || store.revokedUsers[0] != user.ID {
+			t.Errorf("revoked users = %v, want [%s]", store.revokedUsers, user.ID)
+		}
+		if len(store.revokedFamilies) != 0 {
+			t.Errorf("revoked families = %v, want none", store.revokedFamilies)
+		}
+	})
+
+	t.Run("with another user's token", func(t *testing.T) {
+		store := newMockRefreshTokenStore()
+		svc := NewAuthService(nil, store, "test-secret")
+		token, _ := issueRefreshToken(t, svc, store, &models.User{ID: uuid.New(), Email: "other@example.com"})
+
+		if err := svc.Logout(user.ID, token); err != ErrInvalidRefreshToken {
+			t.Errorf("Logout() error = %v, want ErrInvalidRefreshToken", err)
+		}
+		if len(store.revokedFamilies) != 0 || len(store.revokedUsers) != 0 {
+			t.Errorf("nothing must be revoked, got families %v users %v", store.revokedFamilies, store.revokedUsers)
+		}
+	})
+
+	t.Run("unknown token is a no-op", func(t *testing.T) {
+		store := newMockRefreshTokenStore()
+		svc := NewAuthService(nil, store, "test-secret")
+
+		if err := svc.Logout(user.ID, "not-stored"); err != nil {
+			t.Errorf("Logout() error = %v, want nil", err)
+		}
+		if len(store.revokedUsers) != 0 {
+			t.Errorf("revoked users = %v, want none", store.revokedUsers)
+		}
+	})
+}
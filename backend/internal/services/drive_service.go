@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -59,6 +60,9 @@ type driveFileListResponse struct {
 	Files []DriveFileResponse `json:"files"`
 }
 
+// ErrDriveNotConnected is returned when a user has no active Drive connection
+var ErrDriveNotConnected = errors.New("no active Drive connection")
+
 // UploadResult represents the result of a file upload operation
 type UploadResult struct {
 	DriveFileID string
@@ -320,6 +324,59 @@ func (s *DriveService) FindFolder(ctx context.Context, accessToken, folderName s
 	return listResp.Files[0].ID, nil
 }
 
+// FindFilesByName lists the files named name that the app created (drive.file scope),
+// e.g. to find every copy of a postcard before erasing it. Retried uploads may
+// have left more than one.
+func (s *DriveService) FindFilesByName(ctx context.Context, accessToken, name string) ([]DriveFileResponse, error) {
+	query := fmt.Sprintf("name = '%s' and trashed = false", escapeDriveQueryValue(name))
+	endpoint := fmt.Sprintf("%s/drive/v3/files?q=%s&fields=files(id,name,mimeType)&pageSize=100", s.APIEndpoint, url.QueryEscape(query))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file lookup request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("file lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("file lookup failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var listResp driveFileListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
+		return nil, fmt.Errorf("failed to decode file lookup response: %w", err)
+	}
+	return listResp.Files, nil
+}
+
+// DeleteFile permanently deletes a Drive file (it does not go to the trash).
+// A file that no longer exists is not an error.
+func (s *DriveService) DeleteFile(ctx context.Context, accessToken, fileID string) error {
+	endpoint := fmt.Sprintf("%s/drive/v3/files/%s", s.APIEndpoint, url.PathEscape(fileID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create delete request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("file delete failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("file delete failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
 func (s *DriveService) CreateFolder(ctx context.Context, accessToken, folderName string, parentID *string) (string, error) {
 	metadata := map[string]any{
 		"name":     folderName,
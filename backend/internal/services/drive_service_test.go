@@ -451,6 +451,59 @@ func TestDriveService_UploadFile_DriveAPIError(t *testing.T) {
 	}
 }
 
+func TestDriveService_FindFilesByNameAndDelete(t *testing.T) {
+	var deleted []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-access-token" {
+			t.Errorf("Expected Authorization header, got %s", r.Header.Get("Authorization"))
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/drive/v3/files":
+			if q := r.URL.Query().Get("q"); q != "name = 'postcard.jpg' and trashed = false" {
+				t.Errorf("Unexpected query %q", q)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"files": []map[string]string{{"id": "file-1", "name": "postcard.jpg"}, {"id": "file-2", "name": "postcard.jpg"}},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/drive/v3/files/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodDelete && r.URL.Path == "/drive/v3/files/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/drive/v3/files/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	svc := NewDriveService(&models.DriveBackupConfig{APIEndpoint: mockServer.URL})
+	ctx := context.Background()
+
+	files, err := svc.FindFilesByName(ctx, "test-access-token", "postcard.jpg")
+	if err != nil {
+		t.Fatalf("FindFilesByName() error = %v", err)
+	}
+	if len(files) != 2 || files[0].ID != "file-1" || files[1].ID != "file-2" {
+		t.Fatalf("FindFilesByName() = %+v", files)
+	}
+
+	if err := svc.DeleteFile(ctx, "test-access-token", "file-1"); err != nil {
+		t.Errorf("DeleteFile() error = %v", err)
+	}
+	if err := svc.DeleteFile(ctx, "test-access-token", "gone"); err != nil {
+		t.Errorf("DeleteFile() on a missing file error = %v, want nil", err)
+	}
+	if err := svc.DeleteFile(ctx, "test-access-token", "forbidden"); err == nil {
+		t.Error("DeleteFile() expected error for forbidden delete")
+	}
+	if len(deleted) != 1 || deleted[0] != "file-1" {
+		t.Errorf("deleted = %v, want [file-1]", deleted)
+	}
+}
+
 // ========== Tests for token encryption/decryption integration ==========
 
 func TestDriveService_TokenEncryption(t *testing.T) {
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// eventAccessAudience distingue los tokens de acceso a eventos de los demás tokens
+const eventAccessAudience = "event-access"
+
+// DefaultEventAccessTokenTTL vigencia de un token de acceso: al vencer se vuelve a pedir el código
+const DefaultEventAccessTokenTTL = 12 * time.Hour
+
+// Largo permitido de un código de acceso elegido por el owner
+const (
+	MinAccessCodeLength = 4
+	MaxAccessCodeLength = 64
+)
+
+// generatedAccessCodeLength largo del código cuando el owner no elige uno
+const generatedAccessCodeLength = 6
+
+// accessCodeAlphabet sin caracteres que se confunden al dictarlos (0/O, 1/I/L)
+const accessCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// EventAccessTokenService firma y valida los tokens de acceso a eventos protegidos
+// con código. El token es un JWT HS256 con el evento y la versión de acceso vigente
+// al emitirlo: rotar el código o revocar las sesiones incrementa la versión del
+// evento y deja sin efecto los tokens anteriores.
+type EventAccessTokenService struct {
+	signingKey []byte
+	ttl        time.Duration
+}
+
+// EventAccessClaims datos verificados de un token de acceso
+type EventAccessClaims struct {
+	EventID   uuid.UUID
+	Version   int
+	ExpiresAt time.Time
+}
+
+type eventAccessTokenClaims struct {
+	EventID uuid.UUID `json:"event_id"`
+	Version int       `json:"ver"`
+	jwt.RegisteredClaims
+}
+
+// NewEventAccessTokenService crea el servicio a partir del secreto del servidor
+func NewEventAccessTokenService(secret string) *EventAccessTokenService {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("event-access-token"))
+
+	return &EventAccessTokenService{
+		signingKey: mac.Sum(nil),
+		ttl:        DefaultEventAccessTokenTTL,
+	}
+}
+
+// Issue genera un token de acceso para la versión actual del evento
+func (s *EventAccessTokenService) Issue(event *models.Event) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	claims := eventAccessTokenClaims{
+		EventID: event.ID,
+		Version: event.AccessVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{eventAccessAudience},
+			Subject:   event.ID.String(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.signingKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// Validate verifica firma, expiración y audiencia de un token de acceso
+func (s *EventAccessTokenService) Validate(tokenString string) (*EventAccessClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &eventAccessTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.signingKey, nil
+	}, jwt.WithAudience(eventAccessAudience))
+	if err != nil {
+		return nil, ErrInvalidEventAccessToken
+	}
+
+	claims, ok := token.Claims.(*eventAccessTokenClaims)
+	if !ok || !token.Valid || claims.EventID == uuid.Nil {
+		return nil, ErrInvalidEventAccessToken
+	}
+
+	return &EventAccessClaims{
+		EventID:   claims.EventID,
+		Version:   claims.Version,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// Authorize verifica que el token dé acceso al evento: los eventos sin código no lo
+// exigen; en los protegidos tiene que ser de ese evento y de su versión de acceso actual.
+func (s *EventAccessTokenService) Authorize(tokenString string, event *models.Event) error {
+	if !event.AccessProtected {
+		return nil
+	}
+	if tokenString == "" {
+		return ErrEventAccessRequired
+	}
+
+	claims, err := s.Validate(tokenString)
+	if err != nil {
+		return err
+	}
+	if claims.EventID != event.ID || claims.Version != event.AccessVersion {
+		return ErrInvalidEventAccessToken
+	}
+	return nil
+}
+
+// NormalizeAccessCode ignora espacios y mayúsculas: el código se dicta o se copia de un cartel
+func NormalizeAccessCode(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), ""))
+}
+
+// HashAccessCode hashea con bcrypt el código ya normalizado
+func HashAccessCode(code string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(NormalizeAccessCode(code)), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckAccessCode compara el código ingresado con el hash del evento
+func CheckAccessCode(event *models.Event, code string) bool {
+	if event.AccessCodeHash == nil {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(*event.AccessCodeHash), []byte(NormalizeAccessCode(code))) == nil
+}
+
+// GenerateAccessCode genera un código aleatorio fácil de dictar (ej. "K7PX3M")
+func GenerateAccessCode() (string, error) {
+	code := make([]byte, generatedAccessCodeLength)
+	alphabetSize := big.NewInt(int64(len(accessCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = accessCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// ErrEventAccessRequired el evento está protegido y no se envió token de acceso
+var ErrEventAccessRequired = errors.New("event access code required")
+
+// ErrInvalidEventAccessToken token de acceso inválido, expirado, de otro evento o revocado
+var ErrInvalidEventAccessToken = errors.New("invalid or expired event access token")
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func newProtectedEvent(t *testing.T, code string) *models.Event {
+	t.Helper()
+	hash, err := HashAccessCode(code)
+	if err != nil {
+		t.Fatalf("HashAccessCode() error = %v", err)
+	}
+	return &models.Event{ID: uuid.New(), AccessCodeHash: &hash, AccessVersion: 1, AccessProtected: true}
+}
+
+func TestEventAccessTokenService_Authorize(t *testing.T) {
+	svc := NewEventAccessTokenService("test-secret")
+	event := newProtectedEvent(t, "fiesta")
+
+	token, expiresAt, err := svc.Issue(event)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if time.Until(expiresAt) > DefaultEventAccessTokenTTL {
+		t.Errorf("expires_at %v beyond the default TTL", expiresAt)
+	}
+	if err := svc.Authorize(token, event); err != nil {
+		t.Errorf("Authorize() error = %v", err)
+	}
+
+	other := newProtectedEvent(t, "fiesta")
+	other.AccessVersion = event.AccessVersion
+	expiredSvc := NewEventAccessTokenService("test-secret")
+	expiredSvc.ttl = -time.Minute
+	expired, _, err := expiredSvc.Issue(event)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	rotated := *event
+	rotated.AccessVersion++
+
+	tests := []struct {
+		name  string
+		svc   *EventAccessTokenService
+		token string
+		event *models.Event
+		want  error
+	}{
+		{name: "missing token", svc: svc, token: "", event: event, want: ErrEventAccessRequired},
+		{name: "garbage", svc: svc, token: "not-a-token", event: event, want: ErrInvalidEventAccessToken},
+		{name: "other event", svc: svc, token: token, event: other, want: ErrInvalidEventAccessToken},
+		{name: "revoked version", svc: svc, token: token, event: &rotated, want: ErrInvalidEventAccessToken},
+		{name: "expired", svc: svc, token: expired, event: event, want: ErrInvalidEventAccessToken},
+		{name: "different secret", svc: NewEventAccessTokenService("other-secret"), token: token, event: event, want: ErrInvalidEventAccessToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.svc.Authorize(tt.token, tt.event); err != tt.want {
+				t.Errorf("Authorize() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("public events need no token", func(t *testing.T) {
+		if err := svc.Authorize("", &models.Event{ID: uuid.New()}); err != nil {
+			t.Errorf("Authorize() error = %v", err)
+		}
+	})
+}
+
+func TestEventAccessTokenService_NotInterchangeableWithPlayerTokens(t *testing.T) {
+	secret := "shared-secret"
+	access := NewEventAccessTokenService(secret)
+	players := NewPlayerTokenService(secret)
+
+	playerToken, err := players.Issue(uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if _, err := access.Validate(playerToken); err == nil {
+		t.Error("player token must not validate as an event access token")
+	}
+
+	accessToken, _, err := access.Issue(&models.Event{ID: uuid.New()})
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if _, err := players.Validate(accessToken); err == nil {
+		t.Error("event access token must not validate as a player token")
+	}
+}
+
+func TestCheckAccessCode(t *testing.T) {
+	event := newProtectedEvent(t, "Mile 2026")
+
+	for _, code := range []string{"MILE2026", "mile 2026", "  mIlE2026 "} {
+		if !CheckAccessCode(event, code) {
+			t.Errorf("CheckAccessCode(%q) = false, want true", code)
+		}
+	}
+	if CheckAccessCode(event, "MILE2025") {
+		t.Error("wrong code must not match")
+	}
+	if CheckAccessCode(&models.Event{}, "MILE2026") {
+		t.Error("events without code never match")
+	}
+}
+
+func TestGenerateAccessCode(t *testing.T) {
+	code, err := GenerateAccessCode()
+	if err != nil {
+		t.Fatalf("GenerateAccessCode() error = %v", err)
+	}
+	if len(code) != generatedAccessCodeLength {
+		t.Errorf("len(code) = %d, want %d", len(code), generatedAccessCodeLength)
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(accessCodeAlphabet, r) {
+			t.Errorf("unexpected character %q in %s", r, code)
+		}
+	}
+	if NormalizeAccessCode(code) != code {
+		t.Errorf("generated code %s is not normalized", code)
+	}
+}
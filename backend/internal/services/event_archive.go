@@ -0,0 +1,292 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// Archivos de datos dentro del ZIP del evento
+const (
+	EventArchiveManifestFile    = "manifest.json"
+	EventArchiveEventFile       = "event.json"
+	EventArchivePostcardsFile   = "postcards.json"
+	EventArchivePostcardsCSV    = "postcards.csv"
+	EventArchiveRankingFile     = "ranking.json"
+	EventArchiveRankingCSV      = "ranking.csv"
+	EventArchiveQuizAnswersFile = "quiz_answers.json"
+)
+
+// csvBOM hace que Excel abra los CSV como UTF-8 (acentos y emojis)
+const csvBOM = "\xef\xbb\xbf"
+
+// ArchiveEventSource obtiene el evento a exportar
+type ArchiveEventSource interface {
+	GetByID(id uuid.UUID) (*models.Event, error)
+}
+
+// ArchiveThemeSource obtiene el tema del evento (o el de por defecto)
+type ArchiveThemeSource interface {
+	GetThemeForEvent(eventID string) (*models.Theme, error)
+}
+
+// ArchivePostcardSource lista todas las postales del evento
+type ArchivePostcardSource interface {
+	ListForModeration(eventID uuid.UUID, status string) ([]models.Postcard, error)
+}
+
+// ArchivePlayerSource lista los jugadores del evento ordenados por puntaje
+type ArchivePlayerSource interface {
+	ListByEvent(eventID uuid.UUID) ([]models.Player, error)
+}
+
+// ArchiveAnswerSource obtiene las respuestas del quiz y las aceptaciones del host
+type ArchiveAnswerSource interface {
+	ListAnswersByEvent(eventID uuid.UUID) ([]models.PlayerQuizAnswers, error)
+	ListAcceptedByEvent(eventID uuid.UUID) (map[uuid.UUID]map[string]string, error)
+}
+
+// ArchiveProgressFunc informa cuántos items del ZIP ya se escribieron
+type ArchiveProgressFunc func(done, total int)
+
+// EventArchiver arma el ZIP con todo el evento: media y thumbnails de las postales,
+// postales con remitente y mensaje (JSON y CSV), ranking final, respuestas del quiz,
+// tema y settings.
+type EventArchiver struct {
+	events    ArchiveEventSource
+	themes    ArchiveThemeSource
+	postcards ArchivePostcardSource
+	players   ArchivePlayerSource
+	answers   ArchiveAnswerSource
+	media     MediaStore
+}
+
+// NewEventArchiver crea un nuevo EventArchiver
+func NewEventArchiver(events ArchiveEventSource, themes ArchiveThemeSource, postcards ArchivePostcardSource,
+	players ArchivePlayerSource, answers ArchiveAnswerSource, media MediaStore) *EventArchiver {
+	return &EventArchiver{
+		events:    events,
+		themes:    themes,
+		postcards: postcards,
+		players:   players,
+		answers:   answers,
+		media:     media,
+	}
+}
+
+// BuildArchive lee los datos del evento y escribe el ZIP en w
+func (a *EventArchiver) BuildArchive(ctx context.Context, eventID uuid.UUID, w io.Writer, progress ArchiveProgressFunc) error {
+	data, err := a.Load(eventID)
+	if err != nil {
+		return err
+	}
+	return WriteEventArchive(ctx, w, data, a.media, progress)
+}
+
+// Load reúne los datos del evento que van al ZIP
+func (a *EventArchiver) Load(eventID uuid.UUID) (*models.EventArchiveData, error) {
+	event, err := a.events.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	theme, err := a.themes.GetThemeForEvent(eventID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get theme: %w", err)
+	}
+	postcards, err := a.postcards.ListForModeration(eventID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list postcards: %w", err)
+	}
+	players, err := a.players.ListByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list players: %w", err)
+	}
+	answers, err := a.answers.ListAnswersByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quiz answers: %w", err)
+	}
+	accepted, err := a.answers.ListAcceptedByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accepted answers: %w", err)
+	}
+
+	ranking := make([]models.RankingEntry, len(players))
+	for i, player := range players {
+		ranking[i] = models.RankingEntry{Position: i + 1, Player: player}
+	}
+
+	return &models.EventArchiveData{
+		Event:           event,
+		Theme:           theme,
+		Postcards:       postcards,
+		Ranking:         ranking,
+		QuizAnswers:     answers,
+		AcceptedAnswers: accepted,
+	}, nil
+}
+
+// WriteEventArchive escribe el ZIP del evento. La media de cada postal va en
+// media/<postal>.<ext> y su thumbnail en thumbnails/<postal>.<ext>; los archivos que
+// no se pueden leer quedan en missing_media del manifest en vez de cortar el export.
+// progress (puede ser nil) se llama después de cada postal: total = postales + 1.
+func WriteEventArchive(ctx context.Context, w io.Writer, data *models.EventArchiveData, media MediaStore, progress ArchiveProgressFunc) error {
+	if progress == nil {
+		progress = func(done, total int) {}
+	}
+	total := len(data.Postcards) + 1
+	progress(0, total)
+
+	zw := zip.NewWriter(w)
+	manifest := models.EventArchiveManifest{
+		ExportedAt:   time.Now(),
+		Event:        models.PlayerDataEvent{ID: data.Event.ID, Slug: data.Event.Slug, Name: data.Event.Name},
+		Postcards:    len(data.Postcards),
+		Players:      len(data.Ranking),
+		QuizAnswers:  len(data.QuizAnswers),
+		MissingMedia: []models.PlayerMediaFile{},
+	}
+
+	rows := make([]models.EventArchivePostcard, len(data.Postcards))
+	for i, postcard := range data.Postcards {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rows[i] = models.EventArchivePostcard{
+			ID:               postcard.ID,
+			Sender:           postcard.PlayerName,
+			PlayerID:         postcard.PlayerID,
+			Message:          postcard.Message,
+			MediaType:        postcard.MediaType,
+			IsSecret:         postcard.IsSecret,
+			RevealedAt:       postcard.RevealedAt,
+			ModerationStatus: postcard.ModerationStatus,
+			CreatedAt:        postcard.CreatedAt,
+		}
+
+		entry, err := archiveMedia(ctx, zw, media, &manifest, postcard.ID, postcard.ImagePath, "media/")
+		if err != nil {
+			return err
+		}
+		rows[i].Media = entry
+
+		thumb := ""
+		if postcard.ThumbnailPath != nil {
+			thumb = *postcard.ThumbnailPath
+		} else if postcard.Renditions != nil {
+			thumb = postcard.Renditions.Thumb
+		}
+		if thumb != "" {
+			if rows[i].Thumbnail, err = archiveMedia(ctx, zw, media, &manifest, postcard.ID, thumb, "thumbnails/"); err != nil {
+				return err
+			}
+		}
+		progress(i+1, total)
+	}
+
+	answers := make([]models.EventArchiveQuizAnswers, len(data.QuizAnswers))
+	for i, a := range data.QuizAnswers {
+		answers[i] = models.EventArchiveQuizAnswers{PlayerQuizAnswers: a, AcceptedAnswers: data.AcceptedAnswers[a.Player.ID]}
+	}
+
+	files := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{EventArchiveEventFile, jsonFile(models.EventArchiveSettings{Event: data.Event, Theme: data.Theme})},
+		{EventArchivePostcardsFile, jsonFile(rows)},
+		{EventArchivePostcardsCSV, func(w io.Writer) error { return writePostcardsCSV(w, rows) }},
+		{EventArchiveRankingFile, jsonFile(data.Ranking)},
+		{EventArchiveRankingCSV, func(w io.Writer) error { return writeRankingCSV(w, data.Ranking) }},
+		{EventArchiveQuizAnswersFile, jsonFile(answers)},
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if err := f.write(fw); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+		manifest.Files = append(manifest.Files, f.name)
+	}
+
+	fw, err := zw.Create(EventArchiveManifestFile)
+	if err != nil {
+		return err
+	}
+	if err := jsonFile(manifest)(fw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	progress(total, total)
+	return nil
+}
+
+// archiveMedia copia un archivo de la postal al ZIP bajo dir y lo registra en el
+// manifest. Devuelve el path dentro del ZIP, vacío si el archivo falta.
+func archiveMedia(ctx context.Context, zw *zip.Writer, media MediaStore, manifest *models.EventArchiveManifest, postcardID uuid.UUID, url, dir string) (string, error) {
+	entry := dir + postcardID.String() + path.Ext(url)
+	copied, err := copyMediaToZip(ctx, zw, media, url, entry)
+	if err != nil {
+		return "", err
+	}
+	if !copied {
+		manifest.MissingMedia = append(manifest.MissingMedia, models.PlayerMediaFile{PostcardID: postcardID, URL: url, Missing: true})
+		return "", nil
+	}
+	manifest.MediaFiles++
+	manifest.Files = append(manifest.Files, entry)
+	return entry, nil
+}
+
+// jsonFile escribe v como JSON indentado
+func jsonFile(v any) func(io.Writer) error {
+	return func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+}
+
+// writePostcardsCSV postales con remitente y mensaje, una por fila
+func writePostcardsCSV(w io.Writer, rows []models.EventArchivePostcard) error {
+	if _, err := io.WriteString(w, csvBOM); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"id", "created_at", "sender", "message", "media_type", "is_secret", "moderation_status", "media", "thumbnail"})
+	for _, p := range rows {
+		cw.Write([]string{
+			p.ID.String(), p.CreatedAt.Format(time.RFC3339), p.Sender, p.Message, p.MediaType,
+			strconv.FormatBool(p.IsSecret), p.ModerationStatus, p.Media, p.Thumbnail,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeRankingCSV ranking final, una fila por jugador
+func writeRankingCSV(w io.Writer, ranking []models.RankingEntry) error {
+	if _, err := io.WriteString(w, csvBOM); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"position", "name", "avatar", "score"})
+	for _, r := range ranking {
+		cw.Write([]string{strconv.Itoa(r.Position), r.Player.Name, r.Player.Avatar, strconv.Itoa(r.Player.Score)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+type fakeArchiveSources struct {
+	event     *models.Event
+	postcards []models.Postcard
+	players   []models.Player
+	answers   []models.PlayerQuizAnswers
+	accepted  map[uuid.UUID]map[string]string
+}
+
+func (f *fakeArchiveSources) GetByID(id uuid.UUID) (*models.Event, error) { return f.event, nil }
+func (f *fakeArchiveSources) GetThemeForEvent(eventID string) (*models.Theme, error) {
+	return &models.Theme{EventID: eventID, PrimaryColor: "#ff00aa"}, nil
+}
+func (f *fakeArchiveSources) ListForModeration(eventID uuid.UUID, status string) ([]models.Postcard, error) {
+	return f.postcards, nil
+}
+func (f *fakeArchiveSources) ListByEvent(eventID uuid.UUID) ([]models.Player, error) {
+	return f.players, nil
+}
+func (f *fakeArchiveSources) ListAnswersByEvent(eventID uuid.UUID) ([]models.PlayerQuizAnswers, error) {
+	return f.answers, nil
+}
+func (f *fakeArchiveSources) ListAcceptedByEvent(eventID uuid.UUID) (map[uuid.UUID]map[string]string, error) {
+	return f.accepted, nil
+}
+
+func readArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a ZIP: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestEventArchiver_BuildArchive(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalMediaStore(t.TempDir())
+	for key, content := range map[string]string{
+		"postcards/photo.jpg":            "jpeg",
+		"postcards/thumb/photo.jpg":      "thumb",
+		"postcards/video.mp4":            "mp4",
+		"postcards/thumbnails/video.jpg": "poster",
+	} {
+		if err := store.Put(ctx, key, strings.NewReader(content), -1, ""); err != nil {
+			t.Fatalf("Put(%s) error = %v", key, err)
+		}
+	}
+
+	ana := models.Player{ID: uuid.New(), Name: "Ana", Avatar: "🎉", Score: 9}
+	luis := models.Player{ID: uuid.New(), Name: "Luis", Avatar: "🎈", Score: 4}
+	videoThumb := store.URL("postcards/thumbnails/video.jpg")
+	photo := models.Postcard{
+		ID: uuid.New(), PlayerID: &ana.ID, PlayerName: "Ana", Message: "¡Feliz cumple, Mile!", MediaType: "image",
+		ImagePath: store.URL("postcards/photo.jpg"), ModerationStatus: models.ModerationApproved,
+		Renditions: &models.ImageRenditions{Thumb: store.URL("postcards/thumb/photo.jpg"), Full: store.URL("postcards/photo.jpg")},
+	}
+	video := models.Postcard{
+		ID: uuid.New(), PlayerName: "Tía Marta", Message: "Sorpresa", MediaType: "video", IsSecret: true,
+		ImagePath: store.URL("postcards/video.mp4"), ThumbnailPath: &videoThumb, ModerationStatus: models.ModerationApproved,
+	}
+	gone := models.Postcard{ID: uuid.New(), PlayerName: "Luis", MediaType: "image", ImagePath: store.URL("postcards/gone.jpg")}
+
+	src := &fakeArchiveSources{
+		event:     &models.Event{ID: uuid.New(), Slug: "mile-30", Name: "Los 30 de Mile", Settings: models.EventSettings{}},
+		postcards: []models.Postcard{photo, video, gone},
+		players:   []models.Player{ana, luis},
+		answers:   []models.PlayerQuizAnswers{{Player: ana, Favorites: map[string]string{"color": "rosa"}}},
+		accepted:  map[uuid.UUID]map[string]string{ana.ID: {"color": "rosa"}},
+	}
+	archiver := NewEventArchiver(src, src, src, src, src, store)
+
+	var steps [][2]int
+	var buf bytes.Buffer
+	if err := archiver.BuildArchive(ctx, src.event.ID, &buf, func(done, total int) {
+		steps = append(steps, [2]int{done, total})
+	}); err != nil {
+		t.Fatalf("BuildArchive() error = %v", err)
+	}
+	files := readArchive(t, buf.Bytes())
+
+	for entry, want := range map[string]string{
+		"media/" + photo.ID.String() + ".jpg":      "jpeg",
+		"thumbnails/" + photo.ID.String() + ".jpg": "thumb",
+		"media/" + video.ID.String() + ".mp4":      "mp4",
+		"thumbnails/" + video.ID.String() + ".jpg": "poster",
+	} {
+		if files[entry] != want {
+			t.Errorf("%s = %q, want %q", entry, files[entry], want)
+		}
+	}
+
+	// Progreso: 0 al empezar, una vez por postal y al terminar
+	if len(steps) != 5 || steps[0] != [2]int{0, 4} || steps[4] != [2]int{4, 4} {
+		t.Errorf("progress = %v, want 0..4 of 4", steps)
+	}
+
+	var manifest models.EventArchiveManifest
+	if err := json.Unmarshal([]byte(files[EventArchiveManifestFile]), &manifest); err != nil {
+		t.Fatalf("manifest.json: %v", err)
+	}
+	if manifest.Event.Slug != "mile-30" || manifest.Postcards != 3 || manifest.Players != 2 || manifest.MediaFiles != 4 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	if len(manifest.MissingMedia) != 1 || manifest.MissingMedia[0].PostcardID != gone.ID {
+		t.Errorf("missing_media = %+v, want the deleted file", manifest.MissingMedia)
+	}
+
+	var postcards []models.EventArchivePostcard
+	if err := json.Unmarshal([]byte(files[EventArchivePostcardsFile]), &postcards); err != nil {
+		t.Fatalf("postcards.json: %v", err)
+	}
+	if len(postcards) != 3 || postcards[1].Sender != "Tía Marta" || !postcards[1].IsSecret || postcards[2].Media != "" {
+		t.Errorf("postcards.json = %+v", postcards)
+	}
+
+	if !strings.HasPrefix(files[EventArchivePostcardsCSV], csvBOM) {
+		t.Error("postcards.csv should start with a UTF-8 BOM for Excel")
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(files[EventArchivePostcardsCSV], csvBOM))).ReadAll()
+	if err != nil {
+		t.Fatalf("postcards.csv: %v", err)
+	}
+	if len(records) != 4 || records[1][2] != "Ana" || records[1][3] != "¡Feliz cumple, Mile!" {
+		t.Errorf("postcards.csv = %v", records)
+	}
+
+	var ranking []models.RankingEntry
+	if err := json.Unmarshal([]byte(files[EventArchiveRankingFile]), &ranking); err != nil {
+		t.Fatalf("ranking.json: %v", err)
+	}
+	if len(ranking) != 2 || ranking[0].Position != 1 || ranking[0].Player.Name != "Ana" {
+		t.Errorf("ranking.json = %+v", ranking)
+	}
+	if !strings.Contains(files[EventArchiveRankingCSV], "2,Luis,🎈,4") {
+		t.Errorf("ranking.csv = %q", files[EventArchiveRankingCSV])
+	}
+
+	var answers []models.EventArchiveQuizAnswers
+	if err := json.Unmarshal([]byte(files[EventArchiveQuizAnswersFile]), &answers); err != nil {
+		t.Fatalf("quiz_answers.json: %v", err)
+	}
+	if len(answers) != 1 || answers[0].Favorites["color"] != "rosa" || answers[0].AcceptedAnswers["color"] != "rosa" {
+		t.Errorf("quiz_answers.json = %+v", answers)
+	}
+
+	var settings models.EventArchiveSettings
+	if err := json.Unmarshal([]byte(files[EventArchiveEventFile]), &settings); err != nil {
+		t.Fatalf("event.json: %v", err)
+	}
+	if settings.Event.Name != "Los 30 de Mile" || settings.Theme == nil || settings.Theme.PrimaryColor != "#ff00aa" {
+		t.Errorf("event.json = %+v", settings)
+	}
+}
+
+func TestWriteEventArchive_EmptyEvent(t *testing.T) {
+	data := &models.EventArchiveData{Event: &models.Event{ID: uuid.New(), Slug: "vacio"}}
+
+	var buf bytes.Buffer
+	if err := WriteEventArchive(context.Background(), &buf, data, nil, nil); err != nil {
+		t.Fatalf("WriteEventArchive() error = %v", err)
+	}
+	files := readArchive(t, buf.Bytes())
+	if files[EventArchivePostcardsFile] != "[]\n" {
+		t.Errorf("postcards.json = %q, want an empty list", files[EventArchivePostcardsFile])
+	}
+	if _, ok := files[EventArchiveManifestFile]; !ok {
+		t.Error("manifest.json missing")
+	}
+}
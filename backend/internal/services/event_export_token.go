@@ -0,0 +1,90 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// exportDownloadAudience distingue los links de descarga de los demás tokens
+const exportDownloadAudience = "event-export-download"
+
+// DefaultExportDownloadTTL vigencia de un link de descarga. Consultar el export de
+// nuevo genera otro link mientras el ZIP no haya expirado.
+const DefaultExportDownloadTTL = time.Hour
+
+// ExportDownloadTokenService firma y valida los tokens de los links de descarga de
+// exports del evento. El navegador descarga el ZIP con un link común (sin header
+// Authorization), así que el link lleva un JWT HS256 de corta duración con el export.
+type ExportDownloadTokenService struct {
+	signingKey []byte
+	ttl        time.Duration
+}
+
+type exportDownloadClaims struct {
+	ExportID uuid.UUID `json:"export_id"`
+	jwt.RegisteredClaims
+}
+
+// NewExportDownloadTokenService crea el servicio a partir del secreto del servidor
+func NewExportDownloadTokenService(secret string) *ExportDownloadTokenService {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("event-export-download-token"))
+
+	return &ExportDownloadTokenService{
+		signingKey: mac.Sum(nil),
+		ttl:        DefaultExportDownloadTTL,
+	}
+}
+
+// Issue genera un token de descarga del export. Nunca vence después que el ZIP.
+func (s *ExportDownloadTokenService) Issue(exportID uuid.UUID, archiveExpiresAt time.Time) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	if archiveExpiresAt.Before(expiresAt) {
+		expiresAt = archiveExpiresAt
+	}
+	claims := exportDownloadClaims{
+		ExportID: exportID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{exportDownloadAudience},
+			Subject:   exportID.String(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.signingKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// Validate verifica firma, expiración y audiencia; devuelve el ID del export
+func (s *ExportDownloadTokenService) Validate(tokenString string) (uuid.UUID, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &exportDownloadClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.signingKey, nil
+	}, jwt.WithAudience(exportDownloadAudience))
+	if err != nil {
+		return uuid.Nil, ErrInvalidExportDownloadToken
+	}
+
+	claims, ok := token.Claims.(*exportDownloadClaims)
+	if !ok || !token.Valid || claims.ExportID == uuid.Nil {
+		return uuid.Nil, ErrInvalidExportDownloadToken
+	}
+	return claims.ExportID, nil
+}
+
+// ErrInvalidExportDownloadToken el link de descarga es inválido o venció
+var ErrInvalidExportDownloadToken = errors.New("invalid or expired export download token")
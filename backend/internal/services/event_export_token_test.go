@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestExportDownloadTokenService(t *testing.T) {
+	svc := NewExportDownloadTokenService("secret")
+	exportID := uuid.New()
+
+	t.Run("valid token", func(t *testing.T) {
+		token, expiresAt, err := svc.Issue(exportID, time.Now().Add(24*time.Hour))
+		if err != nil {
+			t.Fatalf("Issue() error = %v", err)
+		}
+		if d := time.Until(expiresAt); d > DefaultExportDownloadTTL || d < DefaultExportDownloadTTL-time.Minute {
+			t.Errorf("expiresAt in %s, want %s", d, DefaultExportDownloadTTL)
+		}
+		got, err := svc.Validate(token)
+		if err != nil || got != exportID {
+			t.Errorf("Validate() = %s, %v; want %s", got, err, exportID)
+		}
+	})
+
+	t.Run("never outlives the archive", func(t *testing.T) {
+		archiveExpiresAt := time.Now().Add(10 * time.Minute)
+		_, expiresAt, err := svc.Issue(exportID, archiveExpiresAt)
+		if err != nil {
+			t.Fatalf("Issue() error = %v", err)
+		}
+		if !expiresAt.Equal(archiveExpiresAt) {
+			t.Errorf("expiresAt = %s, want the archive expiry %s", expiresAt, archiveExpiresAt)
+		}
+	})
+
+	t.Run("expired archive", func(t *testing.T) {
+		token, _, err := svc.Issue(exportID, time.Now().Add(-time.Minute))
+		if err != nil {
+			t.Fatalf("Issue() error = %v", err)
+		}
+		if _, err := svc.Validate(token); err != ErrInvalidExportDownloadToken {
+			t.Errorf("Validate() error = %v, want ErrInvalidExportDownloadToken", err)
+		}
+	})
+
+	t.Run("other tokens are rejected", func(t *testing.T) {
+		other, _, _ := NewExportDownloadTokenService("other-secret").Issue(exportID, time.Now().Add(time.Hour))
+		playerToken, _ := NewPlayerTokenService("secret").Issue(uuid.New(), uuid.New())
+		for name, token := range map[string]string{"other secret": other, "player token": playerToken, "garbage": "nope"} {
+			if _, err := svc.Validate(token); err != ErrInvalidExportDownloadToken {
+				t.Errorf("%s: Validate() error = %v, want ErrInvalidExportDownloadToken", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// matchAnswer busca la respuesta del usuario entre las respuestas válidas.
+// Las respuestas deben venir normalizadas. Primero se busca coincidencia exacta
+// (la primera respuesta es la principal, el resto son alias) y, si la pregunta
+// tiene regla fuzzy, coincidencia aproximada.
+// Retorna el tipo de coincidencia ("" si no hubo) y la respuesta válida que coincidió.
+func matchAnswer(candidates []string, answer string, fuzzy *models.FuzzyMatchRule) (string, string) {
+	if answer == "" {
+		return "", ""
+	}
+
+	for i, candidate := range candidates {
+		if answer == candidate {
+			if i == 0 {
+				return models.MatchExact, candidate
+			}
+			return models.MatchAlias, candidate
+		}
+	}
+
+	if !fuzzy.IsEnabled() {
+		return "", ""
+	}
+
+	for _, candidate := range candidates {
+		if fuzzyEqual(answer, candidate, fuzzy) {
+			return models.MatchFuzzy, candidate
+		}
+	}
+
+	return "", ""
+}
+
+// fuzzyEqual aplica los criterios activos de la regla; basta con que uno acepte
+func fuzzyEqual(answer, candidate string, rule *models.FuzzyMatchRule) bool {
+	if candidate == "" {
+		return false
+	}
+
+	if rule.StemPlurals {
+		answer = stemPhrase(answer)
+		candidate = stemPhrase(candidate)
+		if answer == candidate {
+			return true
+		}
+	}
+
+	// Respuestas muy cortas ("te", "sol") no toleran errores: cualquier
+	// edición las convierte en otra palabra
+	if rule.MaxEditDistance > 0 && len([]rune(candidate)) > rule.MaxEditDistance*2 {
+		if levenshtein(answer, candidate) <= rule.MaxEditDistance {
+			return true
+		}
+	}
+
+	if rule.TokenSetSimilarity > 0 {
+		if tokenSetSimilarity(answer, candidate) >= rule.TokenSetSimilarity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// levenshtein calcula la distancia de edición entre dos textos (por runas)
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// tokenSetSimilarity retorna la similitud de Jaccard entre los conjuntos de palabras
+// (1 = mismas palabras en cualquier orden)
+func tokenSetSimilarity(a, b string) float64 {
+	setA := tokenSet(a)
+	setB := tokenSet(b)
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for token := range setA {
+		if setB[token] {
+			intersection++
+		}
+	}
+	union := len(setA) + len(setB) - intersection
+
+	return float64(intersection) / float64(union)
+}
+
+func tokenSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, token := range strings.Fields(s) {
+		set[token] = true
+	}
+	return set
+}
+
+// stemPhrase aplica stemPlural a cada palabra
+func stemPhrase(s string) string {
+	words := strings.Fields(s)
+	for i, word := range words {
+		words[i] = stemPlural(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// zSingulars son los singulares en -z cuyo plural termina en -ces (ya sin acentos).
+// El resto de las palabras en -ces se tratan como plurales de -ce (dulce-s, trece-s).
+var zSingulars = map[string]bool{
+	"luz": true, "voz": true, "cruz": true, "paz": true, "pez": true, "vez": true,
+	"nuez": true, "raiz": true, "maiz": true, "arroz": true, "lapiz": true, "nariz": true,
+	"actriz": true, "cicatriz": true, "perdiz": true, "codorniz": true, "lombriz": true,
+	"matiz": true, "tapiz": true, "barniz": true, "disfraz": true, "antifaz": true,
+	"avestruz": true, "altavoz": true, "aprendiz": true, "feliz": true, "veloz": true,
+	"feroz": true, "capaz": true, "audaz": true,
+}
+
+// stemPlural reduce un plural español simple a su singular:
+// luces → luz, dulces → dulce, flores → flor, girasoles → girasol, rosas → rosa.
+// No pretende ser un stemmer completo; se aplica igual a ambos lados de la comparación.
+func stemPlural(word string) string {
+	runes := []rune(word)
+	n := len(runes)
+	if n <= 3 || runes[n-1] != 's' {
+		return word
+	}
+
+	// -ces es plural de -z (luces) o de -ce (dulces): solo se pasa a -z si el singular es conocido
+	if strings.HasSuffix(word, "ces") {
+		if singular := string(runes[:n-3]) + "z"; zSingulars[singular] {
+			return singular
+		}
+	}
+
+	// Plurales en -es tras consonante típica (flor-es, girasol-es, cancion-es, pared-es, reloj-es)
+	if runes[n-2] == 'e' && strings.ContainsRune("lrndj", runes[n-3]) {
+		return string(runes[:n-2])
+	}
+
+	// Plurales en -s tras vocal (rosa-s, llave-s)
+	if strings.ContainsRune("aeiou", runes[n-2]) {
+		return string(runes[:n-1])
+	}
+
+	return word
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"ricardo arjona", "ricardo arjna", 1},
+		{"girasol", "girasoles", 2},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.expected {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestStemPlural(t *testing.T) {
+	tests := map[string]string{
+		"girasoles": "girasol",
+		"flores":    "flor",
+		"rosas":     "rosa",
+		"llaves":    "llave",
+		"luces":     "luz",
+		"lapices":   "lapiz",
+		"dulces":    "dulce",
+		"dulce":     "dulce",
+		"girasol":   "girasol",
+		"mes":       "mes",
+	}
+
+	for input, expected := range tests {
+		if got := stemPlural(input); got != expected {
+			t.Errorf("stemPlural(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestTokenSetSimilarity(t *testing.T) {
+	if got := tokenSetSimilarity("bestia y bella", "bella y bestia"); got != 1 {
+		t.Errorf("same tokens in any order should be 1, got %f", got)
+	}
+	if got := tokenSetSimilarity("ricardo arjona", "arjona"); got != 0.5 {
+		t.Errorf("expected 0.5, got %f", got)
+	}
+	if got := tokenSetSimilarity("", "arjona"); got != 0 {
+		t.Errorf("empty answer should be 0, got %f", got)
+	}
+}
+
+func TestMatchAnswer(t *testing.T) {
+	candidates := []string{"ricardo arjona", "arjona"}
+
+	tests := []struct {
+		name    string
+		answer  string
+		rule    *models.FuzzyMatchRule
+		match   string
+		matched string
+	}{
+		{name: "exact", answer: "ricardo arjona", match: models.MatchExact, matched: "ricardo arjona"},
+		{name: "alias", answer: "arjona", match: models.MatchAlias, matched: "arjona"},
+		{name: "typo without rule", answer: "ricardo arjna", match: ""},
+		{name: "typo with edit distance", answer: "ricardo arjna", rule: &models.FuzzyMatchRule{MaxEditDistance: 1}, match: models.MatchFuzzy, matched: "ricardo arjona"},
+		{name: "too many typos", answer: "rikardo arjna", rule: &models.FuzzyMatchRule{MaxEditDistance: 1}, match: ""},
+		{name: "token set", answer: "arjona ricardo", rule: &models.FuzzyMatchRule{TokenSetSimilarity: 0.9}, match: models.MatchFuzzy, matched: "ricardo arjona"},
+		{name: "disabled rule", answer: "ricardo arjna", rule: &models.FuzzyMatchRule{}, match: ""},
+		{name: "empty answer", answer: "", rule: &models.FuzzyMatchRule{TokenSetSimilarity: 0.1}, match: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, matched := matchAnswer(candidates, tt.answer, tt.rule)
+			if match != tt.match || matched != tt.matched {
+				t.Errorf("matchAnswer(%q) = (%q, %q), want (%q, %q)", tt.answer, match, matched, tt.match, tt.matched)
+			}
+		})
+	}
+}
+
+func TestMatchAnswer_PluralsAndShortAnswers(t *testing.T) {
+	plurals := &models.FuzzyMatchRule{StemPlurals: true}
+	if match, _ := matchAnswer([]string{"girasol"}, "girasoles", plurals); match != models.MatchFuzzy {
+		t.Errorf("girasoles should match girasol with stem_plurals, got %q", match)
+	}
+	if match, _ := matchAnswer([]string{"dulce"}, "dulces", plurals); match != models.MatchFuzzy {
+		t.Errorf("dulces should match dulce with stem_plurals, got %q", match)
+	}
+
+	// Respuestas cortas no toleran ediciones aunque la regla lo permita
+	edits := &models.FuzzyMatchRule{MaxEditDistance: 1}
+	if match, _ := matchAnswer([]string{"te"}, "pe", edits); match != "" {
+		t.Errorf("short answers should not fuzzy match, got %q", match)
+	}
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/the-mile-game/backend/internal/models"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // registra el decoder de WebP para image.Decode
+)
+
+// Tamaño máximo (lado mayor, en px) de cada rendition. Las imágenes más chicas
+// no se agrandan.
+var ImageRenditionSizes = map[string]int{
+	models.ImageVariantThumb: 320,
+	models.ImageVariantBoard: 1024,
+	models.ImageVariantFull:  2048,
+}
+
+const (
+	// MaxImagePixels límite de píxeles de la imagen original (evita decompression bombs)
+	MaxImagePixels = 50_000_000
+	// ImageJPEGQuality calidad de las renditions JPEG
+	ImageJPEGQuality = 85
+)
+
+// ImageRendition una versión procesada de la imagen, lista para guardar
+type ImageRendition struct {
+	Variant     string // thumb | board | full
+	Data        []byte
+	Width       int
+	Height      int
+	ContentType string // image/jpeg, o image/png si la imagen tiene transparencia
+	Ext         string
+}
+
+// ProcessImage decodifica una imagen subida (JPEG, PNG o WebP), la rota según
+// la orientación EXIF y genera las renditions thumb, board y full.
+// Las renditions se vuelven a codificar, así que no conservan metadatos
+// (EXIF, GPS, etc.).
+func ProcessImage(data []byte) ([]ImageRendition, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	// Se escala primero y se rota después: rotar la versión full es mucho más
+	// barato que rotar el original, y el lado mayor no cambia con la rotación.
+	full := applyOrientation(scaleToFit(src, ImageRenditionSizes[models.ImageVariantFull]), jpegOrientation(data))
+	opaque := full.Opaque()
+
+	renditions := make([]ImageRendition, 0, len(ImageRenditionSizes))
+	for _, variant := range []string{models.ImageVariantThumb, models.ImageVariantBoard, models.ImageVariantFull} {
+		img := full
+		if variant != models.ImageVariantFull {
+			img = scaleToFit(full, ImageRenditionSizes[variant])
+		}
+
+		rendition, err := encodeRendition(variant, img, opaque)
+		if err != nil {
+			return nil, err
+		}
+		renditions = append(renditions, rendition)
+	}
+
+	return renditions, nil
+}
+
+// encodeRendition codifica como JPEG, o PNG si hay que conservar la transparencia
+func encodeRendition(variant string, img *image.RGBA, opaque bool) (ImageRendition, error) {
+	var buf bytes.Buffer
+	rendition := ImageRendition{
+		Variant: variant,
+		Width:   img.Bounds().Dx(),
+		Height:  img.Bounds().Dy(),
+	}
+
+	if opaque {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: ImageJPEGQuality}); err != nil {
+			return rendition, fmt.Errorf("failed to encode %s rendition: %w", variant, err)
+		}
+		rendition.ContentType, rendition.Ext = "image/jpeg", ".jpg"
+	} else {
+		if err := png.Encode(&buf, img); err != nil {
+			return rendition, fmt.Errorf("failed to encode %s rendition: %w", variant, err)
+		}
+		rendition.ContentType, rendition.Ext = "image/png", ".png"
+	}
+
+	rendition.Data = buf.Bytes()
+	return rendition, nil
+}
+
+// scaleToFit escala img para que su lado mayor no supere maxSide (sin agrandar)
+func scaleToFit(img image.Image, maxSide int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > maxSide || h > maxSide {
+		if w >= h {
+			w, h = maxSide, max(1, h*maxSide/w)
+		} else {
+			w, h = max(1, w*maxSide/h), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	if w == b.Dx() && h == b.Dy() {
+		draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	}
+	return dst
+}
+
+// applyOrientation aplica la transformación del tag EXIF Orientation (1-8)
+func applyOrientation(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 { // 5-8 intercambian ancho y alto
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // espejo horizontal
+				dx, dy = w-1-x, y
+			case 3: // 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // espejo vertical
+				dx, dy = x, h-1-y
+			case 5: // transpuesta
+				dx, dy = y, x
+			case 6: // 90° horario
+				dx, dy = h-1-y, x
+			case 7: // transversa
+				dx, dy = h-1-y, w-1-x
+			case 8: // 90° antihorario
+				dx, dy = y, w-1-x
+			}
+			si := img.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], img.Pix[si:si+4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation lee el tag Orientation del segmento EXIF (APP1) de un JPEG.
+// Devuelve 1 (sin rotación) si no es JPEG o no tiene el tag.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 { // inicio de los datos de imagen / fin
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation busca el tag 0x0112 en el IFD0 de un bloque TIFF
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// ErrInvalidImage la imagen no se pudo decodificar
+var ErrInvalidImage = errors.New("invalid image")
+
+// ErrImageTooLarge la imagen supera MaxImagePixels
+var ErrImageTooLarge = errors.New("image dimensions too large")
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// twoColorJPEG genera un JPEG de w×h con la mitad izquierda roja y la derecha azul
+func twoColorJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.Set(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				img.Set(x, y, color.RGBA{0, 0, 255, 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withExif inserta un segmento APP1 con Orientation y un puntero a GPS (como los celulares)
+func withExif(jpegData []byte, orientation uint16) []byte {
+	tiff := new(bytes.Buffer)
+	tiff.WriteString("MM")
+	binary.Write(tiff, binary.BigEndian, uint16(42))
+	binary.Write(tiff, binary.BigEndian, uint32(8)) // IFD0
+	binary.Write(tiff, binary.BigEndian, uint16(2)) // 2 entradas
+	// Orientation (SHORT)
+	binary.Write(tiff, binary.BigEndian, []uint16{0x0112, 3})
+	binary.Write(tiff, binary.BigEndian, uint32(1))
+	binary.Write(tiff, binary.BigEndian, []uint16{orientation, 0})
+	// GPSInfo IFD pointer (LONG) con coordenadas ficticias a continuación
+	binary.Write(tiff, binary.BigEndian, []uint16{0x8825, 4})
+	binary.Write(tiff, binary.BigEndian, uint32(1))
+	binary.Write(tiff, binary.BigEndian, uint32(38))
+	binary.Write(tiff, binary.BigEndian, uint32(0)) // sin IFD siguiente
+	tiff.WriteString("GPS-34.6037-58.3816")
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, jpegData[:2]...)
+	out = append(out, segment...)
+	return append(out, jpegData[2:]...)
+}
+
+func renditionByVariant(t *testing.T, renditions []ImageRendition, variant string) ImageRendition {
+	t.Helper()
+	for _, r := range renditions {
+		if r.Variant == variant {
+			return r
+		}
+	}
+	t.Fatalf("missing %s rendition", variant)
+	return ImageRendition{}
+}
+
+func TestProcessImage_RotatesAndStripsExif(t *testing.T) {
+	data := withExif(twoColorJPEG(t, 40, 20), 6) // 90° horario
+	if got := jpegOrientation(data); got != 6 {
+		t.Fatalf("jpegOrientation = %d, want 6", got)
+	}
+
+	renditions, err := ProcessImage(data)
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+	if len(renditions) != 3 {
+		t.Fatalf("got %d renditions, want 3", len(renditions))
+	}
+
+	full := renditionByVariant(t, renditions, models.ImageVariantFull)
+	if full.Width != 20 || full.Height != 40 {
+		t.Errorf("full = %dx%d, want 20x40 (rotated, not upscaled)", full.Width, full.Height)
+	}
+	if full.ContentType != "image/jpeg" || full.Ext != ".jpg" {
+		t.Errorf("full content type = %s (%s), want image/jpeg", full.ContentType, full.Ext)
+	}
+
+	for _, r := range renditions {
+		if bytes.Contains(r.Data, []byte("Exif")) || bytes.Contains(r.Data, []byte("GPS-34")) {
+			t.Errorf("%s rendition still contains EXIF/GPS data", r.Variant)
+		}
+		if jpegOrientation(r.Data) != 1 {
+			t.Errorf("%s rendition still has an orientation tag", r.Variant)
+		}
+	}
+
+	// Al rotar 90° horario la mitad izquierda (roja) queda arriba
+	img, err := jpeg.Decode(bytes.NewReader(full.Data))
+	if err != nil {
+		t.Fatalf("decode full: %v", err)
+	}
+	if r, _, b, _ := img.At(10, 5).RGBA(); r < b {
+		t.Errorf("top of rotated image should be red, got r=%d b=%d", r>>8, b>>8)
+	}
+	if r, _, b, _ := img.At(10, 35).RGBA(); b < r {
+		t.Errorf("bottom of rotated image should be blue, got r=%d b=%d", r>>8, b>>8)
+	}
+}
+
+func TestProcessImage_RenditionSizes(t *testing.T) {
+	renditions, err := ProcessImage(twoColorJPEG(t, 3000, 1500))
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+
+	want := map[string][2]int{
+		models.ImageVariantThumb: {320, 160},
+		models.ImageVariantBoard: {1024, 512},
+		models.ImageVariantFull:  {2048, 1024},
+	}
+	for variant, size := range want {
+		r := renditionByVariant(t, renditions, variant)
+		if r.Width != size[0] || r.Height != size[1] {
+			t.Errorf("%s = %dx%d, want %dx%d", variant, r.Width, r.Height, size[0], size[1])
+		}
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(r.Data))
+		if err != nil || cfg.Width != size[0] || cfg.Height != size[1] {
+			t.Errorf("%s encoded as %dx%d (%v)", variant, cfg.Width, cfg.Height, err)
+		}
+	}
+}
+
+func TestProcessImage_KeepsTransparencyAsPNG(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	img.Set(1, 1, color.NRGBA{255, 0, 0, 128})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	renditions, err := ProcessImage(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ProcessImage failed: %v", err)
+	}
+	for _, r := range renditions {
+		if r.ContentType != "image/png" || r.Ext != ".png" {
+			t.Errorf("%s content type = %s, want image/png", r.Variant, r.ContentType)
+		}
+	}
+}
+
+func TestProcessImage_Invalid(t *testing.T) {
+	if _, err := ProcessImage([]byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10}); err != ErrInvalidImage {
+		t.Errorf("truncated JPEG err = %v, want ErrInvalidImage", err)
+	}
+
+	// Cabecera PNG que declara 10000×10000 px
+	ihdr := new(bytes.Buffer)
+	ihdr.WriteString("IHDR")
+	binary.Write(ihdr, binary.BigEndian, []uint32{10000, 10000})
+	ihdr.Write([]byte{8, 6, 0, 0, 0})
+	header := new(bytes.Buffer)
+	header.WriteString("\x89PNG\r\n\x1a\n\x00\x00\x00\x0d")
+	header.Write(ihdr.Bytes())
+	binary.Write(header, binary.BigEndian, crc32.ChecksumIEEE(ihdr.Bytes()))
+	if _, err := ProcessImage(header.Bytes()); err != ErrImageTooLarge {
+		t.Errorf("huge PNG err = %v, want ErrImageTooLarge", err)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 2×1: pixel (0,0) marcado
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, color.RGBA{255, 255, 255, 255})
+
+	tests := []struct {
+		orientation int
+		w, h        int
+		markX       int
+		markY       int
+	}{
+		{1, 2, 1, 0, 0},
+		{2, 2, 1, 1, 0},
+		{3, 2, 1, 1, 0},
+		{4, 2, 1, 0, 0},
+		{5, 1, 2, 0, 0},
+		{6, 1, 2, 0, 0},
+		{7, 1, 2, 0, 1},
+		{8, 1, 2, 0, 1},
+	}
+	for _, tt := range tests {
+		dst := applyOrientation(src, tt.orientation)
+		if dst.Bounds().Dx() != tt.w || dst.Bounds().Dy() != tt.h {
+			t.Errorf("orientation %d: size %v, want %dx%d", tt.orientation, dst.Bounds(), tt.w, tt.h)
+			continue
+		}
+		if r, _, _, _ := dst.At(tt.markX, tt.markY).RGBA(); r == 0 {
+			t.Errorf("orientation %d: mark not at (%d,%d)", tt.orientation, tt.markX, tt.markY)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/repository"
+)
+
+// LiveEventFinder obtiene un evento por slug
+type LiveEventFinder interface {
+	GetBySlug(slug string) (*models.Event, error)
+}
+
+// LiveMemberFinder obtiene el rol de un usuario en un evento
+type LiveMemberFinder interface {
+	GetRole(eventID, userID uuid.UUID) (models.EventRole, error)
+}
+
+// LivePlayerFinder obtiene un jugador por ID
+type LivePlayerFinder interface {
+	GetByID(id uuid.UUID) (*models.Player, error)
+}
+
+// LiveQuizAuth autentica a los participantes del quiz en vivo que llegan por WebSocket:
+// el host con su access token y los jugadores con su token de sesión.
+type LiveQuizAuth struct {
+	auth         *AuthService
+	playerTokens *PlayerTokenService
+	events       LiveEventFinder
+	members      LiveMemberFinder
+	players      LivePlayerFinder
+}
+
+// NewLiveQuizAuth crea el autenticador del quiz en vivo
+func NewLiveQuizAuth(auth *AuthService, playerTokens *PlayerTokenService, events LiveEventFinder, members LiveMemberFinder, players LivePlayerFinder) *LiveQuizAuth {
+	return &LiveQuizAuth{
+		auth:         auth,
+		playerTokens: playerTokens,
+		events:       events,
+		members:      members,
+		players:      players,
+	}
+}
+
+// AuthenticateHost valida el access token y que el usuario pueda conducir el evento
+// (owner o co_host)
+func (a *LiveQuizAuth) AuthenticateHost(eventSlug, accessToken string) (*models.Event, error) {
+	claims, err := a.auth.ValidateToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := a.events.GetBySlug(eventSlug)
+	if err != nil {
+		return nil, err
+	}
+	if event.OwnerID == claims.UserID {
+		return event, nil
+	}
+
+	role, err := a.members.GetRole(event.ID, claims.UserID)
+	if err == repository.ErrNotEventMember || (err == nil && !role.Can(models.PermissionManage)) {
+		return nil, ErrNotEventHost
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// AuthenticatePlayer valida el token de sesión y que el jugador sea del evento
+func (a *LiveQuizAuth) AuthenticatePlayer(eventSlug, playerToken string) (*models.Player, error) {
+	claims, err := a.playerTokens.Validate(playerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := a.events.GetBySlug(eventSlug)
+	if err != nil {
+		return nil, err
+	}
+	if claims.EventID != event.ID {
+		return nil, ErrInvalidPlayerToken
+	}
+
+	return a.players.GetByID(claims.PlayerID)
+}
+
+// ErrNotEventHost el usuario no es host (owner o co_host) del evento
+var ErrNotEventHost = errors.New("not the host of this event")
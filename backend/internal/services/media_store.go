@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalMediaURLPrefix is the public prefix of files served from the local uploads
+// directory (nginx serves it as /uploads/).
+const LocalMediaURLPrefix = "/uploads/"
+
+// MediaStore stores uploaded media (postcards, thumbnails, event logos).
+// Keys are slash-separated relative paths such as "postcards/<uuid>.jpg".
+type MediaStore interface {
+	// Put stores the content under key. size may be -1 when unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the stored object. Returns ErrMediaNotFound if it does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL saved in the database for key.
+	URL(key string) string
+	// SignedURL returns a time-limited URL for private media.
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// KeyFromURL maps a URL returned by URL back to its key.
+	KeyFromURL(url string) (string, bool)
+}
+
+// CleanMediaKey validates a media key and returns it in canonical form.
+// Rejects empty keys, absolute paths and keys that escape the store root.
+func CleanMediaKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidMediaKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidMediaKey
+	}
+	return cleaned, nil
+}
+
+// CopyMedia duplicates the object stored under srcKey into dstKey, e.g. when an
+// event is cloned and each copy must own its files. Returns ErrMediaNotFound if
+// the source does not exist.
+func CopyMedia(ctx context.Context, store MediaStore, srcKey, dstKey string) error {
+	src, err := store.Get(ctx, srcKey)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(dstKey))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return store.Put(ctx, dstKey, src, -1, contentType)
+}
+
+// LocalMediaStore stores media in a directory on the local filesystem.
+// Only suitable for a single API instance (or a shared volume).
+type LocalMediaStore struct {
+	dir string
+}
+
+// NewLocalMediaStore creates a store rooted at dir
+func NewLocalMediaStore(dir string) *LocalMediaStore {
+	return &LocalMediaStore{dir: dir}
+}
+
+// path resolves a key to its location on disk
+func (s *LocalMediaStore) path(key string) (string, error) {
+	cleaned, err := CleanMediaKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
+
+// Put writes the content to a temporary file and renames it into place, so
+// readers never see a partially written file.
+func (s *LocalMediaStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	diskPath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(diskPath), 0755); err != nil {
+		return fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(diskPath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create media file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set media permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), diskPath); err != nil {
+		return fmt.Errorf("failed to store media file: %w", err)
+	}
+	return nil
+}
+
+// Get opens the file stored under key
+func (s *LocalMediaStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	diskPath, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(diskPath)
+	if os.IsNotExist(err) {
+		return nil, ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open media file: %w", err)
+	}
+	return f, nil
+}
+
+// Delete removes the file stored under key
+func (s *LocalMediaStore) Delete(ctx context.Context, key string) error {
+	diskPath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(diskPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete media file: %w", err)
+	}
+	return nil
+}
+
+// URL returns the /uploads/ path nginx serves the file from
+func (s *LocalMediaStore) URL(key string) string {
+	return LocalMediaURLPrefix + key
+}
+
+// SignedURL returns the public URL: local uploads are served by nginx without
+// authentication, so there is nothing to sign.
+func (s *LocalMediaStore) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if _, err := CleanMediaKey(key); err != nil {
+		return "", err
+	}
+	return s.URL(key), nil
+}
+
+// KeyFromURL maps an /uploads/... path back to its key
+func (s *LocalMediaStore) KeyFromURL(url string) (string, bool) {
+	return keyWithPrefix(url, LocalMediaURLPrefix)
+}
+
+// keyWithPrefix strips prefix from url and validates the remaining key
+func keyWithPrefix(url, prefix string) (string, bool) {
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	key, err := CleanMediaKey(strings.TrimPrefix(url, prefix))
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// ErrMediaNotFound is returned when the requested media object does not exist
+var ErrMediaNotFound = errors.New("media not found")
+
+// ErrInvalidMediaKey is returned for empty keys or keys outside the store root
+var ErrInvalidMediaKey = errors.New("invalid media key")
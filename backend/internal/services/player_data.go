@@ -0,0 +1,88 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"path"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// PlayerDataFileName nombre del JSON con los datos del jugador dentro del ZIP
+const PlayerDataFileName = "data.json"
+
+// WritePlayerDataArchive escribe el export GDPR del jugador como ZIP: la media original
+// de cada postal en media/<postal>/<archivo> y data.json con todos sus datos, incluida
+// la lista de archivos (media_files). Los archivos que no se pueden leer quedan marcados
+// como missing en vez de cortar el export. Completa data.MediaFiles.
+func WritePlayerDataArchive(ctx context.Context, w io.Writer, data *models.PlayerDataExport, media MediaStore) error {
+	zw := zip.NewWriter(w)
+
+	data.MediaFiles = []models.PlayerMediaFile{}
+	for _, postcard := range data.Postcards {
+		urls := []string{postcard.ImagePath}
+		if postcard.ThumbnailPath != nil && *postcard.ThumbnailPath != "" {
+			urls = append(urls, *postcard.ThumbnailPath)
+		}
+		for _, url := range urls {
+			file := models.PlayerMediaFile{PostcardID: postcard.ID, URL: url}
+			entry := "media/" + postcard.ID.String() + "/" + path.Base(url)
+			copied, err := copyMediaToZip(ctx, zw, media, url, entry)
+			if err != nil {
+				return err
+			}
+			if copied {
+				file.Path = entry
+			} else {
+				file.Missing = true
+			}
+			data.MediaFiles = append(data.MediaFiles, file)
+		}
+	}
+
+	fw, err := zw.Create(PlayerDataFileName)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// copyMediaToZip copia un archivo del MediaStore al ZIP. Devuelve false (sin error) si el
+// archivo no está en el store; los errores de escritura del ZIP sí cortan el export.
+func copyMediaToZip(ctx context.Context, zw *zip.Writer, media MediaStore, url, entry string) (bool, error) {
+	if media == nil || url == "" {
+		return false, nil
+	}
+	key, ok := media.KeyFromURL(url)
+	if !ok {
+		return false, nil
+	}
+	r, err := media.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ErrMediaNotFound) {
+			log.Printf("[WARN] Player export: failed to open media %s: %v", key, err)
+		}
+		return false, nil
+	}
+	defer r.Close()
+
+	// Store: fotos y videos ya vienen comprimidos
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: entry, Method: zip.Store})
+	if err != nil {
+		return false, err
+	}
+	if _, err := io.Copy(fw, r); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestWritePlayerDataArchive(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalMediaStore(t.TempDir())
+	if err := store.Put(ctx, "postcards/photo.jpg", strings.NewReader("jpeg bytes"), -1, "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	photo := models.Postcard{ID: uuid.New(), ImagePath: store.URL("postcards/photo.jpg"), Message: "¡Feliz cumple!"}
+	missingThumb := store.URL("postcards/thumbnails/gone.jpg")
+	video := models.Postcard{ID: uuid.New(), ImagePath: "https://cdn.example.com/video.mp4", ThumbnailPath: &missingThumb}
+
+	data := &models.PlayerDataExport{
+		ExportedAt: time.Now(),
+		Player:     models.Player{ID: uuid.New(), Name: "Ana"},
+		Postcards:  []models.Postcard{photo, video},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePlayerDataArchive(ctx, &buf, data, store); err != nil {
+		t.Fatalf("WritePlayerDataArchive() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("output is not a ZIP: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		files[f.Name] = string(content)
+	}
+
+	photoEntry := "media/" + photo.ID.String() + "/photo.jpg"
+	if files[photoEntry] != "jpeg bytes" {
+		t.Errorf("%s = %q, want the stored media", photoEntry, files[photoEntry])
+	}
+	if len(files) != 2 {
+		t.Errorf("ZIP has %d files, want data.json and one media file", len(files))
+	}
+
+	var exported models.PlayerDataExport
+	if err := json.Unmarshal([]byte(files[PlayerDataFileName]), &exported); err != nil {
+		t.Fatalf("data.json: %v", err)
+	}
+	if exported.Player.Name != "Ana" || len(exported.Postcards) != 2 {
+		t.Errorf("data.json player = %q with %d postcards", exported.Player.Name, len(exported.Postcards))
+	}
+	if len(exported.MediaFiles) != 3 {
+		t.Fatalf("media_files = %+v, want 3 entries", exported.MediaFiles)
+	}
+	if exported.MediaFiles[0].Path != photoEntry || exported.MediaFiles[0].Missing {
+		t.Errorf("media_files[0] = %+v", exported.MediaFiles[0])
+	}
+	// Media externa y archivos borrados quedan listados como missing
+	for _, f := range exported.MediaFiles[1:] {
+		if !f.Missing || f.Path != "" {
+			t.Errorf("media file %s should be missing, got %+v", f.URL, f)
+		}
+	}
+}
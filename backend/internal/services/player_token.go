@@ -0,0 +1,95 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// playerTokenAudience distingue los tokens de invitado de los access tokens de usuarios
+const playerTokenAudience = "player"
+
+// DefaultPlayerTokenTTL vigencia de un token de jugador (cubre el evento y el "after")
+const DefaultPlayerTokenTTL = 30 * 24 * time.Hour
+
+// PlayerTokenService firma y valida tokens de sesión de jugadores (invitados).
+// El token es un JWT HS256 con alcance a un jugador y un evento, y se firma con
+// una clave derivada del secreto JWT para que no sea intercambiable con los
+// access tokens de administradores.
+type PlayerTokenService struct {
+	signingKey []byte
+	ttl        time.Duration
+}
+
+// PlayerClaims datos verificados de un token de jugador
+type PlayerClaims struct {
+	PlayerID uuid.UUID
+	EventID  uuid.UUID
+}
+
+type playerTokenClaims struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	EventID  uuid.UUID `json:"event_id"`
+	jwt.RegisteredClaims
+}
+
+// NewPlayerTokenService crea el servicio a partir del secreto del servidor
+func NewPlayerTokenService(secret string) *PlayerTokenService {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("player-session-token"))
+
+	return &PlayerTokenService{
+		signingKey: mac.Sum(nil),
+		ttl:        DefaultPlayerTokenTTL,
+	}
+}
+
+// Issue genera un token firmado para el jugador dentro del evento
+func (s *PlayerTokenService) Issue(playerID, eventID uuid.UUID) (string, error) {
+	now := time.Now()
+	claims := playerTokenClaims{
+		PlayerID: playerID,
+		EventID:  eventID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{playerTokenAudience},
+			Subject:   playerID.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.signingKey)
+}
+
+// Validate verifica firma, expiración y audiencia de un token de jugador
+func (s *PlayerTokenService) Validate(tokenString string) (*PlayerClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &playerTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.signingKey, nil
+	}, jwt.WithAudience(playerTokenAudience))
+	if err != nil {
+		return nil, ErrInvalidPlayerToken
+	}
+
+	claims, ok := token.Claims.(*playerTokenClaims)
+	if !ok || !token.Valid || claims.PlayerID == uuid.Nil {
+		return nil, ErrInvalidPlayerToken
+	}
+
+	return &PlayerClaims{
+		PlayerID: claims.PlayerID,
+		EventID:  claims.EventID,
+	}, nil
+}
+
+// ErrInvalidPlayerToken token de jugador inválido, expirado o de otro emisor
+var ErrInvalidPlayerToken = errors.New("invalid or expired player token")
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestPlayerTokenService_IssueAndValidate(t *testing.T) {
+	svc := NewPlayerTokenService("test-secret")
+	playerID := uuid.New()
+	eventID := uuid.New()
+
+	token, err := svc.Issue(playerID, eventID)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	claims, err := svc.Validate(token)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if claims.PlayerID != playerID {
+		t.Errorf("PlayerID = %s, want %s", claims.PlayerID, playerID)
+	}
+	if claims.EventID != eventID {
+		t.Errorf("EventID = %s, want %s", claims.EventID, eventID)
+	}
+}
+
+func TestPlayerTokenService_RejectsInvalidTokens(t *testing.T) {
+	svc := NewPlayerTokenService("test-secret")
+	token, err := svc.Issue(uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	expiredSvc := NewPlayerTokenService("test-secret")
+	expiredSvc.ttl = -time.Minute
+	expired, err := expiredSvc.Issue(uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		svc   *PlayerTokenService
+		token string
+	}{
+		{name: "garbage", svc: svc, token: "not-a-token"},
+		{name: "tampered", svc: svc, token: token[:len(token)-2] + "xx"},
+		{name: "different secret", svc: NewPlayerTokenService("other-secret"), token: token},
+		{name: "expired", svc: svc, token: expired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.svc.Validate(tt.token); err != ErrInvalidPlayerToken {
+				t.Errorf("Validate() error = %v, want ErrInvalidPlayerToken", err)
+			}
+		})
+	}
+}
+
+func TestPlayerTokenService_NotInterchangeableWithAccessTokens(t *testing.T) {
+	secret := "shared-secret"
+	auth := NewAuthService(nil, nil, secret)
+	players := NewPlayerTokenService(secret)
+
+	accessToken, err := auth.generateAccessToken(&models.User{ID: uuid.New(), Email: "owner@example.com"})
+	if err != nil {
+		t.Fatalf("generateAccessToken() error = %v", err)
+	}
+	if _, err := players.Validate(accessToken); err == nil {
+		t.Error("access token must not validate as a player token")
+	}
+
+	playerToken, err := players.Issue(uuid.New(), uuid.New())
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if _, err := auth.ValidateToken(playerToken); err == nil {
+		t.Error("player token must not validate as an access token")
+	}
+}
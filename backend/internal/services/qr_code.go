@@ -0,0 +1,77 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Tamaños permitidos (px) de los QR en PNG
+const (
+	DefaultQRSize = 512
+	MinQRSize     = 128
+	MaxQRSize     = 2048
+)
+
+// qrRecoveryLevel corrección de errores media (15%): aguanta impresiones gastadas
+// sin agrandar demasiado el código
+const qrRecoveryLevel = qrcode.Medium
+
+// QRModules codifica content y devuelve la matriz de módulos (true = oscuro),
+// incluido el margen blanco que exige el estándar
+func QRModules(content string) ([][]bool, error) {
+	q, err := qrcode.New(content, qrRecoveryLevel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQRContent, err)
+	}
+	return q.Bitmap(), nil
+}
+
+// QRCodePNG genera el QR como PNG de size x size px, negro sobre blanco
+func QRCodePNG(content string, size int) ([]byte, error) {
+	if size < MinQRSize || size > MaxQRSize {
+		return nil, ErrQRSize
+	}
+	q, err := qrcode.New(content, qrRecoveryLevel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQRContent, err)
+	}
+	return q.PNG(size)
+}
+
+// QRCodeSVG genera el QR como SVG escalable (un módulo = una unidad del viewBox).
+// Los módulos oscuros contiguos de cada fila se unen en un solo rectángulo.
+func QRCodeSVG(content string) ([]byte, error) {
+	modules, err := QRModules(content)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(modules)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" shape-rendering="crispEdges">`, n, n, n*8, n*8)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#ffffff"/><path fill="#000000" d="`, n, n)
+	for y, row := range modules {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes(), nil
+}
+
+// ErrQRContent el contenido no entra en un QR
+var ErrQRContent = errors.New("content cannot be encoded as a QR code")
+
+// ErrQRSize tamaño de QR fuera de rango
+var ErrQRSize = errors.New("QR size out of range")
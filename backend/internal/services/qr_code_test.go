@@ -0,0 +1,80 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestQRCodePNG(t *testing.T) {
+	data, err := QRCodePNG("https://example.com/e/mile-party", 256)
+	if err != nil {
+		t.Fatalf("QRCodePNG() error = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 256 || b.Dy() != 256 {
+		t.Errorf("size = %dx%d, want 256x256", b.Dx(), b.Dy())
+	}
+
+	for _, size := range []int{MinQRSize - 1, MaxQRSize + 1} {
+		if _, err := QRCodePNG("https://example.com", size); err != ErrQRSize {
+			t.Errorf("QRCodePNG(size=%d) error = %v, want ErrQRSize", size, err)
+		}
+	}
+}
+
+func TestQRCodeSVG(t *testing.T) {
+	content := "https://example.com/e/mile-party"
+	svg, err := QRCodeSVG(content)
+	if err != nil {
+		t.Fatalf("QRCodeSVG() error = %v", err)
+	}
+	modules, err := QRModules(content)
+	if err != nil {
+		t.Fatalf("QRModules() error = %v", err)
+	}
+
+	s := string(svg)
+	if !strings.HasPrefix(s, `<svg xmlns="http://www.w3.org/2000/svg"`) || !strings.HasSuffix(s, `</svg>`) {
+		t.Fatalf("not an SVG document: %.80s", s)
+	}
+	n := len(modules)
+	if !strings.Contains(s, `viewBox="0 0 `+strconv.Itoa(n)+` `+strconv.Itoa(n)+`"`) {
+		t.Errorf("viewBox does not match the %d modules", n)
+	}
+
+	// Cada módulo oscuro queda cubierto por exactamente un tramo del path
+	dark := 0
+	for _, row := range modules {
+		for _, on := range row {
+			if on {
+				dark++
+			}
+		}
+	}
+	covered := 0
+	for _, segment := range strings.Split(s[strings.Index(s, `d="`)+3:strings.LastIndex(s, `"/>`)], "z") {
+		if segment == "" {
+			continue
+		}
+		var x, y, w, w2 int
+		if _, err := fmt.Sscanf(segment, "M%d %dh%dv1h-%d", &x, &y, &w, &w2); err != nil {
+			t.Fatalf("unexpected path segment %q: %v", segment, err)
+		}
+		for i := x; i < x+w; i++ {
+			if !modules[y][i] {
+				t.Fatalf("segment %q paints a light module", segment)
+			}
+		}
+		covered += w
+	}
+	if covered != dark {
+		t.Errorf("path covers %d modules, want %d", covered, dark)
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// TopWrongAnswersLimit cantidad de respuestas incorrectas más comunes por pregunta
+const TopWrongAnswersLimit = 5
+
+// AnalyzeQuestions calcula, para cada pregunta puntuable del evento, cuántos
+// jugadores la acertaron y cuáles fueron las respuestas incorrectas más comunes.
+// Usa las respuestas guardadas, el answer key vigente y las aceptaciones del host,
+// igual que el recalculo de puntajes. Las respuestas se agrupan normalizadas.
+func AnalyzeQuestions(questions []models.QuizQuestion, answers []models.PlayerQuizAnswers, accepted map[uuid.UUID]map[string]string) []models.QuestionStats {
+	scorer := NewScorerWithQuestions(questions)
+	normalizer := scorer.GetNormalizer()
+
+	stats := make([]models.QuestionStats, 0, len(questions))
+	index := make(map[string]int)
+	wrong := make(map[string]map[string]int)
+
+	for _, q := range questions {
+		if !q.IsScorable || (q.Section != "favorites" && q.Section != "preferences") {
+			continue
+		}
+		points := q.Points
+		if points <= 0 {
+			points = models.DefaultQuestionPoints
+		}
+		index[q.Key] = len(stats)
+		wrong[q.Key] = make(map[string]int)
+		stats = append(stats, models.QuestionStats{
+			Key:            q.Key,
+			Section:        q.Section,
+			QuestionText:   q.QuestionText,
+			CorrectAnswers: q.CorrectAnswers,
+			Points:         points,
+			Respondents:    len(answers),
+		})
+	}
+
+	for _, a := range answers {
+		scored := make(map[string]bool)
+		breakdown := scorer.ScoreWithOverrides(
+			scorer.NormalizeFavorites(a.Favorites),
+			scorer.NormalizePreferences(a.Preferences),
+			accepted[a.Player.ID],
+		)
+		for _, qs := range breakdown.Questions {
+			idx, ok := index[qs.Key]
+			if !ok {
+				continue
+			}
+			scored[qs.Key] = true
+			s := &stats[idx]
+			switch qs.Result {
+			case models.ScoreResultCorrect:
+				s.Answered++
+				s.Correct++
+			case models.ScoreResultPartial:
+				s.Answered++
+				s.Partial++
+			case models.ScoreResultIncorrect:
+				s.Answered++
+				s.Incorrect++
+				wrong[qs.Key][qs.Answer]++
+			}
+		}
+
+		// Preguntas sin respuesta válida: el scorer las ignora, todo cuenta como incorrecto
+		for key, idx := range index {
+			if scored[key] {
+				continue
+			}
+			raw := a.Favorites[key]
+			if stats[idx].Section == "preferences" {
+				raw = a.Preferences[key]
+			}
+			if answer := normalizer.NormalizeForStorage(raw); answer != "" {
+				stats[idx].Answered++
+				stats[idx].Incorrect++
+				wrong[key][answer]++
+			}
+		}
+	}
+
+	for i := range stats {
+		s := &stats[i]
+		s.TopWrongAnswers = topAnswers(wrong[s.Key], TopWrongAnswersLimit)
+		s.Warnings = []string{}
+		if !scorer.isScored(s.Key) {
+			// Sin answer key la dificultad no significa nada
+			s.Warnings = append(s.Warnings, models.QuestionWarningNoValidAnswer)
+			continue
+		}
+		if s.Answered > 0 && s.Correct == 0 {
+			s.Warnings = append(s.Warnings, models.QuestionWarningNobodyCorrect)
+		}
+		if s.Respondents > 0 {
+			s.CorrectRate = math.Round(float64(s.Correct)/float64(s.Respondents)*10000) / 100
+			s.Difficulty = difficultyFor(s.CorrectRate)
+		}
+	}
+
+	return stats
+}
+
+// difficultyFor clasifica una pregunta según su porcentaje de aciertos
+func difficultyFor(correctRate float64) string {
+	switch {
+	case correctRate >= 70:
+		return models.DifficultyEasy
+	case correctRate >= 30:
+		return models.DifficultyMedium
+	default:
+		return models.DifficultyHard
+	}
+}
+
+// topAnswers ordena las respuestas por cantidad (desempate alfabético) y corta en limit
+func topAnswers(counts map[string]int, limit int) []models.AnswerCount {
+	top := make([]models.AnswerCount, 0, len(counts))
+	for answer, count := range counts {
+		top = append(top, models.AnswerCount{Answer: answer, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Answer < top[j].Answer
+	})
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
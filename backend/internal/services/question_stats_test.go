@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+func TestAnalyzeQuestions(t *testing.T) {
+	questions := []models.QuizQuestion{
+		{Key: "flower", Section: "favorites", QuestionText: "¿Flor favorita?", CorrectAnswers: []string{"girasol"}, IsScorable: true},
+		{Key: "coffee", Section: "preferences", QuestionText: "¿Café o té?", CorrectAnswers: []string{"te"}, IsScorable: true, QuestionScoring: models.QuestionScoring{Points: 2}},
+		{Key: "city", Section: "favorites", QuestionText: "¿Ciudad favorita?", IsScorable: true},
+		{Key: "color", Section: "favorites", QuestionText: "¿Color favorito?", CorrectAnswers: []string{"violeta"}, IsScorable: true},
+		{Key: "about", Section: "description", QuestionText: "Describila", IsScorable: false},
+	}
+
+	ana := models.Player{ID: uuid.New(), Name: "Ana"}
+	beto := models.Player{ID: uuid.New(), Name: "Beto"}
+	caro := models.Player{ID: uuid.New(), Name: "Caro"}
+	dani := models.Player{ID: uuid.New(), Name: "Dani"}
+
+	answers := []models.PlayerQuizAnswers{
+		{Player: ana, Favorites: map[string]string{"flower": "girasol", "city": "Rosario", "color": "rojo"}, Preferences: map[string]string{"coffee": "te"}},
+		{Player: beto, Favorites: map[string]string{"flower": "La Rosa", "city": "rosario", "color": "azul"}, Preferences: map[string]string{"coffee": "cafe"}},
+		{Player: caro, Favorites: map[string]string{"flower": "rosa", "color": "rojo"}, Preferences: map[string]string{"coffee": "cafe"}},
+		{Player: dani, Favorites: map[string]string{"flower": "tulipan", "color": "Rojo"}, Preferences: map[string]string{}},
+	}
+	// El host aceptó el tulipán de Dani
+	accepted := map[uuid.UUID]map[string]string{dani.ID: {"flower": "tulipan"}}
+
+	stats := AnalyzeQuestions(questions, answers, accepted)
+	if len(stats) != 4 {
+		t.Fatalf("got %d questions, want 4 (non-scorable skipped)", len(stats))
+	}
+	byKey := make(map[string]models.QuestionStats)
+	for _, s := range stats {
+		byKey[s.Key] = s
+	}
+
+	flower := byKey["flower"]
+	if flower.Correct != 2 || flower.Incorrect != 2 || flower.Answered != 4 {
+		t.Errorf("flower counts = %d correct / %d incorrect / %d answered, want 2/2/4", flower.Correct, flower.Incorrect, flower.Answered)
+	}
+	if flower.CorrectRate != 50 || flower.Difficulty != models.DifficultyMedium {
+		t.Errorf("flower rate = %.2f (%s), want 50 (medium)", flower.CorrectRate, flower.Difficulty)
+	}
+	// "La Rosa" y "rosa" se agrupan tras normalizar
+	if len(flower.TopWrongAnswers) != 1 || flower.TopWrongAnswers[0] != (models.AnswerCount{Answer: "rosa", Count: 2}) {
+		t.Errorf("flower wrong answers = %+v, want [rosa x2]", flower.TopWrongAnswers)
+	}
+	if len(flower.Warnings) != 0 {
+		t.Errorf("flower warnings = %v, want none", flower.Warnings)
+	}
+
+	coffee := byKey["coffee"]
+	if coffee.Points != 2 || coffee.Correct != 1 || coffee.Answered != 3 {
+		t.Errorf("coffee = %+v, want 2 points, 1 correct of 3 answered", coffee)
+	}
+	if coffee.CorrectRate != 25 || coffee.Difficulty != models.DifficultyHard {
+		t.Errorf("coffee rate = %.2f (%s), want 25 (hard)", coffee.CorrectRate, coffee.Difficulty)
+	}
+
+	city := byKey["city"]
+	if len(city.Warnings) != 1 || city.Warnings[0] != models.QuestionWarningNoValidAnswer {
+		t.Errorf("city warnings = %v, want [no_valid_answer]", city.Warnings)
+	}
+	if city.Answered != 2 || city.Difficulty != "" {
+		t.Errorf("city = %d answered, difficulty %q; want 2 and no difficulty", city.Answered, city.Difficulty)
+	}
+	if len(city.TopWrongAnswers) != 1 || city.TopWrongAnswers[0].Count != 2 {
+		t.Errorf("city answers = %+v, want [rosario x2]", city.TopWrongAnswers)
+	}
+
+	color := byKey["color"]
+	if len(color.Warnings) != 1 || color.Warnings[0] != models.QuestionWarningNobodyCorrect {
+		t.Errorf("color warnings = %v, want [nobody_correct]", color.Warnings)
+	}
+	want := []models.AnswerCount{{Answer: "rojo", Count: 3}, {Answer: "azul", Count: 1}}
+	if len(color.TopWrongAnswers) != 2 || color.TopWrongAnswers[0] != want[0] || color.TopWrongAnswers[1] != want[1] {
+		t.Errorf("color wrong answers = %+v, want %+v", color.TopWrongAnswers, want)
+	}
+}
+
+func TestAnalyzeQuestions_NoSubmissions(t *testing.T) {
+	questions := []models.QuizQuestion{
+		{Key: "flower", Section: "favorites", CorrectAnswers: []string{"girasol"}, IsScorable: true},
+	}
+
+	stats := AnalyzeQuestions(questions, nil, nil)
+	if len(stats) != 1 {
+		t.Fatalf("got %d questions, want 1", len(stats))
+	}
+	if stats[0].Difficulty != "" || stats[0].CorrectRate != 0 || len(stats[0].Warnings) != 0 {
+		t.Errorf("stats = %+v, want no difficulty, rate or warnings", stats[0])
+	}
+	if stats[0].TopWrongAnswers == nil {
+		t.Error("top_wrong_answers should be an empty list, not null")
+	}
+}
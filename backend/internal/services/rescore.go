@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// RescoreQuestionSource obtiene las preguntas vigentes de un evento
+type RescoreQuestionSource interface {
+	ListByEvent(eventID uuid.UUID) ([]models.QuizQuestion, error)
+}
+
+// RescoreAnswerSource obtiene las respuestas guardadas y las aceptaciones del host
+type RescoreAnswerSource interface {
+	ListAnswersByEvent(eventID uuid.UUID) ([]models.PlayerQuizAnswers, error)
+	ListAcceptedByEvent(eventID uuid.UUID) (map[uuid.UUID]map[string]string, error)
+}
+
+// RescoreScoreStore persiste los puntajes y lista el ranking resultante
+type RescoreScoreStore interface {
+	UpdateScores(eventID uuid.UUID, scores map[uuid.UUID]int) error
+	ListByEvent(eventID uuid.UUID) ([]models.Player, error)
+}
+
+// RankingBroadcaster envía el ranking a los clientes conectados al evento
+type RankingBroadcaster interface {
+	BroadcastRankingToRoom(eventSlug string, ranking []models.RankingEntry)
+}
+
+// Rescorer recalcula los puntajes de todos los jugadores de un evento a partir
+// de las respuestas guardadas en quiz_answers, las preguntas vigentes y las
+// respuestas aceptadas por el host.
+type Rescorer struct {
+	questions RescoreQuestionSource
+	answers   RescoreAnswerSource
+	scores    RescoreScoreStore
+	hub       RankingBroadcaster
+}
+
+// NewRescorer crea un nuevo Rescorer. hub puede ser nil (sin broadcast).
+func NewRescorer(questions RescoreQuestionSource, answers RescoreAnswerSource, scores RescoreScoreStore, hub RankingBroadcaster) *Rescorer {
+	return &Rescorer{
+		questions: questions,
+		answers:   answers,
+		scores:    scores,
+		hub:       hub,
+	}
+}
+
+// NewEventScorer arma el scorer de un evento: preguntas de la DB o, si el evento
+// no tiene preguntas cargadas, el set legacy (igual que SubmitQuiz)
+func NewEventScorer(questions []models.QuizQuestion) *Scorer {
+	if len(questions) == 0 {
+		return NewScorer()
+	}
+	return NewScorerWithQuestions(questions)
+}
+
+// RescoreEvent recalcula y guarda los puntajes del evento en una sola transacción
+// y envía un único broadcast del ranking a la sala del evento.
+func (r *Rescorer) RescoreEvent(eventID uuid.UUID, eventSlug string) (*models.RescoreResult, error) {
+	questions, err := r.questions.ListByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+	scorer := NewEventScorer(questions)
+
+	answers, err := r.answers.ListAnswersByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list answers: %w", err)
+	}
+
+	accepted, err := r.answers.ListAcceptedByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accepted answers: %w", err)
+	}
+
+	result := &models.RescoreResult{Players: len(answers)}
+	scores := make(map[uuid.UUID]int, len(answers))
+	for _, a := range answers {
+		score := scorer.ScoreWithOverrides(a.Favorites, a.Preferences, accepted[a.Player.ID]).Total
+		scores[a.Player.ID] = score
+		if score != a.Player.Score {
+			result.Changed++
+		}
+	}
+
+	if err := r.scores.UpdateScores(eventID, scores); err != nil {
+		return nil, fmt.Errorf("failed to update scores: %w", err)
+	}
+
+	players, err := r.scores.ListByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list players: %w", err)
+	}
+
+	result.Ranking = make([]models.RankingEntry, len(players))
+	for i, player := range players {
+		result.Ranking[i] = models.RankingEntry{
+			Position: i + 1,
+			Player:   player,
+		}
+	}
+
+	if r.hub != nil && eventSlug != "" {
+		r.hub.BroadcastRankingToRoom(eventSlug, result.Ranking)
+	}
+
+	return result, nil
+}
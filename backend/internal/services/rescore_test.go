@@ -0,0 +1,105 @@
+package services
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+type fakeRescoreStore struct {
+	questions []models.QuizQuestion
+	answers   []models.PlayerQuizAnswers
+	accepted  map[uuid.UUID]map[string]string
+	players   map[uuid.UUID]models.Player
+	updates   int
+}
+
+func (f *fakeRescoreStore) ListByEvent(eventID uuid.UUID) ([]models.QuizQuestion, error) {
+	return f.questions, nil
+}
+
+func (f *fakeRescoreStore) ListAnswersByEvent(eventID uuid.UUID) ([]models.PlayerQuizAnswers, error) {
+	return f.answers, nil
+}
+
+func (f *fakeRescoreStore) ListAcceptedByEvent(eventID uuid.UUID) (map[uuid.UUID]map[string]string, error) {
+	return f.accepted, nil
+}
+
+func (f *fakeRescoreStore) UpdateScores(eventID uuid.UUID, scores map[uuid.UUID]int) error {
+	f.updates++
+	for id, score := range scores {
+		p := f.players[id]
+		p.Score = score
+		f.players[id] = p
+	}
+	return nil
+}
+
+// fakePlayerLister adapta el store a RescoreScoreStore (ListByEvent de jugadores)
+type fakePlayerLister struct{ *fakeRescoreStore }
+
+func (f fakePlayerLister) ListByEvent(eventID uuid.UUID) ([]models.Player, error) {
+	players := make([]models.Player, 0, len(f.players))
+	for _, p := range f.players {
+		players = append(players, p)
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].Score > players[j].Score })
+	return players, nil
+}
+
+type fakeRankingHub struct {
+	slug  string
+	calls int
+}
+
+func (h *fakeRankingHub) BroadcastRankingToRoom(eventSlug string, ranking []models.RankingEntry) {
+	h.slug = eventSlug
+	h.calls++
+}
+
+func TestRescorer_RescoreEvent(t *testing.T) {
+	ana := models.Player{ID: uuid.New(), Name: "Ana", Score: 1}
+	beto := models.Player{ID: uuid.New(), Name: "Beto", Score: 0}
+
+	store := &fakeRescoreStore{
+		questions: []models.QuizQuestion{
+			{Key: "flower", Section: "favorites", CorrectAnswers: []string{"girasol", "tulipan"}, IsScorable: true, QuestionScoring: models.QuestionScoring{Points: 2}},
+			{Key: "coffee", Section: "preferences", CorrectAnswers: []string{"te"}, IsScorable: true},
+		},
+		answers: []models.PlayerQuizAnswers{
+			{Player: ana, Favorites: map[string]string{"flower": "tulipan"}, Preferences: map[string]string{"coffee": "te"}},
+			{Player: beto, Favorites: map[string]string{"flower": "rosa"}, Preferences: map[string]string{"coffee": "cafe"}},
+		},
+		accepted: map[uuid.UUID]map[string]string{beto.ID: {"flower": "rosa"}},
+		players:  map[uuid.UUID]models.Player{ana.ID: ana, beto.ID: beto},
+	}
+	hub := &fakeRankingHub{}
+
+	rescorer := NewRescorer(store, store, fakePlayerLister{store}, hub)
+	result, err := rescorer.RescoreEvent(uuid.New(), "mile-2026")
+	if err != nil {
+		t.Fatalf("RescoreEvent() error = %v", err)
+	}
+
+	if got := store.players[ana.ID].Score; got != 3 {
+		t.Errorf("Ana score = %d, want 3", got)
+	}
+	if got := store.players[beto.ID].Score; got != 2 {
+		t.Errorf("Beto score = %d, want 2 (host override)", got)
+	}
+	if result.Players != 2 || result.Changed != 2 {
+		t.Errorf("result = %d players / %d changed, want 2/2", result.Players, result.Changed)
+	}
+	if len(result.Ranking) != 2 || result.Ranking[0].Player.ID != ana.ID {
+		t.Errorf("ranking = %+v, want Ana first", result.Ranking)
+	}
+	if store.updates != 1 {
+		t.Errorf("UpdateScores called %d times, want 1", store.updates)
+	}
+	if hub.calls != 1 || hub.slug != "mile-2026" {
+		t.Errorf("broadcast calls = %d to %q, want 1 to mile-2026", hub.calls, hub.slug)
+	}
+}
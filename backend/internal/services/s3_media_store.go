@@ -0,0 +1,359 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3 signature (SigV4) constants
+const (
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3Service        = "s3"
+	s3UnsignedBody   = "UNSIGNED-PAYLOAD"
+	s3TimeFormat     = "20060102T150405Z"
+	s3DateFormat     = "20060102"
+	s3MaxPresignTime = 7 * 24 * time.Hour // AWS limit for presigned URLs
+)
+
+// S3Config configures an S3-compatible media store (AWS S3, MinIO, R2...)
+type S3Config struct {
+	Endpoint        string // e.g. "http://minio:9000" or "https://s3.us-east-1.amazonaws.com"
+	Region          string // defaults to "us-east-1" (what MinIO expects)
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicURL is the base URL saved in the database (CDN or public bucket).
+	// Defaults to the bucket URL on Endpoint.
+	PublicURL string
+	// PathStyle addresses the bucket as Endpoint/bucket/key instead of
+	// bucket.endpoint/key. MinIO requires it.
+	PathStyle bool
+}
+
+// S3MediaStore stores media in an S3-compatible bucket using SigV4-signed requests
+type S3MediaStore struct {
+	config     S3Config
+	endpoint   *url.URL
+	publicURL  string
+	httpClient DriveHTTPClient
+	now        func() time.Time
+}
+
+// NewS3MediaStore creates an S3 store, validating the configuration
+func NewS3MediaStore(config S3Config) (*S3MediaStore, error) {
+	if config.Endpoint == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("S3 endpoint and bucket are required")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 access key and secret key are required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(config.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", config.Endpoint)
+	}
+
+	s := &S3MediaStore{
+		config:     config,
+		endpoint:   endpoint,
+		httpClient: http.DefaultClient,
+		now:        time.Now,
+	}
+	s.publicURL = strings.TrimRight(config.PublicURL, "/")
+	if s.publicURL == "" {
+		s.publicURL = s.bucketURL().String()
+	}
+	return s, nil
+}
+
+// bucketURL returns the base URL of the bucket on the API endpoint
+func (s *S3MediaStore) bucketURL() *url.URL {
+	u := *s.endpoint
+	if s.config.PathStyle {
+		u.Path = strings.TrimRight(u.Path, "/") + "/" + s.config.Bucket
+	} else {
+		u.Host = s.config.Bucket + "." + u.Host
+	}
+	return &u
+}
+
+// objectURL returns the API URL of the object stored under key
+func (s *S3MediaStore) objectURL(key string) (*url.URL, error) {
+	cleaned, err := CleanMediaKey(key)
+	if err != nil {
+		return nil, err
+	}
+	u := s.bucketURL()
+	u.Path = u.Path + "/" + cleaned
+	u.RawPath = s3EscapePath(u.Path)
+	return u, nil
+}
+
+// Put uploads the object. Content of unknown size is buffered because S3
+// requires a Content-Length on PUT.
+func (s *S3MediaStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	if size < 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("failed to read media: %w", err)
+		}
+		r, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), r)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, s3UnsignedBody)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("S3 upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("S3 upload failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// Get downloads the object. The caller must close the returned reader.
+func (s *S3MediaStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrMediaNotFound
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("S3 download failed with status %d: %s", resp.StatusCode, string(body))
+	}
+}
+
+// Delete removes the object (S3 answers 204 even if it did not exist)
+func (s *S3MediaStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("S3 delete failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// do sends a signed bodiless request for the object stored under key
+func (s *S3MediaStore) do(ctx context.Context, method, key string) (*http.Response, error) {
+	u, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	s.sign(req, s3EmptyBodyHash)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// URL returns the public URL of the object
+func (s *S3MediaStore) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+// SignedURL returns a presigned GET URL, valid for expiry (max 7 days)
+func (s *S3MediaStore) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if expiry <= 0 || expiry > s3MaxPresignTime {
+		return "", fmt.Errorf("presigned URL expiry must be between 1s and %s", s3MaxPresignTime)
+	}
+	u, err := s.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+
+	now := s.now().UTC()
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.config.AccessKeyID+"/"+s.credentialScope(now))
+	query.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	u.RawQuery = s3CanonicalQuery(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		u.RawQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedBody,
+	}, "\n")
+
+	u.RawQuery += "&X-Amz-Signature=" + s.signature(now, canonicalRequest)
+	return u.String(), nil
+}
+
+// KeyFromURL maps a public bucket URL back to its key. Legacy /uploads/ paths
+// are accepted too, for files migrated from local storage with the same layout.
+func (s *S3MediaStore) KeyFromURL(url string) (string, bool) {
+	if key, ok := keyWithPrefix(url, s.publicURL+"/"); ok {
+		return key, true
+	}
+	return keyWithPrefix(url, LocalMediaURLPrefix)
+}
+
+// sign adds the SigV4 Authorization header to req
+func (s *S3MediaStore) sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           now.Format(s3TimeFormat),
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.config.AccessKeyID, s.credentialScope(now), signedHeaders, s.signature(now, canonicalRequest)))
+}
+
+// credentialScope returns date/region/s3/aws4_request
+func (s *S3MediaStore) credentialScope(t time.Time) string {
+	return t.Format(s3DateFormat) + "/" + s.config.Region + "/" + s3Service + "/aws4_request"
+}
+
+// signature signs the canonical request with the derived SigV4 key
+func (s *S3MediaStore) signature(t time.Time, canonicalRequest string) string {
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.Format(s3TimeFormat),
+		s.credentialScope(t),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+s.config.SecretAccessKey), t.Format(s3DateFormat))
+	key = s3HMAC(key, s.config.Region)
+	key = s3HMAC(key, s3Service)
+	key = s3HMAC(key, "aws4_request")
+	return hex.EncodeToString(s3HMAC(key, stringToSign))
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EmptyBodyHash is the SHA-256 of an empty payload
+var s3EmptyBodyHash = hex.EncodeToString(sha256.New().Sum(nil))
+
+// s3Escape percent-encodes s as SigV4 requires (RFC 3986 unreserved characters kept)
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// s3EscapePath encodes each segment of an object path, keeping the slashes
+func s3EscapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3CanonicalQuery encodes query parameters sorted by name
+func s3CanonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, s3Escape(name)+"="+s3Escape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
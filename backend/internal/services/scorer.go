@@ -1,14 +1,18 @@
 package services
 
 import (
+	"sort"
+
 	"github.com/the-mile-game/backend/internal/models"
 )
 
 // Scorer calcula el puntaje del quiz usando normalización de texto
 type Scorer struct {
 	normalizer         *Normalizer
-	correctFavorites   map[string][]string // Respuestas YA NORMALIZADAS (múltiples válidas por pregunta)
-	correctPreferences map[string]string   // Respuestas YA NORMALIZADAS
+	correctFavorites   map[string][]string               // Respuestas YA NORMALIZADAS (múltiples válidas por pregunta)
+	correctPreferences map[string]string                 // Respuestas YA NORMALIZADAS
+	scoring            map[string]models.QuestionScoring // Puntos y crédito parcial por key (sin entrada = 1 punto)
+	order              []string                          // Keys en orden de presentación para el desglose
 }
 
 // NewScorer crea un nuevo calculador de puntajes (modo legacy - hardcoded)
@@ -56,24 +60,48 @@ func NewScorer() *Scorer {
 		normalizer:         normalizer,
 		correctFavorites:   normalizedFavorites,
 		correctPreferences: normalizedPreferences,
+		scoring:            map[string]models.QuestionScoring{},
+		order:              sortedKeys(normalizedFavorites, normalizedPreferences),
+	}
+}
+
+// sortedKeys ordena las keys: primero favoritos, luego preferencias (alfabético)
+func sortedKeys(favorites map[string][]string, preferences map[string]string) []string {
+	favKeys := make([]string, 0, len(favorites))
+	for key := range favorites {
+		favKeys = append(favKeys, key)
 	}
+	prefKeys := make([]string, 0, len(preferences))
+	for key := range preferences {
+		prefKeys = append(prefKeys, key)
+	}
+	sort.Strings(favKeys)
+	sort.Strings(prefKeys)
+	return append(favKeys, prefKeys...)
 }
 
 // NewScorerWithQuestions crea un scorer con preguntas de la base de datos.
-// Las preguntas deben tener sus correct_answers ya normalizados (el repo los deserializa).
+// Las correct_answers se normalizan igual que las respuestas de los jugadores.
 func NewScorerWithQuestions(questions []models.QuizQuestion) *Scorer {
 	normalizer := NewNormalizer()
 
 	correctFavorites := make(map[string][]string)
 	correctPreferences := make(map[string]string)
+	scoring := make(map[string]models.QuestionScoring)
+	order := make([]string, 0, len(questions))
 
 	for _, q := range questions {
 		if !q.IsScorable {
 			continue
 		}
 
-		// Las respuestas ya vienen normalizadas desde la DB
-		correctAnswers := q.CorrectAnswers
+		// Normalizar por si el host las cargó con mayúsculas/acentos (idempotente)
+		correctAnswers := make([]string, 0, len(q.CorrectAnswers))
+		for _, answer := range q.CorrectAnswers {
+			if normalized := normalizer.NormalizeForStorage(answer); normalized != "" {
+				correctAnswers = append(correctAnswers, normalized)
+			}
+		}
 		if len(correctAnswers) == 0 {
 			continue
 		}
@@ -84,46 +112,124 @@ func NewScorerWithQuestions(questions []models.QuizQuestion) *Scorer {
 		} else if q.Section == "preferences" {
 			// Preferencias tienen una sola respuesta correcta
 			correctPreferences[q.Key] = correctAnswers[0]
+		} else {
+			continue
 		}
+
+		// Las respuestas parciales las carga el host: se normalizan acá
+		rule := q.QuestionScoring
+		if rule.Points <= 0 {
+			rule.Points = models.DefaultQuestionPoints
+		}
+		if rule.PartialCredit != nil {
+			partial := make([]string, len(rule.PartialCredit.Answers))
+			for i, answer := range rule.PartialCredit.Answers {
+				partial[i] = normalizer.NormalizeForStorage(answer)
+			}
+			rule.PartialCredit = &models.PartialCreditRule{Answers: partial, Points: rule.PartialCredit.Points}
+		}
+		scoring[q.Key] = rule
+		order = append(order, q.Key)
 	}
 
 	return &Scorer{
 		normalizer:         normalizer,
 		correctFavorites:   correctFavorites,
 		correctPreferences: correctPreferences,
+		scoring:            scoring,
+		order:              order,
 	}
 }
 
 // Calculate calcula el puntaje basado en las respuestas del usuario.
 // Las respuestas del usuario deben venir ya normalizadas del handler.
 func (s *Scorer) Calculate(favorites, preferences map[string]string) int {
-	score := 0
-
-	// Puntuar favoritos (7 preguntas)
-	// Cada pregunta puede tener múltiples respuestas válidas — basta con que
-	// la respuesta del usuario coincida con alguna de ellas.
-	for key, correctAnswers := range s.correctFavorites {
-		if userAnswer, ok := favorites[key]; ok {
-			for _, correctAnswer := range correctAnswers {
-				if userAnswer == correctAnswer {
-					score++
-					break
-				}
-			}
+	return s.Score(favorites, preferences).Total
+}
+
+// Score puntúa cada pregunta y retorna el total junto al desglose.
+// Las respuestas del usuario deben venir ya normalizadas del handler.
+//
+// Favoritos: basta con coincidir con alguna de las respuestas válidas.
+// Preferencias: opciones fijas (A/B), una sola respuesta correcta.
+// La coincidencia puede ser exacta, por alias o aproximada (si la pregunta tiene
+// regla fuzzy). Si no coincide se aplica la regla de crédito parcial, si existe.
+func (s *Scorer) Score(favorites, preferences map[string]string) models.ScoreBreakdown {
+	return s.ScoreWithOverrides(favorites, preferences, nil)
+}
+
+// ScoreWithOverrides puntúa igual que Score pero respeta las respuestas que el host
+// aceptó manualmente para este jugador (key → respuesta aceptada). La aceptación
+// solo aplica si la respuesta guardada sigue siendo la misma; vale el puntaje completo.
+func (s *Scorer) ScoreWithOverrides(favorites, preferences, accepted map[string]string) models.ScoreBreakdown {
+	breakdown := models.ScoreBreakdown{Questions: make([]models.QuestionScore, 0, len(s.order))}
+
+	for _, key := range s.order {
+		var section, userAnswer string
+		var correctAnswers []string
+		if answers, ok := s.correctFavorites[key]; ok {
+			section = "favorites"
+			correctAnswers = answers
+			userAnswer = favorites[key]
+		} else {
+			section = "preferences"
+			correctAnswers = []string{s.correctPreferences[key]}
+			userAnswer = preferences[key]
 		}
-	}
 
-	// Puntuar preferencias (6 preguntas)
-	// Las preferencias son opciones fijas (A/B), siempre una sola respuesta correcta.
-	for key, correctAnswer := range s.correctPreferences {
-		if userAnswer, ok := preferences[key]; ok {
-			if userAnswer == correctAnswer {
-				score++
-			}
+		rule := s.scoringFor(key)
+		result := models.QuestionScore{
+			Key:       key,
+			Section:   section,
+			Answer:    userAnswer,
+			MaxPoints: rule.Points,
+		}
+
+		if userAnswer == "" {
+			result.Result = models.ScoreResultUnanswered
+		} else if match, matched := matchAnswer(correctAnswers, userAnswer, rule.Fuzzy); match != "" {
+			result.Result = models.ScoreResultCorrect
+			result.Match, result.MatchedAnswer = match, matched
+			result.Points = rule.Points
+		} else if rule.PartialCredit == nil {
+			result.Result = models.ScoreResultIncorrect
+		} else if match, matched := matchAnswer(rule.PartialCredit.Answers, userAnswer, rule.Fuzzy); match != "" {
+			result.Result = models.ScoreResultPartial
+			result.Match, result.MatchedAnswer = match, matched
+			result.Points = rule.PartialCredit.Points
+		} else {
+			result.Result = models.ScoreResultIncorrect
+		}
+
+		if result.Result != models.ScoreResultCorrect && userAnswer != "" && accepted[key] == userAnswer {
+			result.Result = models.ScoreResultCorrect
+			result.Match, result.MatchedAnswer = models.MatchOverride, userAnswer
+			result.Points = rule.Points
 		}
+
+		breakdown.Total += result.Points
+		breakdown.MaxTotal += result.MaxPoints
+		breakdown.Questions = append(breakdown.Questions, result)
+	}
+
+	return breakdown
+}
+
+// scoringFor retorna la configuración de puntos de una pregunta (default: 1 punto)
+func (s *Scorer) scoringFor(key string) models.QuestionScoring {
+	if rule, ok := s.scoring[key]; ok {
+		return rule
 	}
+	return models.QuestionScoring{Points: models.DefaultQuestionPoints}
+}
 
-	return score
+// isScored indica si el scorer puntúa la pregunta (tiene respuestas válidas)
+func (s *Scorer) isScored(key string) bool {
+	if _, ok := s.correctFavorites[key]; ok {
+		return true
+	}
+	_, ok := s.correctPreferences[key]
+	return ok
 }
 
 // NormalizeFavorites normaliza un mapa de respuestas de favoritos
@@ -533,3 +533,104 @@ func TestScorerWithQuestions_EmptyQuestions(t *testing.T) {
 		t.Errorf("Expected 0 score with no questions, got %d", score)
 	}
 }
+
+func TestScorerWithQuestions_WeightedAndPartialCredit(t *testing.T) {
+	questions := []models.QuizQuestion{
+		{
+			Section:         "favorites",
+			Key:             "flower",
+			CorrectAnswers:  []string{"girasol"},
+			IsScorable:      true,
+			QuestionScoring: models.QuestionScoring{Points: 3, PartialCredit: &models.PartialCreditRule{Answers: []string{"La Margarita"}, Points: 1}},
+		},
+		{
+			Section:         "favorites",
+			Key:             "singer",
+			CorrectAnswers:  []string{"ricardo arjona"},
+			IsScorable:      true,
+			QuestionScoring: models.QuestionScoring{Points: 2},
+		},
+		{
+			Section:        "preferences",
+			Key:            "coffee",
+			CorrectAnswers: []string{"te"},
+			IsScorable:     true, // Points sin definir: vale 1
+		},
+	}
+
+	s := NewScorerWithQuestions(questions)
+
+	tests := []struct {
+		name        string
+		favorites   map[string]string
+		preferences map[string]string
+		expected    int
+		results     []string
+	}{
+		{
+			name:        "all correct uses weights",
+			favorites:   map[string]string{"flower": "girasol", "singer": "ricardo arjona"},
+			preferences: map[string]string{"coffee": "te"},
+			expected:    6,
+			results:     []string{models.ScoreResultCorrect, models.ScoreResultCorrect, models.ScoreResultCorrect},
+		},
+		{
+			name:        "partial credit for near miss",
+			favorites:   map[string]string{"flower": "margarita", "singer": "shakira"},
+			preferences: map[string]string{},
+			expected:    1,
+			results:     []string{models.ScoreResultPartial, models.ScoreResultIncorrect, models.ScoreResultUnanswered},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breakdown := s.Score(tt.favorites, tt.preferences)
+			if breakdown.Total != tt.expected {
+				t.Errorf("Score().Total = %d, want %d", breakdown.Total, tt.expected)
+			}
+			if breakdown.MaxTotal != 6 {
+				t.Errorf("Score().MaxTotal = %d, want 6", breakdown.MaxTotal)
+			}
+			if len(breakdown.Questions) != len(tt.results) {
+				t.Fatalf("Score() returned %d questions, want %d", len(breakdown.Questions), len(tt.results))
+			}
+			for i, want := range tt.results {
+				if got := breakdown.Questions[i].Result; got != want {
+					t.Errorf("question %s result = %s, want %s", breakdown.Questions[i].Key, got, want)
+				}
+			}
+			if got := s.Calculate(tt.favorites, tt.preferences); got != tt.expected {
+				t.Errorf("Calculate() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScorer_ScoreWithOverrides(t *testing.T) {
+	questions := []models.QuizQuestion{
+		{Key: "flower", Section: "favorites", CorrectAnswers: []string{"girasol"}, IsScorable: true, QuestionScoring: models.QuestionScoring{Points: 3}},
+		{Key: "singer", Section: "favorites", CorrectAnswers: []string{"ricardo arjona"}, IsScorable: true},
+	}
+	s := NewScorerWithQuestions(questions)
+	favorites := map[string]string{"flower": "tulipan", "singer": "arjona"}
+
+	// Aceptada para "flower"; la de "singer" ya no coincide con lo guardado
+	accepted := map[string]string{"flower": "tulipan", "singer": "shakira"}
+
+	breakdown := s.ScoreWithOverrides(favorites, nil, accepted)
+	if breakdown.Total != 3 {
+		t.Errorf("ScoreWithOverrides().Total = %d, want 3", breakdown.Total)
+	}
+	flower := breakdown.Questions[0]
+	if flower.Result != models.ScoreResultCorrect || flower.Match != models.MatchOverride {
+		t.Errorf("flower = %s/%s, want correct/override", flower.Result, flower.Match)
+	}
+	if breakdown.Questions[1].Result != models.ScoreResultIncorrect {
+		t.Errorf("singer result = %s, want incorrect", breakdown.Questions[1].Result)
+	}
+
+	if got := s.Score(favorites, nil).Total; got != 0 {
+		t.Errorf("Score() without overrides = %d, want 0", got)
+	}
+}
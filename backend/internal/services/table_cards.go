@@ -0,0 +1,229 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// MaxTableCards límite de tarjetas por PDF
+const MaxTableCards = 200
+
+// Tarjetas A6 (105 x 148.5 mm), cuatro por hoja A4 con líneas de corte
+const (
+	tableCardWidth   = 105.0
+	tableCardHeight  = 148.5
+	tableCardsPerRow = 2
+	tableCardsPerCol = 2
+	tableCardQRSize  = 60.0
+	tableCardLogoMax = 600 // px: el logo se achica antes de embeberlo
+)
+
+// TableCard una tarjeta imprimible cuyo QR lleva a URL
+type TableCard struct {
+	Label string // Opcional, ej. "Mesa 3"
+	URL   string
+}
+
+// TableCardsDocument contenido y estilo comunes a todas las tarjetas del PDF.
+// Los colores son hex (#rrggbb o #rgb) del tema del evento; vacíos o inválidos
+// usan los valores por defecto.
+type TableCardsDocument struct {
+	Title           string // Nombre del evento
+	Message         string // Ej. "Escaneá el código para jugar"
+	Cards           []TableCard
+	PrimaryColor    string
+	AccentColor     string
+	BackgroundColor string
+	TextColor       string
+	Logo            []byte // JPEG, PNG o WebP; si no se puede leer se omite
+}
+
+type rgb struct{ r, g, b int }
+
+// RenderTableCardsPDF arma el PDF de tarjetas para mesas e invitaciones: cada una con
+// el logo, el nombre del evento, su etiqueta, el QR y la URL impresa debajo
+func RenderTableCardsPDF(doc TableCardsDocument, w io.Writer) error {
+	if len(doc.Cards) == 0 || len(doc.Cards) > MaxTableCards {
+		return ErrTableCardsCount
+	}
+
+	primary := parseHexColor(doc.PrimaryColor, rgb{183, 0, 73})
+	accent := parseHexColor(doc.AccentColor, primary)
+	background := parseHexColor(doc.BackgroundColor, rgb{255, 255, 255})
+	text := parseHexColor(doc.TextColor, rgb{33, 33, 33})
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetMargins(0, 0, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // UTF-8 → cp1252 de las fuentes core
+
+	logo := registerTableCardLogo(pdf, doc.Logo)
+
+	perPage := tableCardsPerRow * tableCardsPerCol
+	for i, card := range doc.Cards {
+		if i%perPage == 0 {
+			pdf.AddPage()
+			drawCutLines(pdf)
+		}
+		slot := i % perPage
+		x := float64(slot%tableCardsPerRow) * tableCardWidth
+		y := float64(slot/tableCardsPerRow) * tableCardHeight
+
+		modules, err := QRModules(card.URL)
+		if err != nil {
+			return err
+		}
+
+		// Fondo y bandas del tema
+		pdf.SetFillColor(background.r, background.g, background.b)
+		pdf.Rect(x, y, tableCardWidth, tableCardHeight, "F")
+		pdf.SetFillColor(primary.r, primary.g, primary.b)
+		pdf.Rect(x, y, tableCardWidth, 6, "F")
+		pdf.Rect(x, y+tableCardHeight-6, tableCardWidth, 6, "F")
+
+		if logo != nil {
+			// Entra en una caja de 30 x 18 mm sin deformarse
+			w, h := 30.0, 30.0*logo.Height()/logo.Width()
+			if h > 18 {
+				w, h = 18*logo.Width()/logo.Height(), 18
+			}
+			pdf.ImageOptions("logo", x+(tableCardWidth-w)/2, y+10+(18-h)/2, w, h, false,
+				gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+		}
+
+		pdf.SetTextColor(text.r, text.g, text.b)
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.SetXY(x+7.5, y+31)
+		pdf.MultiCell(tableCardWidth-15, 7, tr(fitLines(pdf, tr, doc.Title, tableCardWidth-15, 2)), "", "C", false)
+
+		if card.Label != "" {
+			pdf.SetTextColor(accent.r, accent.g, accent.b)
+			pdf.SetFont("Helvetica", "B", 14)
+			pdf.SetXY(x+7.5, y+46)
+			pdf.CellFormat(tableCardWidth-15, 7, tr(card.Label), "", 0, "C", false, 0, "")
+		}
+
+		// QR vectorial sobre fondo blanco, así se lee aunque el tema sea oscuro
+		qrX := x + (tableCardWidth-tableCardQRSize)/2
+		qrY := y + 56
+		pdf.SetFillColor(255, 255, 255)
+		pdf.Rect(qrX-2, qrY-2, tableCardQRSize+4, tableCardQRSize+4, "F")
+		drawQR(pdf, modules, qrX, qrY, tableCardQRSize)
+
+		pdf.SetTextColor(text.r, text.g, text.b)
+		if doc.Message != "" {
+			pdf.SetFont("Helvetica", "", 11)
+			pdf.SetXY(x+7.5, y+121)
+			pdf.CellFormat(tableCardWidth-15, 6, tr(doc.Message), "", 0, "C", false, 0, "")
+		}
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.SetXY(x+5, y+131)
+		pdf.CellFormat(tableCardWidth-10, 4, tr(card.URL), "", 0, "C", false, 0, "")
+	}
+
+	return pdf.Output(w)
+}
+
+// registerTableCardLogo decodifica, achica y registra el logo como PNG (gofpdf no lee
+// WebP y un PNG inválido invalidaría todo el documento). Devuelve nil si no hay logo.
+func registerTableCardLogo(pdf *gofpdf.Fpdf, data []byte) *gofpdf.ImageInfoType {
+	if len(data) == 0 {
+		return nil
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxImagePixels {
+		return nil
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaleToFit(src, tableCardLogoMax)); err != nil {
+		return nil
+	}
+	info := pdf.RegisterImageOptionsReader("logo", gofpdf.ImageOptions{ImageType: "PNG"}, &buf)
+	if !pdf.Ok() || info == nil || info.Width() <= 0 || info.Height() <= 0 {
+		return nil
+	}
+	return info
+}
+
+// drawCutLines marca con línea punteada dónde cortar las tarjetas de la hoja
+func drawCutLines(pdf *gofpdf.Fpdf) {
+	pdf.SetDrawColor(190, 190, 190)
+	pdf.SetLineWidth(0.2)
+	pdf.SetDashPattern([]float64{2, 2}, 0)
+	for col := 1; col < tableCardsPerRow; col++ {
+		x := float64(col) * tableCardWidth
+		pdf.Line(x, 0, x, tableCardHeight*tableCardsPerCol)
+	}
+	for row := 1; row < tableCardsPerCol; row++ {
+		y := float64(row) * tableCardHeight
+		pdf.Line(0, y, tableCardWidth*tableCardsPerRow, y)
+	}
+	pdf.SetDashPattern([]float64{}, 0)
+}
+
+// drawQR dibuja los módulos oscuros del QR dentro de un cuadrado de lado size
+func drawQR(pdf *gofpdf.Fpdf, modules [][]bool, x, y, size float64) {
+	module := size / float64(len(modules))
+	pdf.SetFillColor(0, 0, 0)
+	for row, cells := range modules {
+		for col := 0; col < len(cells); {
+			if !cells[col] {
+				col++
+				continue
+			}
+			start := col
+			for col < len(cells) && cells[col] {
+				col++
+			}
+			pdf.Rect(x+float64(start)*module, y+float64(row)*module, float64(col-start)*module, module, "F")
+		}
+	}
+}
+
+// fitLines recorta s para que entre en maxLines renglones de ancho w con la fuente actual.
+// tr traduce a la codificación de la fuente; el resultado sigue en UTF-8.
+func fitLines(pdf *gofpdf.Fpdf, tr func(string) string, s string, w float64, maxLines int) string {
+	if len(pdf.SplitLines([]byte(tr(s)), w)) <= maxLines {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "..."
+		if len(pdf.SplitLines([]byte(tr(candidate)), w)) <= maxLines {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// parseHexColor lee #rrggbb o #rgb; si no puede devuelve fallback
+func parseHexColor(s string, fallback rgb) rgb {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return fallback
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return fallback
+	}
+	return rgb{int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)}
+}
+
+// ErrTableCardsCount cantidad de tarjetas fuera de rango
+var ErrTableCardsCount = errors.New("table cards count out of range")
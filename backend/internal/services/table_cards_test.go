@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func testLogoPNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for x := 0; x < 200; x++ {
+		for y := 0; y < 100; y++ {
+			img.Set(x, y, color.RGBA{183, 0, 73, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRenderTableCardsPDF(t *testing.T) {
+	cards := make([]TableCard, 5)
+	for i := range cards {
+		cards[i] = TableCard{Label: "Mesa", URL: "https://example.com/e/cumple-de-mile"}
+	}
+
+	tests := []struct {
+		name string
+		doc  TableCardsDocument
+	}{
+		{name: "theme and logo", doc: TableCardsDocument{
+			Title:   "Cumpleaños de Mile — una fiesta con un nombre muy largo que no entra en dos renglones de la tarjeta",
+			Message: "Escaneá el código para sumarte", Cards: cards,
+			PrimaryColor: "#b70049", AccentColor: "#f0a", BackgroundColor: "#fff8f9", TextColor: "#2d1b1f",
+			Logo: testLogoPNG(t),
+		}},
+		{name: "defaults and unreadable logo", doc: TableCardsDocument{
+			Title: "Party", Cards: cards, PrimaryColor: "not-a-color", Logo: []byte("not an image"),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderTableCardsPDF(tt.doc, &buf); err != nil {
+				t.Fatalf("RenderTableCardsPDF() error = %v", err)
+			}
+			pdf := buf.String()
+			if !strings.HasPrefix(pdf, "%PDF-") {
+				t.Fatalf("output is not a PDF: %.20q", pdf)
+			}
+			// 5 tarjetas, 4 por hoja
+			if !strings.Contains(pdf, "/Count 2") {
+				t.Error("expected 2 pages")
+			}
+		})
+	}
+}
+
+func TestRenderTableCardsPDF_CardCount(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderTableCardsPDF(TableCardsDocument{Title: "Party"}, &buf); err != ErrTableCardsCount {
+		t.Errorf("no cards: error = %v, want ErrTableCardsCount", err)
+	}
+	tooMany := TableCardsDocument{Title: "Party", Cards: make([]TableCard, MaxTableCards+1)}
+	if err := RenderTableCardsPDF(tooMany, &buf); err != ErrTableCardsCount {
+		t.Errorf("too many cards: error = %v, want ErrTableCardsCount", err)
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	fallback := rgb{1, 2, 3}
+	tests := map[string]rgb{
+		"#b70049": {183, 0, 73},
+		"B70049":  {183, 0, 73},
+		"#f0a":    {255, 0, 170},
+		"":        fallback,
+		"#12345":  fallback,
+		"#zzzzzz": fallback,
+	}
+	for in, want := range tests {
+		if got := parseHexColor(in, fallback); got != want {
+			t.Errorf("parseHexColor(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
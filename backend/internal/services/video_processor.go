@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const (
+	// VideoMaxSide lado mayor (px) de la rendition MP4. Los videos más chicos no se agrandan.
+	VideoMaxSide = 1280
+	// VideoMaxBitrate bitrate máximo de video de la rendition (con 30 s de video ≈ 9 MB)
+	VideoMaxBitrate = "2500k"
+	// MaxTranscodedVideoBytes tamaño máximo aceptado para la rendition MP4
+	MaxTranscodedVideoBytes = 25 * 1024 * 1024
+	// VideoThumbnailMaxSide lado mayor (px) del thumbnail JPEG
+	VideoThumbnailMaxSide = 400
+)
+
+// FFmpegVideoProcessor procesa videos con los binarios ffmpeg y ffprobe del sistema
+type FFmpegVideoProcessor struct{}
+
+// NewFFmpegVideoProcessor crea un procesador de video basado en ffmpeg
+func NewFFmpegVideoProcessor() *FFmpegVideoProcessor {
+	return &FFmpegVideoProcessor{}
+}
+
+// Transcode convierte src a MP4 H.264/AAC apto para navegadores (faststart,
+// yuv420p), limitado a VideoMaxSide px y VideoMaxBitrate. Devuelve
+// ErrVideoTooLarge si el resultado supera MaxTranscodedVideoBytes.
+func (p *FFmpegVideoProcessor) Transcode(ctx context.Context, srcPath, dstPath string) error {
+	scale := fmt.Sprintf("scale='min(%d,iw)':'min(%d,ih)':force_original_aspect_ratio=decrease,scale=trunc(iw/2)*2:trunc(ih/2)*2", VideoMaxSide, VideoMaxSide)
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-i", srcPath,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-vf", scale,
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "26",
+		"-maxrate", VideoMaxBitrate, "-bufsize", "5000k", "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-b:a", "128k",
+		"-movflags", "+faststart",
+		dstPath)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg error: %v, output: %s", err, string(output))
+	}
+
+	info, err := os.Stat(dstPath)
+	if err != nil {
+		return fmt.Errorf("transcoded video not created: %v", err)
+	}
+	if info.Size() > MaxTranscodedVideoBytes {
+		return ErrVideoTooLarge
+	}
+	return nil
+}
+
+// Thumbnail genera un thumbnail JPEG del video (frame del segundo 1)
+func (p *FFmpegVideoProcessor) Thumbnail(ctx context.Context, videoPath, thumbnailPath string) error {
+	// Extraer frame en segundo 1 y escalar manteniendo aspect ratio
+	scale := fmt.Sprintf("scale='min(%d,iw)':'min(%d,ih)':force_original_aspect_ratio=decrease", VideoThumbnailMaxSide, VideoThumbnailMaxSide)
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-i", videoPath, "-ss", "00:00:01", "-vframes", "1", "-vf", scale, "-q:v", "2", thumbnailPath)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg error: %v, output: %s", err, string(output))
+	}
+
+	// Verificar que el thumbnail se creó
+	if _, err := os.Stat(thumbnailPath); err != nil {
+		return fmt.Errorf("thumbnail not created: %v", err)
+	}
+
+	return nil
+}
+
+// Duration devuelve la duración del video en milisegundos
+func (p *FFmpegVideoProcessor) Duration(ctx context.Context, videoPath string) (int, error) {
+	cmd := exec.CommandContext(ctx, "ffprobe", "-v", "error", "-show_entries", "format=duration", "-of", "default=noprint_wrappers=1:nokey=1", videoPath)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe error: %v, output: %s", err, string(output))
+	}
+
+	duration, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse duration: %v", err)
+	}
+
+	return int(duration * 1000), nil
+}
+
+// ErrVideoTooLarge la rendition MP4 supera MaxTranscodedVideoBytes
+var ErrVideoTooLarge = errors.New("transcoded video too large")
@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/the-mile-game/backend/internal/models"
 )
@@ -48,16 +50,47 @@ type Hub struct {
 	// Canal para broadcastear a un evento específico
 	broadcastToRoom chan *RoomMessage
 
+	// Canal para revocar el acceso a un room: así solo Run cierra los canales send
+	revokeRoom chan *revokeRequest
+
 	// Validador de eventos (opcional) - si está presente, se validan los event slugs
 	eventValidator EventValidator
 
+	// Procesador de mensajes entrantes (opcional) - sin él se descartan
+	messageHandler ClientMessageHandler
+
 	// Mutex para acceso seguro concurrente
 	mu sync.RWMutex
 }
 
-// EventValidator interface para validar que un evento existe y está activo
+// EventValidator interface para validar que un evento existe y está activo, y que el
+// token de acceso (vacío si no se envió) alcanza si el evento está protegido con código.
+// Devuelve el slug actual del evento (el pedido puede ser un slug anterior).
 type EventValidator interface {
-	ValidateEvent(slug string) error
+	ValidateEvent(slug, accessToken string) (string, error)
+}
+
+// ErrEventAccessDenied lo devuelve el EventValidator cuando el evento exige código de acceso
+// y el token falta o no es válido
+var ErrEventAccessDenied = errors.New("event access denied")
+
+// ClientMessageHandler procesa los mensajes que envían los clientes (ej: quiz en vivo).
+// HandleClientMessage se llama desde la goroutine de lectura de cada cliente.
+type ClientMessageHandler interface {
+	HandleClientMessage(client *Client, msg IncomingMessage)
+	HandleClientDisconnect(client *Client)
+}
+
+// IncomingMessage mensaje recibido de un cliente: {"type": "...", "data": {...}}
+type IncomingMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// ErrorMessage respuesta a un mensaje entrante inválido o rechazado
+type ErrorMessage struct {
+	Type  string `json:"type"`
+	Error string `json:"error"`
 }
 
 // RoomMessage mensaje dirigido a un room específico
@@ -66,6 +99,13 @@ type RoomMessage struct {
 	Message   []byte
 }
 
+// revokeRequest pedido de RevokeRoomAccess; Run responde cuántos clientes desconectó
+type revokeRequest struct {
+	eventSlug string
+	message   []byte
+	done      chan int
+}
+
 // Client representa una conexión WebSocket
 type Client struct {
 	hub       *Hub
@@ -99,6 +139,16 @@ type SecretRevealMessage struct {
 	Postcards []models.Postcard `json:"postcards"`
 }
 
+// SecretRevealNextMessage mensaje del reveal uno por uno: una postal secreta para animar
+// en la pantalla grande, con el progreso de la cola
+type SecretRevealNextMessage struct {
+	Type      string          `json:"type"`
+	EventSlug string          `json:"event_slug"`
+	Postcard  models.Postcard `json:"postcard"`
+	Revealed  int             `json:"revealed"`
+	Remaining int             `json:"remaining"`
+}
+
 // SecretResetMessage mensaje para resetear la Secret Box (ocultar las postcards reveladas)
 type SecretResetMessage struct {
 	Type      string `json:"type"`
@@ -106,6 +156,38 @@ type SecretResetMessage struct {
 	Count     int64  `json:"count"`
 }
 
+// PostcardRemovedMessage mensaje para quitar una postal de las pantallas
+// (rechazada o eliminada por el host)
+type PostcardRemovedMessage struct {
+	Type       string    `json:"type"`
+	EventSlug  string    `json:"event_slug"`
+	PostcardID uuid.UUID `json:"postcard_id"`
+}
+
+// EventStatusMessage avisa a las pantallas de un evento que cambió su estado
+// (ej. live → ended: el corkboard pasa a solo lectura)
+type EventStatusMessage struct {
+	Type           string             `json:"type"`
+	EventSlug      string             `json:"event_slug"`
+	Status         models.EventStatus `json:"status"`
+	PreviousStatus models.EventStatus `json:"previous_status"`
+}
+
+// EventRenamedMessage avisa a las pantallas de un evento que cambió su slug:
+// tienen que reconectarse con el nuevo para seguir recibiendo mensajes
+type EventRenamedMessage struct {
+	Type      string `json:"type"`
+	EventSlug string `json:"event_slug"` // Slug anterior (el del room)
+	NewSlug   string `json:"new_slug"`
+}
+
+// AccessRevokedMessage avisa que el owner rotó el código o revocó las sesiones del evento;
+// el servidor cierra la conexión a continuación
+type AccessRevokedMessage struct {
+	Type      string `json:"type"`
+	EventSlug string `json:"event_slug"`
+}
+
 // getAllowedOrigins returns the list of allowed origins from the CORS_ALLOWED_ORIGINS env var.
 // If empty, defaults to localhost patterns for development.
 func getAllowedOrigins() []string {
@@ -148,6 +230,7 @@ func NewHub() *Hub {
 		unregister:      make(chan *Client),
 		broadcast:       make(chan []byte),
 		broadcastToRoom: make(chan *RoomMessage, 256), // Buffered para no bloquear
+		revokeRoom:      make(chan *revokeRequest),
 		clients:         make(map[*Client]bool),
 		rooms:           make(map[string]map[*Client]bool),
 	}
@@ -160,6 +243,12 @@ func NewHubWithValidator(validator EventValidator) *Hub {
 	return hub
 }
 
+// SetMessageHandler configura el procesador de mensajes entrantes.
+// Debe llamarse antes de aceptar conexiones.
+func (h *Hub) SetMessageHandler(handler ClientMessageHandler) {
+	h.messageHandler = handler
+}
+
 // Run inicia el loop del hub para manejar registros y broadcasts
 func (h *Hub) Run() {
 	for {
@@ -176,7 +265,7 @@ func (h *Hub) Run() {
 				log.Printf("WebSocket: Cliente conectado al room '%s'. Clientes en room: %d", client.EventSlug, len(h.rooms[client.EventSlug]))
 			}
 			h.mu.Unlock()
-			log.Printf("WebSocket: Cliente conectado. Total: %d", len(h.clients))
+			log.Printf("WebSocket: Cliente conectado. Total: %d", h.GetClientCount())
 
 		case client := <-h.unregister:
 			h.mu.Lock()
@@ -190,7 +279,7 @@ func (h *Hub) Run() {
 				}
 			}
 			h.mu.Unlock()
-			log.Printf("WebSocket: Cliente desconectado. Total: %d", len(h.clients))
+			log.Printf("WebSocket: Cliente desconectado. Total: %d", h.GetClientCount())
 
 		case roomMsg := <-h.broadcastToRoom:
 			// Broadcast a un room específico (evento)
@@ -216,13 +305,18 @@ func (h *Hub) Run() {
 			if len(deadClients) > 0 {
 				h.mu.Lock()
 				for _, client := range deadClients {
-					delete(h.clients, client)
-					delete(h.rooms[roomMsg.EventSlug], client)
-					close(client.send)
+					if _, ok := h.clients[client]; ok {
+						delete(h.clients, client)
+						delete(h.rooms[roomMsg.EventSlug], client)
+						close(client.send)
+					}
 				}
 				h.mu.Unlock()
 			}
 
+		case req := <-h.revokeRoom:
+			req.done <- h.closeRoom(req.eventSlug, req.message)
+
 		case message := <-h.broadcast:
 			h.mu.RLock()
 			// Coleccionar los clientes a borrar para no modificar el mapa durante el RLock
@@ -251,7 +345,7 @@ func (h *Hub) Run() {
 					}
 				}
 				h.mu.Unlock()
-				log.Printf("WebSocket: Borrados %d clientes lentos. Total: %d", len(deadClients), len(h.clients))
+				log.Printf("WebSocket: Borrados %d clientes lentos. Total: %d", len(deadClients), h.GetClientCount())
 			}
 		}
 	}
@@ -260,6 +354,7 @@ func (h *Hub) Run() {
 // ServeHTTP maneja las conexiones WebSocket
 // El query param "event" es opcional; sin él el cliente recibe broadcasts globales
 // Si hay un eventValidator configurado, se validará que el evento exista
+// Los eventos protegidos con código exigen el token de acceso en el query param "event_access"
 func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Extraer event slug del query param
 	eventSlug := r.URL.Query().Get("event")
@@ -267,12 +362,19 @@ func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		// Sin event, el cliente no recibe mensajes específicos
 		log.Printf("WebSocket: Conexión sin event slug - recibirá broadcasts globales nomás")
 	} else if h.eventValidator != nil {
-		// Validar que el evento existe y está activo
-		if err := h.eventValidator.ValidateEvent(eventSlug); err != nil {
+		// Validar que el evento existe y está activo; el cliente entra al room del slug actual
+		slug, err := h.eventValidator.ValidateEvent(eventSlug, r.URL.Query().Get("event_access"))
+		if errors.Is(err, ErrEventAccessDenied) {
+			log.Printf("WebSocket: Acceso al evento '%s' denegado", eventSlug)
+			http.Error(w, "Event access code required", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
 			log.Printf("WebSocket: Evento '%s' no válido: %v", eventSlug, err)
 			http.Error(w, "Event not found or inactive", http.StatusNotFound)
 			return
 		}
+		eventSlug = slug
 		log.Printf("WebSocket: Conexión al evento '%s' aceptada", eventSlug)
 	}
 
@@ -309,7 +411,7 @@ func (h *Hub) BroadcastRanking(ranking []models.RankingEntry) {
 	}
 
 	h.broadcast <- data
-	log.Printf("WebSocket: Ranking broadcasteado a %d clientes", len(h.clients))
+	log.Printf("WebSocket: Ranking broadcasteado a %d clientes", h.GetClientCount())
 }
 
 // BroadcastPostcard envía una nueva postal a todos los clientes conectados
@@ -326,7 +428,7 @@ func (h *Hub) BroadcastPostcard(postcard models.Postcard) {
 	}
 
 	h.broadcast <- data
-	log.Printf("WebSocket: Postal broadcasteada a %d clientes", len(h.clients))
+	log.Printf("WebSocket: Postal broadcasteada a %d clientes", h.GetClientCount())
 }
 
 // BroadcastSecretReveal envía el evento de reveal de la Secret Box a todos los clientes.
@@ -344,7 +446,7 @@ func (h *Hub) BroadcastSecretReveal(postcards []models.Postcard) {
 	}
 
 	h.broadcast <- data
-	log.Printf("WebSocket: Secret Box revelada — %d postales broadcasteadas a %d clientes", len(postcards), len(h.clients))
+	log.Printf("WebSocket: Secret Box revelada — %d postales broadcasteadas a %d clientes", len(postcards), h.GetClientCount())
 }
 
 // GetClientCount devuelve el número de clientes conectados
@@ -444,6 +546,38 @@ func (h *Hub) BroadcastSecretRevealToRoom(eventSlug string, postcards []models.P
 	log.Printf("WebSocket: Secret Box revelada al room '%s' — %d postales broadcasteadas (%d clientes)", eventSlug, len(postcards), roomCount)
 }
 
+// BroadcastSecretRevealNextToRoom envía una postal del reveal uno por uno a los clientes de un evento
+func (h *Hub) BroadcastSecretRevealNextToRoom(eventSlug string, postcard models.Postcard, revealed, remaining int) {
+	if eventSlug == "" {
+		log.Printf("WebSocket: Secret Box reveal next ignorado — no hay eventSlug")
+		return
+	}
+
+	msg := SecretRevealNextMessage{
+		Type:      "secret_box_reveal_next",
+		EventSlug: eventSlug,
+		Postcard:  postcard,
+		Revealed:  revealed,
+		Remaining: remaining,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Error marshaling secret reveal next: %v", err)
+		return
+	}
+
+	h.broadcastToRoom <- &RoomMessage{
+		EventSlug: eventSlug,
+		Message:   data,
+	}
+
+	h.mu.RLock()
+	roomCount := len(h.rooms[eventSlug])
+	h.mu.RUnlock()
+	log.Printf("WebSocket: Postal secreta %s revelada al room '%s' — quedan %d (%d clientes)", postcard.ID, eventSlug, remaining, roomCount)
+}
+
 // BroadcastSecretResetToRoom envía el evento de reset de la Secret Box solo a clientes de un evento específico
 func (h *Hub) BroadcastSecretResetToRoom(eventSlug string, count int64) {
 	if eventSlug == "" {
@@ -474,12 +608,187 @@ func (h *Hub) BroadcastSecretResetToRoom(eventSlug string, count int64) {
 	log.Printf("WebSocket: Secret Box reseteada al room '%s' — %d postcards ocultadas (%d clientes)", eventSlug, count, roomCount)
 }
 
+// BroadcastPostcardRemovedToRoom avisa a los clientes de un evento que dejen de mostrar una postal
+func (h *Hub) BroadcastPostcardRemovedToRoom(eventSlug string, postcardID uuid.UUID) {
+	if eventSlug == "" {
+		log.Printf("WebSocket: Postcard removal ignorado — no hay eventSlug")
+		return
+	}
+
+	msg := PostcardRemovedMessage{
+		Type:       "postcard_removed",
+		EventSlug:  eventSlug,
+		PostcardID: postcardID,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Error marshaling postcard removal: %v", err)
+		return
+	}
+
+	h.broadcastToRoom <- &RoomMessage{
+		EventSlug: eventSlug,
+		Message:   data,
+	}
+
+	h.mu.RLock()
+	roomCount := len(h.rooms[eventSlug])
+	h.mu.RUnlock()
+	log.Printf("WebSocket: Postal %s quitada del room '%s' (%d clientes)", postcardID, eventSlug, roomCount)
+}
+
+// BroadcastEventStatusToRoom avisa a los clientes de un evento que cambió su estado
+func (h *Hub) BroadcastEventStatusToRoom(eventSlug string, from, to models.EventStatus) {
+	if eventSlug == "" {
+		log.Printf("WebSocket: Event status ignorado — no hay eventSlug")
+		return
+	}
+
+	msg := EventStatusMessage{
+		Type:           "event_status",
+		EventSlug:      eventSlug,
+		Status:         to,
+		PreviousStatus: from,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Error marshaling event status: %v", err)
+		return
+	}
+
+	h.broadcastToRoom <- &RoomMessage{
+		EventSlug: eventSlug,
+		Message:   data,
+	}
+
+	h.mu.RLock()
+	roomCount := len(h.rooms[eventSlug])
+	h.mu.RUnlock()
+	log.Printf("WebSocket: Evento '%s' pasó de %s a %s (%d clientes)", eventSlug, from, to, roomCount)
+}
+
+// BroadcastEventRenamedToRoom avisa a los clientes conectados con el slug anterior
+// que el evento ahora usa newSlug
+func (h *Hub) BroadcastEventRenamedToRoom(previousSlug, newSlug string) {
+	if previousSlug == "" {
+		log.Printf("WebSocket: Event renamed ignorado — no hay eventSlug")
+		return
+	}
+
+	msg := EventRenamedMessage{
+		Type:      "event_renamed",
+		EventSlug: previousSlug,
+		NewSlug:   newSlug,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Error marshaling event renamed: %v", err)
+		return
+	}
+
+	h.broadcastToRoom <- &RoomMessage{
+		EventSlug: previousSlug,
+		Message:   data,
+	}
+
+	h.mu.RLock()
+	roomCount := len(h.rooms[previousSlug])
+	h.mu.RUnlock()
+	log.Printf("WebSocket: Evento '%s' ahora es '%s' (%d clientes)", previousSlug, newSlug, roomCount)
+}
+
+// RevokeRoomAccess avisa a los clientes del evento que su acceso fue revocado y los
+// desconecta: para volver a entrar necesitan un token de acceso nuevo.
+// Devuelve cuántos clientes se desconectaron.
+func (h *Hub) RevokeRoomAccess(eventSlug string) int {
+	data, err := json.Marshal(AccessRevokedMessage{
+		Type:      "access_revoked",
+		EventSlug: eventSlug,
+	})
+	if err != nil {
+		log.Printf("Error marshaling access revoked: %v", err)
+		return 0
+	}
+
+	// Lo ejecuta Run para no cerrar un canal que el loop también está cerrando
+	req := &revokeRequest{eventSlug: eventSlug, message: data, done: make(chan int, 1)}
+	h.revokeRoom <- req
+	count := <-req.done
+
+	log.Printf("WebSocket: Acceso revocado en '%s' (%d clientes desconectados)", eventSlug, count)
+	return count
+}
+
+// closeRoom envía el mensaje a los clientes del room y los desconecta. Solo lo llama Run.
+func (h *Hub) closeRoom(eventSlug string, message []byte) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room := h.rooms[eventSlug]
+	for client := range room {
+		// El aviso queda en el buffer y writePump lo envía antes de cerrar
+		select {
+		case client.send <- message:
+		default:
+		}
+		delete(h.clients, client)
+		close(client.send)
+	}
+	delete(h.rooms, eventSlug)
+	return len(room)
+}
+
+// BroadcastJSONToRoom serializa y envía un mensaje arbitrario a los clientes de un evento
+func (h *Hub) BroadcastJSONToRoom(eventSlug string, msg interface{}) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Error marshaling room message: %v", err)
+		return
+	}
+
+	h.broadcastToRoom <- &RoomMessage{
+		EventSlug: eventSlug,
+		Message:   data,
+	}
+}
+
+// SendJSON envía un mensaje solo a este cliente. No bloquea: retorna false si el
+// cliente ya fue desregistrado o su buffer está lleno.
+func (c *Client) SendJSON(msg interface{}) bool {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Error marshaling client message: %v", err)
+		return false
+	}
+
+	// El hub cierra client.send con Lock: chequear registro bajo RLock evita
+	// escribir en un canal cerrado
+	c.hub.mu.RLock()
+	defer c.hub.mu.RUnlock()
+	if !c.hub.clients[c] {
+		return false
+	}
+
+	select {
+	case c.send <- data:
+		return true
+	default:
+		return false
+	}
+}
+
 // readPump bombea mensajes desde el WebSocket al hub
 // The application runs readPump in a per-connection goroutine. The application
 // ensures that there is at most one reader on a connection by executing all
 // reads from this goroutine.
 func (c *Client) readPump() {
 	defer func() {
+		if c.hub.messageHandler != nil {
+			c.hub.messageHandler.HandleClientDisconnect(c)
+		}
 		c.hub.unregister <- c
 		c.conn.Close()
 	}()
@@ -492,15 +801,25 @@ func (c *Client) readPump() {
 	})
 
 	for {
-		_, _, err := c.conn.ReadMessage()
+		_, data, err := c.conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
 			}
 			break
 		}
-		// Por ahora no procesamos mensajes entrantes del cliente
-		// Solo hacemos broadcast del ranking
+
+		// Sin procesador configurado los mensajes entrantes se ignoran
+		if c.hub.messageHandler == nil {
+			continue
+		}
+
+		var msg IncomingMessage
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type == "" {
+			c.SendJSON(ErrorMessage{Type: "error", Error: "invalid message"})
+			continue
+		}
+		c.hub.messageHandler.HandleClientMessage(c, msg)
 	}
 }
 
@@ -80,11 +80,11 @@ func newMockEventValidator() *mockEventValidator {
 	}
 }
 
-func (m *mockEventValidator) ValidateEvent(slug string) error {
+func (m *mockEventValidator) ValidateEvent(slug, accessToken string) (string, error) {
 	if !m.validEvents[slug] {
-		return ErrEventNotFound
+		return "", ErrEventNotFound
 	}
-	return nil
+	return slug, nil
 }
 
 // ErrEventNotFound es el error cuando el evento no existe
@@ -515,3 +515,87 @@ func TestHub_NonExistentRoomBroadcast(t *testing.T) {
 	// El broadcast a room inexistente es un no-op, el código debe continuar
 	time.Sleep(50 * time.Millisecond)
 }
+
+func TestHub_RevokeRoomAccess(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	revoked := &Client{hub: hub, conn: &websocket.Conn{}, send: make(chan []byte, 256), EventSlug: "mile-cumple"}
+	other := &Client{hub: hub, conn: &websocket.Conn{}, send: make(chan []byte, 256), EventSlug: "test-event"}
+	hub.register <- revoked
+	hub.register <- other
+	time.Sleep(50 * time.Millisecond)
+
+	if n := hub.RevokeRoomAccess("mile-cumple"); n != 1 {
+		t.Fatalf("expected 1 disconnected client, got %d", n)
+	}
+
+	// El aviso llega antes de que se cierre el canal
+	msg, ok := <-revoked.send
+	if !ok {
+		t.Fatal("expected access_revoked message before close")
+	}
+	var revokedMsg AccessRevokedMessage
+	if err := json.Unmarshal(msg, &revokedMsg); err != nil {
+		t.Fatalf("Failed to unmarshal message: %v", err)
+	}
+	if revokedMsg.Type != "access_revoked" || revokedMsg.EventSlug != "mile-cumple" {
+		t.Errorf("unexpected message: %+v", revokedMsg)
+	}
+	if _, ok := <-revoked.send; ok {
+		t.Error("send channel should be closed after the notice")
+	}
+
+	hub.mu.RLock()
+	_, revokedRegistered := hub.clients[revoked]
+	_, otherRegistered := hub.clients[other]
+	_, roomExists := hub.rooms["mile-cumple"]
+	hub.mu.RUnlock()
+	if revokedRegistered || roomExists {
+		t.Error("revoked client and its room should be removed")
+	}
+	if !otherRegistered {
+		t.Error("clients of other events should stay connected")
+	}
+
+	// El unregister posterior de readPump no vuelve a cerrar el canal
+	hub.unregister <- revoked
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestHub_RevokeRoomAccessDuringRoomBroadcast(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	for i := 0; i < 50; i++ {
+		// Sin buffer: cada broadcast al room lo trata como cliente lento
+		slow := &Client{hub: hub, conn: &websocket.Conn{}, send: make(chan []byte), EventSlug: "mile-cumple"}
+		hub.register <- slow
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				hub.BroadcastJSONToRoom("mile-cumple", map[string]string{"type": "ping"})
+			}
+		}()
+		disconnected := hub.RevokeRoomAccess("mile-cumple")
+		wg.Wait()
+
+		if disconnected > 1 {
+			t.Fatalf("expected at most 1 disconnected client, got %d", disconnected)
+		}
+
+		// Lo sacó el revoke o la limpieza de clientes lentos, pero el canal se cerró una sola vez
+		hub.mu.RLock()
+		_, registered := hub.clients[slow]
+		hub.mu.RUnlock()
+		if registered {
+			t.Fatal("slow client should be removed after the revoke")
+		}
+		if _, ok := <-slow.send; ok {
+			t.Fatal("send channel should be closed")
+		}
+	}
+}
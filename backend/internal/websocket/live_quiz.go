@@ -0,0 +1,724 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// Estados de una partida del quiz en vivo
+const (
+	LiveStateLobby    = "lobby"    // Esperando jugadores
+	LiveStateQuestion = "question" // Pregunta abierta, corre la cuenta regresiva
+	LiveStateResults  = "results"  // Resultados de la pregunta y leaderboard
+	LiveStateFinished = "finished" // Partida terminada
+)
+
+const (
+	// DefaultLiveTimeLimit tiempo por pregunta si el host no indica otro
+	DefaultLiveTimeLimit = 20 * time.Second
+
+	// MinLiveTimeLimit / MaxLiveTimeLimit límites del tiempo por pregunta
+	MinLiveTimeLimit = 5 * time.Second
+	MaxLiveTimeLimit = 120 * time.Second
+
+	// LivePointsPerQuestionPoint puntos en vivo por cada punto de la pregunta.
+	// Una respuesta instantánea vale el 100%; una al límite del tiempo, el 50%.
+	LivePointsPerQuestionPoint = 1000
+)
+
+// LiveQuestionSource obtiene las preguntas de un evento
+type LiveQuestionSource interface {
+	ListByEvent(eventID uuid.UUID) ([]models.QuizQuestion, error)
+}
+
+// LiveAuthenticator autentica al host y a los jugadores de una partida
+type LiveAuthenticator interface {
+	AuthenticateHost(eventSlug, accessToken string) (*models.Event, error)
+	AuthenticatePlayer(eventSlug, playerToken string) (*models.Player, error)
+}
+
+// ============== Mensajes entrantes ==============
+
+type liveHostPayload struct {
+	AccessToken string `json:"access_token"`
+}
+
+type liveJoinPayload struct {
+	PlayerToken string `json:"player_token"`
+}
+
+type liveStartPayload struct {
+	TimeLimitSeconds int `json:"time_limit_seconds"`
+}
+
+type liveAnswerPayload struct {
+	QuestionIndex int    `json:"question_index"`
+	Answer        string `json:"answer"`
+}
+
+// ============== Mensajes salientes ==============
+
+// LiveQuestion pregunta abierta (sin las respuestas correctas)
+type LiveQuestion struct {
+	Index            int       `json:"index"`
+	Total            int       `json:"total"`
+	Key              string    `json:"key"`
+	Section          string    `json:"section"`
+	QuestionText     string    `json:"question_text"`
+	Options          []string  `json:"options,omitempty"`
+	TimeLimitSeconds int       `json:"time_limit_seconds"`
+	EndsAt           time.Time `json:"ends_at"`
+}
+
+// LivePlayerScore entrada del leaderboard en vivo
+type LivePlayerScore struct {
+	Position int       `json:"position"`
+	PlayerID uuid.UUID `json:"player_id"`
+	Name     string    `json:"name"`
+	Avatar   string    `json:"avatar"`
+	Score    int       `json:"score"`
+}
+
+// LiveAnswerResult resultado de un jugador en una pregunta
+type LiveAnswerResult struct {
+	PlayerID   uuid.UUID `json:"player_id"`
+	PlayerName string    `json:"player_name"`
+	Answer     string    `json:"answer"`
+	Result     string    `json:"result"`
+	Match      string    `json:"match,omitempty"`
+	Points     int       `json:"points"`
+	ElapsedMs  int64     `json:"elapsed_ms"`
+}
+
+// LiveStateMessage estado completo de la partida (respuesta a live_host / live_join)
+type LiveStateMessage struct {
+	Type           string            `json:"type"`
+	Role           string            `json:"role"` // host | player
+	State          string            `json:"state"`
+	QuestionIndex  int               `json:"question_index"`
+	TotalQuestions int               `json:"total_questions"`
+	Question       *LiveQuestion     `json:"question,omitempty"`
+	Leaderboard    []LivePlayerScore `json:"leaderboard"`
+}
+
+// LiveLobbyMessage jugadores en la partida (se envía cuando alguien se une)
+type LiveLobbyMessage struct {
+	Type    string            `json:"type"`
+	Players []LivePlayerScore `json:"players"`
+}
+
+// LiveQuestionMessage nueva pregunta abierta
+type LiveQuestionMessage struct {
+	Type     string       `json:"type"`
+	Question LiveQuestion `json:"question"`
+}
+
+// LiveAnswerAckMessage confirmación de respuesta recibida (solo al jugador)
+type LiveAnswerAckMessage struct {
+	Type          string `json:"type"`
+	QuestionIndex int    `json:"question_index"`
+}
+
+// LiveAnswerProgressMessage cuántos jugadores ya respondieron
+type LiveAnswerProgressMessage struct {
+	Type          string `json:"type"`
+	QuestionIndex int    `json:"question_index"`
+	Answered      int    `json:"answered"`
+	Total         int    `json:"total"`
+}
+
+// LiveQuestionResultsMessage resultados de la pregunta y leaderboard entre rondas
+type LiveQuestionResultsMessage struct {
+	Type           string             `json:"type"`
+	QuestionIndex  int                `json:"question_index"`
+	Key            string             `json:"key"`
+	CorrectAnswers []string           `json:"correct_answers"`
+	Results        []LiveAnswerResult `json:"results"`
+	Leaderboard    []LivePlayerScore  `json:"leaderboard"`
+	IsLast         bool               `json:"is_last"`
+}
+
+// LiveFinishedMessage fin de la partida con el leaderboard final
+type LiveFinishedMessage struct {
+	Type        string            `json:"type"`
+	Leaderboard []LivePlayerScore `json:"leaderboard"`
+}
+
+// ============== Motor ==============
+
+// LiveQuiz administra las partidas en vivo (una por room) y procesa los
+// mensajes de los clientes. Implementa ClientMessageHandler.
+type LiveQuiz struct {
+	hub       *Hub
+	questions LiveQuestionSource
+	auth      LiveAuthenticator
+	now       func() time.Time
+
+	mu    sync.Mutex
+	games map[string]*liveGame
+}
+
+// NewLiveQuiz crea el motor del quiz en vivo
+func NewLiveQuiz(hub *Hub, questions LiveQuestionSource, auth LiveAuthenticator) *LiveQuiz {
+	return &LiveQuiz{
+		hub:       hub,
+		questions: questions,
+		auth:      auth,
+		now:       time.Now,
+		games:     make(map[string]*liveGame),
+	}
+}
+
+// HandleClientMessage procesa un mensaje live_* de un cliente
+func (q *LiveQuiz) HandleClientMessage(client *Client, msg IncomingMessage) {
+	var err error
+	if client.EventSlug == "" {
+		err = errLiveNoEvent
+	} else {
+		switch msg.Type {
+		case "live_host":
+			err = q.handleHost(client, msg.Data)
+		case "live_join":
+			err = q.handleJoin(client, msg.Data)
+		case "live_start":
+			err = q.handleStart(client, msg.Data)
+		case "live_close_question":
+			err = q.withHostGame(client, func(g *liveGame) error { return g.closeQuestion() })
+		case "live_next":
+			err = q.withHostGame(client, func(g *liveGame) error { return g.next() })
+		case "live_end":
+			err = q.withHostGame(client, func(g *liveGame) error { return g.finish() })
+		case "live_answer":
+			err = q.handleAnswer(client, msg.Data)
+		default:
+			err = errLiveUnknownMessage
+		}
+	}
+
+	if err != nil {
+		client.SendJSON(ErrorMessage{Type: "live_error", Error: err.Error()})
+	}
+}
+
+// HandleClientDisconnect saca al cliente de su partida. El jugador conserva su
+// puntaje y puede volver a unirse con su token mientras quede alguien en la partida;
+// cuando se va el último cliente la partida se descarta.
+func (q *LiveQuiz) HandleClientDisconnect(client *Client) {
+	g := q.game(client.EventSlug)
+	if g == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.hosts, client)
+	delete(g.clients, client)
+
+	if len(g.hosts) == 0 && len(g.clients) == 0 {
+		q.removeGame(g)
+		return
+	}
+
+	// Si solo faltaba este jugador, la pregunta se cierra sin esperar el tiempo
+	if g.state == LiveStateQuestion && len(g.answers) > 0 {
+		g.afterAnswer()
+	}
+}
+
+func (q *LiveQuiz) game(eventSlug string) *liveGame {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.games[eventSlug]
+}
+
+func (q *LiveQuiz) getOrCreateGame(eventSlug string, eventID uuid.UUID) *liveGame {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	g, ok := q.games[eventSlug]
+	if !ok {
+		g = &liveGame{
+			quiz:      q,
+			eventSlug: eventSlug,
+			eventID:   eventID,
+			state:     LiveStateLobby,
+			current:   -1,
+			timeLimit: DefaultLiveTimeLimit,
+			hosts:     make(map[*Client]bool),
+			clients:   make(map[*Client]uuid.UUID),
+			players:   make(map[uuid.UUID]*livePlayer),
+		}
+		q.games[eventSlug] = g
+	}
+	return g
+}
+
+// lockGame devuelve la partida del room con g.mu tomado. Si la partida se descartó
+// entre buscarla y bloquearla, se busca (o crea) de nuevo.
+func (q *LiveQuiz) lockGame(eventSlug string, eventID uuid.UUID) *liveGame {
+	for {
+		g := q.getOrCreateGame(eventSlug, eventID)
+		g.mu.Lock()
+		if !g.closed {
+			return g
+		}
+		g.mu.Unlock()
+	}
+}
+
+// removeGame descarta la partida y frena su cuenta regresiva. Asume g.mu tomado.
+func (q *LiveQuiz) removeGame(g *liveGame) {
+	if g.timer != nil {
+		g.timer.Stop()
+	}
+	g.round++
+	g.closed = true
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.games[g.eventSlug] == g {
+		delete(q.games, g.eventSlug)
+	}
+}
+
+func (q *LiveQuiz) handleHost(client *Client, data json.RawMessage) error {
+	var payload liveHostPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.AccessToken == "" {
+		return errLiveInvalidPayload
+	}
+
+	event, err := q.auth.AuthenticateHost(client.EventSlug, payload.AccessToken)
+	if err != nil {
+		return errLiveUnauthorized
+	}
+	if !event.Features.Quiz {
+		return errLiveQuizDisabled
+	}
+
+	g := q.lockGame(client.EventSlug, event.ID)
+	defer g.mu.Unlock()
+
+	g.hosts[client] = true
+	client.SendJSON(g.stateMessage("host"))
+	return nil
+}
+
+func (q *LiveQuiz) handleJoin(client *Client, data json.RawMessage) error {
+	var payload liveJoinPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.PlayerToken == "" {
+		return errLiveInvalidPayload
+	}
+
+	player, err := q.auth.AuthenticatePlayer(client.EventSlug, payload.PlayerToken)
+	if err != nil {
+		return errLiveUnauthorized
+	}
+
+	g := q.lockGame(client.EventSlug, player.EventID)
+	defer g.mu.Unlock()
+
+	if _, exists := g.players[player.ID]; !exists {
+		g.players[player.ID] = &livePlayer{
+			ID:       player.ID,
+			Name:     player.Name,
+			Avatar:   player.Avatar,
+			joinedAt: g.joinSeq,
+		}
+		g.joinSeq++
+	}
+	g.clients[client] = player.ID
+
+	client.SendJSON(g.stateMessage("player"))
+	g.broadcast(LiveLobbyMessage{Type: "live_lobby", Players: g.leaderboard()})
+	return nil
+}
+
+func (q *LiveQuiz) handleStart(client *Client, data json.RawMessage) error {
+	var payload liveStartPayload
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return errLiveInvalidPayload
+		}
+	}
+
+	return q.withHostGame(client, func(g *liveGame) error {
+		questions, err := q.questions.ListByEvent(g.eventID)
+		if err != nil {
+			log.Printf("LiveQuiz: failed to load questions for '%s': %v", g.eventSlug, err)
+			return errLiveNoQuestions
+		}
+		return g.start(questions, liveTimeLimit(payload.TimeLimitSeconds))
+	})
+}
+
+func (q *LiveQuiz) handleAnswer(client *Client, data json.RawMessage) error {
+	var payload liveAnswerPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return errLiveInvalidPayload
+	}
+
+	g := q.game(client.EventSlug)
+	if g == nil {
+		return errLiveNotJoined
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	playerID, ok := g.clients[client]
+	if !ok {
+		return errLiveNotJoined
+	}
+	if err := g.submitAnswer(playerID, payload.QuestionIndex, payload.Answer); err != nil {
+		return err
+	}
+
+	client.SendJSON(LiveAnswerAckMessage{Type: "live_answer_ack", QuestionIndex: payload.QuestionIndex})
+	g.afterAnswer()
+	return nil
+}
+
+// withHostGame ejecuta fn con la partida bloqueada si el cliente es host
+func (q *LiveQuiz) withHostGame(client *Client, fn func(g *liveGame) error) error {
+	g := q.game(client.EventSlug)
+	if g == nil {
+		return errLiveNotHost
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.hosts[client] {
+		return errLiveNotHost
+	}
+	return fn(g)
+}
+
+// liveTimeLimit convierte los segundos pedidos por el host al rango permitido
+func liveTimeLimit(seconds int) time.Duration {
+	if seconds <= 0 {
+		return DefaultLiveTimeLimit
+	}
+	limit := time.Duration(seconds) * time.Second
+	return min(max(limit, MinLiveTimeLimit), MaxLiveTimeLimit)
+}
+
+// livePoints puntaje de una respuesta según lo rápido que llegó
+func livePoints(points int, elapsed, limit time.Duration) int {
+	if points <= 0 {
+		return 0
+	}
+	ratio := math.Min(math.Max(float64(elapsed)/float64(limit), 0), 1)
+	return int(math.Round(float64(points*LivePointsPerQuestionPoint) * (1 - ratio/2)))
+}
+
+// ============== Partida ==============
+
+type livePlayer struct {
+	ID       uuid.UUID
+	Name     string
+	Avatar   string
+	Score    int
+	joinedAt int
+}
+
+type liveAnswer struct {
+	score   models.QuestionScore
+	points  int
+	elapsed time.Duration
+}
+
+// liveGame estado de la partida de un room. Todos los métodos asumen g.mu tomado.
+type liveGame struct {
+	quiz      *LiveQuiz
+	eventSlug string
+	eventID   uuid.UUID
+
+	mu      sync.Mutex
+	hosts   map[*Client]bool
+	clients map[*Client]uuid.UUID // cliente -> jugador
+	players map[uuid.UUID]*livePlayer
+	joinSeq int
+
+	state     string
+	questions []models.QuizQuestion
+	scorers   []*services.Scorer
+	current   int
+	timeLimit time.Duration
+	openedAt  time.Time
+	timer     *time.Timer
+	round     int // invalida timers de preguntas anteriores
+	answers   map[uuid.UUID]*liveAnswer
+	closed    bool // ya no está en LiveQuiz.games
+}
+
+// start arranca (o reinicia) la partida con las preguntas puntuables del evento
+func (g *liveGame) start(questions []models.QuizQuestion, timeLimit time.Duration) error {
+	if g.state != LiveStateLobby && g.state != LiveStateFinished {
+		return errLiveAlreadyRunning
+	}
+
+	g.questions = g.questions[:0]
+	g.scorers = g.scorers[:0]
+	for _, question := range questions {
+		scorer := services.NewScorerWithQuestions([]models.QuizQuestion{question})
+		// El scorer descarta las preguntas no puntuables o sin respuesta válida
+		if len(scorer.Score(nil, nil).Questions) == 0 {
+			continue
+		}
+		g.questions = append(g.questions, question)
+		g.scorers = append(g.scorers, scorer)
+	}
+	if len(g.questions) == 0 {
+		return errLiveNoQuestions
+	}
+
+	for _, p := range g.players {
+		p.Score = 0
+	}
+	g.timeLimit = timeLimit
+	g.openQuestion(0)
+	return nil
+}
+
+// openQuestion abre la pregunta i y arranca la cuenta regresiva
+func (g *liveGame) openQuestion(i int) {
+	g.state = LiveStateQuestion
+	g.current = i
+	g.answers = make(map[uuid.UUID]*liveAnswer)
+	g.openedAt = g.quiz.now()
+	g.round++
+
+	round := g.round
+	g.timer = time.AfterFunc(g.timeLimit, func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		// El host pudo haber cerrado o avanzado antes de que venza
+		if g.state == LiveStateQuestion && g.round == round {
+			g.closeQuestion()
+		}
+	})
+
+	g.broadcast(LiveQuestionMessage{Type: "live_question", Question: *g.currentQuestion()})
+}
+
+// submitAnswer registra y puntúa la respuesta de un jugador a la pregunta abierta
+func (g *liveGame) submitAnswer(playerID uuid.UUID, index int, answer string) error {
+	if g.state != LiveStateQuestion || index != g.current {
+		return errLiveQuestionClosed
+	}
+	if _, answered := g.answers[playerID]; answered {
+		return errLiveAlreadyAnswered
+	}
+
+	elapsed := g.quiz.now().Sub(g.openedAt)
+	if elapsed > g.timeLimit {
+		return errLiveQuestionClosed
+	}
+
+	question := g.questions[g.current]
+	scorer := g.scorers[g.current]
+	normalized := map[string]string{question.Key: scorer.GetNormalizer().NormalizeForStorage(answer)}
+
+	var breakdown models.ScoreBreakdown
+	if question.Section == "preferences" {
+		breakdown = scorer.Score(nil, normalized)
+	} else {
+		breakdown = scorer.Score(normalized, nil)
+	}
+	score := breakdown.Questions[0]
+
+	g.answers[playerID] = &liveAnswer{
+		score:   score,
+		points:  livePoints(score.Points, elapsed, g.timeLimit),
+		elapsed: elapsed,
+	}
+	return nil
+}
+
+// afterAnswer informa el progreso y cierra la pregunta si ya respondieron todos
+func (g *liveGame) afterAnswer() {
+	connected := g.connectedPlayers()
+	g.broadcast(LiveAnswerProgressMessage{
+		Type:          "live_answer_progress",
+		QuestionIndex: g.current,
+		Answered:      len(g.answers),
+		Total:         len(connected),
+	})
+
+	for playerID := range connected {
+		if _, answered := g.answers[playerID]; !answered {
+			return
+		}
+	}
+	g.closeQuestion()
+}
+
+// closeQuestion cierra la pregunta abierta, suma puntos y publica resultados
+func (g *liveGame) closeQuestion() error {
+	if g.state != LiveStateQuestion {
+		return errLiveQuestionClosed
+	}
+	if g.timer != nil {
+		g.timer.Stop()
+	}
+	g.state = LiveStateResults
+
+	question := g.questions[g.current]
+	results := make([]LiveAnswerResult, 0, len(g.answers))
+	for playerID, answer := range g.answers {
+		player := g.players[playerID]
+		player.Score += answer.points
+		results = append(results, LiveAnswerResult{
+			PlayerID:   playerID,
+			PlayerName: player.Name,
+			Answer:     answer.score.Answer,
+			Result:     answer.score.Result,
+			Match:      answer.score.Match,
+			Points:     answer.points,
+			ElapsedMs:  answer.elapsed.Milliseconds(),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Points != results[j].Points {
+			return results[i].Points > results[j].Points
+		}
+		return results[i].ElapsedMs < results[j].ElapsedMs
+	})
+
+	g.broadcast(LiveQuestionResultsMessage{
+		Type:           "live_question_results",
+		QuestionIndex:  g.current,
+		Key:            question.Key,
+		CorrectAnswers: question.CorrectAnswers,
+		Results:        results,
+		Leaderboard:    g.leaderboard(),
+		IsLast:         g.current == len(g.questions)-1,
+	})
+	return nil
+}
+
+// next avanza a la siguiente pregunta o termina la partida tras la última
+func (g *liveGame) next() error {
+	if g.state != LiveStateResults {
+		return errLiveNotInResults
+	}
+	if g.current+1 >= len(g.questions) {
+		return g.finish()
+	}
+	g.openQuestion(g.current + 1)
+	return nil
+}
+
+// finish termina la partida y publica el leaderboard final
+func (g *liveGame) finish() error {
+	if g.state == LiveStateLobby || g.state == LiveStateFinished {
+		return errLiveNotRunning
+	}
+	if g.timer != nil {
+		g.timer.Stop()
+	}
+	g.round++
+	g.state = LiveStateFinished
+
+	g.broadcast(LiveFinishedMessage{Type: "live_finished", Leaderboard: g.leaderboard()})
+	return nil
+}
+
+// connectedPlayers jugadores con al menos una conexión abierta
+func (g *liveGame) connectedPlayers() map[uuid.UUID]bool {
+	connected := make(map[uuid.UUID]bool)
+	for _, playerID := range g.clients {
+		connected[playerID] = true
+	}
+	return connected
+}
+
+// leaderboard jugadores ordenados por puntaje (empate: orden de llegada)
+func (g *liveGame) leaderboard() []LivePlayerScore {
+	players := make([]*livePlayer, 0, len(g.players))
+	for _, p := range g.players {
+		players = append(players, p)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Score != players[j].Score {
+			return players[i].Score > players[j].Score
+		}
+		return players[i].joinedAt < players[j].joinedAt
+	})
+
+	board := make([]LivePlayerScore, len(players))
+	for i, p := range players {
+		board[i] = LivePlayerScore{
+			Position: i + 1,
+			PlayerID: p.ID,
+			Name:     p.Name,
+			Avatar:   p.Avatar,
+			Score:    p.Score,
+		}
+	}
+	return board
+}
+
+// currentQuestion pregunta abierta tal como la ven los clientes
+func (g *liveGame) currentQuestion() *LiveQuestion {
+	if g.current < 0 || g.current >= len(g.questions) {
+		return nil
+	}
+	q := g.questions[g.current]
+	return &LiveQuestion{
+		Index:            g.current,
+		Total:            len(g.questions),
+		Key:              q.Key,
+		Section:          q.Section,
+		QuestionText:     q.QuestionText,
+		Options:          q.Options,
+		TimeLimitSeconds: int(g.timeLimit / time.Second),
+		EndsAt:           g.openedAt.Add(g.timeLimit),
+	}
+}
+
+func (g *liveGame) stateMessage(role string) LiveStateMessage {
+	msg := LiveStateMessage{
+		Type:           "live_state",
+		Role:           role,
+		State:          g.state,
+		QuestionIndex:  g.current,
+		TotalQuestions: len(g.questions),
+		Leaderboard:    g.leaderboard(),
+	}
+	if g.state == LiveStateQuestion {
+		msg.Question = g.currentQuestion()
+	}
+	return msg
+}
+
+func (g *liveGame) broadcast(msg interface{}) {
+	g.quiz.hub.BroadcastJSONToRoom(g.eventSlug, msg)
+}
+
+// Errores devueltos al cliente en mensajes live_error
+var (
+	errLiveNoEvent         = errors.New("connect with ?event=<slug> to play live")
+	errLiveUnknownMessage  = errors.New("unknown message type")
+	errLiveInvalidPayload  = errors.New("invalid message data")
+	errLiveUnauthorized    = errors.New("invalid credentials")
+	errLiveQuizDisabled    = errors.New("quiz is not enabled for this event")
+	errLiveNotHost         = errors.New("only the host can do that")
+	errLiveNotJoined       = errors.New("join the game first")
+	errLiveNoQuestions     = errors.New("no scorable questions for this event")
+	errLiveAlreadyRunning  = errors.New("game already running")
+	errLiveNotRunning      = errors.New("game is not running")
+	errLiveQuestionClosed  = errors.New("question is not open")
+	errLiveAlreadyAnswered = errors.New("already answered")
+	errLiveNotInResults    = errors.New("close the current question first")
+)
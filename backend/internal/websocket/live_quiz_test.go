@@ -0,0 +1,309 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// mockLiveAuth acepta tokens "host" y "player:<nombre>" para tests
+type mockLiveAuth struct {
+	event   *models.Event
+	players map[string]*models.Player
+}
+
+func (m *mockLiveAuth) AuthenticateHost(eventSlug, accessToken string) (*models.Event, error) {
+	if accessToken != "host" || eventSlug != m.event.Slug {
+		return nil, errors.New("unauthorized")
+	}
+	return m.event, nil
+}
+
+func (m *mockLiveAuth) AuthenticatePlayer(eventSlug, playerToken string) (*models.Player, error) {
+	player, ok := m.players[playerToken]
+	if !ok || eventSlug != m.event.Slug {
+		return nil, errors.New("unauthorized")
+	}
+	return player, nil
+}
+
+type mockLiveQuestions struct {
+	questions []models.QuizQuestion
+}
+
+func (m *mockLiveQuestions) ListByEvent(eventID uuid.UUID) ([]models.QuizQuestion, error) {
+	return m.questions, nil
+}
+
+// fakeClock reloj controlable para el puntaje por tiempo
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newLiveTestClient(t *testing.T, hub *Hub, slug string) *Client {
+	t.Helper()
+	client := &Client{
+		hub:       hub,
+		conn:      &websocket.Conn{},
+		send:      make(chan []byte, 256),
+		EventSlug: slug,
+	}
+	hub.register <- client
+	time.Sleep(20 * time.Millisecond)
+	return client
+}
+
+// expectMessage lee mensajes del cliente hasta encontrar uno del tipo pedido
+func expectMessage(t *testing.T, client *Client, msgType string, out interface{}) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case data := <-client.send:
+			var msg Message
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("invalid message: %v", err)
+			}
+			if msg.Type != msgType {
+				continue
+			}
+			if out != nil {
+				if err := json.Unmarshal(data, out); err != nil {
+					t.Fatalf("failed to decode %s: %v", msgType, err)
+				}
+			}
+			return
+		case <-timeout:
+			t.Fatalf("did not receive %s", msgType)
+		}
+	}
+}
+
+func sendLive(q *LiveQuiz, client *Client, msgType string, data interface{}) {
+	raw, _ := json.Marshal(data)
+	q.HandleClientMessage(client, IncomingMessage{Type: msgType, Data: raw})
+}
+
+func setupLiveQuiz(t *testing.T) (*LiveQuiz, *Hub, *fakeClock, map[string]*models.Player) {
+	t.Helper()
+	event := &models.Event{ID: uuid.New(), Slug: "live-event", Features: models.EventFeatures{Quiz: true}}
+	players := map[string]*models.Player{
+		"player:ana":  {ID: uuid.New(), EventID: event.ID, Name: "Ana"},
+		"player:beto": {ID: uuid.New(), EventID: event.ID, Name: "Beto"},
+	}
+	questions := &mockLiveQuestions{questions: []models.QuizQuestion{
+		{Key: "flower", Section: "favorites", QuestionText: "¿Flor favorita?", CorrectAnswers: []string{"girasol"}, IsScorable: true, QuestionScoring: models.QuestionScoring{Points: 1}},
+		{Key: "about", Section: "description", QuestionText: "Describila", IsScorable: false},
+		{Key: "coffee", Section: "preferences", QuestionText: "¿Café o té?", CorrectAnswers: []string{"te"}, Options: []string{"cafe", "te"}, IsScorable: true, QuestionScoring: models.QuestionScoring{Points: 2}},
+	}}
+
+	hub := NewHub()
+	go hub.Run()
+
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)}
+	quiz := NewLiveQuiz(hub, questions, &mockLiveAuth{event: event, players: players})
+	quiz.now = clock.Now
+	hub.SetMessageHandler(quiz)
+
+	return quiz, hub, clock, players
+}
+
+func TestLiveQuiz_FullGame(t *testing.T) {
+	quiz, hub, clock, players := setupLiveQuiz(t)
+
+	host := newLiveTestClient(t, hub, "live-event")
+	ana := newLiveTestClient(t, hub, "live-event")
+	beto := newLiveTestClient(t, hub, "live-event")
+
+	sendLive(quiz, host, "live_host", map[string]string{"access_token": "host"})
+	var state LiveStateMessage
+	expectMessage(t, host, "live_state", &state)
+	if state.Role != "host" || state.State != LiveStateLobby {
+		t.Fatalf("host state = %+v, want host in lobby", state)
+	}
+
+	sendLive(quiz, ana, "live_join", map[string]string{"player_token": "player:ana"})
+	expectMessage(t, ana, "live_state", nil)
+	sendLive(quiz, beto, "live_join", map[string]string{"player_token": "player:beto"})
+	var lobby LiveLobbyMessage
+	expectMessage(t, host, "live_lobby", nil)
+	expectMessage(t, host, "live_lobby", &lobby)
+	if len(lobby.Players) != 2 {
+		t.Fatalf("lobby has %d players, want 2", len(lobby.Players))
+	}
+
+	// Los jugadores no pueden manejar la partida
+	sendLive(quiz, ana, "live_start", nil)
+	expectMessage(t, ana, "live_error", nil)
+
+	sendLive(quiz, host, "live_start", map[string]int{"time_limit_seconds": 10})
+	var question LiveQuestionMessage
+	expectMessage(t, ana, "live_question", &question)
+	if question.Question.Key != "flower" || question.Question.Total != 2 || question.Question.TimeLimitSeconds != 10 {
+		t.Fatalf("first question = %+v, want flower of 2 (non-scorable skipped), 10s", question.Question)
+	}
+	expectMessage(t, beto, "live_question", nil)
+
+	// Ana responde al instante, Beto a la mitad del tiempo
+	sendLive(quiz, ana, "live_answer", map[string]interface{}{"question_index": 0, "answer": "Girasol"})
+	expectMessage(t, ana, "live_answer_ack", nil)
+	sendLive(quiz, ana, "live_answer", map[string]interface{}{"question_index": 0, "answer": "otra"})
+	expectMessage(t, ana, "live_error", nil)
+
+	clock.Advance(5 * time.Second)
+	sendLive(quiz, beto, "live_answer", map[string]interface{}{"question_index": 0, "answer": "girasol"})
+
+	// Respondieron todos: la pregunta se cierra sola
+	var results LiveQuestionResultsMessage
+	expectMessage(t, host, "live_question_results", &results)
+	if len(results.Results) != 2 {
+		t.Fatalf("results = %d, want 2", len(results.Results))
+	}
+	if results.Results[0].PlayerID != players["player:ana"].ID || results.Results[0].Points != 1000 {
+		t.Errorf("first result = %+v, want Ana with 1000", results.Results[0])
+	}
+	if results.Results[1].Points != 750 {
+		t.Errorf("Beto points = %d, want 750", results.Results[1].Points)
+	}
+	if results.IsLast {
+		t.Error("first question should not be the last")
+	}
+
+	sendLive(quiz, host, "live_next", nil)
+	expectMessage(t, beto, "live_question", &question)
+	if question.Question.Key != "coffee" {
+		t.Fatalf("second question = %s, want coffee", question.Question.Key)
+	}
+
+	// Solo responde Beto; el host cierra la pregunta
+	sendLive(quiz, beto, "live_answer", map[string]interface{}{"question_index": 1, "answer": "Té"})
+	sendLive(quiz, host, "live_close_question", nil)
+	expectMessage(t, host, "live_question_results", &results)
+	if !results.IsLast {
+		t.Error("second question should be the last")
+	}
+	if results.Leaderboard[0].PlayerID != players["player:beto"].ID || results.Leaderboard[0].Score != 2750 {
+		t.Errorf("leader = %+v, want Beto with 2750", results.Leaderboard[0])
+	}
+
+	sendLive(quiz, host, "live_next", nil)
+	var finished LiveFinishedMessage
+	expectMessage(t, ana, "live_finished", &finished)
+	if len(finished.Leaderboard) != 2 || finished.Leaderboard[1].Score != 1000 {
+		t.Errorf("final leaderboard = %+v", finished.Leaderboard)
+	}
+}
+
+func TestLiveQuiz_CountdownClosesQuestion(t *testing.T) {
+	quiz, hub, _, _ := setupLiveQuiz(t)
+
+	host := newLiveTestClient(t, hub, "live-event")
+	ana := newLiveTestClient(t, hub, "live-event")
+	sendLive(quiz, host, "live_host", map[string]string{"access_token": "host"})
+	sendLive(quiz, ana, "live_join", map[string]string{"player_token": "player:ana"})
+
+	g := quiz.game("live-event")
+	g.mu.Lock()
+	questions, _ := quiz.questions.ListByEvent(g.eventID)
+	if err := g.start(questions, 50*time.Millisecond); err != nil {
+		g.mu.Unlock()
+		t.Fatalf("start() error = %v", err)
+	}
+	g.mu.Unlock()
+
+	var results LiveQuestionResultsMessage
+	expectMessage(t, ana, "live_question_results", &results)
+	if len(results.Results) != 0 {
+		t.Errorf("results = %d, want 0 (nobody answered)", len(results.Results))
+	}
+
+	// Una respuesta tardía se rechaza
+	sendLive(quiz, ana, "live_answer", map[string]interface{}{"question_index": 0, "answer": "girasol"})
+	expectMessage(t, ana, "live_error", nil)
+}
+
+func TestLiveQuiz_RemovesGameWhenRoomEmpties(t *testing.T) {
+	quiz, hub, _, _ := setupLiveQuiz(t)
+
+	host := newLiveTestClient(t, hub, "live-event")
+	ana := newLiveTestClient(t, hub, "live-event")
+	sendLive(quiz, host, "live_host", map[string]string{"access_token": "host"})
+	sendLive(quiz, ana, "live_join", map[string]string{"player_token": "player:ana"})
+	sendLive(quiz, host, "live_start", nil)
+	expectMessage(t, ana, "live_question", nil)
+	sendLive(quiz, host, "live_end", nil)
+	expectMessage(t, ana, "live_finished", nil)
+
+	// La partida sigue mientras quede alguien conectado
+	quiz.HandleClientDisconnect(host)
+	if quiz.game("live-event") == nil {
+		t.Fatal("game removed while a player is still connected")
+	}
+
+	quiz.HandleClientDisconnect(ana)
+	if quiz.game("live-event") != nil {
+		t.Fatal("game not removed after the last client left")
+	}
+
+	// Volver a entrar arranca una partida nueva en el lobby
+	sendLive(quiz, host, "live_host", map[string]string{"access_token": "host"})
+	var state LiveStateMessage
+	expectMessage(t, host, "live_state", &state)
+	if state.State != LiveStateLobby || len(state.Leaderboard) != 0 {
+		t.Errorf("state after rejoin = %+v, want empty lobby", state)
+	}
+}
+
+func TestLiveQuiz_RejectsUnauthenticated(t *testing.T) {
+	quiz, hub, _, _ := setupLiveQuiz(t)
+
+	client := newLiveTestClient(t, hub, "live-event")
+	sendLive(quiz, client, "live_host", map[string]string{"access_token": "nope"})
+	expectMessage(t, client, "live_error", nil)
+
+	sendLive(quiz, client, "live_answer", map[string]interface{}{"question_index": 0, "answer": "x"})
+	expectMessage(t, client, "live_error", nil)
+
+	noRoom := newLiveTestClient(t, hub, "")
+	sendLive(quiz, noRoom, "live_join", map[string]string{"player_token": "player:ana"})
+	expectMessage(t, noRoom, "live_error", nil)
+}
+
+func TestLivePoints(t *testing.T) {
+	limit := 20 * time.Second
+	tests := []struct {
+		points  int
+		elapsed time.Duration
+		want    int
+	}{
+		{points: 1, elapsed: 0, want: 1000},
+		{points: 1, elapsed: 10 * time.Second, want: 750},
+		{points: 1, elapsed: limit, want: 500},
+		{points: 2, elapsed: 0, want: 2000},
+		{points: 0, elapsed: 0, want: 0},
+	}
+	for _, tt := range tests {
+		if got := livePoints(tt.points, tt.elapsed, limit); got != tt.want {
+			t.Errorf("livePoints(%d, %s) = %d, want %d", tt.points, tt.elapsed, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package worker
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -39,6 +41,8 @@ type DriveServiceInterface interface {
 	RefreshToken(ctx context.Context, refreshToken string) (*services.TokenResponse, error)
 	DecryptToken(ciphertext string) (string, error)
 	IsTokenExpired(expiry time.Time) bool
+	FindFilesByName(ctx context.Context, accessToken, name string) ([]services.DriveFileResponse, error)
+	DeleteFile(ctx context.Context, accessToken, fileID string) error
 }
 
 // BackupWorker processes backup jobs from the queue
@@ -46,6 +50,7 @@ type BackupWorker struct {
 	Config   *models.DriveBackupConfig
 	repo     *repository.DriveRepository
 	drive    DriveServiceInterface
+	media    services.MediaStore // nil: read from UPLOADS_DIR
 	poolSize int
 	jobQueue chan *models.BackupJob
 	stopChan chan struct{}
@@ -70,6 +75,12 @@ func NewBackupWorker(config *models.DriveBackupConfig, repo *repository.DriveRep
 	}
 }
 
+// SetMediaStore makes the worker read postcard media from store instead of
+// the local uploads directory
+func (w *BackupWorker) SetMediaStore(store services.MediaStore) {
+	w.media = store
+}
+
 // Start begins processing jobs
 func (w *BackupWorker) Start() {
 	w.mu.Lock()
@@ -268,60 +279,12 @@ func (w *BackupWorker) processJob(job *models.BackupJob) error {
 		return err
 	}
 
-	conn, err := w.repo.GetDriveConnectionByUserID(event.OwnerID)
-	if err != nil {
-		w.markJobFailed(job, fmt.Errorf("failed to get drive connection: %w", err))
-		return err
-	}
-
-	// Decrypt the access token
-	accessToken, err := w.drive.DecryptToken(conn.DriveAccessToken)
+	accessToken, err := w.ownerAccessToken(event.OwnerID)
 	if err != nil {
-		w.markJobFailed(job, fmt.Errorf("failed to decrypt access token: %w", err))
+		w.markJobFailed(job, err)
 		return err
 	}
 
-	// Check if token is expired and refresh if needed
-	if w.drive.IsTokenExpired(conn.TokenExpiry) {
-		refreshToken, err := w.drive.DecryptToken(conn.DriveRefreshTokenEnc)
-		if err != nil {
-			w.markJobFailed(job, fmt.Errorf("failed to decrypt refresh token: %w", err))
-			return err
-		}
-
-		log.Printf("[BackupWorker] Refreshing expired token for job %s", job.ID)
-		tokenResp, err := w.drive.RefreshToken(context.Background(), refreshToken)
-		if err != nil {
-			w.markJobFailed(job, fmt.Errorf("failed to refresh token: %w", err))
-			return err
-		}
-
-		// Update stored tokens
-		accessToken = tokenResp.AccessToken
-		encryptedAccessToken, err := services.EncryptToken(accessToken)
-		if err != nil {
-			w.markJobFailed(job, fmt.Errorf("failed to encrypt new access token: %w", err))
-			return err
-		}
-
-		// If Google returned a new refresh token (token rotation), persist it too
-		if tokenResp.RefreshToken != "" {
-			encryptedRefreshToken, err := services.EncryptToken(tokenResp.RefreshToken)
-			if err != nil {
-				w.markJobFailed(job, fmt.Errorf("failed to encrypt new refresh token: %w", err))
-				return err
-			}
-			conn.DriveRefreshTokenEnc = encryptedRefreshToken
-		}
-
-		// Update connection in DB
-		conn.DriveAccessToken = encryptedAccessToken
-		conn.TokenExpiry = tokenResp.Expiry
-		if err := w.repo.UpsertDriveConnection(conn); err != nil {
-			log.Printf("[BackupWorker] Warning: failed to update connection after refresh: %v", err)
-		}
-	}
-
 	// Get the postcard to find the media path
 	postcard, err := w.repo.GetPostcardByID(job.PostcardID)
 	if err != nil {
@@ -330,7 +293,7 @@ func (w *BackupWorker) processJob(job *models.BackupJob) error {
 	}
 
 	// Read the media file
-	content, err := readMediaFile(postcard.ImagePath)
+	content, err := w.readMedia(postcard.ImagePath)
 	if err != nil {
 		w.markJobFailed(job, fmt.Errorf("failed to read media file: %w", err))
 		return err
@@ -386,7 +349,7 @@ func (w *BackupWorker) processJob(job *models.BackupJob) error {
 	driveFileID := result.DriveFileID
 
 	if postcard.ThumbnailPath != nil && *postcard.ThumbnailPath != "" {
-		thumbnailContent, thumbErr := readMediaFile(*postcard.ThumbnailPath)
+		thumbnailContent, thumbErr := w.readMedia(*postcard.ThumbnailPath)
 		if thumbErr != nil {
 			w.markJobFailed(job, fmt.Errorf("failed to read thumbnail file: %w", thumbErr))
 			return thumbErr
@@ -433,6 +396,126 @@ func (w *BackupWorker) markJobFailed(job *models.BackupJob, err error) {
 	}
 }
 
+// ownerAccessToken returns a valid Drive access token for the event owner,
+// refreshing (and persisting) it when it has expired
+func (w *BackupWorker) ownerAccessToken(ownerID uuid.UUID) (string, error) {
+	conn, err := w.repo.GetDriveConnectionByUserID(ownerID)
+	if err == sql.ErrNoRows {
+		return "", services.ErrDriveNotConnected
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get drive connection: %w", err)
+	}
+
+	// Decrypt the access token
+	accessToken, err := w.drive.DecryptToken(conn.DriveAccessToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt access token: %w", err)
+	}
+
+	// Check if token is expired and refresh if needed
+	if !w.drive.IsTokenExpired(conn.TokenExpiry) {
+		return accessToken, nil
+	}
+
+	refreshToken, err := w.drive.DecryptToken(conn.DriveRefreshTokenEnc)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt refresh token: %w", err)
+	}
+
+	log.Printf("[BackupWorker] Refreshing expired token for user %s", ownerID)
+	tokenResp, err := w.drive.RefreshToken(context.Background(), refreshToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	// Update stored tokens
+	accessToken = tokenResp.AccessToken
+	encryptedAccessToken, err := services.EncryptToken(accessToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt new access token: %w", err)
+	}
+
+	// If Google returned a new refresh token (token rotation), persist it too
+	if tokenResp.RefreshToken != "" {
+		encryptedRefreshToken, err := services.EncryptToken(tokenResp.RefreshToken)
+		if err != nil {
+			return "", fmt.Errorf("failed to encrypt new refresh token: %w", err)
+		}
+		conn.DriveRefreshTokenEnc = encryptedRefreshToken
+	}
+
+	// Update connection in DB
+	conn.DriveAccessToken = encryptedAccessToken
+	conn.TokenExpiry = tokenResp.Expiry
+	if err := w.repo.UpsertDriveConnection(conn); err != nil {
+		log.Printf("[BackupWorker] Warning: failed to update connection after refresh: %v", err)
+	}
+
+	return accessToken, nil
+}
+
+// DeletePostcardBackups deletes from the owner's Drive every copy this worker made
+// of the given postcards (media and video thumbnail), e.g. when a player's data is
+// erased. Files are looked up by name as well as by the job's drive_file_id, so
+// copies left by retried or partially failed jobs are removed too. Returns the
+// number of files deleted, or services.ErrDriveNotConnected if the owner disconnected Drive.
+func (w *BackupWorker) DeletePostcardBackups(ctx context.Context, ownerID uuid.UUID, postcards []models.Postcard, jobs []models.BackupJob) (int, error) {
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+
+	accessToken, err := w.ownerAccessToken(ownerID)
+	if err != nil {
+		return 0, err
+	}
+
+	fileIDs := make(map[string]bool)
+	for _, job := range jobs {
+		if job.DriveFileID != nil && *job.DriveFileID != "" {
+			fileIDs[*job.DriveFileID] = true
+		}
+	}
+
+	backedUp := make(map[uuid.UUID]bool, len(jobs))
+	for _, job := range jobs {
+		backedUp[job.PostcardID] = true
+	}
+	for _, postcard := range postcards {
+		if !backedUp[postcard.ID] {
+			continue
+		}
+		// Media file names are unique (UUIDs), so they only match this postcard's copies
+		var names []string
+		if postcard.ImagePath != "" {
+			names = append(names, filepath.Base(postcard.ImagePath))
+		}
+		if postcard.ThumbnailPath != nil && *postcard.ThumbnailPath != "" {
+			names = append(names, filepath.Base(*postcard.ThumbnailPath))
+		}
+		for _, name := range names {
+			files, err := w.drive.FindFilesByName(ctx, accessToken, name)
+			if err != nil {
+				return 0, err
+			}
+			for _, file := range files {
+				fileIDs[file.ID] = true
+			}
+		}
+	}
+
+	deleted := 0
+	for fileID := range fileIDs {
+		if err := w.drive.DeleteFile(ctx, accessToken, fileID); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	log.Printf("[BackupWorker] Deleted %d Drive files for %d postcards", deleted, len(postcards))
+	return deleted, nil
+}
+
 // mediaTypeToMimeType maps semantic media_type values ("image", "video") to actual MIME types
 func mediaTypeToMimeType(mediaType string) string {
 	switch mediaType {
@@ -464,6 +547,30 @@ func mimeTypeFromPath(path string) string {
 	}
 }
 
+// readMedia reads a postcard media file from the configured MediaStore.
+// Paths the store does not recognize (legacy relative paths) are read from disk.
+func (w *BackupWorker) readMedia(path string) ([]byte, error) {
+	if w.media == nil {
+		return readMediaFile(path)
+	}
+	key, ok := w.media.KeyFromURL(path)
+	if !ok {
+		return readMediaFile(path)
+	}
+
+	r, err := w.media.Get(context.Background(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read media file %s: %w", path, err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read media file %s: %w", path, err)
+	}
+	return content, nil
+}
+
 // readMediaFile reads the content of a media file from local disk.
 // For MVP, path is relative to the uploads directory (e.g., "/uploads/postcard.jpg").
 // In production, this could be adapted for cloud storage (S3, GCS, etc.).
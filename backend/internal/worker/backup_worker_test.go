@@ -6,6 +6,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -25,6 +26,8 @@ type MockDriveService struct {
 	RefreshTokenFunc        func(ctx context.Context, refreshToken string) (*services.TokenResponse, error)
 	DecryptTokenFunc        func(ciphertext string) (string, error)
 	IsTokenExpiredFunc      func(expiry time.Time) bool
+	FindFilesByNameFunc     func(ctx context.Context, accessToken, name string) ([]services.DriveFileResponse, error)
+	DeleteFileFunc          func(ctx context.Context, accessToken, fileID string) error
 }
 
 func (m *MockDriveService) UploadFile(ctx context.Context, accessToken string, content []byte, mimeType, idempotencyKey string) (*services.UploadResult, error) {
@@ -72,6 +75,20 @@ func (m *MockDriveService) IsTokenExpired(expiry time.Time) bool {
 	return time.Now().After(expiry)
 }
 
+func (m *MockDriveService) FindFilesByName(ctx context.Context, accessToken, name string) ([]services.DriveFileResponse, error) {
+	if m.FindFilesByNameFunc != nil {
+		return m.FindFilesByNameFunc(ctx, accessToken, name)
+	}
+	return nil, nil
+}
+
+func (m *MockDriveService) DeleteFile(ctx context.Context, accessToken, fileID string) error {
+	if m.DeleteFileFunc != nil {
+		return m.DeleteFileFunc(ctx, accessToken, fileID)
+	}
+	return nil
+}
+
 // setupTestDB creates a test database connection
 func setupTestDB(t *testing.T) *sql.DB {
 	db, err := sql.Open("postgres", "host=localhost port=5432 user=user password=password dbname=milegame_test sslmode=disable")
@@ -720,6 +737,66 @@ func TestBackupWorker_ProcessJob_RefreshTokenRotation(t *testing.T) {
 
 // ========== Tests for worker lifecycle ==========
 
+func TestBackupWorker_DeletePostcardBackups(t *testing.T) {
+	worker, _, mockDrive, db, uploadsDir := setupTestWorker(t)
+	if worker == nil {
+		return
+	}
+	defer func() {
+		cleanupDriveTestData(t, db)
+		os.RemoveAll(uploadsDir)
+	}()
+	defer db.Close()
+
+	user := createTestUserForWorker(t, db)
+	event := createTestEventWithDrive(t, db, user.ID)
+	player := createTestPlayer(t, db, event.ID)
+	postcard := createTestPostcard(t, db, event.ID, &player.ID, uploadsDir)
+	thumbnail := "/uploads/postcards/thumbnails/" + uuid.New().String() + ".jpg"
+	postcard.ThumbnailPath = &thumbnail
+
+	// Nothing can be deleted without a Drive connection
+	syncedID := "synced-file-id"
+	jobs := []models.BackupJob{{ID: uuid.New(), PostcardID: postcard.ID, Status: models.BackupJobStatusSynced, DriveFileID: &syncedID}}
+	if _, err := worker.DeletePostcardBackups(context.Background(), user.ID, []models.Postcard{*postcard}, jobs); err != services.ErrDriveNotConnected {
+		t.Fatalf("Expected ErrDriveNotConnected, got %v", err)
+	}
+
+	encryptedAccess, _ := services.EncryptToken("test-access-token")
+	encryptedRefresh, _ := services.EncryptToken("test-refresh-token")
+	createTestDriveConnection(t, db, user.ID, encryptedAccess, encryptedRefresh)
+
+	mockDrive.FindFilesByNameFunc = func(ctx context.Context, accessToken, name string) ([]services.DriveFileResponse, error) {
+		switch name {
+		case filepath.Base(postcard.ImagePath):
+			// The copy recorded in the job plus a duplicate left by a retry
+			return []services.DriveFileResponse{{ID: syncedID}, {ID: "retry-copy-id"}}, nil
+		case filepath.Base(thumbnail):
+			return []services.DriveFileResponse{{ID: "thumbnail-file-id"}}, nil
+		}
+		return nil, nil
+	}
+	var deleted []string
+	mockDrive.DeleteFileFunc = func(ctx context.Context, accessToken, fileID string) error {
+		deleted = append(deleted, fileID)
+		return nil
+	}
+
+	count, err := worker.DeletePostcardBackups(context.Background(), user.ID, []models.Postcard{*postcard}, jobs)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if count != 3 || len(deleted) != 3 {
+		t.Errorf("Expected 3 Drive files deleted, got %d (%v)", count, deleted)
+	}
+
+	// Without backup jobs there is nothing to look up in Drive
+	deleted = nil
+	if count, err := worker.DeletePostcardBackups(context.Background(), user.ID, []models.Postcard{*postcard}, nil); err != nil || count != 0 || len(deleted) != 0 {
+		t.Errorf("Expected no Drive calls without backup jobs, got count=%d err=%v deleted=%v", count, err, deleted)
+	}
+}
+
 func TestBackupWorker_StartStop(t *testing.T) {
 	cfg := &models.DriveBackupConfig{
 		Enabled:       true,
@@ -808,3 +885,37 @@ func TestMediaTypeToMimeType(t *testing.T) {
 		})
 	}
 }
+
+// ========== Tests for readMedia ==========
+
+func TestBackupWorker_ReadMediaFromStore(t *testing.T) {
+	storeDir := t.TempDir()
+	store := services.NewLocalMediaStore(storeDir)
+	if err := store.Put(context.Background(), "postcards/a.jpg", strings.NewReader("from-store"), -1, "image/jpeg"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// Legacy relative paths still resolve against UPLOADS_DIR
+	legacyDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(legacyDir, "legacy.jpg"), []byte("from-disk"), 0644); err != nil {
+		t.Fatalf("Failed to write legacy file: %v", err)
+	}
+	t.Setenv("UPLOADS_DIR", legacyDir)
+
+	w := NewBackupWorker(&models.DriveBackupConfig{}, nil, nil, 1)
+	w.SetMediaStore(store)
+
+	content, err := w.readMedia("/uploads/postcards/a.jpg")
+	if err != nil || string(content) != "from-store" {
+		t.Errorf("readMedia(store) = %q, %v; want from-store", content, err)
+	}
+
+	content, err = w.readMedia("legacy.jpg")
+	if err != nil || string(content) != "from-disk" {
+		t.Errorf("readMedia(legacy) = %q, %v; want from-disk", content, err)
+	}
+
+	if _, err := w.readMedia("/uploads/postcards/missing.jpg"); !errors.Is(err, services.ErrMediaNotFound) {
+		t.Errorf("readMedia(missing) err = %v, want ErrMediaNotFound", err)
+	}
+}
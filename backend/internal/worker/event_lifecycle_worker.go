@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+const (
+	// EventLifecycleInterval is how often events are moved along their lifecycle
+	EventLifecycleInterval = 30 * time.Second
+
+	// EventArchiveAfter is how long an event stays ended (read-only) before it is archived
+	EventArchiveAfter = 30 * 24 * time.Hour
+
+	// eventLifecycleBatchSize is the number of events advanced per query
+	eventLifecycleBatchSize = 50
+)
+
+// EventLifecycleRepo applies the automatic status transitions that are due.
+// Each transition must be returned by exactly one call, even across instances.
+type EventLifecycleRepo interface {
+	AdvanceDueStatuses(archiveAfter time.Duration, limit int) ([]models.EventStatusChange, error)
+}
+
+// EventStatusBroadcaster notifies an event's room of a status change
+type EventStatusBroadcaster interface {
+	BroadcastEventStatusToRoom(eventSlug string, from, to models.EventStatus)
+}
+
+// EventLifecycleWorker moves events from scheduled to live to ended based on
+// StartsAt/EndsAt, and archives ended events after archiveAfter.
+type EventLifecycleWorker struct {
+	repo         EventLifecycleRepo
+	hub          EventStatusBroadcaster
+	interval     time.Duration
+	archiveAfter time.Duration
+	stopChan     chan struct{}
+	wg           sync.WaitGroup
+	running      int32 // atomic
+	mu           sync.Mutex
+}
+
+// NewEventLifecycleWorker creates a new event lifecycle worker.
+// archiveAfter <= 0 disables automatic archiving.
+func NewEventLifecycleWorker(repo EventLifecycleRepo, hub EventStatusBroadcaster, interval, archiveAfter time.Duration) *EventLifecycleWorker {
+	if interval <= 0 {
+		interval = EventLifecycleInterval
+	}
+
+	return &EventLifecycleWorker{
+		repo:         repo,
+		hub:          hub,
+		interval:     interval,
+		archiveAfter: archiveAfter,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins advancing event statuses
+func (w *EventLifecycleWorker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 0, 1) {
+		// Already running
+		return
+	}
+
+	log.Printf("[EventLifecycle] Starting (every %s, archive after %s)", w.interval, w.archiveAfter)
+
+	w.wg.Add(1)
+	go w.loop()
+}
+
+// Stop gracefully stops the worker
+func (w *EventLifecycleWorker) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 1, 0) {
+		// Not running
+		return
+	}
+
+	log.Printf("[EventLifecycle] Stopping...")
+	close(w.stopChan)
+	w.wg.Wait()
+	log.Printf("[EventLifecycle] Stopped")
+}
+
+// loop advances statuses at start and on every tick
+func (w *EventLifecycleWorker) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.Advance()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.Advance()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// Advance applies every due transition and notifies the rooms.
+// Returns the number of events whose status changed.
+func (w *EventLifecycleWorker) Advance() int {
+	changed := 0
+	for {
+		changes, err := w.repo.AdvanceDueStatuses(w.archiveAfter, eventLifecycleBatchSize)
+		if err != nil {
+			log.Printf("[EventLifecycle] Error advancing events: %v", err)
+			return changed
+		}
+
+		for _, change := range changes {
+			log.Printf("[EventLifecycle] %s: %s → %s", change.EventSlug, change.From, change.To)
+			if w.hub != nil {
+				w.hub.BroadcastEventStatusToRoom(change.EventSlug, change.From, change.To)
+			}
+		}
+		changed += len(changes)
+
+		if len(changes) < eventLifecycleBatchSize {
+			return changed
+		}
+	}
+}
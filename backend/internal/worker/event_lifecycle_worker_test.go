@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// mockEventLifecycleRepo applies Event.DueStatus to its events, like the SQL candidates query
+type mockEventLifecycleRepo struct {
+	events []*models.Event
+}
+
+func (m *mockEventLifecycleRepo) AdvanceDueStatuses(archiveAfter time.Duration, limit int) ([]models.EventStatusChange, error) {
+	now := time.Now()
+	var changes []models.EventStatusChange
+	for _, e := range m.events {
+		next := e.DueStatus(now, archiveAfter)
+		if next == e.Status || len(changes) == limit {
+			continue
+		}
+		changes = append(changes, models.EventStatusChange{EventID: e.ID, EventSlug: e.Slug, From: e.Status, To: next})
+		e.Status = next
+		e.StatusChangedAt = now
+	}
+	return changes, nil
+}
+
+type mockEventStatusHub struct {
+	changes map[string][2]models.EventStatus
+}
+
+func (h *mockEventStatusHub) BroadcastEventStatusToRoom(eventSlug string, from, to models.EventStatus) {
+	h.changes[eventSlug] = [2]models.EventStatus{from, to}
+}
+
+func TestEventLifecycleWorker_Advance(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	longAgo := now.Add(-EventArchiveAfter - time.Hour)
+
+	newEvent := func(slug string, status models.EventStatus, startsAt, endsAt *time.Time, changedAt time.Time) *models.Event {
+		return &models.Event{ID: uuid.New(), Slug: slug, Status: status, StartsAt: startsAt, EndsAt: endsAt, StatusChangedAt: changedAt}
+	}
+	repo := &mockEventLifecycleRepo{events: []*models.Event{
+		newEvent("starts", models.EventStatusScheduled, &past, &future, now),
+		newEvent("not-yet", models.EventStatusScheduled, &future, nil, now),
+		newEvent("ends", models.EventStatusLive, &past, &past, now),
+		newEvent("open-ended", models.EventStatusLive, &past, nil, now),
+		newEvent("missed", models.EventStatusScheduled, &past, &past, now),
+		newEvent("old", models.EventStatusEnded, &past, &past, longAgo),
+		newEvent("recent", models.EventStatusEnded, &past, &past, now),
+		newEvent("draft", models.EventStatusDraft, &past, &past, longAgo),
+		newEvent("ended-early", models.EventStatusEnded, &past, &future, now),
+	}}
+	hub := &mockEventStatusHub{changes: map[string][2]models.EventStatus{}}
+
+	w := NewEventLifecycleWorker(repo, hub, 0, EventArchiveAfter)
+	if n := w.Advance(); n != 4 {
+		t.Errorf("changed = %d, want 4", n)
+	}
+
+	want := map[string][2]models.EventStatus{
+		"starts": {models.EventStatusScheduled, models.EventStatusLive},
+		"ends":   {models.EventStatusLive, models.EventStatusEnded},
+		"missed": {models.EventStatusScheduled, models.EventStatusEnded},
+		"old":    {models.EventStatusEnded, models.EventStatusArchived},
+	}
+	for slug, transition := range want {
+		if got := hub.changes[slug]; got != transition {
+			t.Errorf("%s: broadcast %v, want %v", slug, got, transition)
+		}
+	}
+	for _, slug := range []string{"not-yet", "open-ended", "recent", "draft", "ended-early"} {
+		if _, ok := hub.changes[slug]; ok {
+			t.Errorf("%s must not change status", slug)
+		}
+	}
+
+	// Nothing left: a second run is a no-op
+	if n := w.Advance(); n != 0 {
+		t.Errorf("second run changed = %d, want 0", n)
+	}
+}
+
+func TestEventLifecycleWorker_NoArchiving(t *testing.T) {
+	past := time.Now().Add(-EventArchiveAfter - time.Hour)
+	repo := &mockEventLifecycleRepo{events: []*models.Event{
+		{ID: uuid.New(), Slug: "old", Status: models.EventStatusEnded, EndsAt: &past, StatusChangedAt: past},
+	}}
+
+	w := NewEventLifecycleWorker(repo, nil, 0, 0)
+	if n := w.Advance(); n != 0 {
+		t.Errorf("changed = %d, want 0 with archiving disabled", n)
+	}
+}
@@ -0,0 +1,331 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+const (
+	// MaxExportAttempts is the number of automatic attempts before an export fails
+	MaxExportAttempts = 3
+
+	// ExportTimeout bounds a single attempt (reading every media file and uploading the ZIP)
+	ExportTimeout = 30 * time.Minute
+
+	// ExportStaleAfter is how long an export may stay in_progress before it is
+	// assumed abandoned (e.g. the API restarted mid-export) and re-queued
+	ExportStaleAfter = 2 * ExportTimeout
+
+	// ExportRetention is how long a finished archive can be downloaded before
+	// it is deleted from the MediaStore
+	ExportRetention = 7 * 24 * time.Hour
+
+	// ExportCleanupInterval is how often expired archives are deleted
+	ExportCleanupInterval = time.Hour
+
+	// exportCleanupBatchSize is the number of expired exports removed per query
+	exportCleanupBatchSize = 100
+
+	// exportArchivePrefix is the MediaStore prefix of finished archives
+	exportArchivePrefix = "exports/"
+)
+
+// EventExportRepo defines the export job persistence needed by the worker
+type EventExportRepo interface {
+	ClaimQueued(limit int) ([]models.EventExport, error)
+	RequeueStale(olderThan time.Duration) (int64, error)
+	UpdateProgress(id uuid.UUID, processed, total int) error
+	Complete(id uuid.UUID, archiveKey string, sizeBytes int64, expiresAt time.Time) error
+	Requeue(id uuid.UUID, lastError string, delay time.Duration) error
+	Fail(id uuid.UUID, lastError string) error
+	ListExpired(limit int) ([]models.EventExport, error)
+	MarkExpired(id uuid.UUID) error
+}
+
+// EventArchiveBuilder writes the ZIP archive of an event
+type EventArchiveBuilder interface {
+	BuildArchive(ctx context.Context, eventID uuid.UUID, w io.Writer, progress services.ArchiveProgressFunc) error
+}
+
+// ExportWorker builds event archives in the background. Exports run one at a
+// time (they read every media file of the event), report their progress to the
+// database and are stored in the MediaStore until they expire.
+type ExportWorker struct {
+	repo      EventExportRepo
+	builder   EventArchiveBuilder
+	media     services.MediaStore
+	retention time.Duration
+	notify    chan struct{}
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+	running   int32 // atomic
+	mu        sync.Mutex
+}
+
+// NewExportWorker creates a new export worker
+func NewExportWorker(repo EventExportRepo, builder EventArchiveBuilder, media services.MediaStore, retention time.Duration) *ExportWorker {
+	if retention <= 0 {
+		retention = ExportRetention
+	}
+
+	return &ExportWorker{
+		repo:      repo,
+		builder:   builder,
+		media:     media,
+		retention: retention,
+		notify:    make(chan struct{}, 1),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start begins processing exports and the periodic cleanup of expired archives
+func (w *ExportWorker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 0, 1) {
+		// Already running
+		return
+	}
+
+	log.Printf("[ExportWorker] Starting (archives kept for %s)", w.retention)
+
+	if n, err := w.repo.RequeueStale(ExportStaleAfter); err != nil {
+		log.Printf("[ExportWorker] Error re-queuing stale exports: %v", err)
+	} else if n > 0 {
+		log.Printf("[ExportWorker] Re-queued %d stale exports", n)
+	}
+
+	w.wg.Add(2)
+	go w.loop()
+	go w.cleanupLoop()
+}
+
+// Stop gracefully stops the worker, waiting for the export in progress
+func (w *ExportWorker) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 1, 0) {
+		// Not running
+		return
+	}
+
+	log.Printf("[ExportWorker] Stopping...")
+	close(w.stopChan)
+	w.wg.Wait()
+	log.Printf("[ExportWorker] Stopped")
+}
+
+// Wake makes the worker poll the queue now instead of waiting for the next
+// tick (e.g. right after an export was requested). Never blocks.
+func (w *ExportWorker) Wake() {
+	select {
+	case w.notify <- struct{}{}:
+	default:
+		// Already signalled
+	}
+}
+
+// loop processes queued exports on every tick or wake-up
+func (w *ExportWorker) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(WorkerPollInterval)
+	defer ticker.Stop()
+
+	w.ProcessQueued()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.ProcessQueued()
+		case <-w.notify:
+			w.ProcessQueued()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// ProcessQueued claims and builds queued exports one by one until the queue
+// is empty. Returns how many exports were processed.
+func (w *ExportWorker) ProcessQueued() int {
+	processed := 0
+	for {
+		select {
+		case <-w.stopChan:
+			return processed
+		default:
+		}
+
+		jobs, err := w.repo.ClaimQueued(1)
+		if err != nil {
+			log.Printf("[ExportWorker] Error claiming exports: %v", err)
+			return processed
+		}
+		if len(jobs) == 0 {
+			return processed
+		}
+
+		job := &jobs[0]
+		log.Printf("[ExportWorker] Building export %s of event %s (attempt %d)", job.ID, job.EventID, job.Attempts)
+		w.processJob(job)
+		processed++
+	}
+}
+
+// processJob runs one attempt of an export and records the outcome
+func (w *ExportWorker) processJob(job *models.EventExport) {
+	ctx, cancel := context.WithTimeout(context.Background(), ExportTimeout)
+	defer cancel()
+
+	key, size, err := w.buildArchive(ctx, job)
+	if err != nil {
+		w.handleFailure(job, err)
+		return
+	}
+
+	expiresAt := time.Now().Add(w.retention)
+	if err := w.repo.Complete(job.ID, key, size, expiresAt); err != nil {
+		// The archive is orphaned: the next attempt builds it again
+		w.media.Delete(context.Background(), key)
+		w.handleFailure(job, fmt.Errorf("failed to save export: %w", err))
+		return
+	}
+
+	log.Printf("[ExportWorker] Export %s completed (%d bytes), available until %s", job.ID, size, expiresAt.Format(time.RFC3339))
+}
+
+// buildArchive writes the ZIP to a temp file and uploads it to the MediaStore.
+// The key is random so it can't be derived from the export or event IDs.
+func (w *ExportWorker) buildArchive(ctx context.Context, job *models.EventExport) (string, int64, error) {
+	tmp, err := os.CreateTemp("", "event-export-*.zip")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := w.builder.BuildArchive(ctx, job.EventID, tmp, w.progressReporter(job.ID)); err != nil {
+		return "", 0, fmt.Errorf("failed to build archive: %w", err)
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to measure archive: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", 0, fmt.Errorf("failed to rewind archive: %w", err)
+	}
+
+	key := exportArchivePrefix + uuid.New().String() + ".zip"
+	if err := w.media.Put(ctx, key, tmp, size, "application/zip"); err != nil {
+		return "", 0, fmt.Errorf("failed to store archive: %w", err)
+	}
+	return key, size, nil
+}
+
+// progressReporter saves the progress of an export, at most once per percent
+func (w *ExportWorker) progressReporter(id uuid.UUID) services.ArchiveProgressFunc {
+	lastPercent := -1
+	return func(done, total int) {
+		if total <= 0 {
+			return
+		}
+		percent := done * 100 / total
+		if percent == lastPercent {
+			return
+		}
+		lastPercent = percent
+		if err := w.repo.UpdateProgress(id, done, total); err != nil {
+			log.Printf("[ExportWorker] Warning: failed to save progress of export %s: %v", id, err)
+		}
+	}
+}
+
+// handleFailure re-queues the export with exponential backoff, or fails it
+// once MaxExportAttempts is reached
+func (w *ExportWorker) handleFailure(job *models.EventExport, err error) {
+	if job.Attempts < MaxExportAttempts {
+		backoff := BaseBackoff * time.Duration(1<<job.Attempts)
+		log.Printf("[ExportWorker] Export %s failed (attempt %d/%d), retrying in %s: %v", job.ID, job.Attempts, MaxExportAttempts, backoff, err)
+		if requeueErr := w.repo.Requeue(job.ID, err.Error(), backoff); requeueErr != nil {
+			log.Printf("[ExportWorker] Warning: failed to re-queue export %s: %v", job.ID, requeueErr)
+		}
+		return
+	}
+
+	log.Printf("[ExportWorker] Export %s failed after %d attempts: %v", job.ID, job.Attempts, err)
+	if failErr := w.repo.Fail(job.ID, err.Error()); failErr != nil {
+		log.Printf("[ExportWorker] Warning: failed to mark export %s as failed: %v", job.ID, failErr)
+	}
+}
+
+// cleanupLoop deletes expired archives at start and on every tick
+func (w *ExportWorker) cleanupLoop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(ExportCleanupInterval)
+	defer ticker.Stop()
+
+	w.CleanupExpired()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.CleanupExpired()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// CleanupExpired deletes the archives of expired exports and returns how many
+// were removed. An archive that can't be deleted is retried on the next run.
+func (w *ExportWorker) CleanupExpired() int {
+	removed := 0
+	for {
+		exports, err := w.repo.ListExpired(exportCleanupBatchSize)
+		if err != nil {
+			log.Printf("[ExportWorker] Error listing expired exports: %v", err)
+			break
+		}
+
+		deleted := 0
+		for _, export := range exports {
+			if export.ArchiveKey != nil {
+				if err := w.media.Delete(context.Background(), *export.ArchiveKey); err != nil {
+					log.Printf("[ExportWorker] Warning: failed to delete archive of export %s: %v", export.ID, err)
+					continue
+				}
+			}
+			if err := w.repo.MarkExpired(export.ID); err != nil {
+				log.Printf("[ExportWorker] Warning: failed to mark export %s as expired: %v", export.ID, err)
+				continue
+			}
+			deleted++
+		}
+		removed += deleted
+
+		// A short batch means we are done; no progress means every delete failed
+		if len(exports) < exportCleanupBatchSize || deleted == 0 {
+			break
+		}
+	}
+
+	if removed > 0 {
+		log.Printf("[ExportWorker] Removed %d expired archives", removed)
+	}
+	return removed
+}
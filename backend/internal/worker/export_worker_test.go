@@ -0,0 +1,197 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// mockEventExportRepo keeps export jobs in memory
+type mockEventExportRepo struct {
+	mu        sync.Mutex
+	queued    []models.EventExport
+	expired   []models.EventExport
+	progress  [][2]int
+	completed map[uuid.UUID]string // export ID -> archive key
+	requeued  map[uuid.UUID]time.Duration
+	failed    map[uuid.UUID]string
+	marked    []uuid.UUID
+}
+
+func newMockEventExportRepo(queued ...models.EventExport) *mockEventExportRepo {
+	return &mockEventExportRepo{
+		queued:    queued,
+		completed: map[uuid.UUID]string{},
+		requeued:  map[uuid.UUID]time.Duration{},
+		failed:    map[uuid.UUID]string{},
+	}
+}
+
+func (m *mockEventExportRepo) ClaimQueued(limit int) ([]models.EventExport, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if limit > len(m.queued) {
+		limit = len(m.queued)
+	}
+	claimed := m.queued[:limit]
+	m.queued = m.queued[limit:]
+	for i := range claimed {
+		claimed[i].Status = models.EventExportStatusInProgress
+		claimed[i].Attempts++
+	}
+	return claimed, nil
+}
+
+func (m *mockEventExportRepo) RequeueStale(olderThan time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockEventExportRepo) UpdateProgress(id uuid.UUID, processed, total int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.progress = append(m.progress, [2]int{processed, total})
+	return nil
+}
+
+func (m *mockEventExportRepo) Complete(id uuid.UUID, archiveKey string, sizeBytes int64, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.completed[id] = archiveKey
+	return nil
+}
+
+func (m *mockEventExportRepo) Requeue(id uuid.UUID, lastError string, delay time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requeued[id] = delay
+	return nil
+}
+
+func (m *mockEventExportRepo) Fail(id uuid.UUID, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed[id] = lastError
+	return nil
+}
+
+func (m *mockEventExportRepo) ListExpired(limit int) ([]models.EventExport, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expired := m.expired
+	m.expired = nil
+	return expired, nil
+}
+
+func (m *mockEventExportRepo) MarkExpired(id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.marked = append(m.marked, id)
+	return nil
+}
+
+// fakeArchiveBuilder writes a fixed archive in three steps
+type fakeArchiveBuilder struct {
+	err error
+}
+
+func (b *fakeArchiveBuilder) BuildArchive(ctx context.Context, eventID uuid.UUID, w io.Writer, progress services.ArchiveProgressFunc) error {
+	if b.err != nil {
+		return b.err
+	}
+	for i := 0; i <= 3; i++ {
+		progress(i, 3)
+	}
+	_, err := io.WriteString(w, "zip:"+eventID.String())
+	return err
+}
+
+func TestExportWorker_ProcessQueued_Success(t *testing.T) {
+	store := services.NewLocalMediaStore(t.TempDir())
+	job := models.EventExport{ID: uuid.New(), EventID: uuid.New()}
+	repo := newMockEventExportRepo(job)
+	w := NewExportWorker(repo, &fakeArchiveBuilder{}, store, time.Hour)
+
+	if n := w.ProcessQueued(); n != 1 {
+		t.Fatalf("ProcessQueued() = %d, want 1", n)
+	}
+
+	key, ok := repo.completed[job.ID]
+	if !ok {
+		t.Fatalf("export was not completed (failed: %v, requeued: %v)", repo.failed, repo.requeued)
+	}
+	if !strings.HasPrefix(key, "exports/") || strings.Contains(key, job.ID.String()) || strings.Contains(key, job.EventID.String()) {
+		t.Errorf("archive key = %q, want a random key under exports/", key)
+	}
+
+	r, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("archive not stored: %v", err)
+	}
+	content, _ := io.ReadAll(r)
+	r.Close()
+	if string(content) != "zip:"+job.EventID.String() {
+		t.Errorf("archive content = %q", content)
+	}
+
+	if len(repo.progress) != 4 || repo.progress[3] != [2]int{3, 3} {
+		t.Errorf("progress updates = %v, want 0..3 of 3", repo.progress)
+	}
+}
+
+func TestExportWorker_ProcessQueued_RetriesThenFails(t *testing.T) {
+	store := services.NewLocalMediaStore(t.TempDir())
+	w := NewExportWorker(nil, &fakeArchiveBuilder{err: errors.New("disk full")}, store, time.Hour)
+
+	// First attempts are re-queued with backoff
+	first := models.EventExport{ID: uuid.New(), EventID: uuid.New()}
+	w.repo = newMockEventExportRepo(first)
+	w.ProcessQueued()
+	repo := w.repo.(*mockEventExportRepo)
+	if delay, ok := repo.requeued[first.ID]; !ok || delay != 2*BaseBackoff {
+		t.Errorf("requeued = %v, want %s backoff", repo.requeued, 2*BaseBackoff)
+	}
+
+	// The last attempt fails the export
+	last := models.EventExport{ID: uuid.New(), EventID: uuid.New(), Attempts: MaxExportAttempts - 1}
+	w.repo = newMockEventExportRepo(last)
+	w.ProcessQueued()
+	repo = w.repo.(*mockEventExportRepo)
+	if msg := repo.failed[last.ID]; !strings.Contains(msg, "disk full") {
+		t.Errorf("failed = %v, want the build error", repo.failed)
+	}
+	if len(repo.completed) != 0 {
+		t.Errorf("completed = %v, want none", repo.completed)
+	}
+}
+
+func TestExportWorker_CleanupExpired(t *testing.T) {
+	ctx := context.Background()
+	store := services.NewLocalMediaStore(t.TempDir())
+	key := "exports/" + uuid.New().String() + ".zip"
+	if err := store.Put(ctx, key, strings.NewReader("zip"), -1, "application/zip"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	expired := models.EventExport{ID: uuid.New(), ArchiveKey: &key}
+	repo := newMockEventExportRepo()
+	repo.expired = []models.EventExport{expired}
+	w := NewExportWorker(repo, &fakeArchiveBuilder{}, store, time.Hour)
+
+	if n := w.CleanupExpired(); n != 1 {
+		t.Fatalf("CleanupExpired() = %d, want 1", n)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, services.ErrMediaNotFound) {
+		t.Errorf("archive still stored: %v", err)
+	}
+	if len(repo.marked) != 1 || repo.marked[0] != expired.ID {
+		t.Errorf("marked = %v, want the expired export", repo.marked)
+	}
+}
@@ -0,0 +1,356 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+const (
+	// MaxMediaJobAttempts is the number of automatic attempts before a media job fails
+	MaxMediaJobAttempts = 3
+
+	// MediaJobTimeout bounds a single processing attempt (download, ffmpeg, upload)
+	MediaJobTimeout = 10 * time.Minute
+
+	// MediaJobStaleAfter is how long a job may stay in_progress before it is
+	// assumed abandoned (e.g. the API restarted mid-job) and re-queued
+	MediaJobStaleAfter = 2 * MediaJobTimeout
+
+	// MediaWorkerNumWorkers is the default number of concurrent ffmpeg jobs
+	MediaWorkerNumWorkers = 2
+)
+
+// MediaJobRepo defines the media job persistence needed by the worker
+type MediaJobRepo interface {
+	Create(postcardID uuid.UUID, sourceKey string) (*models.MediaJob, error)
+	ClaimQueued(limit int) ([]models.MediaJob, error)
+	RequeueStale(olderThan time.Duration) (int64, error)
+	Complete(job *models.MediaJob, video *models.ProcessedVideo) (*models.Postcard, error)
+	Requeue(jobID uuid.UUID, lastError string, delay time.Duration) error
+	Fail(job *models.MediaJob, lastError string) error
+}
+
+// VideoProcessor runs the ffmpeg steps of a media job on local files
+type VideoProcessor interface {
+	Transcode(ctx context.Context, srcPath, dstPath string) error
+	Thumbnail(ctx context.Context, videoPath, thumbnailPath string) error
+	Duration(ctx context.Context, videoPath string) (int, error)
+}
+
+// MediaReadyFunc is called after a postcard's media finished processing
+type MediaReadyFunc func(postcard *models.Postcard, eventSlug string)
+
+// MediaWorker processes uploaded postcard videos in the background: it
+// transcodes them to H.264/MP4, generates the thumbnail and measures the
+// duration, so uploads don't block on ffmpeg.
+type MediaWorker struct {
+	repo      MediaJobRepo
+	media     services.MediaStore
+	processor VideoProcessor
+	onReady   MediaReadyFunc
+	poolSize  int
+	jobQueue  chan *models.MediaJob
+	notify    chan struct{}
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+	running   int32 // atomic
+	mu        sync.Mutex
+}
+
+// NewMediaWorker creates a new media worker
+func NewMediaWorker(repo MediaJobRepo, media services.MediaStore, processor VideoProcessor, poolSize int) *MediaWorker {
+	if poolSize <= 0 {
+		poolSize = MediaWorkerNumWorkers
+	}
+
+	return &MediaWorker{
+		repo:      repo,
+		media:     media,
+		processor: processor,
+		poolSize:  poolSize,
+		jobQueue:  make(chan *models.MediaJob, poolSize),
+		notify:    make(chan struct{}, 1),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// OnReady sets the callback run when a postcard becomes ready (broadcast, backups)
+func (w *MediaWorker) OnReady(fn MediaReadyFunc) {
+	w.onReady = fn
+}
+
+// Start begins processing jobs
+func (w *MediaWorker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 0, 1) {
+		// Already running
+		return
+	}
+
+	log.Printf("[MediaWorker] Starting with %d workers", w.poolSize)
+
+	if n, err := w.repo.RequeueStale(MediaJobStaleAfter); err != nil {
+		log.Printf("[MediaWorker] Error re-queuing stale jobs: %v", err)
+	} else if n > 0 {
+		log.Printf("[MediaWorker] Re-queued %d stale jobs", n)
+	}
+
+	for i := 0; i < w.poolSize; i++ {
+		w.wg.Add(1)
+		go w.worker(i)
+	}
+
+	w.wg.Add(1)
+	go w.jobFetcher()
+}
+
+// Stop gracefully stops the worker. Jobs already claimed but not started stay
+// in_progress and are re-queued as stale on the next start.
+func (w *MediaWorker) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 1, 0) {
+		// Not running
+		return
+	}
+
+	log.Printf("[MediaWorker] Stopping...")
+	close(w.stopChan)
+	w.wg.Wait()
+	log.Printf("[MediaWorker] Stopped")
+}
+
+// EnqueueMediaJob queues the processing of a postcard video stored under
+// sourceKey and wakes up the fetcher
+func (w *MediaWorker) EnqueueMediaJob(postcardID uuid.UUID, sourceKey string) error {
+	job, err := w.repo.Create(postcardID, sourceKey)
+	if err != nil {
+		return fmt.Errorf("failed to create media job: %w", err)
+	}
+	log.Printf("[MediaWorker] Queued job %s for postcard %s", job.ID, postcardID)
+
+	w.Wake()
+	return nil
+}
+
+// Wake makes the fetcher poll the queue now instead of waiting for the next
+// tick (e.g. after a job was re-queued from the admin API). Never blocks.
+func (w *MediaWorker) Wake() {
+	select {
+	case w.notify <- struct{}{}:
+	default:
+		// Already signalled
+	}
+}
+
+// worker processes jobs from the queue
+func (w *MediaWorker) worker(id int) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case job := <-w.jobQueue:
+			log.Printf("[MediaWorker:%d] Processing job %s (attempt %d)", id, job.ID, job.Attempts)
+			w.processJob(job)
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// jobFetcher claims queued jobs on every tick or wake-up
+func (w *MediaWorker) jobFetcher() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(WorkerPollInterval)
+	defer ticker.Stop()
+
+	w.fetchJobs()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.fetchJobs()
+		case <-w.notify:
+			w.fetchJobs()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// fetchJobs claims as many jobs as there are idle workers and hands them over.
+// Claimed jobs are in_progress in the database, so other instances skip them.
+func (w *MediaWorker) fetchJobs() {
+	free := cap(w.jobQueue) - len(w.jobQueue)
+	if free <= 0 {
+		return
+	}
+
+	jobs, err := w.repo.ClaimQueued(free)
+	if err != nil {
+		log.Printf("[MediaWorker] Error claiming jobs: %v", err)
+		return
+	}
+
+	for i := range jobs {
+		select {
+		case w.jobQueue <- &jobs[i]:
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// processJob runs one attempt of a job and records the outcome
+func (w *MediaWorker) processJob(job *models.MediaJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), MediaJobTimeout)
+	defer cancel()
+
+	video, keys, err := w.processVideo(ctx, job)
+	if err != nil {
+		w.handleFailure(job, err)
+		return
+	}
+
+	postcard, err := w.repo.Complete(job, video)
+	if err != nil {
+		// The outputs are orphaned: the next attempt writes them again
+		for _, key := range keys {
+			w.media.Delete(context.Background(), key)
+		}
+		w.handleFailure(job, fmt.Errorf("failed to save processed video: %w", err))
+		return
+	}
+
+	if err := w.media.Delete(context.Background(), job.SourceKey); err != nil {
+		log.Printf("[MediaWorker] Warning: failed to delete original %s: %v", job.SourceKey, err)
+	}
+
+	log.Printf("[MediaWorker] Job %s completed, postcard %s ready", job.ID, job.PostcardID)
+
+	if w.onReady != nil {
+		w.onReady(postcard, job.EventSlug)
+	}
+}
+
+// handleFailure re-queues the job with exponential backoff, or fails it once
+// MaxMediaJobAttempts is reached
+func (w *MediaWorker) handleFailure(job *models.MediaJob, err error) {
+	if job.Attempts < MaxMediaJobAttempts {
+		backoff := BaseBackoff * time.Duration(1<<job.Attempts)
+		log.Printf("[MediaWorker] Job %s failed (attempt %d/%d), retrying in %s: %v", job.ID, job.Attempts, MaxMediaJobAttempts, backoff, err)
+		if requeueErr := w.repo.Requeue(job.ID, err.Error(), backoff); requeueErr != nil {
+			log.Printf("[MediaWorker] Warning: failed to re-queue job %s: %v", job.ID, requeueErr)
+		}
+		return
+	}
+
+	log.Printf("[MediaWorker] Job %s failed after %d attempts: %v", job.ID, job.Attempts, err)
+	if failErr := w.repo.Fail(job, err.Error()); failErr != nil {
+		log.Printf("[MediaWorker] Warning: failed to mark job %s as failed: %v", job.ID, failErr)
+	}
+}
+
+// processVideo downloads the original, runs ffmpeg and uploads the outputs.
+// Returns the stored keys so they can be cleaned up if saving fails.
+func (w *MediaWorker) processVideo(ctx context.Context, job *models.MediaJob) (*models.ProcessedVideo, []string, error) {
+	tmpDir, err := os.MkdirTemp("", "media-job-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	srcPath := filepath.Join(tmpDir, "source"+filepath.Ext(job.SourceKey))
+	if err := w.download(ctx, job.SourceKey, srcPath); err != nil {
+		return nil, nil, err
+	}
+
+	videoPath := filepath.Join(tmpDir, "video.mp4")
+	if err := w.processor.Transcode(ctx, srcPath, videoPath); err != nil {
+		return nil, nil, fmt.Errorf("failed to transcode video: %w", err)
+	}
+
+	name := job.PostcardID.String()
+	videoKey := "postcards/" + name + ".mp4"
+	if err := w.upload(ctx, videoKey, videoPath, "video/mp4"); err != nil {
+		return nil, nil, err
+	}
+	keys := []string{videoKey}
+	result := &models.ProcessedVideo{VideoPath: w.media.URL(videoKey)}
+
+	// Thumbnail and duration are optional: the video plays without them
+	thumbPath := filepath.Join(tmpDir, "thumbnail.jpg")
+	thumbKey := "postcards/thumbnails/" + name + ".jpg"
+	if err := w.processor.Thumbnail(ctx, videoPath, thumbPath); err != nil {
+		log.Printf("[MediaWorker] Warning: failed to generate thumbnail for job %s: %v", job.ID, err)
+	} else if err := w.upload(ctx, thumbKey, thumbPath, "image/jpeg"); err != nil {
+		log.Printf("[MediaWorker] Warning: failed to store thumbnail for job %s: %v", job.ID, err)
+	} else {
+		thumb := w.media.URL(thumbKey)
+		result.ThumbnailPath = &thumb
+		keys = append(keys, thumbKey)
+	}
+
+	if durationMs, err := w.processor.Duration(ctx, videoPath); err != nil {
+		log.Printf("[MediaWorker] Warning: failed to measure duration for job %s: %v", job.ID, err)
+	} else {
+		result.DurationMs = &durationMs
+	}
+
+	return result, keys, nil
+}
+
+// download copies a MediaStore object to a local file
+func (w *MediaWorker) download(ctx context.Context, key, path string) error {
+	r, err := w.media.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to read original %s: %w", key, err)
+	}
+	defer r.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	_, copyErr := io.Copy(f, r)
+	if closeErr := f.Close(); copyErr == nil {
+		copyErr = closeErr
+	}
+	if copyErr != nil {
+		return fmt.Errorf("failed to download original %s: %w", key, copyErr)
+	}
+	return nil
+}
+
+// upload stores a local file in the MediaStore
+func (w *MediaWorker) upload(ctx context.Context, key, path, contentType string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if err := w.media.Put(ctx, key, f, info.Size(), contentType); err != nil {
+		return fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return nil
+}
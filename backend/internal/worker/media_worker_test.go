@@ -0,0 +1,242 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// mockMediaJobRepo keeps media jobs in memory
+type mockMediaJobRepo struct {
+	mu        sync.Mutex
+	queued    []models.MediaJob
+	completed map[uuid.UUID]*models.ProcessedVideo
+	requeued  map[uuid.UUID]time.Duration
+	failed    map[uuid.UUID]string
+}
+
+func newMockMediaJobRepo() *mockMediaJobRepo {
+	return &mockMediaJobRepo{
+		completed: map[uuid.UUID]*models.ProcessedVideo{},
+		requeued:  map[uuid.UUID]time.Duration{},
+		failed:    map[uuid.UUID]string{},
+	}
+}
+
+func (m *mockMediaJobRepo) Create(postcardID uuid.UUID, sourceKey string) (*models.MediaJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := models.MediaJob{ID: uuid.New(), PostcardID: postcardID, EventSlug: "fiesta", SourceKey: sourceKey, Status: models.MediaJobStatusQueued}
+	m.queued = append(m.queued, job)
+	return &job, nil
+}
+
+func (m *mockMediaJobRepo) ClaimQueued(limit int) ([]models.MediaJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if limit > len(m.queued) {
+		limit = len(m.queued)
+	}
+	claimed := m.queued[:limit]
+	m.queued = m.queued[limit:]
+	for i := range claimed {
+		claimed[i].Status = models.MediaJobStatusInProgress
+		claimed[i].Attempts++
+	}
+	return claimed, nil
+}
+
+func (m *mockMediaJobRepo) RequeueStale(olderThan time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockMediaJobRepo) Complete(job *models.MediaJob, video *models.ProcessedVideo) (*models.Postcard, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.completed[job.ID] = video
+	return &models.Postcard{ID: job.PostcardID, ImagePath: video.VideoPath, ThumbnailPath: video.ThumbnailPath, MediaStatus: models.MediaStatusReady}, nil
+}
+
+func (m *mockMediaJobRepo) Requeue(jobID uuid.UUID, lastError string, delay time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requeued[jobID] = delay
+	return nil
+}
+
+func (m *mockMediaJobRepo) Fail(job *models.MediaJob, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed[job.ID] = lastError
+	return nil
+}
+
+// fakeVideoProcessor writes placeholder outputs instead of running ffmpeg
+type fakeVideoProcessor struct {
+	transcodeErr error
+	thumbnailErr error
+}
+
+func (p *fakeVideoProcessor) Transcode(ctx context.Context, srcPath, dstPath string) error {
+	if p.transcodeErr != nil {
+		return p.transcodeErr
+	}
+	src, err := os.ReadFile(srcPath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dstPath, append([]byte("h264:"), src...), 0644)
+}
+
+func (p *fakeVideoProcessor) Thumbnail(ctx context.Context, videoPath, thumbnailPath string) error {
+	if p.thumbnailErr != nil {
+		return p.thumbnailErr
+	}
+	return os.WriteFile(thumbnailPath, []byte("jpeg"), 0644)
+}
+
+func (p *fakeVideoProcessor) Duration(ctx context.Context, videoPath string) (int, error) {
+	return 12500, nil
+}
+
+func newTestMediaJob(t *testing.T, store services.MediaStore) *models.MediaJob {
+	t.Helper()
+	key := "postcards/originals/" + uuid.New().String() + ".webm"
+	if err := store.Put(context.Background(), key, strings.NewReader("webm"), -1, "video/webm"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	return &models.MediaJob{ID: uuid.New(), PostcardID: uuid.New(), EventSlug: "fiesta", SourceKey: key, Attempts: 1}
+}
+
+func TestMediaWorker_ProcessJob_Success(t *testing.T) {
+	dir := t.TempDir()
+	store := services.NewLocalMediaStore(dir)
+	repo := newMockMediaJobRepo()
+	w := NewMediaWorker(repo, store, &fakeVideoProcessor{}, 1)
+
+	var ready *models.Postcard
+	var readySlug string
+	w.OnReady(func(postcard *models.Postcard, eventSlug string) {
+		ready, readySlug = postcard, eventSlug
+	})
+
+	job := newTestMediaJob(t, store)
+	w.processJob(job)
+
+	video := repo.completed[job.ID]
+	if video == nil {
+		t.Fatalf("job not completed (requeued=%v failed=%v)", repo.requeued, repo.failed)
+	}
+	name := job.PostcardID.String()
+	if video.VideoPath != "/uploads/postcards/"+name+".mp4" {
+		t.Errorf("VideoPath = %q", video.VideoPath)
+	}
+	if video.ThumbnailPath == nil || *video.ThumbnailPath != "/uploads/postcards/thumbnails/"+name+".jpg" {
+		t.Errorf("ThumbnailPath = %v", video.ThumbnailPath)
+	}
+	if video.DurationMs == nil || *video.DurationMs != 12500 {
+		t.Errorf("DurationMs = %v, want 12500", video.DurationMs)
+	}
+
+	r, err := store.Get(context.Background(), "postcards/"+name+".mp4")
+	if err != nil {
+		t.Fatalf("transcoded video not stored: %v", err)
+	}
+	content, _ := io.ReadAll(r)
+	r.Close()
+	if string(content) != "h264:webm" {
+		t.Errorf("stored video = %q", content)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(job.SourceKey))); !os.IsNotExist(err) {
+		t.Errorf("original should be deleted after processing, stat err = %v", err)
+	}
+
+	if ready == nil || ready.ID != job.PostcardID || readySlug != "fiesta" {
+		t.Errorf("OnReady called with %v, %q", ready, readySlug)
+	}
+}
+
+func TestMediaWorker_ProcessJob_ThumbnailIsOptional(t *testing.T) {
+	store := services.NewLocalMediaStore(t.TempDir())
+	repo := newMockMediaJobRepo()
+	w := NewMediaWorker(repo, store, &fakeVideoProcessor{thumbnailErr: errors.New("no frame at 00:00:01")}, 1)
+
+	job := newTestMediaJob(t, store)
+	w.processJob(job)
+
+	video := repo.completed[job.ID]
+	if video == nil {
+		t.Fatal("job should complete without a thumbnail")
+	}
+	if video.ThumbnailPath != nil {
+		t.Errorf("ThumbnailPath = %v, want nil", *video.ThumbnailPath)
+	}
+}
+
+func TestMediaWorker_ProcessJob_RetriesThenFails(t *testing.T) {
+	dir := t.TempDir()
+	store := services.NewLocalMediaStore(dir)
+	repo := newMockMediaJobRepo()
+	w := NewMediaWorker(repo, store, &fakeVideoProcessor{transcodeErr: errors.New("invalid data found")}, 1)
+
+	readyCalled := false
+	w.OnReady(func(*models.Postcard, string) { readyCalled = true })
+
+	job := newTestMediaJob(t, store)
+	for attempt := 1; attempt <= MaxMediaJobAttempts; attempt++ {
+		job.Attempts = attempt
+		w.processJob(job)
+	}
+
+	if delay, ok := repo.requeued[job.ID]; !ok || delay != BaseBackoff*4 {
+		t.Errorf("last requeue delay = %v (%v), want %v", delay, ok, BaseBackoff*4)
+	}
+	if msg := repo.failed[job.ID]; !strings.Contains(msg, "invalid data found") {
+		t.Errorf("failed error = %q", msg)
+	}
+	if readyCalled || len(repo.completed) != 0 {
+		t.Error("a failed job must not complete")
+	}
+
+	// The original is kept so the job can be retried from the admin API
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(job.SourceKey))); err != nil {
+		t.Errorf("original should be kept after a failure: %v", err)
+	}
+}
+
+func TestMediaWorker_EnqueueProcessesJob(t *testing.T) {
+	store := services.NewLocalMediaStore(t.TempDir())
+	repo := newMockMediaJobRepo()
+	w := NewMediaWorker(repo, store, &fakeVideoProcessor{}, 1)
+
+	done := make(chan uuid.UUID, 1)
+	w.OnReady(func(postcard *models.Postcard, eventSlug string) { done <- postcard.ID })
+
+	w.Start()
+	defer w.Stop()
+
+	source := newTestMediaJob(t, store)
+	if err := w.EnqueueMediaJob(source.PostcardID, source.SourceKey); err != nil {
+		t.Fatalf("EnqueueMediaJob failed: %v", err)
+	}
+
+	select {
+	case id := <-done:
+		if id != source.PostcardID {
+			t.Errorf("ready postcard = %s, want %s", id, source.PostcardID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("job was not processed after being enqueued")
+	}
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// RescoreDebounce is how long the worker waits after a question change before
+// rescoring, so bursts (e.g. an import) trigger a single rescore per event
+const RescoreDebounce = 2 * time.Second
+
+// EventRescorer recalculates all scores of an event and broadcasts the ranking
+type EventRescorer interface {
+	RescoreEvent(eventID uuid.UUID, eventSlug string) (*models.RescoreResult, error)
+}
+
+// RescoreWorker rescoring jobs queued after quiz questions change.
+// Requests for the same event are coalesced: each event is rescored once
+// per debounce window, with one transaction and one ranking broadcast.
+type RescoreWorker struct {
+	rescorer EventRescorer
+	debounce time.Duration
+	pending  map[uuid.UUID]string // event ID -> slug
+	pendMu   sync.Mutex
+	notify   chan struct{}
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  int32 // atomic
+	mu       sync.Mutex
+}
+
+// NewRescoreWorker creates a new rescore worker
+func NewRescoreWorker(rescorer EventRescorer, debounce time.Duration) *RescoreWorker {
+	if debounce <= 0 {
+		debounce = RescoreDebounce
+	}
+
+	return &RescoreWorker{
+		rescorer: rescorer,
+		debounce: debounce,
+		pending:  make(map[uuid.UUID]string),
+		notify:   make(chan struct{}, 1),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins processing rescore requests
+func (w *RescoreWorker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 0, 1) {
+		// Already running
+		return
+	}
+
+	log.Printf("[RescoreWorker] Starting (debounce %s)", w.debounce)
+
+	w.wg.Add(1)
+	go w.loop()
+}
+
+// Stop gracefully stops the worker, running any pending rescore first
+func (w *RescoreWorker) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 1, 0) {
+		// Not running
+		return
+	}
+
+	log.Printf("[RescoreWorker] Stopping...")
+	close(w.stopChan)
+	w.wg.Wait()
+	log.Printf("[RescoreWorker] Stopped")
+}
+
+// EnqueueRescore schedules a rescore of the event. It never blocks; repeated
+// requests for the same event before the job runs are merged.
+func (w *RescoreWorker) EnqueueRescore(eventID uuid.UUID, eventSlug string) {
+	w.pendMu.Lock()
+	w.pending[eventID] = eventSlug
+	w.pendMu.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+		// Already signalled; the pending map holds the request
+	}
+}
+
+// loop waits for requests, lets the debounce window pass and flushes
+func (w *RescoreWorker) loop() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.stopChan:
+			w.flush()
+			return
+		case <-w.notify:
+			select {
+			case <-time.After(w.debounce):
+			case <-w.stopChan:
+				w.flush()
+				return
+			}
+			w.flush()
+		}
+	}
+}
+
+// flush rescores every pending event
+func (w *RescoreWorker) flush() {
+	w.pendMu.Lock()
+	pending := w.pending
+	w.pending = make(map[uuid.UUID]string)
+	w.pendMu.Unlock()
+
+	for eventID, slug := range pending {
+		result, err := w.rescorer.RescoreEvent(eventID, slug)
+		if err != nil {
+			log.Printf("[RescoreWorker] Event %s rescore failed: %v", eventID, err)
+			continue
+		}
+		log.Printf("[RescoreWorker] Event %s rescored: %d players, %d changed", eventID, result.Players, result.Changed)
+	}
+}
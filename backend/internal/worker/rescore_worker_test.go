@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+type mockRescorer struct {
+	mu    sync.Mutex
+	calls map[uuid.UUID]int
+	slugs map[uuid.UUID]string
+}
+
+func newMockRescorer() *mockRescorer {
+	return &mockRescorer{calls: make(map[uuid.UUID]int), slugs: make(map[uuid.UUID]string)}
+}
+
+func (m *mockRescorer) RescoreEvent(eventID uuid.UUID, eventSlug string) (*models.RescoreResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls[eventID]++
+	m.slugs[eventID] = eventSlug
+	return &models.RescoreResult{}, nil
+}
+
+func (m *mockRescorer) callsFor(eventID uuid.UUID) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[eventID]
+}
+
+func TestRescoreWorker_CoalescesBurstPerEvent(t *testing.T) {
+	rescorer := newMockRescorer()
+	worker := NewRescoreWorker(rescorer, 50*time.Millisecond)
+	worker.Start()
+	defer worker.Stop()
+
+	eventA := uuid.New()
+	eventB := uuid.New()
+
+	// Una importación dispara varios cambios seguidos
+	for i := 0; i < 5; i++ {
+		worker.EnqueueRescore(eventA, "event-a")
+	}
+	worker.EnqueueRescore(eventB, "event-b")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && (rescorer.callsFor(eventA) == 0 || rescorer.callsFor(eventB) == 0) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := rescorer.callsFor(eventA); got != 1 {
+		t.Errorf("event A rescored %d times, want 1", got)
+	}
+	if got := rescorer.callsFor(eventB); got != 1 {
+		t.Errorf("event B rescored %d times, want 1", got)
+	}
+	if rescorer.slugs[eventA] != "event-a" {
+		t.Errorf("event A slug = %q, want event-a", rescorer.slugs[eventA])
+	}
+
+	// Un cambio posterior vuelve a disparar el recalculo
+	worker.EnqueueRescore(eventA, "event-a")
+	deadline = time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && rescorer.callsFor(eventA) < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := rescorer.callsFor(eventA); got != 2 {
+		t.Errorf("event A rescored %d times after second change, want 2", got)
+	}
+}
+
+func TestRescoreWorker_StopFlushesPending(t *testing.T) {
+	rescorer := newMockRescorer()
+	worker := NewRescoreWorker(rescorer, time.Hour)
+	worker.Start()
+
+	eventID := uuid.New()
+	worker.EnqueueRescore(eventID, "event")
+	worker.Stop()
+
+	if got := rescorer.callsFor(eventID); got != 1 {
+		t.Errorf("pending event rescored %d times on Stop, want 1", got)
+	}
+}
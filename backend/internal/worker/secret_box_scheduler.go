@@ -0,0 +1,132 @@
+package worker
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+const (
+	// SecretBoxSchedulerInterval is how often due Secret Box reveals are checked
+	SecretBoxSchedulerInterval = 5 * time.Second
+
+	// secretBoxRevealBatchSize is the number of events revealed per query
+	secretBoxRevealBatchSize = 20
+)
+
+// ScheduledRevealRepo reveals the Secret Boxes whose scheduled time has passed.
+// Each event must be returned by exactly one call, even across instances.
+type ScheduledRevealRepo interface {
+	RevealDueSecretBoxes(limit int) ([]models.SecretBoxReveal, error)
+}
+
+// SecretRevealBroadcaster sends the reveal animation to an event's screens
+type SecretRevealBroadcaster interface {
+	BroadcastSecretRevealToRoom(eventSlug string, postcards []models.Postcard)
+}
+
+// SecretBoxScheduler runs scheduled Secret Box reveals. The schedule lives in
+// the database, so reveals missed while the server was down run at startup.
+type SecretBoxScheduler struct {
+	repo     ScheduledRevealRepo
+	hub      SecretRevealBroadcaster
+	interval time.Duration
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  int32 // atomic
+	mu       sync.Mutex
+}
+
+// NewSecretBoxScheduler creates a new Secret Box scheduler
+func NewSecretBoxScheduler(repo ScheduledRevealRepo, hub SecretRevealBroadcaster, interval time.Duration) *SecretBoxScheduler {
+	if interval <= 0 {
+		interval = SecretBoxSchedulerInterval
+	}
+
+	return &SecretBoxScheduler{
+		repo:     repo,
+		hub:      hub,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins checking for due reveals
+func (s *SecretBoxScheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		// Already running
+		return
+	}
+
+	log.Printf("[SecretBoxScheduler] Starting (every %s)", s.interval)
+
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop gracefully stops the scheduler
+func (s *SecretBoxScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&s.running, 1, 0) {
+		// Not running
+		return
+	}
+
+	log.Printf("[SecretBoxScheduler] Stopping...")
+	close(s.stopChan)
+	s.wg.Wait()
+	log.Printf("[SecretBoxScheduler] Stopped")
+}
+
+// loop runs the due reveals at start and on every tick
+func (s *SecretBoxScheduler) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.RevealDue()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.RevealDue()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// RevealDue reveals every Secret Box whose time has come and broadcasts it.
+// Returns the number of events revealed.
+func (s *SecretBoxScheduler) RevealDue() int {
+	revealed := 0
+	for {
+		reveals, err := s.repo.RevealDueSecretBoxes(secretBoxRevealBatchSize)
+		if err != nil {
+			log.Printf("[SecretBoxScheduler] Error revealing Secret Boxes: %v", err)
+			return revealed
+		}
+
+		for _, reveal := range reveals {
+			public := models.PublicPostcards(reveal.Postcards)
+			log.Printf("[SecretBoxScheduler] Revealed Secret Box of %s (%d postcards)", reveal.EventSlug, len(public))
+			if s.hub != nil && len(public) > 0 {
+				s.hub.BroadcastSecretRevealToRoom(reveal.EventSlug, public)
+			}
+		}
+		revealed += len(reveals)
+
+		if len(reveals) < secretBoxRevealBatchSize {
+			return revealed
+		}
+	}
+}
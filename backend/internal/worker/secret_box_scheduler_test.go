@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+)
+
+// mockScheduledRevealRepo hands out each due reveal once, like SKIP LOCKED
+type mockScheduledRevealRepo struct {
+	due   []models.SecretBoxReveal
+	err   error
+	calls int
+}
+
+func (m *mockScheduledRevealRepo) RevealDueSecretBoxes(limit int) ([]models.SecretBoxReveal, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	if limit > len(m.due) {
+		limit = len(m.due)
+	}
+	batch := m.due[:limit]
+	m.due = m.due[limit:]
+	return batch, nil
+}
+
+type mockRevealHub struct {
+	reveals map[string][]models.Postcard
+}
+
+func (h *mockRevealHub) BroadcastSecretRevealToRoom(eventSlug string, postcards []models.Postcard) {
+	h.reveals[eventSlug] = postcards
+}
+
+func TestSecretBoxScheduler_RevealDue(t *testing.T) {
+	now := time.Now()
+	visible := models.Postcard{ID: uuid.New(), IsSecret: true, RevealedAt: &now, ModerationStatus: models.ModerationApproved, MediaStatus: models.MediaStatusReady}
+	pending := models.Postcard{ID: uuid.New(), IsSecret: true, RevealedAt: &now, ModerationStatus: models.ModerationPending, MediaStatus: models.MediaStatusReady}
+
+	repo := &mockScheduledRevealRepo{}
+	repo.due = append(repo.due, models.SecretBoxReveal{EventID: uuid.New(), EventSlug: "boda", Postcards: []models.Postcard{visible, pending}})
+	repo.due = append(repo.due, models.SecretBoxReveal{EventID: uuid.New(), EventSlug: "vacia"})
+	for i := 0; i < secretBoxRevealBatchSize; i++ {
+		repo.due = append(repo.due, models.SecretBoxReveal{EventID: uuid.New(), EventSlug: "evento"})
+	}
+	hub := &mockRevealHub{reveals: map[string][]models.Postcard{}}
+
+	s := NewSecretBoxScheduler(repo, hub, 0)
+	if n := s.RevealDue(); n != secretBoxRevealBatchSize+2 {
+		t.Errorf("revealed = %d, want %d", n, secretBoxRevealBatchSize+2)
+	}
+	if repo.calls != 2 {
+		t.Errorf("repo calls = %d, want 2 (full batch, then the rest)", repo.calls)
+	}
+
+	// Only public postcards are broadcast, and empty boxes are not broadcast at all
+	if got := hub.reveals["boda"]; len(got) != 1 || got[0].ID != visible.ID {
+		t.Errorf("broadcast for boda = %v, want only the approved postcard", got)
+	}
+	if _, ok := hub.reveals["vacia"]; ok {
+		t.Error("an empty Secret Box must not be broadcast")
+	}
+
+	// Nothing left: a second run is a no-op
+	if n := s.RevealDue(); n != 0 {
+		t.Errorf("second run revealed = %d, want 0", n)
+	}
+}
+
+func TestSecretBoxScheduler_RepoError(t *testing.T) {
+	repo := &mockScheduledRevealRepo{err: errors.New("db down")}
+	s := NewSecretBoxScheduler(repo, &mockRevealHub{reveals: map[string][]models.Postcard{}}, 0)
+
+	if n := s.RevealDue(); n != 0 {
+		t.Errorf("revealed = %d, want 0", n)
+	}
+	if repo.calls != 1 {
+		t.Errorf("repo calls = %d, want 1", repo.calls)
+	}
+}
@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+const (
+	// UploadCleanupInterval is how often expired upload sessions are removed
+	UploadCleanupInterval = 15 * time.Minute
+
+	// uploadCleanupBatchSize is the number of sessions removed per query
+	uploadCleanupBatchSize = 100
+)
+
+// UploadSessionStore defines the upload session persistence needed by the cleanup
+type UploadSessionStore interface {
+	ListExpired(limit int) ([]models.UploadSession, error)
+	ListChunks(sessionID uuid.UUID) ([]models.UploadChunk, error)
+	Delete(id uuid.UUID) error
+}
+
+// UploadCleanupWorker deletes resumable uploads that were never finished:
+// their chunks are removed from the MediaStore and the session from the database.
+type UploadCleanupWorker struct {
+	repo     UploadSessionStore
+	media    services.MediaStore
+	interval time.Duration
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  int32 // atomic
+	mu       sync.Mutex
+}
+
+// NewUploadCleanupWorker creates a new upload cleanup worker
+func NewUploadCleanupWorker(repo UploadSessionStore, media services.MediaStore, interval time.Duration) *UploadCleanupWorker {
+	if interval <= 0 {
+		interval = UploadCleanupInterval
+	}
+
+	return &UploadCleanupWorker{
+		repo:     repo,
+		media:    media,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the periodic cleanup
+func (w *UploadCleanupWorker) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 0, 1) {
+		// Already running
+		return
+	}
+
+	log.Printf("[UploadCleanupWorker] Starting (every %s)", w.interval)
+
+	w.wg.Add(1)
+	go w.loop()
+}
+
+// Stop gracefully stops the worker
+func (w *UploadCleanupWorker) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&w.running, 1, 0) {
+		// Not running
+		return
+	}
+
+	log.Printf("[UploadCleanupWorker] Stopping...")
+	close(w.stopChan)
+	w.wg.Wait()
+	log.Printf("[UploadCleanupWorker] Stopped")
+}
+
+// loop runs a cleanup at start and on every tick
+func (w *UploadCleanupWorker) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.Cleanup()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.Cleanup()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+// Cleanup removes every expired upload session and returns how many were removed
+func (w *UploadCleanupWorker) Cleanup() int {
+	removed := 0
+	for {
+		sessions, err := w.repo.ListExpired(uploadCleanupBatchSize)
+		if err != nil {
+			log.Printf("[UploadCleanupWorker] Error listing expired uploads: %v", err)
+			break
+		}
+
+		deleted := 0
+		for _, session := range sessions {
+			if w.removeSession(session.ID) {
+				deleted++
+			}
+		}
+		removed += deleted
+
+		// A short batch means we are done; no progress means every delete failed
+		if len(sessions) < uploadCleanupBatchSize || deleted == 0 {
+			break
+		}
+	}
+
+	if removed > 0 {
+		log.Printf("[UploadCleanupWorker] Removed %d expired uploads", removed)
+	}
+	return removed
+}
+
+// removeSession deletes the chunks of a session and then the session itself
+func (w *UploadCleanupWorker) removeSession(id uuid.UUID) bool {
+	chunks, err := w.repo.ListChunks(id)
+	if err != nil {
+		log.Printf("[UploadCleanupWorker] Error listing chunks of upload %s: %v", id, err)
+		return false
+	}
+
+	for _, chunk := range chunks {
+		if err := w.media.Delete(context.Background(), chunk.Key); err != nil {
+			// Keep the session so the next run retries the chunk
+			log.Printf("[UploadCleanupWorker] Error deleting chunk %s: %v", chunk.Key, err)
+			return false
+		}
+	}
+
+	if err := w.repo.Delete(id); err != nil {
+		log.Printf("[UploadCleanupWorker] Error deleting upload %s: %v", id, err)
+		return false
+	}
+	return true
+}
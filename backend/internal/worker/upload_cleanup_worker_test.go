@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/the-mile-game/backend/internal/models"
+	"github.com/the-mile-game/backend/internal/services"
+)
+
+// mockUploadSessionStore holds expired sessions and their chunks in memory
+type mockUploadSessionStore struct {
+	expired   []models.UploadSession
+	chunks    map[uuid.UUID][]models.UploadChunk
+	deleted   []uuid.UUID
+	deleteErr error
+}
+
+func (m *mockUploadSessionStore) ListExpired(limit int) ([]models.UploadSession, error) {
+	var result []models.UploadSession
+	for _, s := range m.expired {
+		if !containsUUID(m.deleted, s.ID) && len(result) < limit {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockUploadSessionStore) ListChunks(sessionID uuid.UUID) ([]models.UploadChunk, error) {
+	return m.chunks[sessionID], nil
+}
+
+func (m *mockUploadSessionStore) Delete(id uuid.UUID) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUploadCleanupWorker_Cleanup(t *testing.T) {
+	dir := t.TempDir()
+	store := services.NewLocalMediaStore(dir)
+	repo := &mockUploadSessionStore{chunks: map[uuid.UUID][]models.UploadChunk{}}
+
+	// More sessions than one batch, each with two stored chunks
+	for i := 0; i < uploadCleanupBatchSize+5; i++ {
+		session := models.UploadSession{ID: uuid.New()}
+		for _, offset := range []string{"000000000000", "000000000004"} {
+			key := "uploads/" + session.ID.String() + "/" + offset
+			if err := store.Put(context.Background(), key, strings.NewReader("data"), 4, "application/octet-stream"); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+			repo.chunks[session.ID] = append(repo.chunks[session.ID], models.UploadChunk{Key: key, Size: 4})
+		}
+		repo.expired = append(repo.expired, session)
+	}
+
+	w := NewUploadCleanupWorker(repo, store, 0)
+	if removed := w.Cleanup(); removed != uploadCleanupBatchSize+5 {
+		t.Errorf("removed = %d, want %d", removed, uploadCleanupBatchSize+5)
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(dir, "uploads"))
+	for _, e := range entries {
+		chunks, _ := os.ReadDir(filepath.Join(dir, "uploads", e.Name()))
+		if len(chunks) > 0 {
+			t.Fatalf("chunks left in %s: %d", e.Name(), len(chunks))
+		}
+	}
+}
+
+func TestUploadCleanupWorker_StopsWhenDeletesFail(t *testing.T) {
+	repo := &mockUploadSessionStore{
+		expired:   []models.UploadSession{{ID: uuid.New()}},
+		chunks:    map[uuid.UUID][]models.UploadChunk{},
+		deleteErr: errors.New("db down"),
+	}
+
+	w := NewUploadCleanupWorker(repo, services.NewLocalMediaStore(t.TempDir()), 0)
+	if removed := w.Cleanup(); removed != 0 {
+		t.Errorf("removed = %d, want 0", removed)
+	}
+}
@@ -0,0 +1,8 @@
+-- Rollback: Refresh token rotation
+
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+DROP INDEX IF EXISTS idx_refresh_tokens_token_hash_unique;
+
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS replaced_by;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS revoked_at;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,4 @@
+-- Rollback: Weighted and partial-credit scoring
+
+ALTER TABLE quiz_questions DROP COLUMN IF EXISTS partial_credit;
+ALTER TABLE quiz_questions DROP COLUMN IF EXISTS points;
@@ -0,0 +1,7 @@
+-- Migration: Weighted and partial-credit scoring
+-- Puntos por pregunta y regla opcional de crédito parcial
+
+ALTER TABLE quiz_questions ADD COLUMN IF NOT EXISTS points INT NOT NULL DEFAULT 1 CHECK (points >= 1);
+
+-- {"answers": ["..."], "points": N}; NULL = sin crédito parcial
+ALTER TABLE quiz_questions ADD COLUMN IF NOT EXISTS partial_credit JSONB;
@@ -0,0 +1,3 @@
+-- Rollback: Fuzzy answer matching
+
+ALTER TABLE quiz_questions DROP COLUMN IF EXISTS fuzzy_match;
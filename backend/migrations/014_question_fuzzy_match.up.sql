@@ -0,0 +1,4 @@
+-- Migration: Fuzzy answer matching
+-- Regla opcional por pregunta: {"max_edit_distance": 1, "token_set_similarity": 0.8, "stem_plurals": true}
+
+ALTER TABLE quiz_questions ADD COLUMN IF NOT EXISTS fuzzy_match JSONB;
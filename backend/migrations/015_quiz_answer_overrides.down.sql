@@ -0,0 +1,3 @@
+-- Rollback: Host overrides for quiz answers
+
+DROP TABLE IF EXISTS quiz_answer_overrides;
@@ -0,0 +1,15 @@
+-- Migration: Host overrides for quiz answers
+-- Respuestas aceptadas manualmente por el host para un jugador puntual.
+-- La aceptación aplica solo mientras la respuesta guardada siga siendo la misma.
+
+CREATE TABLE IF NOT EXISTS quiz_answer_overrides (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    question_key VARCHAR(100) NOT NULL,
+    answer TEXT NOT NULL,
+    accepted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(player_id, question_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_answer_overrides_player ON quiz_answer_overrides(player_id);
@@ -0,0 +1,27 @@
+DROP INDEX IF EXISTS idx_postcard_events_type;
+DROP INDEX IF EXISTS idx_quiz_events_completed_once;
+DROP INDEX IF EXISTS idx_quiz_events_started_once;
+
+CREATE OR REPLACE FUNCTION log_quiz_started(p_event_id UUID, p_player_id UUID)
+RETURNS UUID AS $$
+DECLARE
+    v_id UUID;
+BEGIN
+    INSERT INTO quiz_events (event_id, player_id, event_type)
+    VALUES (p_event_id, p_player_id, 'started')
+    RETURNING id INTO v_id;
+    RETURN v_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION log_quiz_completed(p_event_id UUID, p_player_id UUID, p_score INTEGER, p_time_spent INTEGER)
+RETURNS UUID AS $$
+DECLARE
+    v_id UUID;
+BEGIN
+    INSERT INTO quiz_events (event_id, player_id, event_type, score, time_spent_seconds)
+    VALUES (p_event_id, p_player_id, 'completed', p_score, p_time_spent)
+    RETURNING id INTO v_id;
+    RETURN v_id;
+END;
+$$ LANGUAGE plpgsql;
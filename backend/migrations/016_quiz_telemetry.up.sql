@@ -0,0 +1,54 @@
+-- Migration: Quiz telemetry idempotency
+-- Un jugador tiene a lo sumo un 'started' y un 'completed' por evento: los
+-- inicios repetidos (recargas, doble click) no inflan el funnel y el tiempo
+-- se mide siempre desde el primer inicio.
+
+-- Limpiar duplicados previos, conservando el registro más antiguo
+DELETE FROM quiz_events qe
+USING quiz_events older
+WHERE qe.event_id = older.event_id
+  AND qe.player_id = older.player_id
+  AND qe.event_type = older.event_type
+  AND qe.event_type IN ('started', 'completed')
+  AND (qe.created_at, qe.id) > (older.created_at, older.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quiz_events_started_once
+    ON quiz_events(event_id, player_id) WHERE event_type = 'started';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quiz_events_completed_once
+    ON quiz_events(event_id, player_id) WHERE event_type = 'completed';
+
+CREATE INDEX IF NOT EXISTS idx_postcard_events_type ON postcard_events(event_type);
+
+-- Las funciones de logging respetan la unicidad en lugar de fallar
+CREATE OR REPLACE FUNCTION log_quiz_started(p_event_id UUID, p_player_id UUID)
+RETURNS UUID AS $$
+DECLARE
+    v_id UUID;
+BEGIN
+    INSERT INTO quiz_events (event_id, player_id, event_type)
+    VALUES (p_event_id, p_player_id, 'started')
+    ON CONFLICT (event_id, player_id) WHERE event_type = 'started' DO NOTHING
+    RETURNING id INTO v_id;
+
+    IF v_id IS NULL THEN
+        SELECT id INTO v_id FROM quiz_events
+        WHERE event_id = p_event_id AND player_id = p_player_id AND event_type = 'started';
+    END IF;
+    RETURN v_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION log_quiz_completed(p_event_id UUID, p_player_id UUID, p_score INTEGER, p_time_spent INTEGER)
+RETURNS UUID AS $$
+DECLARE
+    v_id UUID;
+BEGIN
+    INSERT INTO quiz_events (event_id, player_id, event_type, score, time_spent_seconds)
+    VALUES (p_event_id, p_player_id, 'completed', p_score, p_time_spent)
+    ON CONFLICT (event_id, player_id) WHERE event_type = 'completed'
+    DO UPDATE SET score = EXCLUDED.score
+    RETURNING id INTO v_id;
+    RETURN v_id;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_postcards_event_moderation;
+
+ALTER TABLE postcards DROP COLUMN IF EXISTS moderated_by;
+ALTER TABLE postcards DROP COLUMN IF EXISTS moderated_at;
+ALTER TABLE postcards DROP COLUMN IF EXISTS moderation_status;
@@ -0,0 +1,10 @@
+-- Migration: Postcard moderation
+-- Con settings.postcard_moderation = true las postales nuevas del evento quedan
+-- 'pending' hasta que el owner las apruebe. Las existentes quedan aprobadas.
+
+ALTER TABLE postcards ADD COLUMN IF NOT EXISTS moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved'
+    CHECK (moderation_status IN ('pending', 'approved', 'rejected'));
+ALTER TABLE postcards ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMP;
+ALTER TABLE postcards ADD COLUMN IF NOT EXISTS moderated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_postcards_event_moderation ON postcards(event_id, moderation_status);
@@ -0,0 +1,2 @@
+ALTER TABLE postcards DROP COLUMN IF EXISTS image_board_path;
+ALTER TABLE postcards DROP COLUMN IF EXISTS image_thumb_path;
@@ -0,0 +1,7 @@
+-- Migration: Postcard image renditions
+-- Las imágenes subidas se procesan (sin EXIF, rotadas según orientación) y se guardan
+-- en tres tamaños: image_path pasa a ser la versión full, y se agregan thumb y board.
+-- Las postales existentes y los videos quedan con NULL (se usa image_path).
+
+ALTER TABLE postcards ADD COLUMN IF NOT EXISTS image_thumb_path VARCHAR(512);
+ALTER TABLE postcards ADD COLUMN IF NOT EXISTS image_board_path VARCHAR(512);
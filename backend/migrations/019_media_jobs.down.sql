@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_media_jobs_status;
+DROP TABLE IF EXISTS media_jobs;
+ALTER TABLE postcards DROP COLUMN IF EXISTS media_status;
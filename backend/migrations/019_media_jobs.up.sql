@@ -0,0 +1,20 @@
+-- Migration: Asynchronous video processing
+-- Los videos se suben tal cual y un worker genera thumbnail, duración y la
+-- versión H.264/MP4. Mientras tanto la postal queda en media_status 'processing'.
+
+ALTER TABLE postcards ADD COLUMN IF NOT EXISTS media_status VARCHAR(20) NOT NULL DEFAULT 'ready'
+    CHECK (media_status IN ('processing', 'ready', 'failed'));
+
+CREATE TABLE IF NOT EXISTS media_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    postcard_id UUID NOT NULL UNIQUE REFERENCES postcards(id) ON DELETE CASCADE,
+    source_key TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'in_progress', 'done', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    queued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_jobs_status ON media_jobs(status, queued_at) WHERE status IN ('queued', 'in_progress');
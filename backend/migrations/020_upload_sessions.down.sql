@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_upload_sessions_expires_at;
+DROP TABLE IF EXISTS upload_chunks;
+DROP TABLE IF EXISTS upload_sessions;
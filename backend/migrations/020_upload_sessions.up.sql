@@ -0,0 +1,23 @@
+-- Migration: Resumable uploads
+-- Los invitados suben videos grandes en chunks (wifi del salón). Cada chunk se
+-- guarda en el MediaStore; al terminar se arma el archivo y se crea la postal.
+
+CREATE TABLE IF NOT EXISTS upload_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    upload_length BIGINT NOT NULL CHECK (upload_length > 0),
+    upload_offset BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    CHECK (upload_offset <= upload_length)
+);
+
+CREATE TABLE IF NOT EXISTS upload_chunks (
+    session_id UUID NOT NULL REFERENCES upload_sessions(id) ON DELETE CASCADE,
+    chunk_offset BIGINT NOT NULL,
+    chunk_size BIGINT NOT NULL,
+    media_key TEXT NOT NULL,
+    PRIMARY KEY (session_id, chunk_offset)
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_expires_at ON upload_sessions(expires_at);
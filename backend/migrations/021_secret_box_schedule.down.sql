@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_secret_box_reveal_at;
+ALTER TABLE events DROP COLUMN IF EXISTS secret_box_reveal_at;
@@ -0,0 +1,7 @@
+-- Migration: Scheduled Secret Box reveal
+-- Hora a la que el SecretBoxScheduler revela la Secret Box del evento.
+-- Se limpia al revelar (automática o manualmente) o al cancelar.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS secret_box_reveal_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_events_secret_box_reveal_at ON events(secret_box_reveal_at) WHERE secret_box_reveal_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_postcards_reveal_queue;
+ALTER TABLE postcards DROP COLUMN IF EXISTS reveal_position;
@@ -0,0 +1,7 @@
+-- Migration: Staged Secret Box reveal
+-- Orden en que el owner revela las postales secretas una por una (slideshow).
+-- NULL = sin orden explícito: van al final, por fecha de creación.
+
+ALTER TABLE postcards ADD COLUMN IF NOT EXISTS reveal_position INT;
+
+CREATE INDEX IF NOT EXISTS idx_postcards_reveal_queue ON postcards(event_id, reveal_position, created_at) WHERE is_secret = TRUE AND revealed_at IS NULL;
@@ -0,0 +1,19 @@
+-- Migration: Event lifecycle
+-- draft → scheduled → live → ended → archived. El EventLifecycleWorker avanza los estados
+-- según starts_at/ends_at; el owner puede publicar, empezar, terminar o archivar a mano.
+-- is_active se mantiene como "acepta participación" (scheduled o live) para los clientes viejos.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'live'
+    CHECK (status IN ('draft', 'scheduled', 'live', 'ended', 'archived'));
+ALTER TABLE events ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- Eventos existentes: los inactivos quedan archivados, el resto según sus fechas
+UPDATE events SET status = CASE
+    WHEN NOT is_active THEN 'archived'
+    WHEN ends_at IS NOT NULL AND ends_at <= NOW() THEN 'ended'
+    WHEN starts_at IS NOT NULL AND starts_at > NOW() THEN 'scheduled'
+    ELSE 'live'
+END;
+UPDATE events SET is_active = status IN ('scheduled', 'live');
+
+CREATE INDEX IF NOT EXISTS idx_events_status ON events(status) WHERE status IN ('scheduled', 'live', 'ended');
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_member_invitations;
+DROP TABLE IF EXISTS event_members;
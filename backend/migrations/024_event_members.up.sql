@@ -0,0 +1,31 @@
+-- Migration: Event members
+-- Varios organizadores por evento, cada uno con un rol: owner, co_host, moderator, viewer.
+-- Las invitaciones van por email y las acepta el usuario registrado con ese email.
+
+CREATE TABLE IF NOT EXISTS event_members (
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'co_host', 'moderator', 'viewer')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_members_user_id ON event_members(user_id);
+
+CREATE TABLE IF NOT EXISTS event_member_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('co_host', 'moderator', 'viewer')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_member_invitations_event_email ON event_member_invitations(event_id, LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_event_member_invitations_email ON event_member_invitations(LOWER(email));
+
+-- Los owners actuales pasan a ser miembros con rol owner
+INSERT INTO event_members (event_id, user_id, role, created_at)
+SELECT id, owner_id, 'owner', created_at FROM events
+ON CONFLICT (event_id, user_id) DO NOTHING;
@@ -0,0 +1,18 @@
+-- Migration: Event templates
+-- Templates personales: un usuario guarda features, settings, theme y preguntas
+-- de un evento y crea eventos nuevos a partir de ellos.
+-- Los archivos del template (logo, fondo) son copias propias en el MediaStore.
+
+CREATE TABLE IF NOT EXISTS event_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    features JSONB NOT NULL DEFAULT '{}',
+    settings JSONB NOT NULL DEFAULT '{}',
+    theme JSONB,
+    questions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_templates_owner_id ON event_templates(owner_id, created_at DESC);
@@ -0,0 +1,12 @@
+-- Migration: Event slug aliases
+-- Al cambiar el slug de un evento el anterior queda como alias, así los links
+-- y códigos QR ya compartidos siguen resolviendo al evento.
+-- Un alias no se puede reusar como slug de otro evento.
+
+CREATE TABLE IF NOT EXISTS event_slug_aliases (
+    slug VARCHAR(100) PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_slug_aliases_event_id ON event_slug_aliases(event_id);
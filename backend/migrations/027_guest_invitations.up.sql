@@ -0,0 +1,24 @@
+-- Migration: Guest invitations
+-- Lista de invitados del host (importada por CSV o JSON). Cada invitado tiene un link
+-- personal con token para confirmar asistencia (RSVP) y entrar al evento como jugador.
+-- player_id vincula la invitación con el jugador creado desde ese link.
+
+CREATE TABLE IF NOT EXISTS guest_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    token VARCHAR(64) NOT NULL UNIQUE,
+    max_plus_ones INTEGER NOT NULL DEFAULT 0 CHECK (max_plus_ones >= 0),
+    rsvp_status VARCHAR(10) NOT NULL DEFAULT 'pending'
+        CHECK (rsvp_status IN ('pending', 'yes', 'no', 'maybe')),
+    plus_ones INTEGER NOT NULL DEFAULT 0 CHECK (plus_ones >= 0),
+    rsvp_at TIMESTAMP,
+    player_id UUID REFERENCES players(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_guest_invitations_event_id ON guest_invitations(event_id, name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guest_invitations_event_email ON guest_invitations(event_id, LOWER(email)) WHERE email <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guest_invitations_player_id ON guest_invitations(player_id) WHERE player_id IS NOT NULL;
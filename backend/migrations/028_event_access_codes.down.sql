@@ -0,0 +1,2 @@
+ALTER TABLE events DROP COLUMN IF EXISTS access_version;
+ALTER TABLE events DROP COLUMN IF EXISTS access_code_hash;
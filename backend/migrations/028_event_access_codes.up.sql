@@ -0,0 +1,8 @@
+-- Migration: Event access codes
+-- Un evento puede protegerse con un código de acceso: los invitados lo cambian por un
+-- token de acceso de corta duración que exigen todas las rutas públicas del evento y /ws.
+-- Se guarda solo el hash (bcrypt). access_version se incrementa al rotar el código o
+-- revocar las sesiones, e invalida los tokens emitidos con la versión anterior.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS access_code_hash VARCHAR(100);
+ALTER TABLE events ADD COLUMN IF NOT EXISTS access_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_event_exports_active;
+DROP INDEX IF EXISTS idx_event_exports_expires_at;
+DROP INDEX IF EXISTS idx_event_exports_status;
+DROP INDEX IF EXISTS idx_event_exports_event_id;
+DROP TABLE IF EXISTS event_exports;
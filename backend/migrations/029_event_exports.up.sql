@@ -0,0 +1,29 @@
+-- Migration: Event archive exports
+-- El owner pide un ZIP con todo el evento (media, postales, ranking, respuestas,
+-- tema y settings). Un worker lo arma en background e informa el progreso; el ZIP
+-- queda en el MediaStore (archive_key) hasta expires_at y después se borra.
+
+CREATE TABLE IF NOT EXISTS event_exports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued'
+        CHECK (status IN ('queued', 'in_progress', 'done', 'failed', 'expired')),
+    processed_items INT NOT NULL DEFAULT 0,
+    total_items INT NOT NULL DEFAULT 0,
+    archive_key TEXT,
+    size_bytes BIGINT,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    queued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    expires_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_exports_event_id ON event_exports(event_id, queued_at DESC);
+CREATE INDEX IF NOT EXISTS idx_event_exports_status ON event_exports(status, queued_at) WHERE status IN ('queued', 'in_progress');
+CREATE INDEX IF NOT EXISTS idx_event_exports_expires_at ON event_exports(expires_at) WHERE status = 'done';
+
+-- Un solo export en curso por evento
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_exports_active ON event_exports(event_id) WHERE status IN ('queued', 'in_progress');
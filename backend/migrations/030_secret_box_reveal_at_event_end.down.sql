@@ -0,0 +1 @@
+ALTER TABLE events DROP COLUMN IF EXISTS secret_box_reveal_at_event_end;
@@ -0,0 +1,5 @@
+-- Migration: Secret Box reveal linked to the end of the event
+-- Con at_event_end el reveal sigue a ends_at: si el owner mueve el fin del evento,
+-- UpdateDetails mueve también secret_box_reveal_at.
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS secret_box_reveal_at_event_end BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+-- Migration: Claim de sesiones de upload
+-- POST /postcards o /secret-box marca la sesión antes de armar el archivo, así dos
+-- requests con el mismo upload_id no crean dos postales.
+
+ALTER TABLE upload_sessions ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;
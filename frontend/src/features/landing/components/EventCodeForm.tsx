@@ -2,7 +2,7 @@ import { useState } from 'react';
 import { motion } from 'framer-motion';
 import { Search, AlertCircle, CheckCircle } from 'lucide-react';
 import { useNavigate } from 'react-router-dom';
-import { api } from '@/shared/lib/api';
+import { api, isAccessRequiredError } from '@/shared/lib/api';
 import { useLandingStore } from '../store/landingStore';
 import { useAuthStore } from '@/features/auth/store/authStore';
 
@@ -45,6 +45,14 @@ export function EventCodeForm() {
       await new Promise(resolve => setTimeout(resolve, 500));
       navigate(`/e/${code}`);
     } catch (error: any) {
+      // Evento protegido con código: existe, el código se pide al entrar
+      if (isAccessRequiredError(error)) {
+        setCodeValidation(false, null);
+        trackCTA('join');
+        navigate(`/e/${code}`);
+        return;
+      }
+
       // Event not found
       const isNotFound = error?.response?.status === 404 || 
                         error?.message?.includes('404') ||
@@ -2,13 +2,19 @@ import { useCallback, useEffect, useRef } from 'react';
 import { usePostcardStore } from '../store/postcardStore';
 import { postcardService } from '../services/postcardApi';
 import { useWebSocketStore } from '@/shared/store/websocketStore';
+import { api } from '@/shared/lib/api';
 import type { Postcard } from '../types/postcards.types';
 
 // Construir la URL del WebSocket según entorno y evento
 function getWsUrl(eventSlug?: string): string {
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
   const baseUrl = `${protocol}//${window.location.host}/ws`;
-  return eventSlug ? `${baseUrl}?event=${eventSlug}` : baseUrl;
+  if (!eventSlug) return baseUrl;
+
+  // Eventos protegidos con código: el WebSocket recibe el token por query param
+  const accessToken = api.getEventAccessToken(eventSlug);
+  const access = accessToken ? `&event_access=${encodeURIComponent(accessToken)}` : '';
+  return `${baseUrl}?event=${eventSlug}${access}`;
 }
 
 export function usePostcards(eventSlug?: string) {
@@ -3,7 +3,7 @@ import { useEventNavigate } from '@/shared/hooks/useEventNavigate';
 import { useQuizStore } from '../store/quizStore';
 import { quizService } from '../services/quizApi';
 import { useEventStore } from '@/shared/store/eventStore';
-import { api } from '@/shared/lib/api';
+import { api, isAccessRequiredError } from '@/shared/lib/api';
 import type { QuizQuestionResponse } from '../types/quiz-player.types';
 import type { AxiosError } from 'axios';
 
@@ -82,11 +82,13 @@ export function useQuiz(questions: QuizQuestionResponse[] = []) {
     } catch (err) {
       console.error('Error submitting quiz:', err);
       
-      // Manejar error 403 específico: player no pertenece al evento
+      // Manejar 403 específico (player de otro evento) y 401 (token de sesión vencido o inválido)
       const axiosError = err as AxiosError<{ error?: string }>;
-      if (axiosError.response?.status === 403) {
+      const status = axiosError.response?.status;
+      if (status === 403 || (status === 401 && !isAccessRequiredError(err))) {
         const errorMessage = axiosError.response?.data?.error || '';
-        if (errorMessage.includes('Player does not belong to this event')) {
+        // "Player does not belong..." (X-Player-ID) o "Player token does not belong..." (X-Player-Token)
+        if (status === 401 || errorMessage.includes('does not belong to this event')) {
           // Limpiar player y redirigir a registro
           api.clearPlayerId();
           useQuizStore.getState().resetQuiz();
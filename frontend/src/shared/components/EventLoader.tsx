@@ -1,9 +1,9 @@
-import { useEffect, useState } from 'react';
+import { useEffect, useRef, useState } from 'react';
 import { motion } from 'framer-motion';
 import { useParams } from 'react-router-dom';
 import { useEventNavigate } from '@/shared/hooks/useEventNavigate';
 import { useEventStore, type Event } from '@/shared/store/eventStore';
-import { api } from '@/shared/lib/api';
+import { api, isAccessRequiredError } from '@/shared/lib/api';
 import { Button } from '@/shared/components/Button';
 import { EventLandingSkeleton } from './EventSkeletons';
 
@@ -19,6 +19,13 @@ export function EventLoader({ children, fallback, useLandingSkeleton }: EventLoa
   const navigate = useEventNavigate();
   const { currentEvent, setEvent, setLoading, setError, isLoading, error } = useEventStore();
   const [notFound, setNotFound] = useState(false);
+  // Evento protegido con código: se pide antes de cargarlo
+  const [accessRequired, setAccessRequired] = useState(false);
+  const [accessCode, setAccessCode] = useState('');
+  const [accessError, setAccessError] = useState<string | null>(null);
+  const [isEntering, setIsEntering] = useState(false);
+  const [reloadKey, setReloadKey] = useState(0);
+  const triedSecretBoxToken = useRef(false);
 
   useEffect(() => {
     if (!slug) return;
@@ -32,6 +39,7 @@ export function EventLoader({ children, fallback, useLandingSkeleton }: EventLoa
       setLoading(true);
       setError(null);
       setNotFound(false);
+      setAccessRequired(false);
 
       try {
         const event = await api.getEventBySlug(slug);
@@ -63,7 +71,21 @@ export function EventLoader({ children, fallback, useLandingSkeleton }: EventLoa
         
         setEvent(transformedEvent);
       } catch (err: any) {
-        if (err.response?.status === 404) {
+        if (isAccessRequiredError(err)) {
+          // Los links de la Secret Box ya traen su token y sirven como credencial
+          const secretBoxToken = new URLSearchParams(window.location.search).get('token');
+          if (secretBoxToken && window.location.pathname.endsWith('/secret-box') && !triedSecretBoxToken.current) {
+            triedSecretBoxToken.current = true;
+            try {
+              await api.enterEvent(slug, { secret_box_token: secretBoxToken });
+              setReloadKey((key) => key + 1);
+              return;
+            } catch {
+              // Token vencido o rotado: se pide el código
+            }
+          }
+          setAccessRequired(true);
+        } else if (err.response?.status === 404) {
           setNotFound(true);
           setError('Evento no encontrado');
         } else if (err.response?.status === 410) {
@@ -82,7 +104,28 @@ export function EventLoader({ children, fallback, useLandingSkeleton }: EventLoa
     return () => {
       // Don't clear on unmount if navigating to another event route
     };
-  }, [slug]);
+  }, [slug, reloadKey]);
+
+  const handleEnter = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!slug || !accessCode.trim()) return;
+
+    setIsEntering(true);
+    setAccessError(null);
+    try {
+      await api.enterEvent(slug, { code: accessCode });
+      setAccessCode('');
+      setReloadKey((key) => key + 1);
+    } catch (err: any) {
+      if (err.response?.status === 429) {
+        setAccessError('Demasiados intentos. Esperá unos minutos y probá de nuevo.');
+      } else {
+        setAccessError('Código incorrecto. Verificalo e intentá de nuevo.');
+      }
+    } finally {
+      setIsEntering(false);
+    }
+  };
 
   if (isLoading) {
     return (
@@ -101,6 +144,43 @@ export function EventLoader({ children, fallback, useLandingSkeleton }: EventLoa
     );
   }
 
+  if (accessRequired) {
+    return (
+      <div className="min-h-screen flex items-center justify-center bg-gradient-to-br from-pink-50 via-rose-50 to-pink-100">
+        <form onSubmit={handleEnter} className="text-center px-6 max-w-md w-full">
+          <div className="text-7xl mb-6">🔒</div>
+          <h1 className="text-3xl font-display text-gray-800 dark:text-white mb-3">
+            Evento privado
+          </h1>
+          <p className="font-serif text-gray-600 dark:text-gray-400 mb-6">
+            Ingresá el código que te pasaron los anfitriones.
+          </p>
+          <input
+            value={accessCode}
+            onChange={(e) => setAccessCode(e.target.value)}
+            autoFocus
+            autoComplete="off"
+            autoCapitalize="characters"
+            placeholder="Código de acceso"
+            aria-label="Código de acceso"
+            className="w-full text-center text-2xl tracking-widest uppercase bg-transparent border-b-2 border-gray-300 focus:border-accent outline-none py-2 mb-3"
+          />
+          {accessError && (
+            <p className="text-sm text-red-500 mb-3">{accessError}</p>
+          )}
+          <div className="flex flex-col sm:flex-row gap-3 justify-center mt-4">
+            <Button type="submit" disabled={isEntering || !accessCode.trim()} className="px-6 py-3">
+              {isEntering ? 'Verificando...' : 'Entrar'}
+            </Button>
+            <Button type="button" onClick={() => navigate('/')} variant="outline" className="px-6 py-3">
+              ← Volver al inicio
+            </Button>
+          </div>
+        </form>
+      </div>
+    );
+  }
+
   if (notFound || error) {
     return (
       <div className="min-h-screen flex items-center justify-center bg-gradient-to-br from-pink-50 via-rose-50 to-pink-100">
@@ -23,7 +23,10 @@ vi.mock('axios', () => ({
   },
 }))
 
-import { api } from '../api'
+import { api, isAccessRequiredError } from '../api'
+
+// Registered once on import; captured before beforeEach clears the mocks
+const requestInterceptor = mockRequestInterceptorsUse.mock.calls[0][0]
 
 describe('ApiClient', () => {
   beforeEach(() => {
@@ -139,6 +142,54 @@ describe('ApiClient', () => {
     })
   })
 
+  // ─── player session token ───────────────────────────────────────────────────
+
+  describe('player session token', () => {
+    it('stores the token returned when joining an event', async () => {
+      const player = { id: 'player-uuid', name: 'Ana', avatar: '👸', score: 0, created_at: '', player_token: 'player-jwt' }
+      mockPost.mockResolvedValueOnce({ data: player })
+
+      await api.createPlayerScoped('ale-roy', { name: 'Ana', avatar: '👸' })
+
+      expect(api.getPlayerToken()).toBe('player-jwt')
+      expect(localStorage.getItem('mile-game-player-token')).toBe('player-jwt')
+    })
+
+    it('stores the token returned by the legacy /players route', async () => {
+      const player = { id: 'legacy-uuid', name: 'Tía Marta', avatar: '👵', score: 0, created_at: '', player_token: 'legacy-jwt' }
+      mockPost.mockResolvedValueOnce({ data: player })
+
+      await api.createPlayer({ name: 'Tía Marta', avatar: '👵' })
+      mockPost.mockResolvedValueOnce({ data: { score: 8, message: '¡Excelente!' } })
+      await api.submitQuiz({ favorites: {}, preferences: {}, description: '' })
+
+      expect(api.getPlayerToken()).toBe('legacy-jwt')
+      expect(mockPost.mock.calls[1][2].headers['X-Player-Token']).toBe('legacy-jwt')
+    })
+
+    it('sends X-Player-Token instead of the raw player id', async () => {
+      api.setPlayerId('player-uuid', 'ale-roy', 'player-jwt')
+      mockPost.mockResolvedValueOnce({ data: { score: 8, message: '¡Excelente!' } })
+
+      await api.submitQuizScoped('ale-roy', { favorites: {}, preferences: {}, description: '' })
+
+      const headers = mockPost.mock.calls[0][2].headers
+      expect(headers['X-Player-Token']).toBe('player-jwt')
+      expect(headers['X-Player-ID']).toBeUndefined()
+    })
+
+    it('is dropped when another player is set or the player is cleared', () => {
+      api.setPlayerId('player-uuid', 'ale-roy', 'player-jwt')
+      api.setPlayerId('legacy-uuid')
+      expect(api.getPlayerToken()).toBeNull()
+
+      api.setPlayerId('player-uuid', 'ale-roy', 'player-jwt')
+      api.clearPlayerId()
+      expect(api.getPlayerToken()).toBeNull()
+      expect(localStorage.getItem('mile-game-player-token')).toBeNull()
+    })
+  })
+
   // ─── getRanking ─────────────────────────────────────────────────────────────
 
   describe('getRanking', () => {
@@ -361,6 +412,44 @@ describe('ApiClient', () => {
     })
   })
 
+  // ─── event access ────────────────────────────────────────────────────────────
+
+  describe('enterEvent', () => {
+    it('POSTs the code and stores the access token for the event', async () => {
+      mockPost.mockResolvedValueOnce({ data: { access_token: 'access-jwt', expires_at: '', access_protected: true } })
+
+      await api.enterEvent('ale-roy', { code: 'K7PX3M' })
+
+      expect(mockPost).toHaveBeenCalledWith('/events/ale-roy/access', { code: 'K7PX3M' })
+      expect(api.getEventAccessToken('ale-roy')).toBe('access-jwt')
+      expect(api.getEventAccessToken('cumple-meli')).toBeNull()
+    })
+  })
+
+  describe('X-Event-Access header', () => {
+    it('is sent on routes of the event with a stored token', () => {
+      localStorage.setItem('mile-game-event-access:ale-roy', 'access-jwt')
+
+      const config = requestInterceptor({ url: '/events/ale-roy/ranking', headers: {} })
+      expect(config.headers['X-Event-Access']).toBe('access-jwt')
+    })
+
+    it('is not sent to other events or legacy routes', () => {
+      localStorage.setItem('mile-game-event-access:ale-roy', 'access-jwt')
+
+      expect(requestInterceptor({ url: '/events/cumple-meli/ranking', headers: {} }).headers['X-Event-Access']).toBeUndefined()
+      expect(requestInterceptor({ url: '/ranking', headers: {} }).headers['X-Event-Access']).toBeUndefined()
+    })
+  })
+
+  describe('isAccessRequiredError', () => {
+    it('detects the access_required 401', () => {
+      expect(isAccessRequiredError({ response: { status: 401, data: { access_required: true } } })).toBe(true)
+      expect(isAccessRequiredError({ response: { status: 401, data: { error: 'Unauthorized' } } })).toBe(false)
+      expect(isAccessRequiredError(new Error('Network Error'))).toBe(false)
+    })
+  })
+
   // ─── revealSecretBox ─────────────────────────────────────────────────────────
 
   describe('revealSecretBox', () => {
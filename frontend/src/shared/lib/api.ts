@@ -17,6 +17,11 @@ export interface RankingEntry {
   player: Player;
 }
 
+// Jugador recién creado (en un evento o legacy), con su token de sesión firmado
+export interface CreatePlayerResponse extends Player {
+  player_token?: string;
+}
+
 export interface CreatePlayerRequest {
   name: string;
   avatar?: string;
@@ -80,14 +85,42 @@ export interface BackupJob {
   synced_at: string | null;
 }
 
+// Acceso a eventos protegidos con código
+export interface EnterEventRequest {
+  code?: string;
+  invitation_token?: string;
+  secret_box_token?: string;
+}
+
+export interface EventAccessResponse {
+  access_token: string;
+  expires_at: string;
+  access_protected: boolean;
+}
+
+// El backend responde 401 con access_required cuando el evento pide código
+export function isAccessRequiredError(err: unknown): boolean {
+  const axiosErr = err as { response?: { status?: number; data?: { access_required?: boolean } } };
+  return axiosErr.response?.status === 401 && axiosErr.response.data?.access_required === true;
+}
+
+// Slug de las rutas /events/:slug/... (las que pasan por el control de acceso)
+function eventSlugFromUrl(url?: string): string | null {
+  const match = url?.match(/^\/events\/([^/?]+)/);
+  return match ? decodeURIComponent(match[1]) : null;
+}
+
 // Cliente API
 const PLAYER_ID_KEY = 'mile-game-player-id';
 const PLAYER_EVENT_KEY = 'mile-game-player-event'; // Guardar el eventSlug del player
+const PLAYER_TOKEN_KEY = 'mile-game-player-token'; // Token de sesión firmado del player (X-Player-Token)
+const EVENT_ACCESS_KEY_PREFIX = 'mile-game-event-access:'; // + slug → token de acceso al evento
 
 class ApiClient {
   private client: AxiosInstance;
   private playerId: string | null = null;
   private playerEventSlug: string | null = null; // Evento al que pertenece el player
+  private playerToken: string | null = null; // Token de sesión del player (jugadores de eventos)
 
   constructor() {
     // URL del backend (desde variables de entorno o default)
@@ -97,10 +130,12 @@ class ApiClient {
     try {
       this.playerId = localStorage.getItem(PLAYER_ID_KEY);
       this.playerEventSlug = localStorage.getItem(PLAYER_EVENT_KEY);
+      this.playerToken = localStorage.getItem(PLAYER_TOKEN_KEY);
     } catch {
       // localStorage puede no estar disponible (incognito, etc.)
       this.playerId = null;
       this.playerEventSlug = null;
+      this.playerToken = null;
     }
 
     this.client = axios.create({
@@ -123,6 +158,13 @@ class ApiClient {
         } catch {
           // localStorage unavailable (SSR, incognito, etc.)
         }
+
+        // Token de acceso del evento (solo eventos protegidos con código)
+        const slug = eventSlugFromUrl(config.url);
+        const accessToken = slug ? this.getEventAccessToken(slug) : null;
+        if (accessToken) {
+          config.headers['X-Event-Access'] = accessToken;
+        }
         return config;
       },
       (error) => Promise.reject(error)
@@ -133,6 +175,15 @@ class ApiClient {
       (response) => response,
       async (error) => {
         const originalRequest = error.config as AxiosRequestConfig & { _retry?: boolean; headers?: Record<string, string> };
+
+        // Evento protegido sin token válido: no es la sesión del admin, se pide el código
+        if (isAccessRequiredError(error)) {
+          const slug = eventSlugFromUrl(originalRequest.url);
+          if (slug) {
+            this.clearEventAccessToken(slug);
+          }
+          return Promise.reject(error);
+        }
         
         // Skip auth handling for Secret Box requests (they use X-Secret-Token, not JWT)
         const isSecretBoxRequest = originalRequest.headers?.['X-Secret-Token'];
@@ -140,6 +191,12 @@ class ApiClient {
           // Let the component handle the error (show invalid token message)
           return Promise.reject(error);
         }
+
+        // Same for player sessions: a rejected player token is not the admin session
+        const isPlayerRequest = originalRequest.headers?.['X-Player-Token'] || originalRequest.headers?.['X-Player-ID'];
+        if (isPlayerRequest && error.response?.status === 401) {
+          return Promise.reject(error);
+        }
         
         // If 401 and haven't tried refresh yet, try to refresh token
         if (error.response?.status === 401 && !originalRequest._retry) {
@@ -206,11 +263,18 @@ class ApiClient {
     );
   }
 
-  // Guardar player ID para requests posteriores (persiste en localStorage)
-  setPlayerId(id: string, eventSlug?: string) {
+  // Guardar player ID para requests posteriores (persiste en localStorage).
+  // El token de sesión reemplaza al anterior: un player nuevo sin token no hereda el de otro.
+  setPlayerId(id: string, eventSlug?: string, token?: string) {
     this.playerId = id;
+    this.playerToken = token ?? null;
     try {
       localStorage.setItem(PLAYER_ID_KEY, id);
+      if (token) {
+        localStorage.setItem(PLAYER_TOKEN_KEY, token);
+      } else {
+        localStorage.removeItem(PLAYER_TOKEN_KEY);
+      }
       // También guardar el eventSlug del player si se provee
       if (eventSlug) {
         this.playerEventSlug = eventSlug;
@@ -229,6 +293,19 @@ class ApiClient {
     return this.playerEventSlug;
   }
 
+  getPlayerToken(): string | null {
+    return this.playerToken;
+  }
+
+  // Identidad del player para quiz y postales: el token firmado si lo hay; el ID crudo
+  // solo para players creados sin token (rutas legacy), que el backend acepta en modo compat
+  private playerHeaders(playerId: string): Record<string, string> {
+    if (this.playerToken && playerId === this.playerId) {
+      return { 'X-Player-Token': this.playerToken };
+    }
+    return { 'X-Player-ID': playerId };
+  }
+
   // Verificar si el player actual pertenece al evento dado
   isPlayerForEvent(eventSlug: string): boolean {
     return this.playerEventSlug === eventSlug && this.playerId !== null;
@@ -237,14 +314,47 @@ class ApiClient {
   clearPlayerId() {
     this.playerId = null;
     this.playerEventSlug = null;
+    this.playerToken = null;
     try {
       localStorage.removeItem(PLAYER_ID_KEY);
       localStorage.removeItem(PLAYER_EVENT_KEY);
+      localStorage.removeItem(PLAYER_TOKEN_KEY);
     } catch {
       // Silently fail if localStorage is unavailable
     }
   }
 
+  // ==========================================
+  // Event access (eventos protegidos con código)
+  // ==========================================
+
+  getEventAccessToken(eventSlug: string): string | null {
+    try {
+      return localStorage.getItem(EVENT_ACCESS_KEY_PREFIX + eventSlug);
+    } catch {
+      return null;
+    }
+  }
+
+  clearEventAccessToken(eventSlug: string) {
+    try {
+      localStorage.removeItem(EVENT_ACCESS_KEY_PREFIX + eventSlug);
+    } catch {
+      // Silently fail if localStorage is unavailable
+    }
+  }
+
+  // Canjea el código (o el link de invitación / Secret Box) por el token de acceso
+  async enterEvent(eventSlug: string, credentials: EnterEventRequest): Promise<EventAccessResponse> {
+    const response = await this.client.post<EventAccessResponse>(`/events/${eventSlug}/access`, credentials);
+    try {
+      localStorage.setItem(EVENT_ACCESS_KEY_PREFIX + eventSlug, response.data.access_token);
+    } catch {
+      // Silently fail if localStorage is unavailable
+    }
+    return response.data;
+  }
+
   // ==========================================
   // Auth
   // ==========================================
@@ -285,9 +395,9 @@ class ApiClient {
   // ==========================================
 
   async createPlayer(data: CreatePlayerRequest): Promise<Player> {
-    const response = await this.client.post<Player>('/players', data);
-    // Auto-guardar el ID del jugador creado
-    this.setPlayerId(response.data.id);
+    const response = await this.client.post<CreatePlayerResponse>('/players', data);
+    // Auto-guardar el ID del jugador creado y su token de sesión
+    this.setPlayerId(response.data.id, undefined, response.data.player_token);
     return response.data;
   }
 
@@ -314,9 +424,7 @@ class ApiClient {
       '/quiz/submit',
       data,
       {
-        headers: {
-          'X-Player-ID': this.playerId,
-        },
+        headers: this.playerHeaders(this.playerId),
       }
     );
     return response.data;
@@ -369,7 +477,7 @@ class ApiClient {
       {
         headers: {
           'Content-Type': 'multipart/form-data',
-          'X-Player-ID': effectivePlayerId,
+          ...this.playerHeaders(effectivePlayerId),
         },
         timeout: 30000, // 30s para uploads
       }
@@ -517,8 +625,8 @@ class ApiClient {
 
   // Create player scoped to an event
   async createPlayerScoped(eventSlug: string, data: CreatePlayerRequest): Promise<Player> {
-    const response = await this.client.post<Player>(`/events/${eventSlug}/players`, data);
-    this.setPlayerId(response.data.id, eventSlug);
+    const response = await this.client.post<CreatePlayerResponse>(`/events/${eventSlug}/players`, data);
+    this.setPlayerId(response.data.id, eventSlug, response.data.player_token);
     return response.data;
   }
 
@@ -538,9 +646,7 @@ class ApiClient {
       `/events/${eventSlug}/quiz/submit`,
       data,
       {
-        headers: {
-          'X-Player-ID': this.playerId,
-        },
+        headers: this.playerHeaders(this.playerId),
       }
     );
     return response.data;
@@ -604,7 +710,7 @@ class ApiClient {
       {
         headers: {
           'Content-Type': 'multipart/form-data',
-          'X-Player-ID': effectivePlayerId,
+          ...this.playerHeaders(effectivePlayerId),
         },
         timeout: 30000,
       }